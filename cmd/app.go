@@ -1,60 +1,109 @@
 package cmd
 
 import (
-	"context"
 	"fmt"
-	"os"
 	"time"
 
 	"salesforce-splunk-migration/internal/workflows"
-	"salesforce-splunk-migration/services"
 	"salesforce-splunk-migration/utils"
 )
 
-func Execute() error {
-	logger := utils.GetLogger()
+// runApply applies the migration to the selected Splunk targets and returns an
+// error if any target failed. Progress is recorded in MIGRATION_STATE_FILE so that
+// a failed run can be continued with --resume, and a run report is written at the end.
+func runApply(c *cli, args []string) error {
+	fs := c.flagSet("apply")
+	resume := fs.String("resume", "", "continue the run with this ID, skipping the nodes and data inputs it completed")
+	reportPath := fs.String("report", "", "write the JSON run report to this file (default: MIGRATION_REPORT_FILE)")
+	junitPath := fs.String("junit", "", "also write the run report as JUnit XML to this file (default: MIGRATION_JUNIT_FILE)")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
 
-	config, err := utils.LoadConfig(os.Getenv("VAULT_PATH"))
+	config, err := c.loadConfig()
 	if err != nil {
-		return fmt.Errorf("failed to load configuration: %w", err)
+		return err
 	}
 
-	if err := config.Validate(); err != nil {
-		return fmt.Errorf("configuration validation failed: %w", err)
+	targets, err := c.targets(config)
+	if err != nil {
+		return err
 	}
 
-	logger.Info("✅ Configuration loaded and validated")
+	ctx, cancel := c.context()
+	defer cancel()
 
-	splunkService, err := services.NewSplunkService(config)
+	store, err := workflows.OpenRunStore(ctx, config.Migration.StateFile)
 	if err != nil {
-		return fmt.Errorf("failed to create Splunk service: %w", err)
+		return err
 	}
+	defer store.Close()
 
-	dashboardService, err := services.NewDashboardService(config, splunkService)
-	if err != nil {
-		return fmt.Errorf("failed to create Dashboard service: %w", err)
+	session := runSession{store: store, id: *resume, resume: *resume != ""}
+	if session.resume {
+		// Fail before contacting any target when the run does not cover all of them
+		for _, target := range targets {
+			if _, err := store.Load(ctx, session.id, target.Name); err != nil {
+				return configError(err)
+			}
+		}
+	} else {
+		session.id = workflows.NewRunID()
 	}
 
-	migrationGraph, err := workflows.NewMigrationGraph(config, splunkService, dashboardService)
-	if err != nil {
-		return fmt.Errorf("failed to create migration graph: %w", err)
-	}
+	utils.GetLogger().Info("🆔 Migration run",
+		utils.String("run_id", session.id),
+		utils.Bool("resume", session.resume),
+		utils.String("state_file", config.Migration.StateFile))
 
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Minute)
-	defer cancel()
+	startTime := time.Now()
+	results := runTargets(ctx, config, targets, c.newServices, session)
+	report := newRunReport(session.id, startTime, results)
+	reportErr := c.writeRunReport(report, firstNonEmpty(*reportPath, config.Migration.ReportFile), firstNonEmpty(*junitPath, config.Migration.JUnitFile))
 
-	if err := migrationGraph.Execute(ctx); err != nil {
-		return fmt.Errorf("migration workflow failed: %w", err)
+	if err := logTargetSummary(results); err != nil {
+		if reportErr != nil {
+			utils.GetLogger().Error("Failed to write run report", utils.Err(reportErr))
+		}
+		code := ExitFailure
+		if report.Status == statusPartial {
+			code = ExitPartialFailure
+		}
+		return withExitCode(code, fmt.Errorf("%w (resume with --resume %s)", err, session.id))
 	}
+	return reportErr
+}
 
-	state := migrationGraph.GetState()
-	success, failed := state.GetCounters()
+// writeRunReport writes the JSON report and, when junitPath is set, the JUnit report.
+// With --output json the report is also written to the command output.
+func (c *cli) writeRunReport(report *RunReport, jsonPath, junitPath string) error {
+	if c.opts.Output == outputJSON {
+		if err := c.writeJSON(report); err != nil {
+			return err
+		}
+	}
 
-	if failed > 0 {
-		logger.Warn("Migration completed with errors",
-			utils.Int("failed", failed),
-			utils.Int("total", success+failed))
+	if err := writeJSONReport(jsonPath, report); err != nil {
+		return err
 	}
+	utils.GetLogger().Info("📝 Run report written", utils.String("path", jsonPath))
 
+	if junitPath == "" {
+		return nil
+	}
+	if err := writeJUnitReport(junitPath, report); err != nil {
+		return err
+	}
+	utils.GetLogger().Info("📝 JUnit report written", utils.String("path", junitPath))
 	return nil
 }
+
+// firstNonEmpty returns the first value that is not empty
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
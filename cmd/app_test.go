@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"io"
 	"os"
 	"testing"
 
@@ -10,7 +11,7 @@ import (
 	"salesforce-splunk-migration/utils"
 )
 
-func TestExecute(t *testing.T) {
+func TestRunApply(t *testing.T) {
 	// Initialize logger for tests
 	err := utils.InitializeGlobalLogger("test", "cmd", false)
 	require.NoError(t, err)
@@ -25,7 +26,7 @@ func TestExecute(t *testing.T) {
 			}
 		}()
 
-		err := Execute()
+		err := Run([]string{"apply"}, io.Discard)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load configuration")
 	})
@@ -34,7 +35,7 @@ func TestExecute(t *testing.T) {
 		os.Setenv("VAULT_PATH", "/nonexistent/path/credentials.json")
 		defer os.Unsetenv("VAULT_PATH")
 
-		err := Execute()
+		err := Run([]string{"apply"}, io.Discard)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load configuration")
 	})
@@ -52,7 +53,7 @@ func TestExecute(t *testing.T) {
 		os.Setenv("VAULT_PATH", tmpFile.Name())
 		defer os.Unsetenv("VAULT_PATH")
 
-		err = Execute()
+		err = Run([]string{"apply"}, io.Discard)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to load configuration")
 	})
@@ -73,7 +74,7 @@ func TestExecute(t *testing.T) {
 		os.Setenv("VAULT_PATH", tmpFile.Name())
 		defer os.Unsetenv("VAULT_PATH")
 
-		err = Execute()
+		err = Run([]string{"apply"}, io.Discard)
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "validation failed")
 	})
@@ -106,7 +107,7 @@ func TestExecute(t *testing.T) {
 	// 	os.Setenv("VAULT_PATH", tmpFile.Name())
 	// 	defer os.Unsetenv("VAULT_PATH")
 
-	// 	err = Execute()
+	// 	err = Run([]string{"apply"}, io.Discard)
 	// 	require.Error(t, err)
 	// })
 
@@ -138,7 +139,7 @@ func TestExecute(t *testing.T) {
 	// 	os.Setenv("VAULT_PATH", tmpFile.Name())
 	// 	defer os.Unsetenv("VAULT_PATH")
 
-	// 	err = Execute()
+	// 	err = Run([]string{"apply"}, io.Discard)
 	// 	require.Error(t, err)
 	// 	// Should fail during config validation or early execution
 	// 	assert.Error(t, err)
@@ -167,7 +168,7 @@ func TestExecute(t *testing.T) {
 		os.Setenv("VAULT_PATH", tmpFile.Name())
 		defer os.Unsetenv("VAULT_PATH")
 
-		err = Execute()
+		err = Run([]string{"apply"}, io.Discard)
 		require.Error(t, err)
 	})
 
@@ -201,7 +202,7 @@ func TestExecute(t *testing.T) {
 	// 	defer os.Unsetenv("VAULT_PATH")
 
 	// 	// This should fail with network error or service creation error
-	// 	err = Execute()
+	// 	err = Run([]string{"apply"}, io.Discard)
 	// 	assert.Error(t, err)
 	// 	// Should contain either network error or auth error
 	// })
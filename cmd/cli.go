@@ -0,0 +1,267 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// Output formats accepted by --output
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+// defaultTimeout bounds a whole command run unless --timeout is given
+const defaultTimeout = 30 * time.Minute
+
+// Options holds the global command-line flags shared by every subcommand
+type Options struct {
+	ConfigPath string
+	Timeout    time.Duration
+	LogLevel   string
+	Output     string
+	Target     string
+}
+
+// command is a CLI subcommand
+type command struct {
+	name    string
+	args    string
+	summary string
+	run     func(c *cli, args []string) error
+}
+
+// commands lists the subcommands in the order they are shown in the usage text
+var commands = []command{
+	{name: "apply", summary: "Run the full migration against every target (default)", run: runApply},
+	{name: "plan", summary: "Show the changes a migration would make without applying them", run: runPlanCommand},
+	{name: "validate", summary: "Load and validate the configuration without contacting Splunk", run: runValidate},
+	{name: "list-inputs", summary: "List sfdc_object and sfdc_event_log inputs on a target", run: runListInputs},
+	{name: "get-input", args: "<name>", summary: "Show a single sfdc_object input", run: runGetInput},
+	{name: "disable-input", args: "<name>", summary: "Disable a single sfdc_object input", run: runDisableInput},
+	{name: "delete-input", args: "<name>", summary: "Delete a single sfdc_object input (requires --confirm)", run: runDeleteInput},
+	{name: "export-config", summary: "Write a configuration describing the inputs that already exist on a target", run: runExportConfig},
+	{name: "encrypt-config", summary: "Encrypt the configuration file with CONFIG_ENCRYPTION_KEY", run: runEncryptConfig},
+	{name: "dashboards push", summary: "Create or update dashboards from the dashboard directory", run: runDashboardsPush},
+	{name: "dashboards lint", summary: "Check the dashboard directory offline and report problems by file and line", run: runDashboardsLint},
+	{name: "dashboards generate", summary: "Write starter dashboards for the configured DATA_INPUTS to the dashboard directory", run: runDashboardsGenerate},
+}
+
+// cli carries the parsed options and the dependencies used by the subcommands
+type cli struct {
+	opts        Options
+	out         io.Writer
+	newServices serviceFactory
+	cleanups    []func()
+}
+
+// Run parses args (without the program name) and runs the selected subcommand.
+// Command results are written to out; progress is reported through the global logger.
+func Run(args []string, out io.Writer) error {
+	return newCLI(out, newTargetServices).run(args)
+}
+
+// newCLI creates a CLI with default options
+func newCLI(out io.Writer, newServices serviceFactory) *cli {
+	return &cli{
+		opts: Options{
+			ConfigPath: os.Getenv("VAULT_PATH"),
+			Timeout:    defaultTimeout,
+			Output:     outputText,
+		},
+		out:         out,
+		newServices: newServices,
+	}
+}
+
+// run parses the global flags and dispatches to the subcommand. Without a
+// subcommand the migration is applied, matching the behaviour of earlier releases.
+func (c *cli) run(args []string) error {
+	fs := c.flagSet("salesforce-splunk-migration")
+	fs.Usage = func() { c.usage(fs) }
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return nil
+		}
+		return configError(err)
+	}
+
+	rest := fs.Args()
+	if len(rest) == 0 {
+		rest = []string{"apply"}
+	}
+
+	cmd, rest, err := lookupCommand(rest)
+	if err != nil {
+		return err
+	}
+	defer c.cleanup()
+	if err := cmd.run(c, rest); err != nil && !errors.Is(err, flag.ErrHelp) {
+		return err
+	}
+	return nil
+}
+
+// atExit registers a function to run when the subcommand finishes
+func (c *cli) atExit(fn func()) {
+	c.cleanups = append(c.cleanups, fn)
+}
+
+// cleanup runs the registered functions in reverse order
+func (c *cli) cleanup() {
+	for i := len(c.cleanups) - 1; i >= 0; i-- {
+		c.cleanups[i]()
+	}
+	c.cleanups = nil
+}
+
+// lookupCommand finds the subcommand named by the leading arguments
+func lookupCommand(args []string) (command, []string, error) {
+	for _, cmd := range commands {
+		words := strings.Fields(cmd.name)
+		if len(args) < len(words) {
+			continue
+		}
+		if strings.Join(args[:len(words)], " ") == cmd.name {
+			return cmd, args[len(words):], nil
+		}
+	}
+	return command{}, nil, configError(fmt.Errorf("unknown command %q (run with -h for usage)", strings.Join(args, " ")))
+}
+
+// flagSet creates a flag set with the global flags registered. The current option
+// values are used as defaults, so global flags may appear before or after the subcommand.
+func (c *cli) flagSet(name string) *flag.FlagSet {
+	fs := flag.NewFlagSet(name, flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	fs.StringVar(&c.opts.ConfigPath, "config", c.opts.ConfigPath, "path to the configuration file (default: $VAULT_PATH or credentials.json)")
+	fs.DurationVar(&c.opts.Timeout, "timeout", c.opts.Timeout, "maximum duration of the command")
+	fs.StringVar(&c.opts.LogLevel, "log-level", c.opts.LogLevel, "log level: debug, info, warn or error (default: MIGRATION_LOG_LEVEL)")
+	fs.StringVar(&c.opts.Output, "output", c.opts.Output, "output format for command results: text or json")
+	fs.StringVar(&c.opts.Target, "target", c.opts.Target, "name of the Splunk target from TARGETS to operate on")
+	return fs
+}
+
+// parse parses a subcommand's flags and checks the number of positional arguments
+func (c *cli) parse(fs *flag.FlagSet, args []string, positional int) error {
+	if err := fs.Parse(args); err != nil {
+		return configError(err)
+	}
+	if fs.NArg() != positional {
+		return configError(fmt.Errorf("%s expects %d argument(s), got %d", fs.Name(), positional, fs.NArg()))
+	}
+
+	if c.opts.Output != outputText && c.opts.Output != outputJSON {
+		return configError(fmt.Errorf("invalid output format %q (must be %s or %s)", c.opts.Output, outputText, outputJSON))
+	}
+	if c.opts.Timeout <= 0 {
+		return configError(fmt.Errorf("timeout must be positive, got %s", c.opts.Timeout))
+	}
+	if c.opts.LogLevel != "" {
+		return configError(c.setLogLevel(c.opts.LogLevel))
+	}
+	return nil
+}
+
+// setLogLevel re-initializes the global logger at the given level
+func (c *cli) setLogLevel(level string) error {
+	logLevel, err := utils.ParseLogLevel(level)
+	if err != nil {
+		return err
+	}
+	return utils.InitializeGlobalLoggerWithLevel("salesforce-splunk-migration", "main", true, logLevel)
+}
+
+// loadConfig loads and validates the configuration file
+func (c *cli) loadConfig() (*utils.Config, error) {
+	config, err := c.readConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.Validate(); err != nil {
+		return nil, configError(fmt.Errorf("configuration validation failed: %w", err))
+	}
+
+	utils.GetLogger().Info("✅ Configuration loaded and validated")
+	return config, nil
+}
+
+// readConfig loads the configuration file without validating it. MIGRATION_LOG_LEVEL
+// is applied unless --log-level was given.
+func (c *cli) readConfig() (*utils.Config, error) {
+	config, err := utils.LoadConfig(c.opts.ConfigPath)
+	if err != nil {
+		return nil, configError(fmt.Errorf("failed to load configuration: %w", err))
+	}
+
+	if c.opts.LogLevel == "" && config.Migration.LogLevel != "" {
+		if err := c.setLogLevel(config.Migration.LogLevel); err != nil {
+			return nil, configError(fmt.Errorf("configuration validation failed: $.MIGRATION_LOG_LEVEL: %w", err))
+		}
+	}
+	return config, nil
+}
+
+// context returns a context bounded by --timeout
+func (c *cli) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.opts.Timeout)
+}
+
+// targets returns the configured targets, restricted to --target when it is set
+func (c *cli) targets(config *utils.Config) ([]utils.Target, error) {
+	targets, err := config.GetTargets()
+	if err != nil {
+		return nil, configError(fmt.Errorf("failed to load targets: %w", err))
+	}
+	if c.opts.Target == "" {
+		return targets, nil
+	}
+
+	for _, target := range targets {
+		if target.Name == c.opts.Target {
+			return []utils.Target{target}, nil
+		}
+	}
+	return nil, configError(fmt.Errorf("unknown target %q", c.opts.Target))
+}
+
+// singleTarget resolves the one target a per-resource command operates on.
+// --target is required when more than one target is configured.
+func (c *cli) singleTarget(config *utils.Config) (*utils.Config, error) {
+	targets, err := c.targets(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(targets) > 1 {
+		return nil, configError(fmt.Errorf("%d targets are configured; choose one with --target", len(targets)))
+	}
+	return config.ForTarget(targets[0]), nil
+}
+
+// writeJSON writes v to the command output as indented JSON
+func (c *cli) writeJSON(v interface{}) error {
+	encoder := json.NewEncoder(c.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+// usage prints the command overview and the global flags
+func (c *cli) usage(fs *flag.FlagSet) {
+	fmt.Fprintf(c.out, "Usage: salesforce-splunk-migration [global flags] <command> [arguments]\n\nCommands:\n")
+	for _, cmd := range commands {
+		name := strings.TrimSpace(cmd.name + " " + cmd.args)
+		fmt.Fprintf(c.out, "  %-22s %s\n", name, cmd.summary)
+	}
+	fmt.Fprintf(c.out, "\nGlobal flags:\n")
+	fs.PrintDefaults()
+}
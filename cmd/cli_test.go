@@ -0,0 +1,695 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+const cliTestConfig = `{
+	"SPLUNK_URL": "https://splunk-dev.example.com:8089",
+	"SPLUNK_USERNAME": "admin",
+	"SPLUNK_PASSWORD": "changeme",
+	"SPLUNK_INDEX_NAME": "salesforce",
+	"SALESFORCE_ENDPOINT": "login.salesforce.com",
+	"SALESFORCE_CLIENT_ID": "client-id",
+	"SALESFORCE_CLIENT_SECRET": "client-secret",
+	"SALESFORCE_ACCOUNT_NAME": "sf_prod",
+	"MIGRATION_DASHBOARD_DIRECTORY": "./resources/dashboards",
+	"DATA_INPUTS": [{"name": "sf_accounts", "object": "Account", "object_fields": "Id,Name"}]
+}`
+
+// writeCLIConfig writes a configuration file and returns its path. The run state
+// database and run report are placed next to it so that apply does not write into
+// the package directory.
+func writeCLIConfig(t *testing.T, content string) string {
+	t.Helper()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "credentials.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	t.Setenv("MIGRATION_STATE_FILE", filepath.Join(dir, "migration-state.db"))
+	t.Setenv("MIGRATION_REPORT_FILE", filepath.Join(dir, "migration-report.json"))
+	return path
+}
+
+// newTestCLI returns a CLI whose services are the given mocks
+func newTestCLI(out *bytes.Buffer, splunkService *mocks.MockSplunkService, dashboardService *mocks.MockDashboardService) *cli {
+	return newCLI(out, func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+		return splunkService, dashboardService, nil
+	})
+}
+
+func TestCLI_Dispatch(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	t.Run("Success_Help", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"-h"}))
+		assert.Contains(t, out.String(), "dashboards push")
+		assert.Contains(t, out.String(), "-timeout")
+	})
+
+	t.Run("Error_UnknownCommand", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"frobnicate"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unknown command "frobnicate"`)
+	})
+
+	t.Run("Error_DashboardsWithoutPush", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"dashboards"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "unknown command")
+	})
+
+	t.Run("Error_InvalidOutputFormat", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "--output", "yaml", "validate"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid output format")
+	})
+
+	t.Run("Error_InvalidLogLevel", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--log-level", "verbose", "validate"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid log level")
+	})
+
+	t.Run("Error_MissingArgument", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "get-input"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "get-input expects 1 argument(s), got 0")
+	})
+}
+
+func TestCLI_Validate(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	t.Run("Success_Text", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "validate"}))
+		assert.Equal(t, "Configuration is valid: 1 target(s), 1 Salesforce account(s), 1 data input(s), 0 event log input(s)\n", out.String())
+	})
+
+	t.Run("Success_JSON", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"validate", "--config", configPath, "--output", "json"}))
+
+		var result validationResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.True(t, result.Valid)
+		assert.Equal(t, []string{"default"}, result.Targets)
+		assert.Equal(t, []string{"sf_prod"}, result.Accounts)
+		assert.Equal(t, 1, result.DataInputs)
+	})
+
+	t.Run("Error_ReportsEveryProblem", func(t *testing.T) {
+		invalidPath := writeCLIConfig(t, `{
+			"SPLUNK_URL": "https://splunk:8089",
+			"SPLUNK_USERNAME": "admin",
+			"SPLUNK_PASSWORD": "changeme",
+			"SALESFORCE_ENDPOINT": "login.salesforce.com",
+			"SALESFORCE_CLIENT_ID": "client-id",
+			"SALESFORCE_CLIENT_SECRET": "client-secret",
+			"SALESFORCE_ACCOUNT_NAME": "sf_prod",
+			"DATA_INPUTS": [
+				{"name": "sf_accounts", "object": "Account", "object_fields": "Id,Name", "start_date": "2024-01-01"},
+				{"name": "sf_accounts", "object": "Account", "object_fields": "Id,Name", "interval": 0}
+			]
+		}`)
+
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", invalidPath, "validate"})
+		require.Error(t, err)
+		assert.Equal(t, "configuration validation failed with 3 problem(s)", err.Error())
+		assert.Contains(t, out.String(), "$.DATA_INPUTS[0].start_date:")
+		assert.Contains(t, out.String(), "$.DATA_INPUTS[1].name: duplicate data input name")
+		assert.Contains(t, out.String(), "$.DATA_INPUTS[1].interval: must be between 60 and 86400 seconds, got 0")
+
+		out.Reset()
+		require.Error(t, newTestCLI(&out, nil, nil).run([]string{"--config", invalidPath, "--output", "json", "validate"}))
+
+		var result validationResult
+		require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+		assert.False(t, result.Valid)
+		require.Len(t, result.Errors, 3)
+		assert.Equal(t, "$.DATA_INPUTS[0].start_date", result.Errors[0].Path)
+	})
+
+	t.Run("Error_UnreadableConfig", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", "/nonexistent/credentials.json", "validate"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load configuration")
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestCLI_Inputs(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	t.Run("Success_ListInputsJSON", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			ListDataInputsFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"sf_accounts"}, nil
+			},
+			ListEventLogInputsFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"sf_event_logs"}, nil
+			},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, mockService, nil).run([]string{"--config", configPath, "--output", "json", "list-inputs"}))
+
+		var inputs []inputSummary
+		require.NoError(t, json.Unmarshal(out.Bytes(), &inputs))
+		assert.Equal(t, []inputSummary{{Type: "sfdc_object", Name: "sf_accounts"}, {Type: "sfdc_event_log", Name: "sf_event_logs"}}, inputs)
+		assert.Equal(t, 1, mockService.AuthenticateCalls)
+		assert.Equal(t, 1, mockService.LogoutCalls)
+	})
+
+	t.Run("Success_GetInputText", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			GetDataInputFunc: func(ctx context.Context, inputName string) (*models.SFDCObjectInput, error) {
+				return &models.SFDCObjectInput{Name: inputName, Object: "Account", Interval: 300, Disabled: true}, nil
+			},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, mockService, nil).run([]string{"--config", configPath, "get-input", "sf_accounts"}))
+		assert.Contains(t, out.String(), "object         Account")
+		assert.Contains(t, out.String(), "interval       300")
+		assert.Contains(t, out.String(), "disabled       true")
+	})
+
+	t.Run("Error_GetInputNotFound", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, &mocks.MockSplunkService{}, nil).run([]string{"--config", configPath, "get-input", "missing"})
+		require.Error(t, err)
+		assert.Equal(t, "data input missing not found", err.Error())
+	})
+
+	t.Run("Success_DisableInput", func(t *testing.T) {
+		var disabled string
+		mockService := &mocks.MockSplunkService{
+			DisableDataInputFunc: func(ctx context.Context, inputName string) error {
+				disabled = inputName
+				return nil
+			},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, mockService, nil).run([]string{"--config", configPath, "disable-input", "sf_accounts"}))
+		assert.Equal(t, "sf_accounts", disabled)
+		assert.Equal(t, "Disabled data input sf_accounts\n", out.String())
+	})
+
+	t.Run("Error_DeleteInputWithoutConfirm", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{}
+
+		var out bytes.Buffer
+		err := newTestCLI(&out, mockService, nil).run([]string{"--config", configPath, "delete-input", "sf_accounts"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "without --confirm")
+		assert.Equal(t, 0, mockService.AuthenticateCalls)
+		assert.Equal(t, 0, mockService.DeleteDataInputCalls)
+	})
+
+	t.Run("Success_DeleteInputConfirmed", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{}
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, mockService, nil).run([]string{"--config", configPath, "delete-input", "--confirm", "sf_accounts"}))
+		assert.Equal(t, 1, mockService.DeleteDataInputCalls)
+	})
+
+	t.Run("Error_AuthenticationFails", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			AuthenticateFunc: func(ctx context.Context) error {
+				return assert.AnError
+			},
+		}
+
+		var out bytes.Buffer
+		err := newTestCLI(&out, mockService, nil).run([]string{"--config", configPath, "list-inputs"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "authentication failed")
+		assert.Equal(t, 0, mockService.ListDataInputsCalls)
+		assert.Equal(t, 0, mockService.LogoutCalls)
+	})
+}
+
+func TestCLI_TargetSelection(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, `{
+		"SPLUNK_USERNAME": "admin",
+		"SPLUNK_PASSWORD": "changeme",
+		"SALESFORCE_ENDPOINT": "login.salesforce.com",
+		"SALESFORCE_CLIENT_ID": "client-id",
+		"SALESFORCE_CLIENT_SECRET": "client-secret",
+		"SALESFORCE_ACCOUNT_NAME": "sf_prod",
+		"DATA_INPUTS": [{"name": "sf_accounts", "object": "Account", "object_fields": "Id,Name"}],
+		"TARGETS": [
+			{"name": "dev", "splunk_url": "https://splunk-dev.example.com:8089"},
+			{"name": "prod", "splunk_url": "https://splunk-prod.example.com:8089"}
+		]
+	}`)
+
+	newRecordingCLI := func(out *bytes.Buffer, urls *[]string) *cli {
+		return newCLI(out, func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+			*urls = append(*urls, config.Splunk.URL)
+			return &mocks.MockSplunkService{}, &mocks.MockDashboardService{}, nil
+		})
+	}
+
+	t.Run("Error_InputCommandNeedsTarget", func(t *testing.T) {
+		var out bytes.Buffer
+		var urls []string
+		err := newRecordingCLI(&out, &urls).run([]string{"--config", configPath, "list-inputs"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "choose one with --target")
+		assert.Empty(t, urls)
+	})
+
+	t.Run("Success_InputCommandWithTarget", func(t *testing.T) {
+		var out bytes.Buffer
+		var urls []string
+		require.NoError(t, newRecordingCLI(&out, &urls).run([]string{"--config", configPath, "--target", "prod", "list-inputs"}))
+		assert.Equal(t, []string{"https://splunk-prod.example.com:8089"}, urls)
+	})
+
+	t.Run("Success_PlanAllTargetsJSON", func(t *testing.T) {
+		var out bytes.Buffer
+		var urls []string
+		require.NoError(t, newRecordingCLI(&out, &urls).run([]string{"--config", configPath, "--output", "json", "plan"}))
+
+		var plans []targetPlan
+		require.NoError(t, json.Unmarshal(out.Bytes(), &plans))
+		require.Len(t, plans, 2)
+		assert.Equal(t, "dev", plans[0].Target)
+		assert.Equal(t, "prod", plans[1].Target)
+		assert.NotEmpty(t, plans[1].Changes)
+	})
+
+	t.Run("Success_ApplySingleTarget", func(t *testing.T) {
+		var out bytes.Buffer
+		var urls []string
+		require.NoError(t, newRecordingCLI(&out, &urls).run([]string{"--config", configPath, "--target", "dev", "apply"}))
+		assert.Equal(t, []string{"https://splunk-dev.example.com:8089"}, urls)
+	})
+
+	t.Run("Error_UnknownTarget", func(t *testing.T) {
+		var out bytes.Buffer
+		var urls []string
+		err := newRecordingCLI(&out, &urls).run([]string{"--config", configPath, "--target", "qa", "apply"})
+		require.Error(t, err)
+		assert.Equal(t, `unknown target "qa"`, err.Error())
+	})
+}
+
+func TestCLI_ApplyResume(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	t.Run("Error_UnknownRun", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, &mocks.MockSplunkService{}, &mocks.MockDashboardService{}).run([]string{"--config", configPath, "apply", "--resume", "no-such-run"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "migration run not found: no-such-run")
+	})
+
+	t.Run("Success_RetriesFailedInput", func(t *testing.T) {
+		failing := &mocks.MockSplunkService{
+			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
+				return assert.AnError
+			},
+		}
+		var out bytes.Buffer
+		err := newTestCLI(&out, failing, &mocks.MockDashboardService{}).run([]string{"--config", configPath, "apply"})
+		require.Error(t, err)
+		assert.Equal(t, 1, failing.LogoutCalls, "token not revoked after a failed run")
+
+		_, runID, found := strings.Cut(err.Error(), "--resume ")
+		require.True(t, found, err.Error())
+		runID = strings.TrimSuffix(runID, ")")
+
+		healthy := &mocks.MockSplunkService{}
+		require.NoError(t, newTestCLI(&out, healthy, &mocks.MockDashboardService{}).run([]string{"--config", configPath, "apply", "--resume", runID}))
+		assert.Equal(t, 1, healthy.CreateDataInputCalls)
+		assert.Equal(t, 0, healthy.CreateSalesforceAccountCalls)
+		assert.Equal(t, 1, healthy.LogoutCalls)
+	})
+}
+
+func TestCLI_ExitCodes(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, `{
+		"SPLUNK_URL": "https://splunk-dev.example.com:8089",
+		"SPLUNK_USERNAME": "admin",
+		"SPLUNK_PASSWORD": "changeme",
+		"SALESFORCE_ENDPOINT": "login.salesforce.com",
+		"SALESFORCE_CLIENT_ID": "client-id",
+		"SALESFORCE_CLIENT_SECRET": "client-secret",
+		"SALESFORCE_ACCOUNT_NAME": "sf_prod",
+		"DATA_INPUTS": [
+			{"name": "sf_accounts", "object": "Account", "object_fields": "Id,Name"},
+			{"name": "sf_contacts", "object": "Contact", "object_fields": "Id,Name"}
+		]
+	}`)
+	reportPath := filepath.Join(filepath.Dir(configPath), "migration-report.json")
+
+	failInputs := func(names ...string) *mocks.MockSplunkService {
+		return &mocks.MockSplunkService{
+			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
+				for _, name := range names {
+					if input.Name == name {
+						return errors.New("failed to create data input: status 400 - bad request")
+					}
+				}
+				return nil
+			},
+		}
+	}
+
+	readReport := func(t *testing.T) RunReport {
+		t.Helper()
+		data, err := os.ReadFile(reportPath)
+		require.NoError(t, err)
+		var report RunReport
+		require.NoError(t, json.Unmarshal(data, &report))
+		return report
+	}
+
+	t.Run("ConfigError", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", filepath.Join(t.TempDir(), "missing.json"), "apply"})
+		require.Error(t, err)
+		assert.Equal(t, ExitConfigError, ExitCode(err))
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, failInputs(), &mocks.MockDashboardService{}).run([]string{"--config", configPath, "apply"})
+		require.NoError(t, err)
+		assert.Equal(t, ExitOK, ExitCode(err))
+		assert.Equal(t, statusSucceeded, readReport(t).Status)
+	})
+
+	t.Run("PartialFailure", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, failInputs("sf_contacts"), &mocks.MockDashboardService{}).run([]string{"--config", configPath, "apply"})
+		require.Error(t, err)
+		assert.Equal(t, ExitPartialFailure, ExitCode(err))
+
+		report := readReport(t)
+		assert.Equal(t, statusPartial, report.Status)
+		require.Len(t, report.Targets, 1)
+		require.NotNil(t, report.Targets[0].Report)
+		assert.Equal(t, 1, report.Targets[0].FailedInputs())
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		var out bytes.Buffer
+		junitPath := filepath.Join(t.TempDir(), "junit.xml")
+		err := newTestCLI(&out, failInputs("sf_accounts", "sf_contacts"), &mocks.MockDashboardService{}).run([]string{"--config", configPath, "apply", "--junit", junitPath})
+		require.Error(t, err)
+		assert.Equal(t, ExitFailure, ExitCode(err))
+		assert.Equal(t, statusFailed, readReport(t).Status)
+		assert.FileExists(t, junitPath)
+	})
+}
+
+func TestCLI_ExportConfig(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	// Only the Splunk settings are needed to export
+	configPath := writeCLIConfig(t, `{
+		"SPLUNK_URL": "https://splunk-dev.example.com:8089",
+		"SPLUNK_USERNAME": "admin",
+		"SPLUNK_PASSWORD": "changeme"
+	}`)
+
+	splunkService := &mocks.MockSplunkService{
+		ListDataInputDetailsFunc: func(ctx context.Context) ([]*models.SFDCObjectInput, error) {
+			return []*models.SFDCObjectInput{
+				{Name: "sf_accounts", Account: "sf_prod", Object: "Account", ObjectFields: "Id,Name,LastModifiedDate", OrderBy: "LastModifiedDate", StartDate: "2024-01-01T00:00:00.000Z", Interval: 300, Delay: 60, Index: "salesforce"},
+				{Name: "sf_old_cases", Account: "sf_prod", Object: "Case", ObjectFields: "Id", Interval: 300, Index: "salesforce", Disabled: true},
+			}, nil
+		},
+		ListSalesforceAccountsFunc: func(ctx context.Context) ([]*models.SalesforceAccountSettings, error) {
+			return []*models.SalesforceAccountSettings{
+				{Name: "sf_prod", Endpoint: "login.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "client-id"},
+			}, nil
+		},
+	}
+
+	t.Run("Success_WritesLoadableConfig", func(t *testing.T) {
+		outPath := filepath.Join(t.TempDir(), "exported.json")
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, splunkService, &mocks.MockDashboardService{}).run([]string{"--config", configPath, "export-config", "--out", outPath}))
+
+		data, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		assert.NotContains(t, string(data), "changeme")
+
+		exported, err := utils.LoadConfig(outPath)
+		require.NoError(t, err)
+		assert.Equal(t, "salesforce", exported.Splunk.IndexName)
+		assert.Equal(t, "sf_prod", exported.Salesforce.AccountName)
+		inputs, err := exported.GetDataInputs()
+		require.NoError(t, err)
+		require.Len(t, inputs, 1)
+		assert.Equal(t, "sf_accounts", inputs[0].Name)
+
+		// The redacted secrets must be supplied before the config can be applied
+		err = exported.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "$.SPLUNK_PASSWORD: is redacted")
+		assert.Contains(t, err.Error(), "$.SALESFORCE_CLIENT_SECRET: is redacted")
+
+		t.Setenv("SPLUNK_PASSWORD", "changeme")
+		t.Setenv("SALESFORCE_CLIENT_SECRET", "client-secret")
+		exported, err = utils.LoadConfig(outPath)
+		require.NoError(t, err)
+		assert.NoError(t, exported.Validate())
+	})
+
+	t.Run("Success_IncludeDisabledToStdout", func(t *testing.T) {
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, splunkService, &mocks.MockDashboardService{}).run([]string{"--config", configPath, "export-config", "--include-disabled"}))
+
+		var exported map[string]interface{}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &exported))
+		assert.Len(t, exported["DATA_INPUTS"], 2)
+		assert.Equal(t, utils.RedactedSecret, exported["SPLUNK_PASSWORD"])
+	})
+
+	t.Run("Error_ListFails", func(t *testing.T) {
+		failing := &mocks.MockSplunkService{
+			ListDataInputDetailsFunc: func(ctx context.Context) ([]*models.SFDCObjectInput, error) {
+				return nil, errors.New("failed to list data inputs: status 403 - forbidden")
+			},
+		}
+		var out bytes.Buffer
+		err := newTestCLI(&out, failing, &mocks.MockDashboardService{}).run([]string{"--config", configPath, "export-config"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestCLI_EncryptConfig(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+	t.Setenv("CONFIG_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(bytes.Repeat([]byte{0x42}, utils.ConfigKeySize)))
+
+	t.Run("Success_EncryptedConfigLoads", func(t *testing.T) {
+		outPath := filepath.Join(t.TempDir(), "credentials.json.enc")
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "encrypt-config", "--out", outPath}))
+
+		data, err := os.ReadFile(outPath)
+		require.NoError(t, err)
+		assert.True(t, utils.IsEncryptedConfig(data))
+		assert.NotContains(t, string(data), "changeme")
+
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", outPath, "validate"}))
+
+		err = newTestCLI(&out, nil, nil).run([]string{"--config", outPath, "encrypt-config", "--out", filepath.Join(t.TempDir(), "twice.enc")})
+		assert.Equal(t, ExitConfigError, ExitCode(err))
+	})
+
+	t.Run("Error_MissingKey", func(t *testing.T) {
+		t.Setenv("CONFIG_ENCRYPTION_KEY", "")
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "encrypt-config", "--out", filepath.Join(t.TempDir(), "out.enc")})
+		require.Error(t, err)
+		assert.Equal(t, ExitConfigError, ExitCode(err))
+	})
+
+	t.Run("Error_MissingOut", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "encrypt-config"})
+		assert.Equal(t, ExitConfigError, ExitCode(err))
+	})
+}
+
+func TestCLI_DashboardsPush(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	t.Run("Success_DefaultDirectory", func(t *testing.T) {
+		var pushed string
+		dashboardService := &mocks.MockDashboardService{
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				pushed = dashboardDir
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
+			},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, &mocks.MockSplunkService{}, dashboardService).run([]string{"--config", configPath, "dashboards", "push"}))
+		assert.Equal(t, "./resources/dashboards", pushed)
+	})
+
+	t.Run("Success_DirectoryFlag", func(t *testing.T) {
+		var pushed string
+		dashboardService := &mocks.MockDashboardService{
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				pushed = dashboardDir
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
+			},
+		}
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, &mocks.MockSplunkService{}, dashboardService).run([]string{"--config", configPath, "dashboards", "push", "--dir", "/tmp/dashboards"}))
+		assert.Equal(t, "/tmp/dashboards", pushed)
+	})
+
+	t.Run("Error_PrintsOutcomePerDashboard", func(t *testing.T) {
+		dashboardService := &mocks.MockDashboardService{
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return &models.DashboardReconcileResult{Directory: dashboardDir, Outcomes: []models.DashboardOutcome{
+					{Name: "home", Action: models.DashboardUnchanged},
+					{Name: "analytics", Action: models.DashboardUpdated},
+					{Name: "broken", Action: models.DashboardFailed, Err: errors.New("bad XML")},
+				}}, errors.New("1 of 3 dashboards failed to reconcile")
+			},
+		}
+
+		var out bytes.Buffer
+		err := newTestCLI(&out, &mocks.MockSplunkService{}, dashboardService).run([]string{"--config", configPath, "dashboards", "push"})
+		require.Error(t, err)
+		assert.Equal(t, ExitFailure, ExitCode(err))
+		assert.Equal(t, "  unchanged  home\n  updated    analytics\n  failed     broken: bad XML\n", out.String())
+	})
+}
+
+func TestCLI_DashboardsLint(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	writeDashboard := func(t *testing.T, content string) string {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "accounts.xml"), []byte(content), 0o644))
+		return dir
+	}
+
+	t.Run("Success_WarningsDoNotFail", func(t *testing.T) {
+		dir := writeDashboard(t, "<dashboard>\n  <row><panel><table><search>\n    <query>index={{.Index}} sourcetype=sfdc:lead</query>\n  </search></table></panel></row>\n</dashboard>")
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "lint", "--dir", dir}))
+		assert.Equal(t, filepath.Join(dir, "accounts.xml")+":3: warning: search uses sourcetype \"sfdc:lead\", which no configured DATA_INPUTS object produces\n"+
+			"Linted 1 dashboard(s) in "+dir+": 0 error(s), 1 warning(s)\n", out.String())
+	})
+
+	t.Run("Error_StrictFailsOnWarnings", func(t *testing.T) {
+		dir := writeDashboard(t, "<dashboard><row><panel><table><search><query>index=archive</query></search></table></panel></row></dashboard>")
+
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "lint", "--dir", dir, "--strict"})
+		require.Error(t, err)
+		assert.Equal(t, ExitFailure, ExitCode(err))
+	})
+
+	t.Run("Error_ReportsErrorsAsJSON", func(t *testing.T) {
+		dir := writeDashboard(t, "<dashboard>\n  <row>\n</dashboard>")
+
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "--output", "json", "dashboards", "lint", "--dir", dir})
+		require.Error(t, err)
+		assert.Equal(t, ExitFailure, ExitCode(err))
+
+		var reports []struct {
+			Target   string                        `json:"target"`
+			Files    int                           `json:"files"`
+			Problems []models.DashboardLintProblem `json:"problems"`
+		}
+		require.NoError(t, json.Unmarshal(out.Bytes(), &reports))
+		require.Len(t, reports, 1)
+		assert.Equal(t, "default", reports[0].Target)
+		require.Len(t, reports[0].Problems, 1)
+		assert.Equal(t, 3, reports[0].Problems[0].Line)
+		assert.Equal(t, models.LintError, reports[0].Problems[0].Severity)
+	})
+
+	t.Run("Error_MissingDirectory", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "lint", "--dir", filepath.Join(t.TempDir(), "missing")})
+		assert.Equal(t, ExitConfigError, ExitCode(err))
+	})
+}
+
+func TestCLI_DashboardsGenerate(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+	configPath := writeCLIConfig(t, cliTestConfig)
+
+	t.Run("Success_WritesThenKeepsDashboards", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "dashboards")
+
+		var out bytes.Buffer
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "generate", "--dir", dir}))
+		assert.Equal(t, "  written    "+filepath.Join(dir, "sfdc_account.json")+"\n"+
+			"  written    "+filepath.Join(dir, "sfdc_overview.json")+"\n"+
+			"Generated 2 dashboard(s) in "+dir+"\n", out.String())
+
+		data, err := os.ReadFile(filepath.Join(dir, "sfdc_account.json"))
+		require.NoError(t, err)
+		assert.Contains(t, string(data), "index={{(index .Inputs `sf_accounts`).Index}} sourcetype=sfdc:account")
+
+		out.Reset()
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "generate", "--dir", dir, "--prefix", ""}))
+		assert.Contains(t, out.String(), "Generated 2 dashboard(s)")
+
+		out.Reset()
+		require.NoError(t, newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "generate", "--dir", dir}))
+		assert.Contains(t, out.String(), "  kept       "+filepath.Join(dir, "sfdc_account.json")+" (already exists; use --force to overwrite)\n")
+		assert.Contains(t, out.String(), "Generated 0 dashboard(s)")
+	})
+
+	t.Run("Error_InvalidPrefix", func(t *testing.T) {
+		var out bytes.Buffer
+		err := newTestCLI(&out, nil, nil).run([]string{"--config", configPath, "dashboards", "generate", "--dir", t.TempDir(), "--prefix", "sfdc-"})
+		assert.Equal(t, ExitConfigError, ExitCode(err))
+	})
+}
@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"fmt"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+// dashboardLintReport is the JSON form of the lint result of one target
+type dashboardLintReport struct {
+	Target string `json:"target"`
+	*models.DashboardLintResult
+}
+
+// runDashboardsPush creates or updates the dashboards found in the dashboard directory
+// and prints the action taken for each one
+func runDashboardsPush(c *cli, args []string) error {
+	fs := c.flagSet("dashboards push")
+	dir := fs.String("dir", "", "dashboard directory (default: MIGRATION_DASHBOARD_DIRECTORY)")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	config, _, dashboardService, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	dashboardDir := *dir
+	if dashboardDir == "" {
+		dashboardDir = config.Migration.DashboardDirectory
+	}
+	if dashboardDir == "" {
+		return configError(fmt.Errorf("no dashboard directory configured; set MIGRATION_DASHBOARD_DIRECTORY or pass --dir"))
+	}
+
+	result, err := dashboardService.ReconcileDashboards(ctx, dashboardDir)
+	if result != nil {
+		for _, outcome := range result.Outcomes {
+			if outcome.Err != nil {
+				fmt.Fprintf(c.out, "  %-10s %s: %s\n", outcome.Action, outcome.Name, utils.Redact(outcome.Err.Error()))
+				continue
+			}
+			fmt.Fprintf(c.out, "  %-10s %s\n", outcome.Action, outcome.Name)
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("failed to push dashboards: %w", err)
+	}
+
+	fmt.Fprintf(c.out, "Pushed dashboards from %s\n", dashboardDir)
+	return nil
+}
+
+// runDashboardsLint checks the dashboard directory without contacting Splunk. Each
+// selected target is linted with its own settings, because dashboards are rendered
+// per target. It fails on errors, and on warnings too with --strict.
+func runDashboardsLint(c *cli, args []string) error {
+	fs := c.flagSet("dashboards lint")
+	dir := fs.String("dir", "", "dashboard directory (default: MIGRATION_DASHBOARD_DIRECTORY)")
+	strict := fs.Bool("strict", false, "fail on warnings as well as errors")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	config, err := c.readConfig()
+	if err != nil {
+		return err
+	}
+
+	dashboardDir := *dir
+	if dashboardDir == "" {
+		dashboardDir = config.Migration.DashboardDirectory
+	}
+	if dashboardDir == "" {
+		return configError(fmt.Errorf("no dashboard directory configured; set MIGRATION_DASHBOARD_DIRECTORY or pass --dir"))
+	}
+
+	targets, err := c.targets(config)
+	if err != nil {
+		return err
+	}
+
+	var reports []dashboardLintReport
+	errorCount, warningCount := 0, 0
+	for _, target := range targets {
+		result, err := services.LintDashboards(config.ForTarget(target), dashboardDir)
+		if err != nil {
+			return configError(fmt.Errorf("failed to lint dashboards: %w", err))
+		}
+		reports = append(reports, dashboardLintReport{Target: target.Name, DashboardLintResult: result})
+		errorCount += result.Errors()
+		warningCount += result.Warnings()
+	}
+
+	if c.opts.Output == outputJSON {
+		if err := c.writeJSON(reports); err != nil {
+			return err
+		}
+	} else {
+		for _, report := range reports {
+			if len(reports) > 1 {
+				fmt.Fprintf(c.out, "Target %s:\n", report.Target)
+			}
+			for _, problem := range report.Problems {
+				fmt.Fprintln(c.out, problem.String())
+			}
+			fmt.Fprintf(c.out, "Linted %d dashboard(s) in %s: %d error(s), %d warning(s)\n",
+				report.Files, dashboardDir, report.Errors(), report.Warnings())
+		}
+	}
+
+	if errorCount > 0 || (*strict && warningCount > 0) {
+		return fmt.Errorf("dashboard lint found %d error(s) and %d warning(s)", errorCount, warningCount)
+	}
+	return nil
+}
+
+// runDashboardsGenerate writes starter Dashboard Studio definitions for the DATA_INPUTS
+// of the target to the dashboard directory, where they are reviewed before being
+// deployed with the other dashboards. Existing files are kept unless --force is given.
+func runDashboardsGenerate(c *cli, args []string) error {
+	fs := c.flagSet("dashboards generate")
+	dir := fs.String("dir", "", "dashboard directory (default: MIGRATION_DASHBOARD_DIRECTORY)")
+	prefix := fs.String("prefix", "sfdc_", "prefix of the generated dashboard names")
+	force := fs.Bool("force", false, "overwrite dashboards that were already generated")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	config, err := c.readConfig()
+	if err != nil {
+		return err
+	}
+	targetConfig, err := c.singleTarget(config)
+	if err != nil {
+		return err
+	}
+
+	dashboardDir := *dir
+	if dashboardDir == "" {
+		dashboardDir = targetConfig.Migration.DashboardDirectory
+	}
+	if dashboardDir == "" {
+		return configError(fmt.Errorf("no dashboard directory configured; set MIGRATION_DASHBOARD_DIRECTORY or pass --dir"))
+	}
+
+	inputs, err := targetConfig.GetDataInputs()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load data inputs: %w", err))
+	}
+	dashboards, err := services.GenerateDashboards(inputs, targetConfig.Splunk.IndexName, *prefix)
+	if err != nil {
+		return configError(fmt.Errorf("failed to generate dashboards: %w", err))
+	}
+
+	written, kept, err := services.WriteGeneratedDashboards(dashboardDir, dashboards, *force)
+	for _, path := range written {
+		fmt.Fprintf(c.out, "  %-10s %s\n", "written", path)
+	}
+	for _, path := range kept {
+		fmt.Fprintf(c.out, "  %-10s %s (already exists; use --force to overwrite)\n", "kept", path)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write dashboards: %w", err)
+	}
+
+	fmt.Fprintf(c.out, "Generated %d dashboard(s) in %s\n", len(written), dashboardDir)
+	return nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// runEncryptConfig encrypts the configuration file with the key from CONFIG_ENCRYPTION_KEY
+// or CONFIG_ENCRYPTION_KEY_FILE. The encrypted file is read like a plain one whenever
+// the key is available.
+func runEncryptConfig(c *cli, args []string) error {
+	fs := c.flagSet("encrypt-config")
+	outPath := fs.String("out", "", "write the encrypted configuration to this file (required)")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+	if *outPath == "" {
+		return configError(fmt.Errorf("encrypt-config requires --out"))
+	}
+
+	inPath := c.opts.ConfigPath
+	if inPath == "" {
+		inPath = "credentials.json"
+	}
+
+	plaintext, err := os.ReadFile(inPath)
+	if err != nil {
+		return configError(fmt.Errorf("failed to read configuration: %w", err))
+	}
+	if utils.IsEncryptedConfig(plaintext) {
+		return configError(fmt.Errorf("%s is already encrypted", inPath))
+	}
+
+	key, err := utils.ConfigKeyFromEnv()
+	if err != nil {
+		return configError(err)
+	}
+	encrypted, err := utils.EncryptConfig(plaintext, key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(*outPath, encrypted, 0o600); err != nil {
+		return fmt.Errorf("failed to write encrypted configuration: %w", err)
+	}
+	utils.GetLogger().Info("🔒 Configuration encrypted", utils.String("path", *outPath))
+	return nil
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import "errors"
+
+// Process exit codes returned by ExitCode
+const (
+	ExitOK             = 0 // the command succeeded
+	ExitFailure        = 1 // the command failed; for apply, no target migrated anything
+	ExitConfigError    = 2 // invalid flags, configuration or target selection
+	ExitPartialFailure = 3 // apply migrated some targets or inputs but not all
+)
+
+// exitError attaches a process exit code to an error
+type exitError struct {
+	code int
+	err  error
+}
+
+func (e *exitError) Error() string {
+	return e.err.Error()
+}
+
+func (e *exitError) Unwrap() error {
+	return e.err
+}
+
+// withExitCode wraps err so that ExitCode reports code for it
+func withExitCode(code int, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &exitError{code: code, err: err}
+}
+
+// configError marks err as a flag, configuration or target selection problem
+func configError(err error) error {
+	return withExitCode(ExitConfigError, err)
+}
+
+// ExitCode returns the process exit code for an error returned by Run
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+
+	var exitErr *exitError
+	if errors.As(err, &exitErr) {
+		return exitErr.code
+	}
+	return ExitFailure
+}
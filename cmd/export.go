@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// exportedConfig is a credentials.json-compatible configuration describing what already
+// exists on a Splunk target. Secrets are replaced by utils.RedactedSecret.
+type exportedConfig struct {
+	SplunkURL           string `json:"SPLUNK_URL"`
+	SplunkUsername      string `json:"SPLUNK_USERNAME,omitempty"`
+	SplunkPassword      string `json:"SPLUNK_PASSWORD"`
+	SplunkTokenName     string `json:"SPLUNK_TOKEN_NAME,omitempty"`
+	SplunkTokenAudience string `json:"SPLUNK_TOKEN_AUDIENCE,omitempty"`
+	SplunkSkipSSLVerify bool   `json:"SPLUNK_SKIP_SSL_VERIFY,omitempty"`
+	SplunkIndexName     string `json:"SPLUNK_INDEX_NAME,omitempty"`
+
+	SalesforceEndpoint     string                    `json:"SALESFORCE_ENDPOINT,omitempty"`
+	SalesforceAPIVersion   string                    `json:"SALESFORCE_API_VERSION,omitempty"`
+	SalesforceAuthType     string                    `json:"SALESFORCE_AUTH_TYPE,omitempty"`
+	SalesforceClientID     string                    `json:"SALESFORCE_CLIENT_ID,omitempty"`
+	SalesforceClientSecret string                    `json:"SALESFORCE_CLIENT_SECRET,omitempty"`
+	SalesforceAccountName  string                    `json:"SALESFORCE_ACCOUNT_NAME,omitempty"`
+	SalesforceAccounts     []utils.SalesforceAccount `json:"SALESFORCE_ACCOUNTS,omitempty"`
+
+	ProxyEnabled  bool   `json:"SALESFORCE_PROXY_ENABLED,omitempty"`
+	ProxyType     string `json:"SALESFORCE_PROXY_TYPE,omitempty"`
+	ProxyURL      string `json:"SALESFORCE_PROXY_URL,omitempty"`
+	ProxyPort     int    `json:"SALESFORCE_PROXY_PORT,omitempty"`
+	ProxyUsername string `json:"SALESFORCE_PROXY_USERNAME,omitempty"`
+	ProxyPassword string `json:"SALESFORCE_PROXY_PASSWORD,omitempty"`
+	ProxyRDNS     bool   `json:"SALESFORCE_PROXY_RDNS,omitempty"`
+
+	DataInputs []utils.DataInput `json:"DATA_INPUTS"`
+}
+
+// runExportConfig writes a configuration built from the sfdc_object inputs, Salesforce
+// accounts and proxy settings that already exist on a target, so that hand-created
+// inputs can be brought under management. Only the Splunk settings of the configuration
+// file are needed.
+func runExportConfig(c *cli, args []string) error {
+	fs := c.flagSet("export-config")
+	outPath := fs.String("out", "", "write the configuration to this file instead of the command output")
+	includeDisabled := fs.Bool("include-disabled", false, "also export disabled inputs, marked disabled")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	config, err := c.readConfig()
+	if err != nil {
+		return err
+	}
+
+	targetConfig, splunkService, _, err := c.connectTarget(ctx, config)
+	if err != nil {
+		return err
+	}
+
+	inputs, err := splunkService.ListDataInputDetails(ctx)
+	if err != nil {
+		return err
+	}
+	accounts, err := splunkService.ListSalesforceAccounts(ctx)
+	if err != nil {
+		return err
+	}
+	proxy, err := splunkService.GetProxySettings(ctx)
+	if err != nil {
+		return err
+	}
+
+	exported, skipped := newExportedConfig(targetConfig, inputs, accounts, proxy, *includeDisabled)
+	for _, name := range skipped {
+		utils.GetLogger().Warn("Disabled data input not exported (use --include-disabled to export it)", utils.String("name", name))
+	}
+
+	data, err := json.MarshalIndent(exported, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode configuration: %w", err)
+	}
+	data = append(data, '\n')
+
+	if *outPath == "" {
+		_, err := c.out.Write(data)
+		return err
+	}
+	if err := os.WriteFile(*outPath, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write configuration: %w", err)
+	}
+	utils.GetLogger().Info("📝 Configuration exported",
+		utils.String("path", *outPath),
+		utils.Int("data_inputs", len(exported.DataInputs)))
+	return nil
+}
+
+// newExportedConfig builds the exported configuration. SPLUNK_INDEX_NAME and
+// SALESFORCE_ACCOUNT_NAME are the index and account used by most inputs. Accounts are
+// exported through the SALESFORCE_* fields when the inputs use one account and through
+// SALESFORCE_ACCOUNTS otherwise. It also returns the names of the disabled inputs that
+// were left out.
+func newExportedConfig(target *utils.Config, inputs []*models.SFDCObjectInput, accounts []*models.SalesforceAccountSettings, proxy *models.ProxySettings, includeDisabled bool) (*exportedConfig, []string) {
+	exported := &exportedConfig{
+		SplunkURL:           target.Splunk.URL,
+		SplunkUsername:      target.Splunk.Username,
+		SplunkPassword:      utils.RedactedSecret,
+		SplunkTokenName:     target.Splunk.TokenName,
+		SplunkTokenAudience: target.Splunk.TokenAudience,
+		SplunkSkipSSLVerify: target.Splunk.SkipSSLVerify,
+		DataInputs:          make([]utils.DataInput, 0, len(inputs)),
+	}
+
+	var skipped []string
+	var indexes, accountNames []string
+	for _, input := range inputs {
+		if input.Disabled && !includeDisabled {
+			skipped = append(skipped, input.Name)
+			continue
+		}
+		var disabled *bool
+		if input.Disabled {
+			disabled = &input.Disabled
+		}
+		exported.DataInputs = append(exported.DataInputs, utils.DataInput{
+			Name:         input.Name,
+			Account:      input.Account,
+			Object:       input.Object,
+			ObjectFields: input.ObjectFields,
+			OrderBy:      input.OrderBy,
+			StartDate:    input.StartDate,
+			Interval:     input.Interval,
+			Delay:        input.Delay,
+			Index:        input.Index,
+			Disabled:     disabled,
+		})
+		indexes = append(indexes, input.Index)
+		accountNames = append(accountNames, input.Account)
+	}
+	sort.Slice(exported.DataInputs, func(i, j int) bool {
+		return exported.DataInputs[i].Name < exported.DataInputs[j].Name
+	})
+	sort.Strings(skipped)
+
+	exported.SplunkIndexName = mostCommon(indexes)
+	exported.SalesforceAccountName = mostCommon(accountNames)
+
+	// Export the accounts the inputs read from, or every account when there are no inputs
+	settings := make(map[string]*models.SalesforceAccountSettings, len(accounts))
+	exportAll := len(accountNames) == 0
+	for _, account := range accounts {
+		settings[account.Name] = account
+		if exportAll {
+			accountNames = append(accountNames, account.Name)
+		}
+	}
+	exportedAccounts := make([]utils.SalesforceAccount, 0)
+	for _, name := range distinctSorted(accountNames) {
+		account := utils.SalesforceAccount{Name: name, ClientSecret: utils.RedactedSecret}
+		if s, ok := settings[name]; ok {
+			account.Endpoint = s.Endpoint
+			account.APIVersion = s.APIVersion
+			account.AuthType = s.AuthType
+			account.ClientID = s.ClientID
+		}
+		exportedAccounts = append(exportedAccounts, account)
+	}
+
+	switch len(exportedAccounts) {
+	case 0:
+	case 1:
+		account := exportedAccounts[0]
+		exported.SalesforceAccountName = account.Name
+		exported.SalesforceEndpoint = account.Endpoint
+		exported.SalesforceAPIVersion = account.APIVersion
+		exported.SalesforceAuthType = account.AuthType
+		exported.SalesforceClientID = account.ClientID
+		exported.SalesforceClientSecret = account.ClientSecret
+	default:
+		exported.SalesforceAccounts = exportedAccounts
+	}
+
+	if proxy != nil && (proxy.Enabled || proxy.URL != "") {
+		exported.ProxyEnabled = proxy.Enabled
+		exported.ProxyType = proxy.Type
+		exported.ProxyURL = proxy.URL
+		exported.ProxyPort = proxy.Port
+		exported.ProxyUsername = proxy.Username
+		exported.ProxyRDNS = proxy.RDNS
+		if proxy.Username != "" {
+			exported.ProxyPassword = utils.RedactedSecret
+		}
+	}
+
+	return exported, skipped
+}
+
+// mostCommon returns the most frequent non-empty value, preferring the smallest on ties
+func mostCommon(values []string) string {
+	counts := make(map[string]int)
+	for _, value := range values {
+		if value != "" {
+			counts[value]++
+		}
+	}
+
+	best := ""
+	for value, count := range counts {
+		if count > counts[best] || (count == counts[best] && value < best) {
+			best = value
+		}
+	}
+	return best
+}
+
+// distinctSorted returns the distinct non-empty values in sorted order
+func distinctSorted(values []string) []string {
+	seen := make(map[string]bool)
+	var distinct []string
+	for _, value := range values {
+		if value != "" && !seen[value] {
+			seen[value] = true
+			distinct = append(distinct, value)
+		}
+	}
+	sort.Strings(distinct)
+	return distinct
+}
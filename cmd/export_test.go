@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+func TestNewExportedConfig(t *testing.T) {
+	target := &utils.Config{
+		Splunk: utils.SplunkConfig{
+			URL:      "https://splunk-dev.example.com:8089",
+			Username: "admin",
+			Password: "changeme",
+		},
+	}
+	accounts := []*models.SalesforceAccountSettings{
+		{Name: "sf_prod", Endpoint: "login.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "prod-id"},
+		{Name: "sf_sandbox", Endpoint: "test.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "sandbox-id"},
+		{Name: "sf_unused", Endpoint: "unused.salesforce.com"},
+	}
+
+	t.Run("Success_SingleAccount", func(t *testing.T) {
+		inputs := []*models.SFDCObjectInput{
+			{Name: "sf_contacts", Account: "sf_prod", Object: "Contact", ObjectFields: "Id,Name", Interval: 300, Delay: 60, Index: "salesforce"},
+			{Name: "sf_accounts", Account: "sf_prod", Object: "Account", ObjectFields: "Id,Name", Interval: 600, Index: "salesforce"},
+			{Name: "sf_leads", Account: "sf_prod", Object: "Lead", ObjectFields: "Id", Index: "sfdc_leads"},
+		}
+
+		exported, skipped := newExportedConfig(target, inputs, accounts, &models.ProxySettings{}, false)
+		assert.Empty(t, skipped)
+		assert.Equal(t, "https://splunk-dev.example.com:8089", exported.SplunkURL)
+		assert.Equal(t, utils.RedactedSecret, exported.SplunkPassword)
+		assert.Equal(t, "salesforce", exported.SplunkIndexName)
+
+		assert.Equal(t, "sf_prod", exported.SalesforceAccountName)
+		assert.Equal(t, "login.salesforce.com", exported.SalesforceEndpoint)
+		assert.Equal(t, "prod-id", exported.SalesforceClientID)
+		assert.Equal(t, utils.RedactedSecret, exported.SalesforceClientSecret)
+		assert.Empty(t, exported.SalesforceAccounts)
+		assert.Empty(t, exported.ProxyURL)
+
+		require.Len(t, exported.DataInputs, 3)
+		assert.Equal(t, "sf_accounts", exported.DataInputs[0].Name)
+		assert.Equal(t, utils.DataInput{
+			Name: "sf_contacts", Account: "sf_prod", Object: "Contact", ObjectFields: "Id,Name", Interval: 300, Delay: 60, Index: "salesforce",
+		}, exported.DataInputs[1])
+	})
+
+	t.Run("Success_SeveralAccounts", func(t *testing.T) {
+		inputs := []*models.SFDCObjectInput{
+			{Name: "sf_accounts", Account: "sf_prod", Object: "Account", Index: "salesforce"},
+			{Name: "sf_contacts", Account: "sf_prod", Object: "Contact", Index: "salesforce"},
+			{Name: "sandbox_accounts", Account: "sf_sandbox", Object: "Account", Index: "salesforce"},
+			{Name: "legacy_leads", Account: "sf_legacy", Object: "Lead", Index: "salesforce"},
+		}
+
+		exported, _ := newExportedConfig(target, inputs, accounts, nil, false)
+		assert.Equal(t, "sf_prod", exported.SalesforceAccountName)
+		assert.Empty(t, exported.SalesforceEndpoint)
+		assert.Empty(t, exported.SalesforceClientSecret)
+
+		require.Len(t, exported.SalesforceAccounts, 3)
+		assert.Equal(t, utils.SalesforceAccount{Name: "sf_legacy", ClientSecret: utils.RedactedSecret}, exported.SalesforceAccounts[0])
+		assert.Equal(t, "sf_prod", exported.SalesforceAccounts[1].Name)
+		assert.Equal(t, "sandbox-id", exported.SalesforceAccounts[2].ClientID)
+		for _, account := range exported.SalesforceAccounts {
+			assert.Equal(t, utils.RedactedSecret, account.ClientSecret)
+		}
+	})
+
+	t.Run("Success_DisabledInputsSkipped", func(t *testing.T) {
+		inputs := []*models.SFDCObjectInput{
+			{Name: "sf_accounts", Account: "sf_prod", Object: "Account"},
+			{Name: "sf_old_cases", Account: "sf_prod", Object: "Case", Disabled: true},
+		}
+
+		exported, skipped := newExportedConfig(target, inputs, accounts, nil, false)
+		assert.Equal(t, []string{"sf_old_cases"}, skipped)
+		require.Len(t, exported.DataInputs, 1)
+
+		exported, skipped = newExportedConfig(target, inputs, accounts, nil, true)
+		assert.Empty(t, skipped)
+		assert.Len(t, exported.DataInputs, 2)
+	})
+
+	t.Run("Success_NoInputsExportsEveryAccount", func(t *testing.T) {
+		exported, _ := newExportedConfig(target, nil, accounts, nil, false)
+		assert.NotNil(t, exported.DataInputs)
+		assert.Empty(t, exported.DataInputs)
+		assert.Len(t, exported.SalesforceAccounts, 3)
+		assert.Empty(t, exported.SalesforceAccountName)
+	})
+
+	t.Run("Success_ProxyPasswordRedacted", func(t *testing.T) {
+		proxy := &models.ProxySettings{Enabled: true, Type: "http", URL: "proxy.corp.local", Port: 3128, Username: "svc"}
+
+		exported, _ := newExportedConfig(target, nil, nil, proxy, false)
+		assert.True(t, exported.ProxyEnabled)
+		assert.Equal(t, "proxy.corp.local", exported.ProxyURL)
+		assert.Equal(t, 3128, exported.ProxyPort)
+		assert.Equal(t, "svc", exported.ProxyUsername)
+		assert.Equal(t, utils.RedactedSecret, exported.ProxyPassword)
+	})
+}
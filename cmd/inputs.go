@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"text/tabwriter"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+// inputSummary is one row of list-inputs output
+type inputSummary struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// connect loads the configuration, resolves the single target and authenticates against it
+func (c *cli) connect(ctx context.Context) (*utils.Config, services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+	config, err := c.loadConfig()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return c.connectTarget(ctx, config)
+}
+
+// connectTarget resolves the single target of config and authenticates against it.
+// The token is revoked when the command finishes.
+func (c *cli) connectTarget(ctx context.Context, config *utils.Config) (*utils.Config, services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+	targetConfig, err := c.singleTarget(config)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	splunkService, dashboardService, err := c.newServices(targetConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	if err := splunkService.Authenticate(ctx); err != nil {
+		return nil, nil, nil, fmt.Errorf("authentication failed: %w", err)
+	}
+	c.atExit(func() { logout(splunkService, utils.GetLogger()) })
+	return targetConfig, splunkService, dashboardService, nil
+}
+
+// runListInputs lists the sfdc_object and sfdc_event_log inputs on a target
+func runListInputs(c *cli, args []string) error {
+	fs := c.flagSet("list-inputs")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, splunkService, _, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	dataInputs, err := splunkService.ListDataInputs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list data inputs: %w", err)
+	}
+	eventLogInputs, err := splunkService.ListEventLogInputs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list event log inputs: %w", err)
+	}
+
+	inputs := make([]inputSummary, 0, len(dataInputs)+len(eventLogInputs))
+	for _, name := range dataInputs {
+		inputs = append(inputs, inputSummary{Type: workflows.ResourceDataInput, Name: name})
+	}
+	for _, name := range eventLogInputs {
+		inputs = append(inputs, inputSummary{Type: workflows.ResourceEventLog, Name: name})
+	}
+
+	if c.opts.Output == outputJSON {
+		return c.writeJSON(inputs)
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TYPE\tNAME")
+	for _, input := range inputs {
+		fmt.Fprintf(w, "%s\t%s\n", input.Type, input.Name)
+	}
+	return w.Flush()
+}
+
+// runGetInput shows the settings of a single sfdc_object input
+func runGetInput(c *cli, args []string) error {
+	fs := c.flagSet("get-input")
+	if err := c.parse(fs, args, 1); err != nil {
+		return err
+	}
+	name := fs.Arg(0)
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, splunkService, _, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	input, err := splunkService.GetDataInput(ctx, name)
+	if err != nil {
+		return err
+	}
+	if input == nil {
+		return fmt.Errorf("data input %s not found", name)
+	}
+
+	if c.opts.Output == outputJSON {
+		return c.writeJSON(input)
+	}
+
+	w := tabwriter.NewWriter(c.out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "name\t%s\n", input.Name)
+	fmt.Fprintf(w, "account\t%s\n", input.Account)
+	fmt.Fprintf(w, "object\t%s\n", input.Object)
+	fmt.Fprintf(w, "object_fields\t%s\n", input.ObjectFields)
+	fmt.Fprintf(w, "order_by\t%s\n", input.OrderBy)
+	fmt.Fprintf(w, "start_date\t%s\n", input.StartDate)
+	fmt.Fprintf(w, "interval\t%d\n", input.Interval)
+	fmt.Fprintf(w, "delay\t%d\n", input.Delay)
+	fmt.Fprintf(w, "index\t%s\n", input.Index)
+	fmt.Fprintf(w, "disabled\t%t\n", input.Disabled)
+	return w.Flush()
+}
+
+// runDisableInput disables a single sfdc_object input
+func runDisableInput(c *cli, args []string) error {
+	fs := c.flagSet("disable-input")
+	if err := c.parse(fs, args, 1); err != nil {
+		return err
+	}
+	name := fs.Arg(0)
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, splunkService, _, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := splunkService.DisableDataInput(ctx, name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "Disabled data input %s\n", name)
+	return nil
+}
+
+// runDeleteInput deletes a single sfdc_object input. Deletion cannot be undone, so like
+// MIGRATION_PRUNE_CONFIRM for prune it only happens with --confirm.
+func runDeleteInput(c *cli, args []string) error {
+	fs := c.flagSet("delete-input")
+	confirm := fs.Bool("confirm", false, "actually delete the input")
+	if err := c.parse(fs, args, 1); err != nil {
+		return err
+	}
+	name := fs.Arg(0)
+
+	if !*confirm {
+		return configError(fmt.Errorf("refusing to delete data input %s without --confirm", name))
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	_, splunkService, _, err := c.connect(ctx)
+	if err != nil {
+		return err
+	}
+
+	if err := splunkService.DeleteDataInput(ctx, name); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(c.out, "Deleted data input %s\n", name)
+	return nil
+}
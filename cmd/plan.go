@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+// targetPlan is the JSON form of one target's plan
+type targetPlan struct {
+	Target  string                     `json:"target"`
+	URL     string                     `json:"url"`
+	Changes []workflows.ResourceChange `json:"changes"`
+}
+
+// runPlanCommand runs the migration graph read-only for every selected target and
+// writes the resulting plans. No POST or DELETE requests are sent to Splunk.
+func runPlanCommand(c *cli, args []string) error {
+	fs := c.flagSet("plan")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	config, err := c.loadConfig()
+	if err != nil {
+		return err
+	}
+
+	targets, err := c.targets(config)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	plans := make([]targetPlan, 0, len(targets))
+	for _, target := range targets {
+		targetConfig := config.ForTarget(target)
+
+		splunkService, _, err := c.newServices(targetConfig)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", target.Name, err)
+		}
+
+		plan, err := buildPlan(ctx, targetConfig, splunkService)
+		if err != nil {
+			return fmt.Errorf("target %s: %w", target.Name, err)
+		}
+
+		if c.opts.Output == outputJSON {
+			plans = append(plans, targetPlan{Target: target.Name, URL: targetConfig.Splunk.URL, Changes: plan.Changes()})
+			continue
+		}
+
+		if len(targets) > 1 {
+			fmt.Fprintf(c.out, "\nTarget %s (%s):\n", target.Name, targetConfig.Splunk.URL)
+		}
+		if err := plan.Render(c.out); err != nil {
+			return err
+		}
+	}
+
+	if c.opts.Output == outputJSON {
+		return c.writeJSON(plans)
+	}
+	return nil
+}
+
+// buildPlan executes the plan graph against the given Splunk service
+func buildPlan(ctx context.Context, config *utils.Config, splunkService services.SplunkServiceInterface) (*workflows.Plan, error) {
+	// Dashboards are never touched in plan mode, so no dashboard service is needed
+	planGraph, err := workflows.NewMigrationPlanGraph(config, splunkService, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create migration plan graph: %w", err)
+	}
+
+	if err := planGraph.Execute(ctx); err != nil {
+		return nil, fmt.Errorf("migration plan failed: %w", err)
+	}
+
+	return planGraph.GetPlan(), nil
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/utils"
+)
+
+func TestPlan(t *testing.T) {
+	err := utils.InitializeGlobalLogger("test", "cmd", false)
+	require.NoError(t, err)
+
+	t.Run("Error_InvalidConfigPath", func(t *testing.T) {
+		os.Setenv("VAULT_PATH", "/nonexistent/path/credentials.json")
+		defer os.Unsetenv("VAULT_PATH")
+
+		var out bytes.Buffer
+		err := Run([]string{"plan"}, &out)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to load configuration")
+		assert.Empty(t, out.String())
+	})
+
+	t.Run("Success_RendersPlan", func(t *testing.T) {
+		config := &utils.Config{
+			Splunk:     utils.SplunkConfig{IndexName: "test_index", DefaultIndex: "test_index"},
+			Salesforce: utils.SalesforceConfig{AccountName: "test_account"},
+			Migration:  utils.MigrationConfig{ConcurrentRequests: 1},
+			Extensions: map[string]interface{}{
+				"DATA_INPUTS": []interface{}{
+					map[string]interface{}{"name": "test_input", "object": "Account", "object_fields": "Id"},
+				},
+			},
+		}
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return true, nil
+			},
+		}
+
+		plan, err := buildPlan(context.Background(), config, mockService)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		require.NoError(t, plan.Render(&out))
+		assert.Contains(t, out.String(), "+ sfdc_object.test_input will be created")
+		assert.Equal(t, 0, mockService.CreateDataInputCalls)
+	})
+}
@@ -0,0 +1,277 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"time"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/utils"
+)
+
+// Statuses of a run and of each target in the run report
+const (
+	statusSucceeded = "succeeded"
+	statusPartial   = "partial"
+	statusFailed    = "failed"
+)
+
+// RunReport is the machine-readable summary written at the end of apply
+type RunReport struct {
+	RunID      string         `json:"run_id"`
+	Status     string         `json:"status"`
+	StartTime  time.Time      `json:"start_time"`
+	EndTime    time.Time      `json:"end_time"`
+	DurationMS int64          `json:"duration_ms"`
+	Targets    []TargetReport `json:"targets"`
+}
+
+// TargetReport is the outcome of one target. The nodes, inputs and dashboards of
+// the target's graph execution are inlined.
+type TargetReport struct {
+	Target  string `json:"target"`
+	URL     string `json:"url"`
+	Status  string `json:"status"`
+	Success int    `json:"success"`
+	Failed  int    `json:"failed"`
+	Error   string `json:"error,omitempty"`
+	// HTTP counts the Splunk requests of the target, retries and throttling included
+	HTTP utils.HTTPStats `json:"http"`
+	*workflows.Report
+}
+
+// newRunReport builds the run report from the target results
+func newRunReport(runID string, startTime time.Time, results []TargetResult) *RunReport {
+	endTime := time.Now()
+	report := &RunReport{
+		RunID:      runID,
+		StartTime:  startTime,
+		EndTime:    endTime,
+		DurationMS: endTime.Sub(startTime).Milliseconds(),
+		Targets:    make([]TargetReport, len(results)),
+	}
+
+	succeeded, failed := 0, 0
+	for i, result := range results {
+		target := TargetReport{
+			Target:  result.Target,
+			URL:     result.URL,
+			Status:  result.status(),
+			Success: result.Success,
+			Failed:  result.Failed,
+			HTTP:    result.HTTP,
+			Report:  result.Report,
+		}
+		if result.Err != nil {
+			target.Error = utils.Redact(result.Err.Error())
+		}
+		report.Targets[i] = target
+
+		switch target.Status {
+		case statusSucceeded:
+			succeeded++
+		case statusFailed:
+			failed++
+		}
+	}
+
+	switch {
+	case succeeded == len(results):
+		report.Status = statusSucceeded
+	case failed == len(results):
+		report.Status = statusFailed
+	default:
+		report.Status = statusPartial
+	}
+	return report
+}
+
+// status classifies a target: partial when it failed after migrating some inputs
+func (r TargetResult) status() string {
+	switch {
+	case r.OK():
+		return statusSucceeded
+	case r.Success > 0:
+		return statusPartial
+	default:
+		return statusFailed
+	}
+}
+
+// writeJSONReport writes the run report as indented JSON
+func writeJSONReport(path string, report *RunReport) error {
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode run report: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+		return fmt.Errorf("failed to write run report: %w", err)
+	}
+	return nil
+}
+
+// JUnit XML elements, as understood by common CI systems
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Time     string           `xml:"time,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	Timestamp string          `xml:"timestamp,attr,omitempty"`
+	Cases     []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// writeJUnitReport writes the run report as JUnit XML: one test suite per target
+// with a test case per node, input and dashboard (or the dashboard directory when no
+// dashboard was deployed)
+func writeJUnitReport(path string, report *RunReport) error {
+	suites := junitTestSuites{
+		Name: "salesforce-splunk-migration " + report.RunID,
+		Time: junitSeconds(report.DurationMS),
+	}
+	for _, target := range report.Targets {
+		suite := newJUnitSuite(target)
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JUnit report: %w", err)
+	}
+	data = append([]byte(xml.Header), append(data, '\n')...)
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write JUnit report: %w", err)
+	}
+	return nil
+}
+
+// newJUnitSuite converts one target report into a test suite
+func newJUnitSuite(target TargetReport) junitTestSuite {
+	suite := junitTestSuite{Name: target.Target, Time: junitSeconds(0)}
+
+	if target.Report == nil {
+		// The target failed before its migration graph ran
+		suite.Cases = append(suite.Cases, junitTestCase{
+			Name:      "setup",
+			ClassName: target.Target,
+			Time:      junitSeconds(0),
+			Failure:   &junitFailure{Message: target.Error, Type: "error", Text: target.Error},
+		})
+	} else {
+		suite.Time = junitSeconds(target.DurationMS)
+		suite.Timestamp = target.StartTime.UTC().Format(time.RFC3339)
+
+		for _, node := range target.Nodes {
+			tc := junitTestCase{Name: node.ID, ClassName: target.Target + ".nodes", Time: junitSeconds(node.DurationMS)}
+			switch node.Status {
+			case workflows.NodeFailed:
+				tc.Failure = &junitFailure{Message: node.Error, Type: "error", Text: node.Error}
+			case workflows.NodeSkipped:
+				tc.Skipped = &junitSkipped{Message: "completed in a previous attempt"}
+			case workflows.NodeNotRun:
+				tc.Skipped = &junitSkipped{Message: "not run"}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		for _, input := range target.Inputs {
+			tc := junitTestCase{Name: input.Name, ClassName: target.Target + "." + input.Type, Time: junitSeconds(0)}
+			switch input.Action {
+			case workflows.ActionFailed:
+				tc.Failure = &junitFailure{Message: input.Error, Type: junitFailureType(input.HTTPStatus), Text: input.Error}
+			case workflows.ActionSkipped:
+				tc.Skipped = &junitSkipped{Message: input.Reason}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+
+		if dashboards := target.Dashboards; dashboards != nil {
+			suite.Cases = append(suite.Cases, newJUnitDashboardCases(target.Target, dashboards)...)
+		}
+	}
+
+	for _, tc := range suite.Cases {
+		suite.Tests++
+		if tc.Failure != nil {
+			suite.Failures++
+		}
+		if tc.Skipped != nil {
+			suite.Skipped++
+		}
+	}
+	return suite
+}
+
+// newJUnitDashboardCases returns a test case per deployed dashboard, or a single case
+// for the dashboard directory when it was skipped or failed before any dashboard
+func newJUnitDashboardCases(target string, dashboards *workflows.DashboardReport) []junitTestCase {
+	if len(dashboards.Files) == 0 {
+		tc := junitTestCase{Name: dashboards.Directory, ClassName: target + ".dashboards", Time: junitSeconds(0)}
+		switch dashboards.Action {
+		case workflows.ActionFailed:
+			tc.Failure = &junitFailure{Message: dashboards.Error, Type: junitFailureType(dashboards.HTTPStatus), Text: dashboards.Error}
+		case workflows.ActionSkipped:
+			tc.Skipped = &junitSkipped{Message: dashboards.Reason}
+		}
+		return []junitTestCase{tc}
+	}
+
+	cases := make([]junitTestCase, 0, len(dashboards.Files))
+	for _, file := range dashboards.Files {
+		tc := junitTestCase{Name: file.Name, ClassName: target + ".dashboards", Time: junitSeconds(0)}
+		switch file.Action {
+		case workflows.ActionFailed:
+			tc.Failure = &junitFailure{Message: file.Error, Type: junitFailureType(file.HTTPStatus), Text: file.Error}
+		case workflows.ActionSkipped:
+			tc.Skipped = &junitSkipped{Message: file.Reason}
+		}
+		cases = append(cases, tc)
+	}
+	return cases
+}
+
+// junitFailureType names a failure after its HTTP status when there is one
+func junitFailureType(httpStatus int) string {
+	if httpStatus == 0 {
+		return "error"
+	}
+	return fmt.Sprintf("http_%d", httpStatus)
+}
+
+// junitSeconds formats a duration in milliseconds as JUnit seconds
+func junitSeconds(ms int64) string {
+	return fmt.Sprintf("%.3f", float64(ms)/1000)
+}
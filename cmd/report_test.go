@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/utils"
+)
+
+func TestNewRunReport(t *testing.T) {
+	startTime := time.Now().Add(-time.Second)
+	succeeded := TargetResult{Target: "dev", Success: 2, HTTP: utils.HTTPStats{Requests: 12, Retries: 3, RateLimited: 2}}
+	partial := TargetResult{Target: "qa", Success: 1, Failed: 1, Err: errors.New("1 data inputs failed to create")}
+	failed := TargetResult{Target: "prod", Err: errors.New("authentication failed")}
+
+	tests := []struct {
+		name    string
+		results []TargetResult
+		want    string
+	}{
+		{name: "AllSucceeded", results: []TargetResult{succeeded}, want: statusSucceeded},
+		{name: "AllFailed", results: []TargetResult{failed}, want: statusFailed},
+		{name: "SomeInputsFailed", results: []TargetResult{partial}, want: statusPartial},
+		{name: "SomeTargetsFailed", results: []TargetResult{succeeded, failed}, want: statusPartial},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := newRunReport("run-1", startTime, tt.results)
+			assert.Equal(t, tt.want, report.Status)
+			assert.Equal(t, "run-1", report.RunID)
+			assert.Len(t, report.Targets, len(tt.results))
+			assert.GreaterOrEqual(t, report.DurationMS, int64(1000))
+		})
+	}
+
+	t.Run("Success_TargetStatusAndError", func(t *testing.T) {
+		report := newRunReport("run-1", startTime, []TargetResult{succeeded, partial, failed})
+		assert.Equal(t, statusSucceeded, report.Targets[0].Status)
+		assert.Empty(t, report.Targets[0].Error)
+		assert.Equal(t, int64(3), report.Targets[0].HTTP.Retries)
+		assert.Equal(t, int64(2), report.Targets[0].HTTP.RateLimited)
+		assert.Equal(t, statusPartial, report.Targets[1].Status)
+		assert.Equal(t, statusFailed, report.Targets[2].Status)
+		assert.Equal(t, "authentication failed", report.Targets[2].Error)
+	})
+
+	t.Run("Success_ErrorRedacted", func(t *testing.T) {
+		utils.RegisterSecret("report-Pa55word")
+		leaked := TargetResult{Target: "prod", Err: errors.New("token authentication failed with status 401: bad password report-Pa55word")}
+		report := newRunReport("run-1", startTime, []TargetResult{leaked})
+		assert.NotContains(t, report.Targets[0].Error, "report-Pa55word")
+		assert.Contains(t, report.Targets[0].Error, "status 401")
+	})
+}
+
+func newTestRunReport() *RunReport {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	return &RunReport{
+		RunID:      "run-1",
+		Status:     statusPartial,
+		StartTime:  start,
+		EndTime:    start.Add(2 * time.Second),
+		DurationMS: 2000,
+		Targets: []TargetReport{
+			{
+				Target:  "dev",
+				Status:  statusPartial,
+				Success: 1,
+				Failed:  1,
+				Report: &workflows.Report{
+					StartTime:  start,
+					DurationMS: 1500,
+					Nodes: []workflows.NodeReport{
+						{ID: "authenticate", Status: workflows.NodeSucceeded, DurationMS: 250},
+						{ID: "create_data_inputs", Status: workflows.NodeFailed, DurationMS: 1000, Error: "1 data inputs failed to create"},
+						{ID: "create_dashboards", Status: workflows.NodeNotRun},
+					},
+					Inputs: []workflows.InputReport{
+						{Type: workflows.InputTypeObject, Name: "sf_accounts", Action: workflows.ActionSkipped, Reason: "unchanged"},
+						{Type: workflows.InputTypeObject, Name: "sf_contacts", Action: workflows.ActionFailed, Error: "status 409 - conflict", HTTPStatus: 409},
+					},
+				},
+			},
+			{Target: "prod", Status: statusFailed, Error: "unsupported splunk type"},
+		},
+	}
+}
+
+func TestWriteJSONReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.json")
+	require.NoError(t, writeJSONReport(path, newTestRunReport()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, "partial", decoded["status"])
+
+	targets := decoded["targets"].([]interface{})
+	require.Len(t, targets, 2)
+	dev := targets[0].(map[string]interface{})
+	assert.Len(t, dev["nodes"], 3)
+	failedInput := dev["inputs"].([]interface{})[1].(map[string]interface{})
+	assert.Equal(t, "failed", failedInput["action"])
+	assert.Equal(t, float64(409), failedInput["http_status"])
+
+	prod := targets[1].(map[string]interface{})
+	assert.Equal(t, "unsupported splunk type", prod["error"])
+	assert.NotContains(t, prod, "nodes")
+}
+
+func TestWriteJUnitReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "junit.xml")
+	require.NoError(t, writeJUnitReport(path, newTestRunReport()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 6, suites.Tests)
+	assert.Equal(t, 3, suites.Failures)
+	assert.Equal(t, 2, suites.Skipped)
+	require.Len(t, suites.Suites, 2)
+
+	dev := suites.Suites[0]
+	assert.Equal(t, "1.500", dev.Time)
+	assert.Equal(t, "2026-01-02T03:04:05Z", dev.Timestamp)
+	require.Len(t, dev.Cases, 5)
+	assert.Equal(t, "dev.nodes", dev.Cases[0].ClassName)
+	assert.Nil(t, dev.Cases[0].Failure)
+	require.NotNil(t, dev.Cases[1].Failure)
+	assert.Equal(t, "error", dev.Cases[1].Failure.Type)
+	require.NotNil(t, dev.Cases[2].Skipped)
+	assert.Equal(t, "dev.sfdc_object", dev.Cases[4].ClassName)
+	require.NotNil(t, dev.Cases[4].Failure)
+	assert.Equal(t, "http_409", dev.Cases[4].Failure.Type)
+
+	prod := suites.Suites[1]
+	require.Len(t, prod.Cases, 1)
+	assert.Equal(t, "setup", prod.Cases[0].Name)
+	require.NotNil(t, prod.Cases[0].Failure)
+	assert.Equal(t, "unsupported splunk type", prod.Cases[0].Failure.Message)
+}
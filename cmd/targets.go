@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+// TargetResult is the outcome of migrating one Splunk target
+type TargetResult struct {
+	Target   string
+	URL      string
+	Success  int
+	Failed   int
+	Duration time.Duration
+	Err      error
+	Report   *workflows.Report // nil when the migration graph could not be created
+	HTTP     utils.HTTPStats   // Splunk requests, retries and throttling of the target
+}
+
+// OK reports whether the target migrated without errors or failed inputs
+func (r TargetResult) OK() bool {
+	return r.Err == nil && r.Failed == 0
+}
+
+// serviceFactory creates the Splunk and dashboard services for one target configuration
+type serviceFactory func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error)
+
+// runSession identifies the persisted run that target migrations record their progress
+// in. A zero session runs without persisted state.
+type runSession struct {
+	store  *workflows.RunStore
+	id     string
+	resume bool
+}
+
+// newTargetServices creates real services that talk to the target's Splunk instance
+func newTargetServices(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+	splunkService, err := services.NewSplunkService(config)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Splunk service: %w", err)
+	}
+
+	dashboardService, err := services.NewDashboardService(config, splunkService)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create Dashboard service: %w", err)
+	}
+
+	return splunkService, dashboardService, nil
+}
+
+// logoutTimeout bounds the revocation of a target's Splunk token
+const logoutTimeout = 30 * time.Second
+
+// logout revokes the Splunk token a service created. It uses its own context so that
+// the token is revoked even when the run was cancelled or timed out.
+func logout(splunkService services.SplunkServiceInterface, logger utils.Logger) {
+	ctx, cancel := context.WithTimeout(context.Background(), logoutTimeout)
+	defer cancel()
+	if err := splunkService.Logout(ctx); err != nil {
+		logger.Warn("Failed to revoke Splunk token", utils.Err(err))
+	}
+}
+
+// runTargets migrates the given targets, at most MIGRATION_MAX_PARALLEL_TARGETS at a time.
+// Results are returned in target order.
+func runTargets(ctx context.Context, config *utils.Config, targets []utils.Target, newServices serviceFactory, session runSession) []TargetResult {
+	maxParallel := config.Migration.MaxParallelTargets
+	if maxParallel < 1 {
+		maxParallel = 1
+	}
+
+	utils.GetLogger().Info("🎯 Migrating Splunk targets",
+		utils.Int("count", len(targets)),
+		utils.Int("max_parallel", maxParallel))
+
+	results := make([]TargetResult, len(targets))
+	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, maxParallel)
+
+	for i, target := range targets {
+		wg.Add(1)
+		go func(idx int, tgt utils.Target) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			results[idx] = runTarget(ctx, config.ForTarget(tgt), tgt.Name, newServices, session)
+		}(i, target)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// runTarget runs the migration graph against a single target with its own services
+func runTarget(ctx context.Context, config *utils.Config, name string, newServices serviceFactory, session runSession) TargetResult {
+	logger := utils.GetLogger().With(utils.String("target", name))
+	result := TargetResult{Target: name, URL: config.Splunk.URL}
+	startTime := time.Now()
+
+	splunkService, dashboardService, err := newServices(config)
+	if err != nil {
+		result.Err = err
+		result.Duration = time.Since(startTime)
+		return result
+	}
+	defer logout(splunkService, logger)
+
+	migrationGraph, err := newTargetGraph(ctx, config, name, splunkService, dashboardService, session)
+	if err != nil {
+		result.Err = fmt.Errorf("failed to create migration graph: %w", err)
+		result.Duration = time.Since(startTime)
+		return result
+	}
+	migrationGraph.SetLogger(logger)
+
+	if err := migrationGraph.Execute(ctx); err != nil {
+		result.Err = fmt.Errorf("migration workflow failed: %w", err)
+	}
+
+	result.Success, result.Failed = migrationGraph.GetState().GetCounters()
+	result.Report = migrationGraph.Report()
+	result.HTTP = splunkService.HTTPStats()
+	result.Duration = time.Since(startTime)
+	return result
+}
+
+// newTargetGraph creates the migration graph for one target, restoring the target's
+// progress when the session resumes an earlier run
+func newTargetGraph(ctx context.Context, config *utils.Config, name string, splunkService services.SplunkServiceInterface, dashboardService services.DashboardServiceInterface, session runSession) (*workflows.MigrationGraph, error) {
+	if session.store == nil {
+		return workflows.NewMigrationGraph(config, splunkService, dashboardService)
+	}
+
+	run := workflows.NewRunState(session.id, name)
+	if session.resume {
+		var err error
+		if run, err = session.store.Load(ctx, session.id, name); err != nil {
+			return nil, err
+		}
+	}
+	return workflows.NewResumableMigrationGraph(config, splunkService, dashboardService, session.store, run)
+}
+
+// logTargetSummary logs one line per target and returns an error naming the failed targets
+func logTargetSummary(results []TargetResult) error {
+	logger := utils.GetLogger()
+
+	var failedTargets []string
+	for _, result := range results {
+		fields := []utils.Field{
+			utils.String("target", result.Target),
+			utils.String("url", result.URL),
+			utils.Int("success", result.Success),
+			utils.Int("failed", result.Failed),
+			utils.Duration("duration", result.Duration),
+			utils.Int64("requests", result.HTTP.Requests),
+			utils.Int64("retries", result.HTTP.Retries),
+			utils.Int64("rate_limited", result.HTTP.RateLimited),
+		}
+		if result.HTTP.CircuitOpened > 0 {
+			fields = append(fields,
+				utils.Int64("circuit_opened", result.HTTP.CircuitOpened),
+				utils.Int64("rejected", result.HTTP.Rejected))
+		}
+
+		if result.OK() {
+			logger.Info("✅ Target migrated", fields...)
+			continue
+		}
+
+		failedTargets = append(failedTargets, result.Target)
+		if result.Err != nil {
+			fields = append(fields, utils.Err(result.Err))
+		}
+		logger.Error("❌ Target failed", fields...)
+	}
+
+	logger.Info("📋 Migration summary",
+		utils.Int("targets", len(results)),
+		utils.Int("succeeded", len(results)-len(failedTargets)),
+		utils.Int("failed", len(failedTargets)))
+
+	if len(failedTargets) > 0 {
+		return fmt.Errorf("migration failed for %d of %d targets: %s", len(failedTargets), len(results), strings.Join(failedTargets, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+func newTargetsTestConfig() *utils.Config {
+	return &utils.Config{
+		Splunk: utils.SplunkConfig{
+			URL:          "https://splunk-dev.example.com:8089",
+			Username:     "admin",
+			Password:     "changeme",
+			IndexName:    "salesforce",
+			DefaultIndex: "salesforce",
+		},
+		Salesforce: utils.SalesforceConfig{AccountName: "sf_prod"},
+		Migration:  utils.MigrationConfig{ConcurrentRequests: 1, MaxParallelTargets: 2},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "sf_accounts", "object": "Account", "object_fields": "Id"},
+			},
+			"TARGETS": []interface{}{
+				map[string]interface{}{"name": "dev"},
+				map[string]interface{}{"name": "uat", "splunk_url": "https://splunk-uat.example.com:8089", "index_name": "sfdc_uat"},
+				map[string]interface{}{"name": "prod", "splunk_url": "https://splunk-prod.example.com:8089", "username": "svc_migration", "password": "prod-secret", "default_index": "sfdc_prod"},
+			},
+		},
+	}
+}
+
+func mustTargets(t *testing.T, config *utils.Config) []utils.Target {
+	t.Helper()
+	targets, err := config.GetTargets()
+	require.NoError(t, err)
+	return targets
+}
+
+func TestRunTargets(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "cmd", false))
+
+	t.Run("Success_EachTargetGetsOwnServicesAndOverrides", func(t *testing.T) {
+		var mu sync.Mutex
+		seen := make(map[string]utils.SplunkConfig)
+		factory := func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+			mu.Lock()
+			seen[config.Splunk.URL] = config.Splunk
+			mu.Unlock()
+			return &mocks.MockSplunkService{}, &mocks.MockDashboardService{}, nil
+		}
+
+		results := runTargets(context.Background(), newTargetsTestConfig(), mustTargets(t, newTargetsTestConfig()), factory, runSession{})
+		require.Len(t, results, 3)
+
+		assert.Equal(t, []string{"dev", "uat", "prod"}, []string{results[0].Target, results[1].Target, results[2].Target})
+		for _, result := range results {
+			assert.True(t, result.OK(), "target %s should succeed: %v", result.Target, result.Err)
+			assert.Equal(t, 1, result.Success)
+		}
+		require.NoError(t, logTargetSummary(results))
+
+		require.Len(t, seen, 3)
+		assert.Equal(t, "admin", seen["https://splunk-dev.example.com:8089"].Username)
+		assert.Equal(t, "salesforce", seen["https://splunk-dev.example.com:8089"].IndexName)
+		assert.Equal(t, "sfdc_uat", seen["https://splunk-uat.example.com:8089"].IndexName)
+		assert.Equal(t, "salesforce", seen["https://splunk-uat.example.com:8089"].DefaultIndex)
+		assert.Equal(t, "svc_migration", seen["https://splunk-prod.example.com:8089"].Username)
+		assert.Equal(t, "prod-secret", seen["https://splunk-prod.example.com:8089"].Password)
+		assert.Equal(t, "sfdc_prod", seen["https://splunk-prod.example.com:8089"].IndexName)
+	})
+
+	t.Run("Error_OneTargetFails", func(t *testing.T) {
+		factory := func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+			mockService := &mocks.MockSplunkService{}
+			if config.Splunk.URL == "https://splunk-uat.example.com:8089" {
+				mockService.AuthenticateFunc = func(ctx context.Context) error {
+					return fmt.Errorf("401 unauthorized")
+				}
+			}
+			return mockService, &mocks.MockDashboardService{}, nil
+		}
+
+		results := runTargets(context.Background(), newTargetsTestConfig(), mustTargets(t, newTargetsTestConfig()), factory, runSession{})
+
+		assert.True(t, results[0].OK())
+		assert.False(t, results[1].OK())
+		assert.Contains(t, results[1].Err.Error(), "401 unauthorized")
+		assert.True(t, results[2].OK())
+
+		err := logTargetSummary(results)
+		require.Error(t, err)
+		assert.Equal(t, "migration failed for 1 of 3 targets: uat", err.Error())
+	})
+
+	t.Run("Error_ServiceCreationFails", func(t *testing.T) {
+		factory := func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+			return nil, nil, fmt.Errorf("failed to create Splunk service")
+		}
+
+		results := runTargets(context.Background(), newTargetsTestConfig(), mustTargets(t, newTargetsTestConfig()), factory, runSession{})
+		for _, result := range results {
+			assert.False(t, result.OK())
+		}
+		require.Error(t, logTargetSummary(results))
+	})
+
+	t.Run("Success_BoundedParallelism", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		factory := func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+			mockService := &mocks.MockSplunkService{
+				AuthenticateFunc: func(ctx context.Context) error {
+					current := atomic.AddInt32(&inFlight, 1)
+					defer atomic.AddInt32(&inFlight, -1)
+					for {
+						seenMax := atomic.LoadInt32(&maxInFlight)
+						if current <= seenMax || atomic.CompareAndSwapInt32(&maxInFlight, seenMax, current) {
+							break
+						}
+					}
+					time.Sleep(20 * time.Millisecond)
+					return nil
+				},
+			}
+			return mockService, &mocks.MockDashboardService{}, nil
+		}
+
+		config := newTargetsTestConfig()
+		config.Migration.MaxParallelTargets = 2
+
+		results := runTargets(context.Background(), config, mustTargets(t, config), factory, runSession{})
+		require.Len(t, results, 3)
+		assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(2))
+	})
+
+	t.Run("Success_WithoutTargetsSection", func(t *testing.T) {
+		config := newTargetsTestConfig()
+		delete(config.Extensions, "TARGETS")
+
+		factory := func(config *utils.Config) (services.SplunkServiceInterface, services.DashboardServiceInterface, error) {
+			return &mocks.MockSplunkService{}, &mocks.MockDashboardService{}, nil
+		}
+
+		results := runTargets(context.Background(), config, mustTargets(t, config), factory, runSession{})
+		require.Len(t, results, 1)
+		assert.Equal(t, "default", results[0].Target)
+		assert.Equal(t, "https://splunk-dev.example.com:8089", results[0].URL)
+	})
+}
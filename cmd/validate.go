@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// validationResult is the JSON form of a validation run
+type validationResult struct {
+	Valid          bool                    `json:"valid"`
+	Errors         []utils.ValidationError `json:"errors,omitempty"`
+	Targets        []string                `json:"targets,omitempty"`
+	Accounts       []string                `json:"accounts,omitempty"`
+	DataInputs     int                     `json:"data_inputs"`
+	EventLogInputs int                     `json:"event_log_inputs"`
+}
+
+// runValidate validates the configuration without contacting Splunk and reports
+// every problem with the JSON path of the offending value
+func runValidate(c *cli, args []string) error {
+	fs := c.flagSet("validate")
+	if err := c.parse(fs, args, 0); err != nil {
+		return err
+	}
+
+	config, err := c.readConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := config.Validate(); err != nil {
+		var problems utils.ValidationErrors
+		if !errors.As(err, &problems) {
+			return configError(fmt.Errorf("configuration validation failed: %w", err))
+		}
+		if err := c.writeValidationProblems(problems); err != nil {
+			return err
+		}
+		return configError(fmt.Errorf("configuration validation failed with %d problem(s)", len(problems)))
+	}
+
+	result := validationResult{Valid: true}
+
+	targets, err := c.targets(config)
+	if err != nil {
+		return err
+	}
+	for _, target := range targets {
+		result.Targets = append(result.Targets, target.Name)
+	}
+
+	accounts, err := config.GetSalesforceAccounts()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load Salesforce accounts: %w", err))
+	}
+	for _, account := range accounts {
+		result.Accounts = append(result.Accounts, account.Name)
+	}
+
+	dataInputs, err := config.GetDataInputs()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load data inputs: %w", err))
+	}
+	result.DataInputs = len(dataInputs)
+
+	eventLogInputs, err := config.GetEventLogInputs()
+	if err != nil {
+		return configError(fmt.Errorf("failed to load event log inputs: %w", err))
+	}
+	result.EventLogInputs = len(eventLogInputs)
+
+	if c.opts.Output == outputJSON {
+		return c.writeJSON(result)
+	}
+
+	fmt.Fprintf(c.out, "Configuration is valid: %d target(s), %d Salesforce account(s), %d data input(s), %d event log input(s)\n",
+		len(result.Targets), len(result.Accounts), result.DataInputs, result.EventLogInputs)
+	return nil
+}
+
+// writeValidationProblems writes one line (or JSON entry) per validation problem
+func (c *cli) writeValidationProblems(problems utils.ValidationErrors) error {
+	if c.opts.Output == outputJSON {
+		return c.writeJSON(validationResult{Valid: false, Errors: problems})
+	}
+
+	fmt.Fprintf(c.out, "Configuration has %d problem(s):\n", len(problems))
+	for _, problem := range problems {
+		fmt.Fprintf(c.out, "  %s: %s\n", problem.Path, problem.Message)
+	}
+	return nil
+}
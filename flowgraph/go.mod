@@ -1,8 +1,6 @@
 module github.com/flowgraph/flowgraph
 
-go 1.21
-
-toolchain go1.21.0
+go 1.23.0
 
 require (
 	github.com/go-playground/validator/v10 v10.27.0
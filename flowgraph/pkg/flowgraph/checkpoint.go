@@ -0,0 +1,39 @@
+package flowgraph
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/flowgraph/flowgraph/internal/adapters/repository/sqlite"
+	"github.com/flowgraph/flowgraph/internal/core/checkpoint"
+	"github.com/flowgraph/flowgraph/pkg/serialization"
+)
+
+// Re-export checkpoint types so callers can persist and inspect execution state
+type Checkpoint = checkpoint.Checkpoint
+type CheckpointMetadata = checkpoint.Metadata
+type CheckpointFilter = checkpoint.Filter
+type CheckpointSaver = checkpoint.Saver
+type SQLiteCheckpointSaver = sqlite.CheckpointSaver
+
+// ErrCheckpointNotFound is returned when a checkpoint ID does not exist
+var ErrCheckpointNotFound = checkpoint.ErrCheckpointNotFound
+
+// OpenSQLiteCheckpointSaver opens (or creates) a SQLite database at path and
+// prepares the checkpoint tables. The caller owns the saver and must Close it.
+func OpenSQLiteCheckpointSaver(ctx context.Context, path string) (*SQLiteCheckpointSaver, error) {
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open checkpoint database: %w", err)
+	}
+	// SQLite allows a single writer; serialize access through one connection
+	db.SetMaxOpenConns(1)
+
+	saver := sqlite.NewCheckpointSaver(db, serialization.DefaultSerializer())
+	if err := saver.CreateTables(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return saver, nil
+}
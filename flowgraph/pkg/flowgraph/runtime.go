@@ -53,9 +53,14 @@ func NewRuntime() *Runtime {
 // This allows callers to register custom node types without reimplementing the
 // rest of the runtime wiring.
 func NewRuntimeWithNodeProcessor(nodeProcessor usecases.NodeProcessor) *Runtime {
+	return NewRuntimeWithCheckpointSaver(nodeProcessor, memory.DefaultInMemorySaver())
+}
+
+// NewRuntimeWithCheckpointSaver constructs a runtime using the supplied node processor
+// and checkpoint saver, e.g. a SQLite saver so that checkpoints outlive the process.
+func NewRuntimeWithCheckpointSaver(nodeProcessor usecases.NodeProcessor, saver CheckpointSaver) *Runtime {
 	edgeEvaluator := usecases.NewDefaultEdgeEvaluator()
 	stateManager := services.NewStateService()
-	saver := memory.DefaultInMemorySaver()
 	checkpointManager := services.NewCheckpointService(saver)
 	repo := graphrepo.NewInMemoryGraphRepository()
 	executor := usecases.NewDefaultGraphExecutor(nodeProcessor, edgeEvaluator, stateManager, checkpointManager, repo)
@@ -2,9 +2,11 @@ package flowgraph
 
 import (
     "context"
+    "path/filepath"
     "testing"
 
     "github.com/flowgraph/flowgraph/internal/app/dto"
+    "github.com/flowgraph/flowgraph/internal/app/usecases"
     coregraph "github.com/flowgraph/flowgraph/internal/core/graph"
     "github.com/stretchr/testify/assert"
     "github.com/stretchr/testify/require"
@@ -31,3 +33,36 @@ func TestRuntime_RunSimple(t *testing.T) {
     assert.Equal(t, dto.ExecutionStatusCompleted, resp.Status)
 }
 
+func TestRuntime_SQLiteCheckpoints(t *testing.T) {
+    ctx := context.Background()
+    path := filepath.Join(t.TempDir(), "state.db")
+
+    saver, err := OpenSQLiteCheckpointSaver(ctx, path)
+    require.NoError(t, err)
+    rt := NewRuntimeWithCheckpointSaver(usecases.NewDefaultNodeProcessor(), saver)
+
+    g := &coregraph.Graph{
+        ID:         "rt-sqlite-graph",
+        Name:       "Runtime SQLite Graph",
+        EntryPoint: "start",
+        Nodes: map[string]*coregraph.Node{
+            "start": {ID: "start", Name: "Start", Type: coregraph.NodeTypeFunction},
+        },
+        Edges: []*coregraph.Edge{},
+    }
+
+    _, err = rt.RunSimple(ctx, g, "thread-1", map[string]interface{}{"msg": "hi"})
+    require.NoError(t, err)
+    require.NoError(t, saver.Close())
+
+    // Checkpoints survive reopening the database
+    reopened, err := OpenSQLiteCheckpointSaver(ctx, path)
+    require.NoError(t, err)
+    defer reopened.Close()
+
+    checkpoints, err := reopened.List(ctx, CheckpointFilter{ThreadID: "thread-1"})
+    require.NoError(t, err)
+    require.NotEmpty(t, checkpoints)
+    assert.Equal(t, "rt-sqlite-graph", checkpoints[0].GraphID)
+    assert.Equal(t, "hi", checkpoints[0].State["msg"])
+}
@@ -0,0 +1,126 @@
+package splunktest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// Stack is the Splunk Cloud stack served by the Admin Config Service (ACS) emulation.
+// Point SPLUNK_ACS_URL at Server.URL and set SPLUNK_STACK to Stack.
+const Stack = "splunktest"
+
+// secondsPerDay converts between ACS searchableDays and frozenTimePeriodInSecs
+const secondsPerDay = 24 * 60 * 60
+
+// acsIndex is an index as ACS describes it
+type acsIndex struct {
+	Name           string `json:"name"`
+	Datatype       string `json:"datatype"`
+	SearchableDays int    `json:"searchableDays"`
+	MaxDataSizeMB  int    `json:"maxDataSizeMB"`
+}
+
+// isACS reports whether a request is for the Admin Config Service of Stack
+func isACS(segments []string) bool {
+	return matchPath(segments, Stack, "adminconfig")
+}
+
+// handleACSIndexes serves /{stack}/adminconfig/v2/indexes. Indexes are shared with
+// data/indexes, so an index created through ACS is visible to the search head.
+func (s *Server) handleACSIndexes(w http.ResponseWriter, r *http.Request, rest []string) {
+	switch {
+	case r.Method == http.MethodGet && len(rest) == 0:
+		var indexes []acsIndex
+		for _, name := range s.names(collectionIndexes) {
+			content, _ := s.get(collectionIndexes, name)
+			indexes = append(indexes, toACSIndex(name, content))
+		}
+		writeJSON(w, http.StatusOK, indexes)
+	case r.Method == http.MethodGet && len(rest) == 1:
+		content, ok := s.get(collectionIndexes, rest[0])
+		if !ok {
+			writeACSError(w, http.StatusNotFound, "404-index-not-found", fmt.Sprintf("index=%s not found", rest[0]))
+			return
+		}
+		writeJSON(w, http.StatusOK, toACSIndex(rest[0], content))
+	case r.Method == http.MethodPost && len(rest) == 0:
+		s.createACSIndex(w, r)
+	case r.Method == http.MethodDelete && len(rest) == 1:
+		s.mu.Lock()
+		_, ok := s.collections[collectionIndexes][rest[0]]
+		delete(s.collections[collectionIndexes], rest[0])
+		s.mu.Unlock()
+		if !ok {
+			writeACSError(w, http.StatusNotFound, "404-index-not-found", fmt.Sprintf("index=%s not found", rest[0]))
+			return
+		}
+		writeJSON(w, http.StatusAccepted, map[string]string{"name": rest[0]})
+	default:
+		writeACSError(w, http.StatusMethodNotAllowed, "405-method-not-allowed", "method not allowed")
+	}
+}
+
+// createACSIndex creates an index from a JSON request and answers 202, as ACS
+// provisions indexes asynchronously
+func (s *Server) createACSIndex(w http.ResponseWriter, r *http.Request) {
+	var request acsIndex
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		writeACSError(w, http.StatusBadRequest, "400-bad-request", fmt.Sprintf("invalid request body: %v", err))
+		return
+	}
+	if request.Name == "" {
+		writeACSError(w, http.StatusBadRequest, "400-bad-request", "name is required")
+		return
+	}
+	if request.Datatype == "" {
+		request.Datatype = "event"
+	}
+	if request.Datatype != "event" && request.Datatype != "metric" {
+		writeACSError(w, http.StatusBadRequest, "400-bad-request", fmt.Sprintf("invalid datatype %q", request.Datatype))
+		return
+	}
+
+	content := map[string]string{
+		"datatype":           request.Datatype,
+		"maxTotalDataSizeMB": strconv.Itoa(request.MaxDataSizeMB),
+		"disabled":           "0",
+	}
+	if request.SearchableDays > 0 {
+		content["frozenTimePeriodInSecs"] = strconv.Itoa(request.SearchableDays * secondsPerDay)
+	}
+
+	s.mu.Lock()
+	indexes := s.collectionLocked(collectionIndexes)
+	if _, exists := indexes[request.Name]; exists {
+		s.mu.Unlock()
+		writeACSError(w, http.StatusConflict, "409-index-already-exists", fmt.Sprintf("index=%s already exists", request.Name))
+		return
+	}
+	indexes[request.Name] = &object{
+		name:    request.Name,
+		content: content,
+		acl:     ACL{App: "search", Owner: "nobody", Sharing: "global"},
+		updated: s.now(),
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusAccepted, toACSIndex(request.Name, content))
+}
+
+// toACSIndex converts stored index settings to the ACS representation
+func toACSIndex(name string, content map[string]string) acsIndex {
+	frozen, _ := strconv.Atoi(content["frozenTimePeriodInSecs"])
+	maxSize, _ := strconv.Atoi(content["maxTotalDataSizeMB"])
+	datatype := content["datatype"]
+	if datatype == "" {
+		datatype = "event"
+	}
+	return acsIndex{Name: name, Datatype: datatype, SearchableDays: frozen / secondsPerDay, MaxDataSizeMB: maxSize}
+}
+
+// writeACSError writes an ACS error response
+func writeACSError(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"code": code, "message": message})
+}
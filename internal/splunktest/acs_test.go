@@ -0,0 +1,72 @@
+package splunktest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+)
+
+// sendACS sends a JSON request to the fake Admin Config Service
+func sendACS(t *testing.T, server *splunktest.Server, method, path, body string, auth func(*http.Request)) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	req, err := http.NewRequest(method, server.URL+"/"+splunktest.Stack+"/adminconfig/v2"+path, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	auth(req)
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return resp, decoded
+}
+
+func TestServer_ACSIndexes(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+	token := bearer(server.IssueToken(time.Hour))
+
+	t.Run("Error_BasicAuthenticationRejected", func(t *testing.T) {
+		resp, body := sendACS(t, server, http.MethodGet, "/indexes/salesforce", "", basicAuth)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "401-unauthorized", body["code"])
+	})
+
+	t.Run("Success_CreateAndGet", func(t *testing.T) {
+		resp, body := sendACS(t, server, http.MethodGet, "/indexes/salesforce", "", token)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		assert.Equal(t, "404-index-not-found", body["code"])
+
+		request := `{"name":"salesforce","datatype":"event","searchableDays":90,"maxDataSizeMB":512}`
+		resp, _ = sendACS(t, server, http.MethodPost, "/indexes", request, token)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode)
+
+		resp, body = sendACS(t, server, http.MethodGet, "/indexes/salesforce", "", token)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, float64(90), body["searchableDays"])
+		assert.Equal(t, float64(512), body["maxDataSizeMB"])
+
+		resp, body = sendACS(t, server, http.MethodPost, "/indexes", request, token)
+		assert.Equal(t, http.StatusConflict, resp.StatusCode)
+		assert.Equal(t, "409-index-already-exists", body["code"])
+
+		index, ok := server.Index("salesforce")
+		require.True(t, ok, "ACS index not visible through data/indexes")
+		assert.Equal(t, "7776000", index["frozenTimePeriodInSecs"])
+	})
+
+	t.Run("Error_InvalidDatatype", func(t *testing.T) {
+		resp, _ := sendACS(t, server, http.MethodPost, "/indexes", `{"name":"other","datatype":"logs"}`, token)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
@@ -0,0 +1,699 @@
+package splunktest
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// addonApp is the app of the Splunk Add-on for Salesforce
+const addonApp = "Splunk_TA_salesforce"
+
+// Collections of the in-memory store
+const (
+	collectionApps          = "apps"
+	collectionIndexes       = "indexes"
+	collectionAddonSettings = "Splunk_TA_salesforce_settings"
+	collectionAccounts      = "Splunk_TA_salesforce_account"
+	collectionDataInputs    = "Splunk_TA_salesforce_sfdc_object"
+	collectionEventLogs     = "Splunk_TA_salesforce_sfdc_event_log"
+	collectionViewsPrefix   = "views/" // followed by the app
+)
+
+// defaultPageSize is the number of entries Splunk returns when count is not given
+const defaultPageSize = 30
+
+// maskedValue replaces encrypted add-on fields in responses, as the add-on does
+const maskedValue = "******"
+
+// addonFields are the arguments each add-on endpoint accepts, from openapi.json
+var addonFields = map[string][]string{
+	collectionAccounts: {"endpoint", "sfdc_api_version", "username", "password", "token", "client_id", "client_secret",
+		"redirect_url", "client_id_oauth_credentials", "client_secret_oauth_credentials", "auth_type"},
+	collectionDataInputs: {"account", "object", "object_fields", "order_by", "start_date", "interval", "delay", "index", "disabled"},
+	collectionEventLogs:  {"account", "monitoring_interval", "start_date", "interval", "index", "disabled"},
+	"logging":            {"loglevel"},
+	"proxy":              {"proxy_enabled", "proxy_type", "proxy_url", "proxy_port", "proxy_username", "proxy_password", "proxy_rdns"},
+}
+
+// encryptedFields are never returned in clear by the add-on
+var encryptedFields = map[string]bool{
+	"password":                        true,
+	"token":                           true,
+	"client_secret":                   true,
+	"client_secret_oauth_credentials": true,
+	"proxy_password":                  true,
+}
+
+// ACL is the access control list of a knowledge object
+type ACL struct {
+	App     string
+	Owner   string
+	Sharing string // user, app or global
+	Read    []string
+	Write   []string
+}
+
+// Dashboard is a dashboard stored under data/ui/views
+type Dashboard struct {
+	Name string
+	Data string // eai:data, the Simple XML or Dashboard Studio source
+	ACL  ACL
+}
+
+// object is a stored entry
+type object struct {
+	name    string
+	content map[string]string
+	acl     ACL
+	updated time.Time
+}
+
+// Index returns the settings of an index
+func (s *Server) Index(name string) (map[string]string, bool) {
+	return s.get(collectionIndexes, name)
+}
+
+// AddIndex creates an index
+func (s *Server) AddIndex(name string) {
+	s.put(collectionIndexes, name, map[string]string{"datatype": "event"})
+}
+
+// Account returns the settings of a Splunk_TA_salesforce account, encrypted fields included
+func (s *Server) Account(name string) (map[string]string, bool) {
+	return s.get(collectionAccounts, name)
+}
+
+// AddAccount creates a Splunk_TA_salesforce account
+func (s *Server) AddAccount(name string, content map[string]string) {
+	s.put(collectionAccounts, name, content)
+}
+
+// DataInput returns the settings of an sfdc_object input
+func (s *Server) DataInput(name string) (map[string]string, bool) {
+	return s.get(collectionDataInputs, name)
+}
+
+// AddDataInput creates an sfdc_object input
+func (s *Server) AddDataInput(name string, content map[string]string) {
+	s.put(collectionDataInputs, name, withDefault(content, "disabled", "0"))
+}
+
+// DataInputs returns the names of the sfdc_object inputs, sorted
+func (s *Server) DataInputs() []string {
+	return s.names(collectionDataInputs)
+}
+
+// EventLogInput returns the settings of an sfdc_event_log input
+func (s *Server) EventLogInput(name string) (map[string]string, bool) {
+	return s.get(collectionEventLogs, name)
+}
+
+// AddEventLogInput creates an sfdc_event_log input
+func (s *Server) AddEventLogInput(name string, content map[string]string) {
+	s.put(collectionEventLogs, name, withDefault(content, "disabled", "0"))
+}
+
+// AddonSettings returns the add-on settings stanza "logging" or "proxy"
+func (s *Server) AddonSettings(stanza string) map[string]string {
+	content, _ := s.get(collectionAddonSettings, stanza)
+	return content
+}
+
+// Dashboard returns a dashboard of app
+func (s *Server) Dashboard(app, name string) (Dashboard, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.collections[collectionViewsPrefix+app][name]
+	if !ok {
+		return Dashboard{}, false
+	}
+	return Dashboard{Name: obj.name, Data: obj.content["eai:data"], ACL: obj.acl}, true
+}
+
+// AddDashboard stores a dashboard in dashboard.ACL.App. An empty owner or sharing
+// defaults to a dashboard shared in the app by nobody.
+func (s *Server) AddDashboard(dashboard Dashboard) {
+	acl := dashboard.ACL
+	if acl.Owner == "" {
+		acl.Owner = "nobody"
+	}
+	if acl.Sharing == "" {
+		acl.Sharing = "app"
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collectionLocked(collectionViewsPrefix + acl.App)[dashboard.Name] = &object{
+		name:    dashboard.Name,
+		content: map[string]string{"eai:data": dashboard.Data},
+		acl:     acl,
+		updated: s.now(),
+	}
+}
+
+// Dashboards returns the names of the dashboards of app, sorted
+func (s *Server) Dashboards(app string) []string {
+	return s.names(collectionViewsPrefix + app)
+}
+
+// get returns a copy of the content of a stored object
+func (s *Server) get(collection, name string) (map[string]string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	obj, ok := s.collections[collection][name]
+	if !ok {
+		return nil, false
+	}
+	return copyContent(obj.content), true
+}
+
+// put stores an object, replacing any object with the same name
+func (s *Server) put(collection, name string, content map[string]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.collectionLocked(collection)[name] = &object{
+		name:    name,
+		content: copyContent(content),
+		acl:     ACL{App: addonApp, Owner: "nobody", Sharing: "app"},
+		updated: s.now(),
+	}
+}
+
+// names returns the sorted names of a collection
+func (s *Server) names(collection string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.collections[collection]))
+	for name := range s.collections[collection] {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// collectionLocked returns a collection, creating it when needed; s.mu must be held
+func (s *Server) collectionLocked(collection string) map[string]*object {
+	objects, ok := s.collections[collection]
+	if !ok {
+		objects = make(map[string]*object)
+		s.collections[collection] = objects
+	}
+	return objects
+}
+
+// resource describes how an endpoint stores objects and reports errors
+type resource struct {
+	collection string
+	// fields are the accepted arguments besides name; nil accepts any argument
+	fields []string
+	// defaults are applied to created objects
+	defaults map[string]string
+	// acl is the ACL of created objects
+	acl ACL
+	// notFound and conflict write the responses for a missing and a duplicate object
+	notFound func(w http.ResponseWriter, name string)
+	conflict func(w http.ResponseWriter, name string)
+}
+
+// confNotFound and confConflict are splunkd's answers for conf-backed endpoints
+func confNotFound(w http.ResponseWriter, name string) {
+	writeMessage(w, http.StatusNotFound, "ERROR", fmt.Sprintf("Could not find object id=%s", name))
+}
+
+func confConflict(w http.ResponseWriter, name string) {
+	writeMessage(w, http.StatusConflict, "ERROR", fmt.Sprintf("Object id=%s cannot be created in config=indexes, because it already exists.", name))
+}
+
+// addonNotFound and addonConflict are the add-on REST handler's answers: it reports
+// missing and duplicate objects as 500 errors with the real status in the message
+func addonNotFound(w http.ResponseWriter, name string) {
+	writeMessage(w, http.StatusInternalServerError, "ERROR",
+		fmt.Sprintf(`Unexpected error "<class 'splunktaucclib.rest_handler.error.RestError'>" from python handler: "REST Error [404]: Not Found -- Could not find object id=%s". See splunkd.log/python.log for more details.`, name))
+}
+
+func addonConflict(w http.ResponseWriter, name string) {
+	writeMessage(w, http.StatusInternalServerError, "ERROR",
+		fmt.Sprintf(`Unexpected error "<class 'splunktaucclib.rest_handler.error.RestError'>" from python handler: "REST Error [409]: Conflict -- Name %q is already in use". See splunkd.log/python.log for more details.`, name))
+}
+
+// handleIndexes serves data/indexes
+func (s *Server) handleIndexes(w http.ResponseWriter, r *http.Request, rest []string) {
+	s.handleCollection(w, r, collectionIndexes, rest, &resource{
+		collection: collectionIndexes,
+		defaults:   map[string]string{"datatype": "event", "maxTotalDataSizeMB": "500000", "disabled": "0"},
+		acl:        ACL{App: "search", Owner: "nobody", Sharing: "global"},
+		notFound:   confNotFound,
+		conflict:   confConflict,
+	})
+}
+
+// handleAddon serves the Splunk_TA_salesforce endpoints below /servicesNS/-/Splunk_TA_salesforce
+func (s *Server) handleAddon(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) == 0 {
+		writeMessage(w, http.StatusNotFound, "ERROR", "Not Found")
+		return
+	}
+
+	switch handler := rest[0]; handler {
+	case collectionAccounts, collectionDataInputs, collectionEventLogs:
+		res := &resource{
+			collection: handler,
+			fields:     addonFields[handler],
+			acl:        ACL{App: addonApp, Owner: "nobody", Sharing: "app"},
+			notFound:   addonNotFound,
+			conflict:   addonConflict,
+		}
+		if handler != collectionAccounts {
+			res.defaults = map[string]string{"disabled": "0"}
+		}
+		s.handleCollection(w, r, handler, rest[1:], res)
+	case collectionAddonSettings:
+		s.handleAddonSettings(w, r, rest[1:])
+	default:
+		writeMessage(w, http.StatusNotFound, "ERROR", "Not Found")
+	}
+}
+
+// handleAddonSettings serves Splunk_TA_salesforce_settings/{logging,proxy}. POST
+// updates the stanza; the add-on has no other settings objects to create.
+func (s *Server) handleAddonSettings(w http.ResponseWriter, r *http.Request, rest []string) {
+	if len(rest) != 1 || addonFields[rest[0]] == nil {
+		writeMessage(w, http.StatusNotFound, "ERROR", "Not Found")
+		return
+	}
+	stanza := rest[0]
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := checkArguments(r.PostForm, addonFields[stanza]); err != nil {
+			writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+			return
+		}
+		s.mu.Lock()
+		obj := s.collections[collectionAddonSettings][stanza]
+		for key, values := range r.PostForm {
+			if key != "output_mode" && key != "name" {
+				obj.content[key] = values[0]
+			}
+		}
+		obj.updated = s.now()
+		s.mu.Unlock()
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+		return
+	}
+
+	s.mu.Lock()
+	entry := s.entryLocked(r, s.collections[collectionAddonSettings][stanza])
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, envelope([]map[string]interface{}{entry}, nil))
+}
+
+// handleViews serves data/ui/views of an app. The owner of the request path becomes
+// the owner of created dashboards; dashboards created as nobody are shared in the app.
+func (s *Server) handleViews(w http.ResponseWriter, r *http.Request, owner, app string, rest []string) {
+	sharing := "user"
+	if owner == "nobody" || owner == "-" {
+		owner, sharing = "nobody", "app"
+	}
+	res := &resource{
+		collection: collectionViewsPrefix + app,
+		fields:     []string{"eai:data"},
+		acl:        ACL{App: app, Owner: owner, Sharing: sharing},
+		notFound:   confNotFound,
+		conflict: func(w http.ResponseWriter, name string) {
+			writeMessage(w, http.StatusConflict, "ERROR", fmt.Sprintf("An object with name=%s already exists", name))
+		},
+	}
+
+	if len(rest) == 2 && rest[1] == "acl" {
+		s.handleACL(w, r, res, rest[0])
+		return
+	}
+	if app == "-" && !(r.Method == http.MethodGet && len(rest) == 0) {
+		writeMessage(w, http.StatusBadRequest, "ERROR", "An app must be given to change a view")
+		return
+	}
+	if app == "-" {
+		s.listViews(w, r)
+		return
+	}
+	s.handleCollection(w, r, res.collection, rest, res)
+}
+
+// listViews lists the dashboards of every app
+func (s *Server) listViews(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	var objects []*object
+	for collection, stored := range s.collections {
+		if strings.HasPrefix(collection, collectionViewsPrefix) {
+			for _, obj := range stored {
+				objects = append(objects, obj)
+			}
+		}
+	}
+	s.mu.Unlock()
+
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].acl.App != objects[j].acl.App {
+			return objects[i].acl.App < objects[j].acl.App
+		}
+		return objects[i].name < objects[j].name
+	})
+	s.writePage(w, r, objects)
+}
+
+// handleACL serves data/ui/views/{name}/acl
+func (s *Server) handleACL(w http.ResponseWriter, r *http.Request, res *resource, name string) {
+	s.mu.Lock()
+	obj, ok := s.collections[res.collection][name]
+	s.mu.Unlock()
+	if !ok {
+		res.notFound(w, name)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		sharing := r.PostForm.Get("sharing")
+		if sharing != "user" && sharing != "app" && sharing != "global" {
+			writeMessage(w, http.StatusBadRequest, "ERROR", `Argument "sharing" must be one of user, app or global`)
+			return
+		}
+		owner := r.PostForm.Get("owner")
+		if owner == "" {
+			writeMessage(w, http.StatusBadRequest, "ERROR", `Argument "owner" is required`)
+			return
+		}
+		s.mu.Lock()
+		obj.acl.Sharing = sharing
+		obj.acl.Owner = owner
+		if _, ok := r.PostForm["perms.read"]; ok {
+			obj.acl.Read = splitList(r.PostForm.Get("perms.read"))
+		}
+		if _, ok := r.PostForm["perms.write"]; ok {
+			obj.acl.Write = splitList(r.PostForm.Get("perms.write"))
+		}
+		obj.updated = s.now()
+		s.mu.Unlock()
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+		return
+	}
+
+	s.mu.Lock()
+	entry := s.entryLocked(r, obj)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, envelope([]map[string]interface{}{entry}, nil))
+}
+
+// handleCollection serves the list, create, get, update and delete operations of a
+// collection. A nil res makes the collection read-only.
+func (s *Server) handleCollection(w http.ResponseWriter, r *http.Request, collection string, rest []string, res *resource) {
+	if len(rest) > 1 {
+		writeMessage(w, http.StatusNotFound, "ERROR", "Not Found")
+		return
+	}
+	if res == nil && r.Method != http.MethodGet {
+		writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+		return
+	}
+
+	if len(rest) == 0 {
+		switch r.Method {
+		case http.MethodGet:
+			s.mu.Lock()
+			objects := make([]*object, 0, len(s.collections[collection]))
+			for _, obj := range s.collections[collection] {
+				objects = append(objects, obj)
+			}
+			s.mu.Unlock()
+			sort.Slice(objects, func(i, j int) bool { return objects[i].name < objects[j].name })
+			s.writePage(w, r, objects)
+		case http.MethodPost:
+			s.createObject(w, r, res)
+		default:
+			writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+		}
+		return
+	}
+
+	name := rest[0]
+	s.mu.Lock()
+	obj, ok := s.collections[collection][name]
+	s.mu.Unlock()
+	if !ok {
+		if res != nil {
+			res.notFound(w, name)
+		} else {
+			confNotFound(w, name)
+		}
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPost:
+		if err := checkArguments(r.PostForm, res.fields); err != nil {
+			writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+			return
+		}
+		s.mu.Lock()
+		for key, values := range r.PostForm {
+			if key != "output_mode" {
+				obj.content[key] = values[0]
+			}
+		}
+		obj.updated = s.now()
+		s.mu.Unlock()
+	case http.MethodDelete:
+		s.mu.Lock()
+		delete(s.collections[collection], name)
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, envelope(nil, nil))
+		return
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+		return
+	}
+
+	s.mu.Lock()
+	entry := s.entryLocked(r, obj)
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, envelope([]map[string]interface{}{entry}, nil))
+}
+
+// createObject stores the object described by a create request
+func (s *Server) createObject(w http.ResponseWriter, r *http.Request, res *resource) {
+	name := r.PostForm.Get("name")
+	if name == "" {
+		writeMessage(w, http.StatusBadRequest, "ERROR", `Argument "name" is required`)
+		return
+	}
+	if err := checkArguments(r.PostForm, res.fields); err != nil {
+		writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	objects := s.collectionLocked(res.collection)
+	if _, exists := objects[name]; exists {
+		s.mu.Unlock()
+		res.conflict(w, name)
+		return
+	}
+	content := copyContent(res.defaults)
+	for key, values := range r.PostForm {
+		if key != "name" && key != "output_mode" {
+			content[key] = values[0]
+		}
+	}
+	obj := &object{name: name, content: content, acl: res.acl, updated: s.now()}
+	objects[name] = obj
+	entry := s.entryLocked(r, obj)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, envelope([]map[string]interface{}{entry}, nil))
+}
+
+// writePage writes the page of objects selected by the count and offset parameters.
+// Like Splunk, a missing count returns 30 entries and count=0 returns all of them.
+func (s *Server) writePage(w http.ResponseWriter, r *http.Request, objects []*object) {
+	query := r.URL.Query()
+	count, err := intParam(query, "count", defaultPageSize)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+		return
+	}
+	offset, err := intParam(query, "offset", 0)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+		return
+	}
+
+	total := len(objects)
+	start := min(offset, total)
+	end := total
+	if count > 0 {
+		end = min(start+count, total)
+	}
+
+	s.mu.Lock()
+	entries := make([]map[string]interface{}, 0, end-start)
+	for _, obj := range objects[start:end] {
+		entries = append(entries, s.entryLocked(r, obj))
+	}
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, envelope(entries, map[string]int{"total": total, "perPage": count, "offset": start}))
+}
+
+// entryLocked renders a stored object as a response entry; s.mu must be held
+func (s *Server) entryLocked(r *http.Request, obj *object) map[string]interface{} {
+	content := make(map[string]interface{}, len(obj.content))
+	for key, value := range obj.content {
+		switch {
+		case key == "disabled":
+			disabled, _ := strconv.ParseBool(value)
+			content[key] = disabled
+		case encryptedFields[key] && value != "":
+			content[key] = maskedValue
+		default:
+			content[key] = value
+		}
+	}
+
+	read, write := obj.acl.Read, obj.acl.Write
+	if read == nil {
+		read = []string{"*"}
+	}
+	if write == nil {
+		write = []string{"admin"}
+	}
+	path := entryPath(r, obj.name)
+
+	return map[string]interface{}{
+		"name":    obj.name,
+		"id":      s.URL + path,
+		"updated": obj.updated.Format(time.RFC3339),
+		"links":   map[string]string{"alternate": path},
+		"author":  obj.acl.Owner,
+		"acl": map[string]interface{}{
+			"app":     obj.acl.App,
+			"owner":   obj.acl.Owner,
+			"sharing": obj.acl.Sharing,
+			"perms":   map[string][]string{"read": read, "write": write},
+		},
+		"content": content,
+	}
+}
+
+// entryPath returns the path of an object from the path of the request that returned it
+func entryPath(r *http.Request, name string) string {
+	segments, _ := pathSegments(r.URL)
+	if n := len(segments); n > 0 && segments[n-1] == "acl" {
+		segments = segments[:n-1]
+	}
+	if n := len(segments); n == 0 || segments[n-1] != name {
+		segments = append(segments, name)
+	}
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+// envelope wraps entries in Splunk's JSON response envelope
+func envelope(entries []map[string]interface{}, paging map[string]int) map[string]interface{} {
+	if entries == nil {
+		entries = []map[string]interface{}{}
+	}
+	if paging == nil {
+		paging = map[string]int{"total": len(entries), "perPage": defaultPageSize, "offset": 0}
+	}
+	return map[string]interface{}{
+		"links":     map[string]string{},
+		"origin":    "",
+		"updated":   time.Now().Format(time.RFC3339),
+		"generator": map[string]string{"build": "splunktest", "version": "9.2.0"},
+		"entry":     entries,
+		"paging":    paging,
+		"messages":  []interface{}{},
+	}
+}
+
+// checkArguments rejects arguments the endpoint does not support, as splunkd does.
+// name and output_mode are always accepted.
+func checkArguments(form url.Values, fields []string) error {
+	if fields == nil {
+		return nil
+	}
+	for key := range form {
+		if key == "name" || key == "output_mode" {
+			continue
+		}
+		supported := false
+		for _, field := range fields {
+			if key == field {
+				supported = true
+				break
+			}
+		}
+		if !supported {
+			return fmt.Errorf("Argument %q is not supported by this handler.", key)
+		}
+	}
+	return nil
+}
+
+// intParam reads a non-negative integer query parameter
+func intParam(query url.Values, key string, fallback int) (int, error) {
+	value := query.Get(key)
+	if value == "" {
+		return fallback, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid %s %q", key, value)
+	}
+	return n, nil
+}
+
+// splitList splits a comma-separated list, dropping empty items
+func splitList(list string) []string {
+	items := make([]string, 0)
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// withDefault returns a copy of content with key set to value unless it is set
+func withDefault(content map[string]string, key, value string) map[string]string {
+	result := copyContent(content)
+	if _, ok := result[key]; !ok {
+		result[key] = value
+	}
+	return result
+}
+
+// copyContent returns a copy of content; a nil map gives an empty map
+func copyContent(content map[string]string) map[string]string {
+	result := make(map[string]string, len(content))
+	for key, value := range content {
+		result[key] = value
+	}
+	return result
+}
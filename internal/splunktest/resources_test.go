@@ -0,0 +1,174 @@
+package splunktest_test
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+)
+
+const addonPath = "/servicesNS/-/Splunk_TA_salesforce"
+
+func entries(t *testing.T, body map[string]interface{}) []map[string]interface{} {
+	t.Helper()
+	raw, ok := body["entry"].([]interface{})
+	require.True(t, ok, "no entry in %v", body)
+	result := make([]map[string]interface{}, 0, len(raw))
+	for _, entry := range raw {
+		result = append(result, entry.(map[string]interface{}))
+	}
+	return result
+}
+
+func TestServer_Indexes(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+
+	resp, body := send(t, server, http.MethodGet, "/services/data/indexes/salesforce?output_mode=json", nil, basicAuth)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Equal(t, "Could not find object id=salesforce", firstMessage(t, body))
+
+	form := url.Values{"name": {"salesforce"}, "datatype": {"event"}, "maxTotalDataSizeMB": {"1000"}}
+	resp, _ = send(t, server, http.MethodPost, "/servicesNS/nobody/000-self-service/data/indexes", form, basicAuth)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, _ = send(t, server, http.MethodPost, "/servicesNS/nobody/000-self-service/data/indexes", form, basicAuth)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, body = send(t, server, http.MethodGet, "/services/data/indexes/salesforce?output_mode=json", nil, basicAuth)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "1000", entries(t, body)[0]["content"].(map[string]interface{})["maxTotalDataSizeMB"])
+
+	index, ok := server.Index("salesforce")
+	require.True(t, ok)
+	assert.Equal(t, "event", index["datatype"])
+}
+
+func TestServer_AddonInputs(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+	path := addonPath + "/Splunk_TA_salesforce_sfdc_object"
+
+	t.Run("Error_MissingObjectIs500", func(t *testing.T) {
+		resp, body := send(t, server, http.MethodGet, path+"/Account?output_mode=json", nil, basicAuth)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Contains(t, firstMessage(t, body), "REST Error [404]: Not Found -- Could not find object id=Account")
+	})
+
+	t.Run("Success_CreateGetUpdateDelete", func(t *testing.T) {
+		form := url.Values{"name": {"Account"}, "account": {"prod"}, "object": {"Account"}, "object_fields": {"Id,Name"},
+			"order_by": {"LastModifiedDate"}, "interval": {"300"}, "delay": {"60"}, "index": {"salesforce"}}
+		resp, _ := send(t, server, http.MethodPost, path, form, basicAuth)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		resp, body := send(t, server, http.MethodPost, path, form, basicAuth)
+		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+		assert.Contains(t, firstMessage(t, body), "REST Error [409]")
+
+		resp, body = send(t, server, http.MethodGet, path+"/Account?output_mode=json", nil, basicAuth)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		content := entries(t, body)[0]["content"].(map[string]interface{})
+		assert.Equal(t, "300", content["interval"])
+		assert.Equal(t, false, content["disabled"])
+
+		resp, _ = send(t, server, http.MethodPost, path+"/Account", url.Values{"interval": {"600"}, "disabled": {"1"}}, basicAuth)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		input, _ := server.DataInput("Account")
+		assert.Equal(t, "600", input["interval"])
+		assert.Equal(t, "1", input["disabled"])
+
+		resp, _ = send(t, server, http.MethodDelete, path+"/Account?output_mode=json", nil, basicAuth)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Empty(t, server.DataInputs())
+	})
+
+	t.Run("Error_UnsupportedArgument", func(t *testing.T) {
+		resp, body := send(t, server, http.MethodPost, path, url.Values{"name": {"Lead"}, "objects": {"Lead"}}, basicAuth)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		assert.Contains(t, firstMessage(t, body), `"objects" is not supported`)
+	})
+
+	t.Run("Success_Paging", func(t *testing.T) {
+		for i := 0; i < 35; i++ {
+			server.AddDataInput(fmt.Sprintf("Input_%02d", i), map[string]string{"object": "Account"})
+		}
+
+		_, body := send(t, server, http.MethodGet, path+"?output_mode=json", nil, basicAuth)
+		assert.Len(t, entries(t, body), 30, "default page size")
+		assert.Equal(t, float64(35), body["paging"].(map[string]interface{})["total"])
+
+		_, body = send(t, server, http.MethodGet, path+"?output_mode=json&count=0", nil, basicAuth)
+		assert.Len(t, entries(t, body), 35)
+
+		_, body = send(t, server, http.MethodGet, path+"?output_mode=json&count=10&offset=30", nil, basicAuth)
+		page := entries(t, body)
+		require.Len(t, page, 5)
+		assert.Equal(t, "Input_30", page[0]["name"])
+	})
+}
+
+func TestServer_AddonAccountsMaskSecrets(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+	server.AddAccount("prod", map[string]string{"endpoint": "login.salesforce.com", "client_secret_oauth_credentials": "s3cret"})
+
+	_, body := send(t, server, http.MethodGet, addonPath+"/Splunk_TA_salesforce_account/prod?output_mode=json", nil, basicAuth)
+	content := entries(t, body)[0]["content"].(map[string]interface{})
+	assert.Equal(t, "******", content["client_secret_oauth_credentials"])
+	assert.Equal(t, "login.salesforce.com", content["endpoint"])
+
+	account, _ := server.Account("prod")
+	assert.Equal(t, "s3cret", account["client_secret_oauth_credentials"])
+}
+
+func TestServer_AddonSettings(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+
+	_, body := send(t, server, http.MethodGet, addonPath+"/Splunk_TA_salesforce_settings/logging?output_mode=json", nil, basicAuth)
+	assert.Equal(t, "INFO", entries(t, body)[0]["content"].(map[string]interface{})["loglevel"])
+
+	resp, _ := send(t, server, http.MethodPost, addonPath+"/Splunk_TA_salesforce_settings/logging", url.Values{"loglevel": {"DEBUG"}}, basicAuth)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "DEBUG", server.AddonSettings("logging")["loglevel"])
+}
+
+func TestServer_Dashboards(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+	path := "/servicesNS/nobody/search/data/ui/views"
+
+	form := url.Values{"name": {"salesforce_overview"}, "eai:data": {"<dashboard><label>Overview</label></dashboard>"}}
+	resp, _ := send(t, server, http.MethodPost, path, form, basicAuth)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	resp, _ = send(t, server, http.MethodPost, path, form, basicAuth)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, _ = send(t, server, http.MethodPost, path+"/salesforce_overview", url.Values{"eai:data": {"<dashboard><label>v2</label></dashboard>"}}, basicAuth)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	acl := url.Values{"sharing": {"global"}, "owner": {"admin"}, "perms.read": {"user,power"}, "perms.write": {"admin"}}
+	resp, body := send(t, server, http.MethodPost, path+"/salesforce_overview/acl", acl, basicAuth)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "global", entries(t, body)[0]["acl"].(map[string]interface{})["sharing"])
+
+	dashboard, ok := server.Dashboard("search", "salesforce_overview")
+	require.True(t, ok)
+	assert.True(t, strings.Contains(dashboard.Data, "v2"))
+	assert.Equal(t, splunktest.ACL{App: "search", Owner: "admin", Sharing: "global", Read: []string{"user", "power"}, Write: []string{"admin"}}, dashboard.ACL)
+
+	server.AddDashboard(splunktest.Dashboard{Name: "other", Data: "{}", ACL: splunktest.ACL{App: "Splunk_TA_salesforce"}})
+	_, body = send(t, server, http.MethodGet, "/servicesNS/-/-/data/ui/views?output_mode=json&count=0", nil, basicAuth)
+	assert.Len(t, entries(t, body), 2)
+
+	resp, _ = send(t, server, http.MethodDelete, path+"/salesforce_overview", nil, basicAuth)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, server.Dashboards("search"))
+}
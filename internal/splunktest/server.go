@@ -0,0 +1,480 @@
+// Package splunktest provides an in-process fake of the Splunk management REST API for
+// end-to-end tests. It keeps indexes, Splunk_TA_salesforce accounts and inputs, add-on
+// settings and dashboards in memory, answers with Splunk's JSON envelopes and quirks
+// (such as the add-on's 500 responses for missing objects), and can inject latency,
+// throttling, server errors and credential expiry. The indexes endpoint of the Splunk
+// Cloud Admin Config Service is served below /{Stack}/adminconfig.
+package splunktest
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Credentials accepted for HTTP Basic authentication, token creation and session login
+const (
+	Username = "admin"
+	Password = "changeme"
+)
+
+// Server is a fake Splunk management server. Create it with NewServer and point
+// SPLUNK_URL at Server.URL.
+type Server struct {
+	// URL is the base URL of the server, of the form http://ipaddr:port
+	URL string
+
+	httpServer *httptest.Server
+	now        func() time.Time
+
+	mu          sync.Mutex
+	tokens      map[string]*token // by token ID
+	sessionKeys map[string]bool
+	collections map[string]map[string]*object
+	faults      []*Fault
+	requests    []Request
+}
+
+// token is an authentication token created through /services/authorization/tokens
+type token struct {
+	id        string
+	value     string
+	user      string
+	expiresAt time.Time
+	expired   bool
+}
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	Path   string // unescaped path without the query
+	Query  url.Values
+	Form   url.Values
+}
+
+// Fault makes the server answer matching requests with an error, or delays them.
+// Faults are checked in the order they were injected; the first match applies.
+type Fault struct {
+	// Method matches the request method; empty matches every method
+	Method string
+	// Path matches requests whose unescaped path starts with it; empty matches every path
+	Path string
+	// Status is the status to answer with. Zero lets the request through after Latency.
+	Status int
+	// Body replaces the Splunk message response written for Status
+	Body string
+	// RetryAfter is sent as the Retry-After header when set
+	RetryAfter string
+	// Latency delays the response
+	Latency time.Duration
+	// Times limits how many requests the fault applies to; zero means every request
+	Times int
+
+	hits int
+}
+
+// NewServer starts a fake Splunk server with the Splunk_TA_salesforce add-on installed
+// and default add-on settings. Call Close when done.
+func NewServer() *Server {
+	s := &Server{
+		now:         time.Now,
+		tokens:      make(map[string]*token),
+		sessionKeys: make(map[string]bool),
+		collections: make(map[string]map[string]*object),
+	}
+	s.put(collectionApps, addonApp, map[string]string{"label": "Splunk Add-on for Salesforce", "version": "4.8.0", "disabled": "0"})
+	s.put(collectionAddonSettings, "logging", map[string]string{"loglevel": "INFO"})
+	s.put(collectionAddonSettings, "proxy", map[string]string{"proxy_enabled": "0", "proxy_type": "http", "proxy_rdns": "0"})
+
+	s.httpServer = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.httpServer.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.httpServer.Close()
+}
+
+// Inject adds a fault
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// ExpireCredentials makes every token and session key issued so far invalid, as if
+// they had expired. Requests using them are answered 401 Unauthorized.
+func (s *Server) ExpireCredentials() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tok := range s.tokens {
+		tok.expired = true
+	}
+	s.sessionKeys = make(map[string]bool)
+}
+
+// IssueToken creates a token for Username that expires after lifetime, as an
+// administrator would in Splunk Web, and returns it
+func (s *Server) IssueToken(lifetime time.Duration) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.issueTokenLocked(Username, "Automation", s.now().Add(lifetime)).value
+}
+
+// ActiveTokens returns how many created tokens are neither revoked nor expired
+func (s *Server) ActiveTokens() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	active := 0
+	for _, tok := range s.tokens {
+		if tok.valid(s.now()) {
+			active++
+		}
+	}
+	return active
+}
+
+// Requests returns the requests received so far
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// CountRequests returns how many requests with method and a path starting with path
+// were received. An empty method matches every method.
+func (s *Server) CountRequests(method, path string) int {
+	count := 0
+	for _, req := range s.Requests() {
+		if (method == "" || req.Method == method) && strings.HasPrefix(req.Path, path) {
+			count++
+		}
+	}
+	return count
+}
+
+// valid reports whether the token is accepted at now
+func (t *token) valid(now time.Time) bool {
+	return !t.expired && now.Before(t.expiresAt)
+}
+
+// serveHTTP records the request, applies faults and authentication, and routes it
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+		return
+	}
+	segments, err := pathSegments(r.URL)
+	if err != nil {
+		writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+		return
+	}
+	path := "/" + strings.Join(segments, "/")
+
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{Method: r.Method, Path: path, Query: r.URL.Query(), Form: r.PostForm})
+	fault := s.matchFaultLocked(r.Method, path)
+	s.mu.Unlock()
+
+	if fault != nil {
+		if fault.Latency > 0 {
+			select {
+			case <-time.After(fault.Latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+		if fault.Status != 0 {
+			writeFault(w, fault)
+			return
+		}
+	}
+
+	if !s.authenticated(r, segments) {
+		if isACS(segments) {
+			writeACSError(w, http.StatusUnauthorized, "401-unauthorized", "authentication token is missing or invalid")
+			return
+		}
+		writeMessage(w, http.StatusUnauthorized, "WARN", "call not properly authenticated")
+		return
+	}
+
+	s.route(w, r, segments)
+}
+
+// matchFaultLocked returns a copy of the first fault matching the request and counts
+// the hit; s.mu must be held
+func (s *Server) matchFaultLocked(method, path string) *Fault {
+	for _, fault := range s.faults {
+		if fault.Method != "" && fault.Method != method {
+			continue
+		}
+		if !strings.HasPrefix(path, fault.Path) {
+			continue
+		}
+		if fault.Times > 0 && fault.hits >= fault.Times {
+			continue
+		}
+		fault.hits++
+		matched := *fault
+		return &matched
+	}
+	return nil
+}
+
+// writeFault writes the response of an injected fault
+func writeFault(w http.ResponseWriter, fault *Fault) {
+	if fault.RetryAfter != "" {
+		w.Header().Set("Retry-After", fault.RetryAfter)
+	}
+	if fault.Body != "" {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(fault.Status)
+		_, _ = w.Write([]byte(fault.Body))
+		return
+	}
+	writeMessage(w, fault.Status, "ERROR", http.StatusText(fault.Status))
+}
+
+// authenticated checks the Authorization header. Session login needs no credential;
+// ACS only accepts authentication tokens.
+func (s *Server) authenticated(r *http.Request, segments []string) bool {
+	if matchPath(segments, "services", "auth", "login") {
+		return true
+	}
+
+	header := r.Header.Get("Authorization")
+	scheme, credential, _ := strings.Cut(header, " ")
+	switch scheme {
+	case "Basic":
+		if isACS(segments) {
+			return false
+		}
+		username, password, ok := r.BasicAuth()
+		return ok && username == Username && password == Password
+	case "Bearer":
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		for _, tok := range s.tokens {
+			if tok.value == credential {
+				return tok.valid(s.now())
+			}
+		}
+		return false
+	case "Splunk":
+		if isACS(segments) {
+			return false
+		}
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.sessionKeys[credential]
+	default:
+		return false
+	}
+}
+
+// route dispatches an authenticated request to its endpoint
+func (s *Server) route(w http.ResponseWriter, r *http.Request, segments []string) {
+	switch {
+	case matchPath(segments, "services", "auth", "login"):
+		s.handleLogin(w, r)
+	case matchPath(segments, "services", "authorization", "tokens"):
+		s.handleTokens(w, r, segments[3:])
+	case matchPath(segments, "services", "apps", "local"):
+		s.handleCollection(w, r, collectionApps, segments[3:], nil)
+	case matchPath(segments, "services", "data", "indexes"):
+		s.handleIndexes(w, r, segments[3:])
+	case matchPath(segments, "servicesNS", "*", "*", "data", "indexes"):
+		s.handleIndexes(w, r, segments[5:])
+	case matchPath(segments, "servicesNS", "*", "*", "data", "ui", "views"):
+		s.handleViews(w, r, segments[1], segments[2], segments[6:])
+	case matchPath(segments, "servicesNS", "*", addonApp):
+		s.handleAddon(w, r, segments[3:])
+	case matchPath(segments, Stack, "adminconfig", "v2", "indexes"):
+		s.handleACSIndexes(w, r, segments[4:])
+	default:
+		writeMessage(w, http.StatusNotFound, "ERROR", "Not Found")
+	}
+}
+
+// handleLogin serves /services/auth/login
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+		return
+	}
+	if r.PostForm.Get("username") != Username || r.PostForm.Get("password") != Password {
+		writeMessage(w, http.StatusUnauthorized, "WARN", "Login failed")
+		return
+	}
+
+	sessionKey := randomHex(20)
+	s.mu.Lock()
+	s.sessionKeys[sessionKey] = true
+	s.mu.Unlock()
+	writeJSON(w, http.StatusOK, map[string]string{"sessionKey": sessionKey})
+}
+
+// handleTokens serves /services/authorization/tokens: POST creates a token, DELETE
+// on /services/authorization/tokens/{user}?id= revokes one
+func (s *Server) handleTokens(w http.ResponseWriter, r *http.Request, rest []string) {
+	switch {
+	case r.Method == http.MethodPost && len(rest) == 0:
+		s.createToken(w, r)
+	case r.Method == http.MethodDelete && len(rest) == 1:
+		id := r.URL.Query().Get("id")
+		s.mu.Lock()
+		tok, ok := s.tokens[id]
+		if ok && tok.user == rest[0] {
+			delete(s.tokens, id)
+		}
+		s.mu.Unlock()
+		if !ok || tok.user != rest[0] {
+			writeMessage(w, http.StatusNotFound, "ERROR", fmt.Sprintf("Could not find object id=%s", id))
+			return
+		}
+		writeJSON(w, http.StatusOK, envelope(nil, nil))
+	default:
+		writeMessage(w, http.StatusMethodNotAllowed, "ERROR", "Method Not Allowed")
+	}
+}
+
+// createToken creates a JWT for the user named in the form
+func (s *Server) createToken(w http.ResponseWriter, r *http.Request) {
+	user := r.PostForm.Get("name")
+	audience := r.PostForm.Get("audience")
+	if user == "" || audience == "" {
+		writeMessage(w, http.StatusBadRequest, "ERROR", `Missing required arguments: "name" and "audience"`)
+		return
+	}
+	if user != Username {
+		writeMessage(w, http.StatusBadRequest, "ERROR", fmt.Sprintf("User %q does not exist", user))
+		return
+	}
+
+	s.mu.Lock()
+	expiresAt, err := parseExpiresOn(r.PostForm.Get("expires_on"), s.now())
+	if err != nil {
+		s.mu.Unlock()
+		writeMessage(w, http.StatusBadRequest, "ERROR", err.Error())
+		return
+	}
+	tok := s.issueTokenLocked(user, audience, expiresAt)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, envelope([]map[string]interface{}{{
+		"name":    "tokens",
+		"content": map[string]string{"id": tok.id, "token": tok.value},
+	}}, nil))
+}
+
+// issueTokenLocked creates and stores a token; s.mu must be held
+func (s *Server) issueTokenLocked(user, audience string, expiresAt time.Time) *token {
+	id := randomHex(32)
+	header, _ := json.Marshal(map[string]string{"kid": "splunk.secret", "alg": "HS512", "ver": "v2", "ttyp": "static"})
+	claims, _ := json.Marshal(map[string]interface{}{
+		"iss": user + " from splunktest",
+		"sub": user,
+		"aud": audience,
+		"idp": "Splunk",
+		"jti": id,
+		"iat": s.now().Unix(),
+		"exp": expiresAt.Unix(),
+	})
+	value := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims) + "." + randomHex(32)
+
+	tok := &token{id: id, value: value, user: user, expiresAt: expiresAt}
+	s.tokens[id] = tok
+	return tok
+}
+
+// parseExpiresOn parses the expires_on argument of token creation: a relative time
+// such as "+3600s", "+60m" or "+1h", or a Unix timestamp. Tokens without expires_on
+// are valid for 30 days.
+func parseExpiresOn(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return now.Add(30 * 24 * time.Hour), nil
+	}
+	if relative, ok := strings.CutPrefix(value, "+"); ok {
+		d, err := time.ParseDuration(relative)
+		if err != nil || d <= 0 {
+			return time.Time{}, fmt.Errorf("invalid expires_on %q", value)
+		}
+		return now.Add(d), nil
+	}
+	unix, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid expires_on %q", value)
+	}
+	return time.Unix(unix, 0), nil
+}
+
+// pathSegments returns the unescaped segments of a request path. Segments are split
+// before unescaping so that names containing an escaped slash stay whole.
+func pathSegments(u *url.URL) ([]string, error) {
+	escaped := strings.Split(strings.Trim(u.EscapedPath(), "/"), "/")
+	segments := make([]string, 0, len(escaped))
+	for _, segment := range escaped {
+		unescaped, err := url.PathUnescape(segment)
+		if err != nil {
+			return nil, fmt.Errorf("invalid path segment %q: %w", segment, err)
+		}
+		segments = append(segments, unescaped)
+	}
+	return segments, nil
+}
+
+// matchPath reports whether segments start with pattern, where "*" matches any segment
+func matchPath(segments []string, pattern ...string) bool {
+	if len(segments) < len(pattern) {
+		return false
+	}
+	for i, want := range pattern {
+		if want != "*" && segments[i] != want {
+			return false
+		}
+	}
+	return true
+}
+
+// randomHex returns n random bytes, hex encoded
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
+
+// writeMessage writes a Splunk message response
+func writeMessage(w http.ResponseWriter, status int, messageType, text string) {
+	writeJSON(w, status, map[string]interface{}{
+		"messages": []map[string]string{{"type": messageType, "text": text}},
+	})
+}
+
+// writeJSON writes value as a JSON response
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(value)
+}
@@ -0,0 +1,177 @@
+package splunktest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+)
+
+// send performs a request against the fake server and decodes the JSON response
+func send(t *testing.T, server *splunktest.Server, method, path string, form url.Values, auth func(*http.Request)) (*http.Response, map[string]interface{}) {
+	t.Helper()
+
+	var body io.Reader
+	if form != nil {
+		body = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequest(method, server.URL+path, body)
+	require.NoError(t, err)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if auth != nil {
+		auth(req)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&decoded))
+	return resp, decoded
+}
+
+func basicAuth(req *http.Request) {
+	req.SetBasicAuth(splunktest.Username, splunktest.Password)
+}
+
+func bearer(token string) func(*http.Request) {
+	return func(req *http.Request) { req.Header.Set("Authorization", "Bearer "+token) }
+}
+
+func firstMessage(t *testing.T, body map[string]interface{}) string {
+	t.Helper()
+	messages, ok := body["messages"].([]interface{})
+	require.True(t, ok && len(messages) > 0, "no messages in %v", body)
+	return messages[0].(map[string]interface{})["text"].(string)
+}
+
+func TestServer_Authentication(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+
+	t.Run("Error_MissingCredential", func(t *testing.T) {
+		resp, body := send(t, server, http.MethodGet, "/services/data/indexes?output_mode=json", nil, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "call not properly authenticated", firstMessage(t, body))
+	})
+
+	t.Run("Error_WrongPassword", func(t *testing.T) {
+		resp, _ := send(t, server, http.MethodGet, "/services/data/indexes", nil, func(req *http.Request) {
+			req.SetBasicAuth(splunktest.Username, "wrong")
+		})
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+
+	t.Run("Success_TokenLifecycle", func(t *testing.T) {
+		form := url.Values{"name": {splunktest.Username}, "audience": {"Automation"}, "expires_on": {"+3600s"}, "output_mode": {"json"}}
+		resp, body := send(t, server, http.MethodPost, "/services/authorization/tokens", form, basicAuth)
+		require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+		content := body["entry"].([]interface{})[0].(map[string]interface{})["content"].(map[string]interface{})
+		token, id := content["token"].(string), content["id"].(string)
+		assert.Len(t, strings.Split(token, "."), 3, "token is not a JWT")
+		assert.Equal(t, 1, server.ActiveTokens())
+
+		resp, _ = send(t, server, http.MethodGet, "/services/data/indexes", nil, bearer(token))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, _ = send(t, server, http.MethodDelete, "/services/authorization/tokens/"+splunktest.Username+"?id="+id, nil, bearer(token))
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, 0, server.ActiveTokens())
+
+		resp, _ = send(t, server, http.MethodGet, "/services/data/indexes", nil, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode, "revoked token accepted")
+
+		resp, _ = send(t, server, http.MethodDelete, "/services/authorization/tokens/"+splunktest.Username+"?id="+id, nil, basicAuth)
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	})
+
+	t.Run("Success_SessionLogin", func(t *testing.T) {
+		resp, body := send(t, server, http.MethodPost, "/services/auth/login", url.Values{"username": {splunktest.Username}, "password": {splunktest.Password}}, nil)
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		sessionKey := body["sessionKey"].(string)
+
+		resp, _ = send(t, server, http.MethodGet, "/services/data/indexes", nil, func(req *http.Request) {
+			req.Header.Set("Authorization", "Splunk "+sessionKey)
+		})
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+		resp, body = send(t, server, http.MethodPost, "/services/auth/login", url.Values{"username": {splunktest.Username}, "password": {"wrong"}}, nil)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Equal(t, "Login failed", firstMessage(t, body))
+	})
+
+	t.Run("Success_ExpireCredentials", func(t *testing.T) {
+		token := server.IssueToken(time.Hour)
+		resp, _ := send(t, server, http.MethodGet, "/services/data/indexes", nil, bearer(token))
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		server.ExpireCredentials()
+
+		resp, _ = send(t, server, http.MethodGet, "/services/data/indexes", nil, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		resp, _ = send(t, server, http.MethodGet, "/services/data/indexes", nil, basicAuth)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "Basic authentication does not expire")
+	})
+
+	t.Run("Success_ExpiredToken", func(t *testing.T) {
+		token := server.IssueToken(-time.Minute)
+		resp, _ := send(t, server, http.MethodGet, "/services/data/indexes", nil, bearer(token))
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	})
+}
+
+func TestServer_Faults(t *testing.T) {
+	t.Run("Success_StatusWithRetryAfter", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.Inject(splunktest.Fault{Method: http.MethodGet, Path: "/services/data/indexes", Status: http.StatusTooManyRequests, RetryAfter: "2", Times: 2})
+
+		for i := 0; i < 2; i++ {
+			resp, body := send(t, server, http.MethodGet, "/services/data/indexes", nil, basicAuth)
+			assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+			assert.Equal(t, "2", resp.Header.Get("Retry-After"))
+			assert.Equal(t, "Too Many Requests", firstMessage(t, body))
+		}
+
+		resp, _ := send(t, server, http.MethodGet, "/services/data/indexes", nil, basicAuth)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, "fault applied more than Times")
+		assert.Equal(t, 3, server.CountRequests(http.MethodGet, "/services/data/indexes"))
+	})
+
+	t.Run("Success_FaultsBeforeAuthentication", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.Inject(splunktest.Fault{Status: http.StatusServiceUnavailable, Body: `{"messages":[{"type":"ERROR","text":"maintenance"}]}`})
+
+		resp, body := send(t, server, http.MethodGet, "/services/data/indexes", nil, nil)
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+		assert.Equal(t, "maintenance", firstMessage(t, body))
+
+		server.ClearFaults()
+		resp, _ = send(t, server, http.MethodGet, "/services/data/indexes", nil, basicAuth)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+
+	t.Run("Success_Latency", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.Inject(splunktest.Fault{Path: "/services/data/indexes", Latency: 50 * time.Millisecond})
+
+		start := time.Now()
+		resp, _ := send(t, server, http.MethodGet, "/services/data/indexes", nil, basicAuth)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond)
+	})
+}
@@ -0,0 +1,148 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// configureAddonSettingsNode applies the configured Splunk_TA_salesforce proxy and
+// logging settings so the account created next can reach Salesforce
+func (p *MigrationNodeProcessor) configureAddonSettingsNode(ctx context.Context) error {
+	proxy := p.config.Salesforce.Proxy
+	logLevel := strings.ToUpper(p.config.Salesforce.AddonLogLevel)
+
+	if !proxy.IsConfigured() && logLevel == "" {
+		p.logger.Debug("No add-on proxy or logging settings configured. Skipping...")
+		return nil
+	}
+
+	p.logger.Info("⚙️  Node 4: Applying Splunk Add-on for Salesforce settings...")
+
+	if proxy.IsConfigured() {
+		if err := p.applyProxySettings(ctx, &proxy); err != nil {
+			return err
+		}
+	}
+
+	if logLevel != "" {
+		if err := p.applyAddonLogLevel(ctx, logLevel); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyProxySettings updates the add-on proxy unless it already matches the configuration.
+// The proxy password is never logged; it cannot be read back, so a configured password
+// always triggers an update.
+func (p *MigrationNodeProcessor) applyProxySettings(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+	live, err := p.splunkService.GetProxySettings(ctx)
+	if err != nil {
+		p.logger.Warn("Could not read add-on proxy settings, will update", utils.Err(err))
+		live = nil
+	}
+
+	if live != nil && proxy.Password == "" && len(proxySettingsDiff(live, proxy)) == 0 {
+		p.logger.Info("Add-on proxy settings unchanged, skipping update",
+			utils.String("proxy_url", proxy.URL))
+		return nil
+	}
+
+	if err := p.splunkService.UpdateProxySettings(ctx, proxy); err != nil {
+		p.logger.Error("Failed to update add-on proxy settings", utils.Err(err))
+		return err
+	}
+
+	p.logger.Info("✅ Add-on proxy settings updated",
+		utils.Bool("enabled", proxy.Enabled),
+		utils.String("proxy_type", proxy.Type),
+		utils.String("proxy_url", proxy.URL),
+		utils.Int("proxy_port", proxy.Port),
+		utils.String("proxy_username", proxy.Username),
+		utils.Bool("password_set", proxy.Password != ""))
+	return nil
+}
+
+// applyAddonLogLevel updates the add-on log level unless it is already set
+func (p *MigrationNodeProcessor) applyAddonLogLevel(ctx context.Context, level string) error {
+	current, err := p.splunkService.GetAddonLogLevel(ctx)
+	if err != nil {
+		p.logger.Warn("Could not read add-on log level, will update", utils.Err(err))
+	} else if strings.EqualFold(current, level) {
+		p.logger.Info("Add-on log level unchanged, skipping update", utils.String("loglevel", level))
+		return nil
+	}
+
+	if err := p.splunkService.UpdateAddonLogLevel(ctx, level); err != nil {
+		p.logger.Error("Failed to update add-on log level", utils.Err(err))
+		return err
+	}
+
+	p.logger.Info("✅ Add-on log level updated",
+		utils.String("from", current),
+		utils.String("to", level))
+	return nil
+}
+
+// proxySettingsDiff compares live proxy settings with the configuration (password excluded)
+func proxySettingsDiff(live *models.ProxySettings, desired *utils.SalesforceProxyConfig) []FieldDiff {
+	var diffs []FieldDiff
+	add := func(field, old, new string) {
+		if old != new {
+			diffs = append(diffs, FieldDiff{Field: field, Old: old, New: new})
+		}
+	}
+
+	add("proxy_enabled", strconv.FormatBool(live.Enabled), strconv.FormatBool(desired.Enabled))
+	add("proxy_type", live.Type, desired.Type)
+	add("proxy_url", live.URL, desired.URL)
+	add("proxy_port", strconv.Itoa(live.Port), strconv.Itoa(desired.Port))
+	add("proxy_username", live.Username, desired.Username)
+	add("proxy_rdns", strconv.FormatBool(live.RDNS), strconv.FormatBool(desired.RDNS))
+	return diffs
+}
+
+// planAddonSettingsNode plans the add-on proxy and logging settings
+func (p *MigrationNodeProcessor) planAddonSettingsNode(ctx context.Context) error {
+	proxy := p.config.Salesforce.Proxy
+	if proxy.IsConfigured() {
+		live, err := p.splunkService.GetProxySettings(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to plan proxy settings: %w", err)
+		}
+
+		diffs := proxySettingsDiff(live, &proxy)
+		if proxy.Password != "" {
+			diffs = append(diffs, FieldDiff{Field: "proxy_password", Old: planSensitiveValue, New: planSensitiveValue})
+		}
+
+		change := ResourceChange{Type: ResourceSettings, Name: "proxy", Action: PlanActionUnchanged}
+		if len(diffs) > 0 {
+			change.Action = PlanActionUpdate
+			change.Diffs = diffs
+		}
+		p.plan.Add(change)
+	}
+
+	if level := strings.ToUpper(p.config.Salesforce.AddonLogLevel); level != "" {
+		current, err := p.splunkService.GetAddonLogLevel(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to plan logging settings: %w", err)
+		}
+
+		change := ResourceChange{Type: ResourceSettings, Name: "logging", Action: PlanActionUnchanged}
+		if !strings.EqualFold(current, level) {
+			change.Action = PlanActionUpdate
+			change.Diffs = []FieldDiff{{Field: "loglevel", Old: current, New: level}}
+		}
+		p.plan.Add(change)
+	}
+
+	return nil
+}
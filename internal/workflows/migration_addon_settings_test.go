@@ -0,0 +1,154 @@
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+
+	"github.com/flowgraph/flowgraph/pkg/flowgraph"
+)
+
+// withAddonSettings configures an HTTP proxy and the DEBUG add-on log level
+func withAddonSettings(config *utils.Config) {
+	config.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, Type: "http", URL: "proxy.corp.local", Port: 3128}
+	config.Salesforce.AddonLogLevel = "DEBUG"
+}
+
+func runAddonSettingsNode(config *utils.Config, mockService *mocks.MockSplunkService) error {
+	processor := workflows.NewMigrationNodeProcessor(config, mockService, &mocks.MockDashboardService{})
+	_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "configure_addon_settings"}, make(map[string]interface{}))
+	return err
+}
+
+func TestMigrationNodeProcessor_ConfigureAddonSettings(t *testing.T) {
+	matchingProxy := func(ctx context.Context) (*models.ProxySettings, error) {
+		return &models.ProxySettings{Enabled: true, Type: "http", URL: "proxy.corp.local", Port: 3128}, nil
+	}
+
+	t.Run("Success_NothingConfigured", func(t *testing.T) {
+		config := newTestConfig()
+		mockService := &mocks.MockSplunkService{}
+
+		require.NoError(t, runAddonSettingsNode(config, mockService))
+		assert.Equal(t, 0, mockService.GetProxySettingsCalls)
+		assert.Equal(t, 0, mockService.GetAddonLogLevelCalls)
+	})
+
+	t.Run("Success_AppliesDriftedSettings", func(t *testing.T) {
+		var applied *utils.SalesforceProxyConfig
+		var level string
+		mockService := &mocks.MockSplunkService{
+			UpdateProxySettingsFunc: func(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+				applied = proxy
+				return nil
+			},
+			UpdateAddonLogLevelFunc: func(ctx context.Context, l string) error {
+				level = l
+				return nil
+			},
+		}
+
+		require.NoError(t, runAddonSettingsNode(newTestConfig(withAddonSettings), mockService))
+		require.NotNil(t, applied)
+		assert.Equal(t, "proxy.corp.local", applied.URL)
+		assert.Equal(t, "DEBUG", level)
+	})
+
+	t.Run("Success_SkipsUnchangedSettings", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			GetProxySettingsFunc: matchingProxy,
+			GetAddonLogLevelFunc: func(ctx context.Context) (string, error) { return "debug", nil },
+		}
+
+		require.NoError(t, runAddonSettingsNode(newTestConfig(withAddonSettings), mockService))
+		assert.Equal(t, 0, mockService.UpdateProxySettingsCalls)
+		assert.Equal(t, 0, mockService.UpdateAddonLogLevelCalls)
+	})
+
+	t.Run("Success_PasswordAlwaysApplied", func(t *testing.T) {
+		config := newTestConfig(withAddonSettings)
+		config.Salesforce.Proxy.Username = "svc"
+		config.Salesforce.Proxy.Password = "p@ss"
+		mockService := &mocks.MockSplunkService{GetProxySettingsFunc: matchingProxy}
+
+		require.NoError(t, runAddonSettingsNode(config, mockService))
+		assert.Equal(t, 1, mockService.UpdateProxySettingsCalls)
+	})
+
+	t.Run("Success_ReadErrorStillApplies", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			GetProxySettingsFunc: func(ctx context.Context) (*models.ProxySettings, error) {
+				return nil, fmt.Errorf("status 500")
+			},
+		}
+
+		require.NoError(t, runAddonSettingsNode(newTestConfig(withAddonSettings), mockService))
+		assert.Equal(t, 1, mockService.UpdateProxySettingsCalls)
+	})
+
+	t.Run("Error_UpdateFails", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			UpdateProxySettingsFunc: func(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+				return fmt.Errorf("failed to update proxy settings: status 400")
+			},
+		}
+
+		err := runAddonSettingsNode(newTestConfig(withAddonSettings), mockService)
+		require.Error(t, err)
+		assert.Equal(t, 0, mockService.UpdateAddonLogLevelCalls)
+	})
+}
+
+func TestMigrationGraph_AddonSettingsBeforeAccount(t *testing.T) {
+	var order []string
+	mockService := &mocks.MockSplunkService{
+		UpdateProxySettingsFunc: func(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+			order = append(order, "proxy")
+			return nil
+		},
+		CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
+			order = append(order, "account")
+			return nil
+		},
+	}
+
+	graph, err := workflows.NewMigrationGraph(newTestConfig(withAddonSettings), mockService, &mocks.MockDashboardService{})
+	require.NoError(t, err)
+	require.NoError(t, graph.Execute(context.Background()))
+	assert.Equal(t, []string{"proxy", "account"}, order)
+}
+
+func TestMigrationPlanGraph_AddonSettings(t *testing.T) {
+	config := newTestConfig(withAddonSettings)
+	config.Salesforce.Proxy.Username = "svc"
+	config.Salesforce.Proxy.Password = "p@ss"
+	mockService := &mocks.MockSplunkService{
+		CheckIndexExistsFunc: func(ctx context.Context, name string) (bool, error) { return true, nil },
+		GetProxySettingsFunc: func(ctx context.Context) (*models.ProxySettings, error) {
+			return &models.ProxySettings{Type: "http"}, nil
+		},
+	}
+
+	graph, err := workflows.NewMigrationPlanGraph(config, mockService, nil)
+	require.NoError(t, err)
+	require.NoError(t, graph.Execute(context.Background()))
+	assert.Equal(t, 0, mockService.UpdateProxySettingsCalls)
+	assert.Equal(t, 0, mockService.UpdateAddonLogLevelCalls)
+
+	var out bytes.Buffer
+	require.NoError(t, graph.GetPlan().Render(&out))
+	rendered := out.String()
+	assert.Contains(t, rendered, "~ settings.proxy will be updated in-place")
+	assert.Contains(t, rendered, `proxy_url        = "" -> "proxy.corp.local"`)
+	assert.Contains(t, rendered, `loglevel         = "INFO" -> "DEBUG"`)
+	assert.NotContains(t, rendered, "p@ss")
+}
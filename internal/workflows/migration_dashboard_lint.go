@@ -0,0 +1,53 @@
+package workflows
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// lintDashboardsNode checks the dashboard directory before anything is sent to Splunk,
+// so a malformed dashboard fails the run at its start instead of in create_dashboards.
+// Warnings are logged without failing the run.
+func (p *MigrationNodeProcessor) lintDashboardsNode(ctx context.Context) error {
+	dashboardDir := p.config.Migration.DashboardDirectory
+	if dashboardDir == "" {
+		p.logger.Debug("Dashboard directory not configured, skipping dashboard lint")
+		return nil
+	}
+	if exists, err := utils.FileExists(dashboardDir); err != nil || !exists {
+		p.logger.Debug("Dashboard directory not found, skipping dashboard lint",
+			utils.String("directory", dashboardDir))
+		return nil
+	}
+
+	p.logger.Info("🧪 Node 0: Linting dashboards before contacting Splunk...",
+		utils.String("directory", dashboardDir))
+
+	result, err := p.dashboardService.LintDashboards(ctx, dashboardDir)
+	if err != nil {
+		p.logger.Error("Failed to lint dashboards", utils.Err(err))
+		return fmt.Errorf("failed to lint dashboards: %w", err)
+	}
+
+	var errs []error
+	for _, problem := range result.Problems {
+		if problem.Severity == models.LintError {
+			p.logger.Error("Dashboard lint error", utils.String("problem", problem.String()))
+			errs = append(errs, errors.New(problem.String()))
+			continue
+		}
+		p.logger.Warn("Dashboard lint warning", utils.String("problem", problem.String()))
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%d dashboard lint error(s): %w", len(errs), errors.Join(errs...))
+	}
+
+	p.logger.Info("✅ Dashboards passed lint",
+		utils.Int("files", result.Files),
+		utils.Int("warnings", result.Warnings()))
+	return nil
+}
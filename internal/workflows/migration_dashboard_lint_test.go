@@ -0,0 +1,66 @@
+package workflows_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+
+	"github.com/flowgraph/flowgraph/pkg/flowgraph"
+)
+
+func newLintMockDashboardService(problems ...models.DashboardLintProblem) *mocks.MockDashboardService {
+	return &mocks.MockDashboardService{
+		LintDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardLintResult, error) {
+			return &models.DashboardLintResult{Directory: dashboardDir, Files: 1, Problems: problems}, nil
+		},
+	}
+}
+
+func TestMigrationNodeProcessor_LintDashboards(t *testing.T) {
+	lintNode := &flowgraph.Node{ID: "lint_dashboards"}
+
+	t.Run("Success_SkippedWithoutDirectory", func(t *testing.T) {
+		dashboardService := newLintMockDashboardService()
+		processor := workflows.NewMigrationNodeProcessor(newTestConfig(), &mocks.MockSplunkService{}, dashboardService)
+
+		_, err := processor.Process(context.Background(), lintNode, make(map[string]interface{}))
+		require.NoError(t, err)
+		assert.Equal(t, 0, dashboardService.LintDashboardsCalls)
+	})
+
+	t.Run("Success_WarningsDoNotFail", func(t *testing.T) {
+		dashboardService := newLintMockDashboardService(models.DashboardLintProblem{
+			File: "accounts.xml", Line: 3, Severity: models.LintWarning, Message: `search uses index "archive", which no configured input writes to`,
+		})
+		processor := workflows.NewMigrationNodeProcessor(newTestConfig(withDashboardDirectory(t.TempDir())), &mocks.MockSplunkService{}, dashboardService)
+
+		output, err := processor.Process(context.Background(), lintNode, make(map[string]interface{}))
+		require.NoError(t, err)
+		assert.Equal(t, 1, dashboardService.LintDashboardsCalls)
+		assert.Equal(t, "lint_dashboards", output["last_completed_step"])
+	})
+
+	t.Run("Error_FailsBeforeAuthenticating", func(t *testing.T) {
+		dashboardService := newLintMockDashboardService(
+			models.DashboardLintProblem{File: "accounts.xml", Line: 4, Severity: models.LintError, Message: "invalid XML: element <row> closed by </dashboard>"},
+			models.DashboardLintProblem{File: "accounts.xml", Line: 6, Severity: models.LintWarning, Message: `search uses index "archive", which no configured input writes to`},
+		)
+		mockService := &mocks.MockSplunkService{}
+
+		graph, err := workflows.NewMigrationGraph(newTestConfig(withDashboardDirectory(t.TempDir())), mockService, dashboardService)
+		require.NoError(t, err)
+
+		err = graph.Execute(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 dashboard lint error(s)")
+		assert.Contains(t, err.Error(), "accounts.xml:4: error: invalid XML")
+		assert.Equal(t, 0, mockService.AuthenticateCalls)
+		assert.Equal(t, 0, dashboardService.ReconcileDashboardsCalls)
+	})
+}
@@ -0,0 +1,277 @@
+package workflows_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+const e2eInputsPath = "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_object"
+
+const e2eEventLogsPath = "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_event_log"
+
+func newE2EConfig(server *splunktest.Server) *utils.Config {
+	return &utils.Config{
+		Splunk: utils.SplunkConfig{
+			URL:          server.URL,
+			Username:     splunktest.Username,
+			Password:     splunktest.Password,
+			IndexName:    "salesforce",
+			DefaultIndex: "salesforce",
+		},
+		Salesforce: utils.SalesforceConfig{
+			AccountName:  "sf_prod",
+			Endpoint:     "login.salesforce.com",
+			APIVersion:   "64.0",
+			AuthType:     "oauth_client_credentials",
+			ClientID:     "client-id",
+			ClientSecret: "client-Secret-value",
+		},
+		Migration: utils.MigrationConfig{ConcurrentRequests: 2},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "Account_Input", "object": "Account", "object_fields": "Id,Name,LastModifiedDate"},
+				map[string]interface{}{"name": "Opportunity_Input", "object": "Opportunity", "object_fields": "Id,Amount", "interval": float64(600)},
+			},
+			"EVENT_LOG_INPUTS": []interface{}{
+				map[string]interface{}{"name": "EventLog_Daily", "monitoring_interval": "Daily"},
+			},
+		},
+	}
+}
+
+// newE2EService creates a Splunk service that talks to server through the real HTTP
+// client, with retry delays short enough for tests
+func newE2EService(t *testing.T, config *utils.Config) *services.SplunkService {
+	t.Helper()
+	client := utils.NewHTTPClient(utils.HTTPClientConfig{
+		BaseURL:     config.Splunk.URL,
+		Timeout:     5 * time.Second,
+		RetryConfig: utils.RetryConfig{MaxRetries: 3, RetryDelay: time.Millisecond, MaxDelay: 10 * time.Millisecond},
+	})
+	service, err := services.NewSplunkServiceWithClient(config, client)
+	require.NoError(t, err)
+	return service
+}
+
+// runE2EMigration runs the full migration graph against server and returns its report
+func runE2EMigration(t *testing.T, config *utils.Config, service *services.SplunkService) (*workflows.Report, error) {
+	t.Helper()
+	graph, err := workflows.NewMigrationGraph(config, service, &mocks.MockDashboardService{})
+	require.NoError(t, err)
+	err = graph.Execute(context.Background())
+	return graph.Report(), err
+}
+
+func inputActions(report *workflows.Report) map[string]string {
+	actions := make(map[string]string, len(report.Inputs))
+	for _, input := range report.Inputs {
+		actions[input.Name] = input.Action
+	}
+	return actions
+}
+
+func TestMigrationGraph_EndToEnd(t *testing.T) {
+	t.Run("Success_ProvisionsFreshTarget", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		config := newE2EConfig(server)
+		service := newE2EService(t, config)
+
+		report, err := runE2EMigration(t, config, service)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"Account_Input":     workflows.ActionCreated,
+			"Opportunity_Input": workflows.ActionCreated,
+			"EventLog_Daily":    workflows.ActionCreated,
+		}, inputActions(report))
+
+		account, ok := server.Account("sf_prod")
+		require.True(t, ok)
+		assert.Equal(t, "login.salesforce.com", account["endpoint"])
+		assert.Equal(t, "client-Secret-value", account["client_secret_oauth_credentials"])
+
+		assert.Equal(t, []string{"Account_Input", "Opportunity_Input"}, server.DataInputs())
+		opportunity, _ := server.DataInput("Opportunity_Input")
+		assert.Equal(t, "sf_prod", opportunity["account"])
+		assert.Equal(t, "600", opportunity["interval"])
+		assert.Equal(t, "salesforce", opportunity["index"])
+		eventLog, ok := server.EventLogInput("EventLog_Daily")
+		require.True(t, ok)
+		assert.Equal(t, "Daily", eventLog["monitoring_interval"])
+
+		require.NoError(t, service.Logout(context.Background()))
+		assert.Equal(t, 0, server.ActiveTokens(), "token not revoked")
+	})
+
+	t.Run("Success_CreatesIndexesThroughACSOnSplunkCloud", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		config := newE2EConfig(server)
+		config.Splunk.Platform = utils.SplunkPlatformCloud
+		config.Splunk.Stack = splunktest.Stack
+		config.Splunk.ACSURL = server.URL
+		config.Splunk.IndexSearchableDays = 30
+		config.Extensions["EVENT_LOG_INPUTS"] = []interface{}{
+			map[string]interface{}{"name": "EventLog_Daily", "monitoring_interval": "Daily", "index": "sfdc_events"},
+		}
+
+		_, err := runE2EMigration(t, config, newE2EService(t, config))
+		require.NoError(t, err)
+
+		for _, name := range []string{"salesforce", "sfdc_events"} {
+			index, ok := server.Index(name)
+			require.True(t, ok, "index %s not created", name)
+			assert.Equal(t, "2592000", index["frozenTimePeriodInSecs"])
+		}
+		assert.Equal(t, 2, server.CountRequests(http.MethodPost, "/"+splunktest.Stack+"/adminconfig/v2/indexes"))
+		assert.Equal(t, 0, server.CountRequests(http.MethodPost, "/servicesNS/nobody"), "indexes created through REST on Splunk Cloud")
+		eventLog, _ := server.EventLogInput("EventLog_Daily")
+		assert.Equal(t, "sfdc_events", eventLog["index"])
+	})
+
+	t.Run("Success_SecondRunChangesNothing", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		config := newE2EConfig(server)
+
+		_, err := runE2EMigration(t, config, newE2EService(t, config))
+		require.NoError(t, err)
+		creates := server.CountRequests(http.MethodPost, e2eInputsPath)
+		eventLogCreates := server.CountRequests(http.MethodPost, e2eEventLogsPath)
+
+		report, err := runE2EMigration(t, config, newE2EService(t, config))
+		require.NoError(t, err)
+
+		assert.Equal(t, workflows.ActionSkipped, inputActions(report)["Account_Input"])
+		assert.Equal(t, workflows.ActionSkipped, inputActions(report)["Opportunity_Input"])
+		assert.Equal(t, workflows.ActionSkipped, inputActions(report)["EventLog_Daily"])
+		assert.Equal(t, creates, server.CountRequests(http.MethodPost, e2eInputsPath), "unchanged data inputs were written")
+		assert.Equal(t, eventLogCreates, server.CountRequests(http.MethodPost, e2eEventLogsPath), "unchanged event log inputs were written")
+	})
+
+	t.Run("Success_UpdatesDriftedInput", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		server.AddDataInput("Account_Input", map[string]string{
+			"account": "sf_prod", "object": "Account", "object_fields": "Id,Name,LastModifiedDate", "order_by": "LastModifiedDate",
+			"start_date": "2024-01-01T00:00:00.000Z", "interval": "900", "delay": "60", "index": "salesforce", "disabled": "1",
+		})
+		config := newE2EConfig(server)
+
+		report, err := runE2EMigration(t, config, newE2EService(t, config))
+		require.NoError(t, err)
+
+		assert.Equal(t, workflows.ActionUpdated, inputActions(report)["Account_Input"])
+		input, _ := server.DataInput("Account_Input")
+		assert.Equal(t, "300", input["interval"])
+		// disabled is not configured, so the input stays disabled
+		assert.Contains(t, []string{"1", "true"}, strings.ToLower(input["disabled"]))
+	})
+
+	t.Run("Success_RedeployUpdatesFixedDashboard", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		server.AddDashboard(splunktest.Dashboard{
+			Name: "analytics_dashboard",
+			Data: "<dashboard><label>Broken</label></dashboard>",
+			ACL:  splunktest.ACL{App: utils.DefaultDashboardApp},
+		})
+		fixed, err := os.ReadFile("../../resources/dashboards/analytics_dashboard.xml")
+		require.NoError(t, err)
+		dashboardDir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dashboardDir, "analytics_dashboard.xml"), fixed, 0o644))
+
+		config := newE2EConfig(server)
+		config.Migration.DashboardDirectory = dashboardDir
+		config.Migration.DashboardApp = utils.DefaultDashboardApp
+		service := newE2EService(t, config)
+		dashboardService, err := services.NewDashboardService(config, service)
+		require.NoError(t, err)
+		graph, err := workflows.NewMigrationGraph(config, service, dashboardService)
+		require.NoError(t, err)
+		require.NoError(t, graph.Execute(context.Background()))
+
+		report := graph.Report()
+		require.NotNil(t, report.Dashboards)
+		require.Len(t, report.Dashboards.Files, 1)
+		assert.Equal(t, workflows.ActionUpdated, report.Dashboards.Files[0].Action)
+		assert.Equal(t, []string{"analytics_dashboard"}, server.Dashboards(utils.DefaultDashboardApp))
+		dashboard, _ := server.Dashboard(utils.DefaultDashboardApp, "analytics_dashboard")
+		assert.Equal(t, strings.ReplaceAll(string(fixed), "{{.Index}}", "salesforce"), dashboard.Data)
+	})
+
+	t.Run("Success_RecoversFromThrottlingAndServerErrors", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		server.Inject(splunktest.Fault{Method: http.MethodPost, Path: e2eInputsPath, Status: http.StatusTooManyRequests, Times: 1})
+		server.Inject(splunktest.Fault{Method: http.MethodGet, Path: "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_account", Status: http.StatusServiceUnavailable, Times: 2})
+		server.Inject(splunktest.Fault{Path: "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_event_log", Latency: 20 * time.Millisecond})
+		config := newE2EConfig(server)
+		service := newE2EService(t, config)
+
+		report, err := runE2EMigration(t, config, service)
+		require.NoError(t, err)
+
+		assert.Equal(t, workflows.ActionCreated, inputActions(report)["Account_Input"])
+		assert.Equal(t, workflows.ActionCreated, inputActions(report)["Opportunity_Input"])
+		stats := service.HTTPStats()
+		assert.Equal(t, int64(1), stats.RateLimited)
+		assert.Equal(t, int64(2), stats.Unavailable)
+		assert.GreaterOrEqual(t, stats.Retries, int64(3))
+	})
+
+	t.Run("Success_AuthenticatesAgainAfterCredentialExpiry", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		config := newE2EConfig(server)
+		service := newE2EService(t, config)
+		require.NoError(t, service.Authenticate(context.Background()))
+		server.ExpireCredentials()
+
+		report, err := runE2EMigration(t, config, service)
+		require.NoError(t, err)
+
+		assert.Equal(t, workflows.ActionCreated, inputActions(report)["Account_Input"])
+		assert.Equal(t, 2, server.CountRequests(http.MethodPost, "/services/authorization/tokens"))
+	})
+
+	t.Run("Error_ForbiddenInputIsNotCreated", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		server.Inject(splunktest.Fault{Method: http.MethodGet, Path: e2eInputsPath + "/Opportunity_Input", Status: http.StatusForbidden})
+		config := newE2EConfig(server)
+
+		report, err := runE2EMigration(t, config, newE2EService(t, config))
+		require.Error(t, err)
+
+		for _, input := range report.Inputs {
+			if input.Name == "Opportunity_Input" {
+				assert.Equal(t, workflows.ActionFailed, input.Action)
+				assert.Equal(t, http.StatusForbidden, input.HTTPStatus)
+			}
+		}
+		assert.Equal(t, []string{"Account_Input"}, server.DataInputs())
+	})
+}
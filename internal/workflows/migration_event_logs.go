@@ -0,0 +1,157 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+// createEventLogInputsNode creates or updates the configured sfdc_event_log inputs in parallel
+func (p *MigrationNodeProcessor) createEventLogInputsNode(ctx context.Context) error {
+	if len(p.eventLogInputs) == 0 {
+		p.logger.Debug("No event log inputs configured. Skipping...")
+		return nil
+	}
+
+	maxParallelism := p.config.Migration.ConcurrentRequests
+	p.logger.Info("📜 Node 8: Creating event log inputs in parallel",
+		utils.Int("count", len(p.eventLogInputs)),
+		utils.Int("max_workers", maxParallelism))
+
+	if p.run != nil && len(p.run.FailedEventLogInputs) > 0 {
+		p.logger.Info("🔁 Retrying event log inputs that failed in a previous attempt",
+			utils.String("run_id", p.run.RunID),
+			utils.Int("count", len(p.run.FailedEventLogInputs)))
+	}
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, maxParallelism)
+	startTime := time.Now()
+	failed := 0
+
+	for _, input := range p.eventLogInputs {
+		wg.Add(1)
+		go func(inp utils.EventLogInput) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if p.eventLogInputCompleted(inp.Name) {
+				p.logger.Info("Event log input completed in a previous attempt, skipping",
+					utils.String("name", inp.Name),
+					utils.String("run_id", p.run.RunID))
+				p.incrementSuccess()
+				p.recordInputReport(InputTypeEventLog, inp.Name, ActionSkipped, "completed in a previous attempt", nil)
+				return
+			}
+
+			action, err := p.applyEventLogInput(ctx, &inp)
+			if err != nil {
+				p.logger.Error("Failed to provision event log input",
+					utils.String("name", inp.Name),
+					utils.String("monitoring_interval", inp.MonitoringInterval),
+					utils.Err(err))
+				p.incrementFailedEventLog(inp.Name)
+				p.recordInputReport(InputTypeEventLog, inp.Name, ActionFailed, "", err)
+
+				mu.Lock()
+				failed++
+				mu.Unlock()
+				return
+			}
+			if action == ActionSkipped {
+				p.incrementUnchanged(inp.Name)
+				p.recordInputReport(InputTypeEventLog, inp.Name, action, "unchanged", nil)
+				p.recordEventLogInput(ctx, inp.Name)
+				return
+			}
+			p.incrementSuccess()
+			p.recordInputReport(InputTypeEventLog, inp.Name, action, "", nil)
+			p.recordEventLogInput(ctx, inp.Name)
+		}(input)
+	}
+
+	wg.Wait()
+
+	duration := time.Since(startTime)
+	if failed > 0 {
+		p.logger.Warn("❌ Event log inputs creation completed with errors",
+			utils.Int("success", len(p.eventLogInputs)-failed),
+			utils.Int("failed", failed),
+			utils.Duration("duration", duration))
+		return fmt.Errorf("%d event log inputs failed to create", failed)
+	}
+
+	p.logger.Info("✅ All event log inputs created successfully",
+		utils.Int("count", len(p.eventLogInputs)),
+		utils.Duration("duration", duration))
+
+	return nil
+}
+
+// applyEventLogInput updates an event log input when it exists and has drifted, and
+// creates it otherwise. It returns the action taken for the run report.
+func (p *MigrationNodeProcessor) applyEventLogInput(ctx context.Context, input *utils.EventLogInput) (string, error) {
+	exists, err := p.splunkService.CheckEventLogInputExists(ctx, input.Name)
+	if isAuthError(err) {
+		return ActionFailed, err
+	}
+	if err != nil {
+		p.logger.Warn("Could not check if event log input exists, will attempt to create",
+			utils.String("name", input.Name),
+			utils.Err(err))
+		exists = false
+	}
+
+	if exists && !p.eventLogInputDrifted(ctx, input) {
+		p.logger.Info("Event log input unchanged, skipping update", utils.String("name", input.Name))
+		return ActionSkipped, nil
+	}
+
+	if exists {
+		p.logger.Info("Event log input exists, updating...", utils.String("name", input.Name))
+		if err := p.splunkService.UpdateEventLogInput(ctx, input); err != nil {
+			return ActionFailed, err
+		}
+		p.logger.Info("Event log input updated successfully", utils.String("name", input.Name))
+		return ActionUpdated, nil
+	}
+
+	if err := p.splunkService.CreateEventLogInput(ctx, input); err != nil {
+		return ActionFailed, err
+	}
+	p.logger.Info("Event log input created successfully", utils.String("name", input.Name))
+	return ActionCreated, nil
+}
+
+// eventLogInputDrifted reports whether an existing event log input differs from its
+// configuration, logging each drifted field. If the live input cannot be read it is
+// treated as drifted so that the update is still applied.
+func (p *MigrationNodeProcessor) eventLogInputDrifted(ctx context.Context, input *utils.EventLogInput) bool {
+	live, err := p.splunkService.GetEventLogInput(ctx, input.Name)
+	if err != nil {
+		p.logger.Warn("Could not read event log input for drift detection, will update",
+			utils.String("name", input.Name),
+			utils.Err(err))
+		return true
+	}
+	if live == nil {
+		return true
+	}
+
+	drift := services.DiffEventLogInput(live, input, p.inputAccount(input.Account), p.config.Splunk.DefaultIndex)
+	for _, field := range drift {
+		p.logger.Info("Event log input drift detected",
+			utils.String("name", input.Name),
+			utils.String("field", field.Field),
+			utils.String("live", field.Live),
+			utils.String("desired", field.Desired))
+	}
+	return len(drift) > 0
+}
@@ -0,0 +1,183 @@
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+
+	"github.com/flowgraph/flowgraph/pkg/flowgraph"
+)
+
+var eventLogTestInputs = withEventLogInputs(
+	map[string]interface{}{"name": "eventlog_daily", "monitoring_interval": "Daily"},
+	map[string]interface{}{"name": "eventlog_hourly", "monitoring_interval": "Hourly"},
+)
+
+func runEventLogNode(t *testing.T, config *utils.Config, mockService *mocks.MockSplunkService) (*workflows.MigrationNodeProcessor, error) {
+	processor := workflows.NewMigrationNodeProcessor(config, mockService, &mocks.MockDashboardService{})
+	_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "load_data_inputs"}, make(map[string]interface{}))
+	require.NoError(t, err)
+	_, err = processor.Process(context.Background(), &flowgraph.Node{ID: "create_event_log_inputs"}, make(map[string]interface{}))
+	return processor, err
+}
+
+func TestMigrationNodeProcessor_CreateEventLogInputs(t *testing.T) {
+	t.Run("Success_CreatesMissingAndUpdatesExisting", func(t *testing.T) {
+		var created, updated []string
+		mockService := &mocks.MockSplunkService{
+			CheckEventLogInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+				return name == "eventlog_hourly", nil
+			},
+			CreateEventLogInputFunc: func(ctx context.Context, input *utils.EventLogInput) error {
+				created = append(created, input.Name)
+				return nil
+			},
+			UpdateEventLogInputFunc: func(ctx context.Context, input *utils.EventLogInput) error {
+				updated = append(updated, input.Name)
+				return nil
+			},
+		}
+
+		processor, err := runEventLogNode(t, newTestConfig(eventLogTestInputs), mockService)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"eventlog_daily"}, created)
+		assert.Equal(t, []string{"eventlog_hourly"}, updated)
+		success, failed := processor.GetCounters()
+		assert.Equal(t, 2, success)
+		assert.Equal(t, 0, failed)
+	})
+
+	t.Run("Success_SkipsUnchanged", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckEventLogInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+				return true, nil
+			},
+			// eventlog_hourly has drifted to a daily monitoring interval
+			GetEventLogInputFunc: func(ctx context.Context, name string) (*models.SFDCEventLogInput, error) {
+				return &models.SFDCEventLogInput{
+					Name:               name,
+					Account:            "test_account",
+					MonitoringInterval: "Daily",
+					StartDate:          "2024-01-01T00:00:00.000Z",
+					Interval:           3600,
+					Index:              "test_index",
+				}, nil
+			},
+		}
+
+		processor, err := runEventLogNode(t, newTestConfig(eventLogTestInputs), mockService)
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockService.UpdateEventLogInputCalls)
+		assert.Equal(t, 0, mockService.CreateEventLogInputCalls)
+		assert.Equal(t, []string{"eventlog_daily"}, processor.GetUnchangedInputs())
+		success, _ := processor.GetCounters()
+		assert.Equal(t, 2, success)
+	})
+
+	t.Run("Success_CheckErrorFallsBackToCreate", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckEventLogInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+				return false, fmt.Errorf("connection reset")
+			},
+		}
+
+		_, err := runEventLogNode(t, newTestConfig(eventLogTestInputs), mockService)
+		require.NoError(t, err)
+		assert.Equal(t, 2, mockService.CreateEventLogInputCalls)
+		assert.Equal(t, 0, mockService.UpdateEventLogInputCalls)
+	})
+
+	t.Run("Error_CheckUnauthorizedDoesNotCreate", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckEventLogInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+				return false, fmt.Errorf("failed to check event log input existence: %w", utils.NewHTTPError(401, nil))
+			},
+		}
+
+		processor, err := runEventLogNode(t, newTestConfig(eventLogTestInputs), mockService)
+		require.Error(t, err)
+		assert.Equal(t, 0, mockService.CreateEventLogInputCalls)
+		_, failed := processor.GetCounters()
+		assert.Equal(t, 2, failed)
+	})
+
+	t.Run("Success_NoEventLogInputsConfigured", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{}
+
+		_, err := runEventLogNode(t, newTestConfig(), mockService)
+		require.NoError(t, err)
+		assert.Equal(t, 0, mockService.CheckEventLogInputExistsCalls)
+		assert.Equal(t, 0, mockService.CreateEventLogInputCalls)
+	})
+
+	t.Run("Error_CreateFails", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CreateEventLogInputFunc: func(ctx context.Context, input *utils.EventLogInput) error {
+				if input.Name == "eventlog_hourly" {
+					return fmt.Errorf("status 400")
+				}
+				return nil
+			},
+		}
+
+		processor, err := runEventLogNode(t, newTestConfig(eventLogTestInputs), mockService)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 event log inputs failed to create")
+		success, failed := processor.GetCounters()
+		assert.Equal(t, 1, success)
+		assert.Equal(t, 1, failed)
+	})
+}
+
+func TestMigrationPlanGraph_EventLogInputs(t *testing.T) {
+	mockService := &mocks.MockSplunkService{
+		CheckIndexExistsFunc: func(ctx context.Context, name string) (bool, error) { return true, nil },
+		CheckEventLogInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+			return name != "eventlog_daily", nil
+		},
+		GetEventLogInputFunc: func(ctx context.Context, name string) (*models.SFDCEventLogInput, error) {
+			live := &models.SFDCEventLogInput{
+				Name:               name,
+				Account:            "test_account",
+				MonitoringInterval: "Hourly",
+				StartDate:          "2024-01-01T00:00:00.000Z",
+				Interval:           3600,
+				Index:              "test_index",
+			}
+			if name == "eventlog_weekly" {
+				live.Interval = 7200
+			}
+			return live, nil
+		},
+	}
+	config := newTestConfig(withEventLogInputs(
+		map[string]interface{}{"name": "eventlog_daily", "monitoring_interval": "Daily"},
+		map[string]interface{}{"name": "eventlog_hourly", "monitoring_interval": "Hourly"},
+		map[string]interface{}{"name": "eventlog_weekly", "monitoring_interval": "Hourly"},
+	))
+
+	graph, err := workflows.NewMigrationPlanGraph(config, mockService, nil)
+	require.NoError(t, err)
+	require.NoError(t, graph.Execute(context.Background()))
+
+	assert.Equal(t, 0, mockService.CreateEventLogInputCalls)
+	assert.Equal(t, 0, mockService.UpdateEventLogInputCalls)
+
+	var out bytes.Buffer
+	require.NoError(t, graph.GetPlan().Render(&out))
+	assert.Contains(t, out.String(), "+ sfdc_event_log.eventlog_daily will be created")
+	assert.Contains(t, out.String(), "sfdc_event_log.eventlog_hourly is up to date")
+	assert.Contains(t, out.String(), "~ sfdc_event_log.eventlog_weekly will be updated in-place")
+	assert.Contains(t, out.String(), `interval         = "7200" -> "3600"`)
+	assert.NotContains(t, out.String(), "(not read)")
+}
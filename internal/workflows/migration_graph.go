@@ -15,6 +15,8 @@ type MigrationGraph struct {
 	runtime   *flowgraph.Runtime
 	graph     *flowgraph.Graph
 	processor *MigrationNodeProcessor
+	plan      *Plan
+	threadID  string
 	startTime time.Time
 	endTime   time.Time
 	logger    utils.Logger
@@ -24,9 +26,32 @@ func NewMigrationGraph(config *utils.Config, splunkService services.SplunkServic
 	// Create custom node processor for migration nodes
 	processor := NewMigrationNodeProcessor(config, splunkService, dashboardService)
 
-	// Create FlowGraph runtime with custom processor
-	runtime := flowgraph.NewRuntimeWithNodeProcessor(processor)
+	return newMigrationGraph(processor, nil, flowgraph.NewRuntimeWithNodeProcessor(processor), "migration-thread-1")
+}
+
+// NewResumableMigrationGraph creates a migration graph that checkpoints every node to
+// store and records its progress in run. Nodes and data inputs that run already
+// lists as completed are skipped; inputs that failed before are retried.
+func NewResumableMigrationGraph(config *utils.Config, splunkService services.SplunkServiceInterface, dashboardService services.DashboardServiceInterface, store *RunStore, run *RunState) (*MigrationGraph, error) {
+	processor := NewMigrationNodeProcessor(config, splunkService, dashboardService)
+	processor.run = run
+	processor.runStore = store
+
+	runtime := flowgraph.NewRuntimeWithCheckpointSaver(processor, store.saver)
+	return newMigrationGraph(processor, nil, runtime, run.threadID())
+}
 
+// NewMigrationPlanGraph creates a migration graph that runs every node read-only
+// and records what an apply run would change instead of sending POST requests
+func NewMigrationPlanGraph(config *utils.Config, splunkService services.SplunkServiceInterface, dashboardService services.DashboardServiceInterface) (*MigrationGraph, error) {
+	plan := NewPlan()
+	processor := NewMigrationPlanProcessor(config, splunkService, dashboardService, plan)
+
+	return newMigrationGraph(processor, plan, flowgraph.NewRuntimeWithNodeProcessor(processor), "migration-thread-1")
+}
+
+// newMigrationGraph saves the migration graph into a FlowGraph runtime that uses processor
+func newMigrationGraph(processor *MigrationNodeProcessor, plan *Plan, runtime *flowgraph.Runtime, threadID string) (*MigrationGraph, error) {
 	// Build the migration graph
 	migrationGraph, err := buildMigrationGraph()
 	if err != nil {
@@ -43,6 +68,8 @@ func NewMigrationGraph(config *utils.Config, splunkService services.SplunkServic
 		runtime:   runtime,
 		graph:     migrationGraph,
 		processor: processor,
+		plan:      plan,
+		threadID:  threadID,
 		logger:    utils.GetLogger(),
 	}, nil
 }
@@ -50,9 +77,9 @@ func NewMigrationGraph(config *utils.Config, splunkService services.SplunkServic
 // buildMigrationGraph constructs the FlowGraph structure for migration
 func buildMigrationGraph() (*flowgraph.Graph, error) {
 	g := &flowgraph.Graph{
-		ID:         "salesforce-splunk-migration",
+		ID:         migrationGraphID,
 		Name:       "Salesforce to Splunk Migration",
-		EntryPoint: "authenticate",
+		EntryPoint: "lint_dashboards",
 		Nodes:      make(map[string]*flowgraph.Node),
 		CreatedAt:  time.Now(),
 		UpdatedAt:  time.Now(),
@@ -60,6 +87,13 @@ func buildMigrationGraph() (*flowgraph.Graph, error) {
 
 	// Define migration nodes
 	nodes := []*flowgraph.Node{
+		{
+			ID:        "lint_dashboards",
+			Name:      "Lint Dashboards",
+			Type:      flowgraph.NodeTypeFunction,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
 		{
 			ID:        "authenticate",
 			Name:      "Authenticate with Splunk",
@@ -81,6 +115,13 @@ func buildMigrationGraph() (*flowgraph.Graph, error) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
+		{
+			ID:        "configure_addon_settings",
+			Name:      "Configure Add-on Proxy and Logging",
+			Type:      flowgraph.NodeTypeFunction,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
 		{
 			ID:        "create_account",
 			Name:      "Create Salesforce Account",
@@ -102,6 +143,13 @@ func buildMigrationGraph() (*flowgraph.Graph, error) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
+		{
+			ID:        "create_event_log_inputs",
+			Name:      "Create Event Log Inputs",
+			Type:      flowgraph.NodeTypeFunction,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
 		{
 			ID:        "verify_inputs",
 			Name:      "Verify Data Inputs",
@@ -109,6 +157,13 @@ func buildMigrationGraph() (*flowgraph.Graph, error) {
 			CreatedAt: time.Now(),
 			UpdatedAt: time.Now(),
 		},
+		{
+			ID:        "prune_inputs",
+			Name:      "Prune Unmanaged Data Inputs",
+			Type:      flowgraph.NodeTypeFunction,
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		},
 		{
 			ID:        "create_dashboards",
 			Name:      "Create Dashboards",
@@ -127,13 +182,17 @@ func buildMigrationGraph() (*flowgraph.Graph, error) {
 
 	// Define edges (sequential workflow)
 	edges := []*flowgraph.Edge{
+		{Source: "lint_dashboards", Target: "authenticate"},
 		{Source: "authenticate", Target: "check_salesforce_addon"},
 		{Source: "check_salesforce_addon", Target: "create_index"},
-		{Source: "create_index", Target: "create_account"},
+		{Source: "create_index", Target: "configure_addon_settings"},
+		{Source: "configure_addon_settings", Target: "create_account"},
 		{Source: "create_account", Target: "load_data_inputs"},
 		{Source: "load_data_inputs", Target: "create_data_inputs"},
-		{Source: "create_data_inputs", Target: "verify_inputs"},
-		{Source: "verify_inputs", Target: "create_dashboards"},
+		{Source: "create_data_inputs", Target: "create_event_log_inputs"},
+		{Source: "create_event_log_inputs", Target: "verify_inputs"},
+		{Source: "verify_inputs", Target: "prune_inputs"},
+		{Source: "prune_inputs", Target: "create_dashboards"},
 	}
 
 	// Add edges to graph
@@ -159,10 +218,13 @@ func (mg *MigrationGraph) Execute(ctx context.Context) error {
 		}
 	}()
 
+	// Record the run before the first node so that even an early failure can be resumed
+	mg.processor.saveRun(ctx)
+
 	// Execute the graph using FlowGraph runtime
 	req := &flowgraph.ExecutionRequest{
 		GraphID:  mg.graph.ID,
-		ThreadID: "migration-thread-1",
+		ThreadID: mg.threadID,
 		Input:    make(map[string]interface{}),
 		Config: flowgraph.ExecutionConfig{
 			MaxSteps:        100,
@@ -173,12 +235,12 @@ func (mg *MigrationGraph) Execute(ctx context.Context) error {
 	}
 
 	response, err := mg.runtime.Execute(ctx, req)
+	mg.endTime = time.Now()
 	if err != nil {
 		mg.logger.Error("Migration execution failed", utils.Err(err))
 		return err
 	}
 
-	mg.endTime = time.Now()
 	duration := mg.endTime.Sub(mg.startTime)
 
 	if response.Status == "completed" {
@@ -193,6 +255,18 @@ func (mg *MigrationGraph) Execute(ctx context.Context) error {
 	return nil
 }
 
+// SetLogger replaces the logger used by the graph and its node processor,
+// e.g. to tag every log line with the Splunk target being migrated
+func (mg *MigrationGraph) SetLogger(logger utils.Logger) {
+	mg.logger = logger
+	mg.processor.logger = logger
+}
+
+// GetPlan returns the plan recorded by a plan graph (nil for apply graphs)
+func (mg *MigrationGraph) GetPlan() *Plan {
+	return mg.plan
+}
+
 // GetState returns counters for backwards compatibility
 func (mg *MigrationGraph) GetState() *MigrationState {
 	success, failed := mg.processor.GetCounters()
@@ -11,6 +11,7 @@ import (
 
 	"salesforce-splunk-migration/internal/workflows"
 	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
 	"salesforce-splunk-migration/utils"
 )
 
@@ -127,7 +128,7 @@ func TestMigrationGraph_Execute(t *testing.T) {
 				callOrder = append(callOrder, "create_index")
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				callOrder = append(callOrder, "create_account")
 				return nil
 			},
@@ -256,7 +257,7 @@ func TestMigrationGraph_Execute(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -380,7 +381,7 @@ func TestMigrationGraph_GetState(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -495,7 +496,7 @@ func TestMigrationGraph_ExecuteWithDataInputs(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -542,7 +543,7 @@ func TestMigrationGraph_ExecuteWithDataInputs(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			ListDataInputsFunc: func(ctx context.Context) ([]string, error) {
@@ -676,7 +677,7 @@ func TestMigrationGraph_ExecuteWithNonCompletedStatus(t *testing.T) {
 				time.Sleep(100 * time.Millisecond)
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				time.Sleep(100 * time.Millisecond)
 				return nil
 			},
@@ -743,7 +744,7 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -755,9 +756,9 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 		}
 
 		mockDashboardService := &mocks.MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, receivedDir string) error {
+			ReconcileDashboardsFunc: func(ctx context.Context, receivedDir string) (*models.DashboardReconcileResult, error) {
 				assert.Equal(t, dashboardDir, receivedDir)
-				return nil
+				return &models.DashboardReconcileResult{Directory: receivedDir}, nil
 			},
 		}
 
@@ -768,7 +769,7 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 		err = graph.Execute(ctx)
 
 		require.NoError(t, err)
-		assert.Equal(t, 1, mockDashboardService.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mockDashboardService.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_NoDashboardDirectory", func(t *testing.T) {
@@ -804,7 +805,7 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -816,8 +817,8 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 		}
 
 		mockDashboardService := &mocks.MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
-				return nil
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
 			},
 		}
 
@@ -829,7 +830,7 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 
 		require.NoError(t, err)
 		// Should not call dashboard creation when directory is empty
-		assert.Equal(t, 0, mockDashboardService.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mockDashboardService.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Error_DashboardCreationFails", func(t *testing.T) {
@@ -868,7 +869,7 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 			CreateIndexFunc: func(ctx context.Context, indexName string) error {
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -880,8 +881,8 @@ func TestMigrationGraph_DashboardCreation(t *testing.T) {
 		}
 
 		mockDashboardService := &mocks.MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
-				return fmt.Errorf("dashboard creation failed")
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return nil, fmt.Errorf("dashboard creation failed")
 			},
 		}
 
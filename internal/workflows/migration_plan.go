@@ -0,0 +1,150 @@
+package workflows
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// PlanAction describes what an apply run would do to a resource
+type PlanAction string
+
+const (
+	PlanActionCreate    PlanAction = "create"
+	PlanActionUpdate    PlanAction = "update"
+	PlanActionUnchanged PlanAction = "unchanged"
+	PlanActionUnmanaged PlanAction = "unmanaged"
+	PlanActionDisable   PlanAction = "disable"
+	PlanActionDelete    PlanAction = "delete"
+)
+
+// Resource types reported in a plan
+const (
+	ResourceIndex     = "index"
+	ResourceSettings  = "settings"
+	ResourceAccount   = "account"
+	ResourceDataInput = "sfdc_object"
+	ResourceEventLog  = "sfdc_event_log"
+)
+
+// Placeholders shown in field diffs
+const (
+	planUnreadValue    = "(not read)"
+	planSensitiveValue = "(sensitive value)"
+)
+
+// FieldDiff describes a single field change on a resource
+type FieldDiff struct {
+	Field string `json:"field"`
+	Old   string `json:"old,omitempty"`
+	New   string `json:"new,omitempty"`
+}
+
+// ResourceChange describes the planned action for one Splunk resource
+type ResourceChange struct {
+	Type   string      `json:"type"`
+	Name   string      `json:"name"`
+	Action PlanAction  `json:"action"`
+	Diffs  []FieldDiff `json:"diffs,omitempty"`
+}
+
+// Plan collects the resource changes computed by a read-only migration run
+type Plan struct {
+	changes []ResourceChange
+	mu      sync.Mutex
+}
+
+// NewPlan creates an empty plan
+func NewPlan() *Plan {
+	return &Plan{changes: make([]ResourceChange, 0)}
+}
+
+// Add records a resource change (safe for concurrent use)
+func (p *Plan) Add(change ResourceChange) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changes = append(p.changes, change)
+}
+
+// Changes returns the recorded changes ordered by resource type and name
+func (p *Plan) Changes() []ResourceChange {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	changes := make([]ResourceChange, len(p.changes))
+	copy(changes, p.changes)
+
+	typeOrder := map[string]int{ResourceIndex: 0, ResourceSettings: 1, ResourceAccount: 2, ResourceDataInput: 3, ResourceEventLog: 4}
+	sort.SliceStable(changes, func(i, j int) bool {
+		if changes[i].Type != changes[j].Type {
+			return typeOrder[changes[i].Type] < typeOrder[changes[j].Type]
+		}
+		return changes[i].Name < changes[j].Name
+	})
+	return changes
+}
+
+// Count returns the number of changes with the given action
+func (p *Plan) Count(action PlanAction) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	count := 0
+	for _, change := range p.changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// HasChanges reports whether applying the plan would modify Splunk
+func (p *Plan) HasChanges() bool {
+	return p.Count(PlanActionCreate)+p.Count(PlanActionUpdate)+p.Count(PlanActionDisable)+p.Count(PlanActionDelete) > 0
+}
+
+// Render writes a Terraform-style plan to w
+func (p *Plan) Render(w io.Writer) error {
+	var b strings.Builder
+
+	b.WriteString("Migration plan:\n\n")
+	for _, change := range p.Changes() {
+		address := fmt.Sprintf("%s.%s", change.Type, change.Name)
+		switch change.Action {
+		case PlanActionCreate:
+			fmt.Fprintf(&b, "  + %s will be created\n", address)
+			for _, diff := range change.Diffs {
+				fmt.Fprintf(&b, "      + %-16s = %q\n", diff.Field, diff.New)
+			}
+		case PlanActionUpdate:
+			fmt.Fprintf(&b, "  ~ %s will be updated in-place\n", address)
+			for _, diff := range change.Diffs {
+				fmt.Fprintf(&b, "      ~ %-16s = %q -> %q\n", diff.Field, diff.Old, diff.New)
+			}
+		case PlanActionUnchanged:
+			fmt.Fprintf(&b, "    %s is up to date\n", address)
+		case PlanActionUnmanaged:
+			fmt.Fprintf(&b, "  ? %s exists in Splunk but is not in DATA_INPUTS\n", address)
+		case PlanActionDisable:
+			fmt.Fprintf(&b, "  ! %s will be disabled (not in DATA_INPUTS)\n", address)
+		case PlanActionDelete:
+			fmt.Fprintf(&b, "  - %s will be deleted (not in DATA_INPUTS)\n", address)
+		}
+	}
+
+	fmt.Fprintf(&b, "\nPlan: %d to create, %d to update", p.Count(PlanActionCreate), p.Count(PlanActionUpdate))
+	if disable := p.Count(PlanActionDisable); disable > 0 {
+		fmt.Fprintf(&b, ", %d to disable", disable)
+	}
+	if del := p.Count(PlanActionDelete); del > 0 {
+		fmt.Fprintf(&b, ", %d to delete", del)
+	}
+	fmt.Fprintf(&b, ", %d unchanged, %d unmanaged.\n",
+		p.Count(PlanActionUnchanged),
+		p.Count(PlanActionUnmanaged))
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
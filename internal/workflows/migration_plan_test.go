@@ -0,0 +1,282 @@
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+)
+
+// planTestInputs are a data input missing from Splunk and one that exists
+var planTestInputs = withDataInputs(
+	map[string]interface{}{"name": "new_input", "object": "Account", "object_fields": "Id,Name"},
+	map[string]interface{}{"name": "existing_input", "object": "Contact", "object_fields": "Id,Email"},
+)
+
+func TestMigrationPlanGraph_Execute(t *testing.T) {
+	t.Run("Success_ReadOnlyPlan", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return true, nil
+			},
+			CheckDataInputExistsFunc: func(ctx context.Context, inputName string) (bool, error) {
+				return inputName == "existing_input", nil
+			},
+			ListDataInputsFunc: func(ctx context.Context) ([]string, error) {
+				return []string{"existing_input", "legacy_input"}, nil
+			},
+		}
+
+		graph, err := workflows.NewMigrationPlanGraph(newTestConfig(planTestInputs), mockService, nil)
+		require.NoError(t, err)
+
+		require.NoError(t, graph.Execute(context.Background()))
+
+		// No mutating calls may be made in plan mode
+		assert.Equal(t, 0, mockService.AuthenticateCalls)
+		assert.Equal(t, 0, mockService.CreateIndexCalls)
+		assert.Equal(t, 0, mockService.CreateSalesforceAccountCalls)
+		assert.Equal(t, 0, mockService.UpdateSalesforceAccountCalls)
+		assert.Equal(t, 0, mockService.CreateDataInputCalls)
+		assert.Equal(t, 0, mockService.UpdateDataInputCalls)
+
+		plan := graph.GetPlan()
+		require.NotNil(t, plan)
+		assert.Equal(t, 2, plan.Count(workflows.PlanActionCreate))
+		assert.Equal(t, 1, plan.Count(workflows.PlanActionUpdate))
+		assert.Equal(t, 1, plan.Count(workflows.PlanActionUnchanged))
+		assert.Equal(t, 1, plan.Count(workflows.PlanActionUnmanaged))
+		assert.True(t, plan.HasChanges())
+
+		var out bytes.Buffer
+		require.NoError(t, plan.Render(&out))
+		rendered := out.String()
+		assert.Contains(t, rendered, "+ account.test_account will be created")
+		assert.Contains(t, rendered, "+ sfdc_object.new_input will be created")
+		assert.Contains(t, rendered, "~ sfdc_object.existing_input will be updated in-place")
+		assert.Contains(t, rendered, "? sfdc_object.legacy_input exists in Splunk")
+		assert.Contains(t, rendered, "index.test_index is up to date")
+		assert.Contains(t, rendered, "Plan: 2 to create, 1 to update, 1 unchanged, 1 unmanaged.")
+		assert.NotContains(t, rendered, "super-secret")
+	})
+
+	t.Run("Success_DiffsLiveAccount", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) { return true, nil },
+			GetSalesforceAccountFunc: func(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error) {
+				return &models.SalesforceAccountSettings{Name: accountName, Endpoint: "https://test.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "client-id"}, nil
+			},
+		}
+
+		graph, err := workflows.NewMigrationPlanGraph(newTestConfig(planTestInputs), mockService, nil)
+		require.NoError(t, err)
+		require.NoError(t, graph.Execute(context.Background()))
+
+		var account workflows.ResourceChange
+		for _, change := range graph.GetPlan().Changes() {
+			if change.Type == workflows.ResourceAccount {
+				account = change
+			}
+		}
+		assert.Equal(t, workflows.PlanActionUpdate, account.Action)
+		assert.Equal(t, []workflows.FieldDiff{
+			{Field: "endpoint", Old: "https://test.salesforce.com", New: "https://login.salesforce.com"},
+			{Field: "client_secret", Old: "(sensitive value)", New: "(sensitive value)"},
+		}, account.Diffs)
+		assert.Equal(t, 1, mockService.GetSalesforceAccountCalls)
+		assert.Equal(t, 0, mockService.CheckSalesforceAccountExistsCalls)
+	})
+
+	t.Run("Error_CheckFails", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return false, fmt.Errorf("connection refused")
+			},
+		}
+
+		graph, err := workflows.NewMigrationPlanGraph(newTestConfig(planTestInputs), mockService, nil)
+		require.NoError(t, err)
+
+		err = graph.Execute(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "connection refused")
+	})
+
+	t.Run("Success_PlansInputIndexes", func(t *testing.T) {
+		config := newTestConfig(planTestInputs, withEventLogInputs(map[string]interface{}{"name": "EventLog_Daily", "index": "sfdc_events"}))
+		config.Splunk.IndexSearchableDays = 90
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return indexName == "test_index", nil
+			},
+		}
+
+		graph, err := workflows.NewMigrationPlanGraph(config, mockService, nil)
+		require.NoError(t, err)
+		require.NoError(t, graph.Execute(context.Background()))
+
+		var indexes []workflows.ResourceChange
+		for _, change := range graph.GetPlan().Changes() {
+			if change.Type == workflows.ResourceIndex {
+				indexes = append(indexes, change)
+			}
+		}
+		require.Len(t, indexes, 2)
+		assert.Equal(t, workflows.PlanActionUnchanged, indexes[1].Action)
+		assert.Equal(t, "sfdc_events", indexes[0].Name)
+		assert.Equal(t, workflows.PlanActionCreate, indexes[0].Action)
+		assert.Equal(t, []workflows.FieldDiff{{Field: "datatype", New: "event"}, {Field: "searchableDays", New: "90"}}, indexes[0].Diffs)
+		assert.Equal(t, 0, mockService.CreateIndexCalls)
+	})
+
+	t.Run("Success_ApplyGraphHasNoPlan", func(t *testing.T) {
+		graph, err := workflows.NewMigrationGraph(newTestConfig(planTestInputs), &mocks.MockSplunkService{}, &mocks.MockDashboardService{})
+		require.NoError(t, err)
+		assert.Nil(t, graph.GetPlan())
+	})
+}
+
+func TestPlan_Render(t *testing.T) {
+	t.Run("Success_EmptyPlan", func(t *testing.T) {
+		plan := workflows.NewPlan()
+
+		var out bytes.Buffer
+		require.NoError(t, plan.Render(&out))
+		assert.Contains(t, out.String(), "Plan: 0 to create, 0 to update, 0 unchanged, 0 unmanaged.")
+		assert.False(t, plan.HasChanges())
+	})
+
+	t.Run("Success_OrdersByTypeAndName", func(t *testing.T) {
+		plan := workflows.NewPlan()
+		plan.Add(workflows.ResourceChange{Type: workflows.ResourceDataInput, Name: "b", Action: workflows.PlanActionCreate})
+		plan.Add(workflows.ResourceChange{Type: workflows.ResourceDataInput, Name: "a", Action: workflows.PlanActionCreate})
+		plan.Add(workflows.ResourceChange{Type: workflows.ResourceIndex, Name: "idx", Action: workflows.PlanActionUnchanged})
+
+		changes := plan.Changes()
+		require.Len(t, changes, 3)
+		assert.Equal(t, "idx", changes[0].Name)
+		assert.Equal(t, "a", changes[1].Name)
+		assert.Equal(t, "b", changes[2].Name)
+	})
+
+	t.Run("Success_RendersFieldDiffs", func(t *testing.T) {
+		plan := workflows.NewPlan()
+		plan.Add(workflows.ResourceChange{
+			Type:   workflows.ResourceDataInput,
+			Name:   "input",
+			Action: workflows.PlanActionUpdate,
+			Diffs:  []workflows.FieldDiff{{Field: "interval", Old: "300", New: "600"}},
+		})
+
+		var out bytes.Buffer
+		require.NoError(t, plan.Render(&out))
+		assert.Contains(t, out.String(), `"300" -> "600"`)
+	})
+}
+
+func TestMigrationPlanGraph_DataInputDrift(t *testing.T) {
+	t.Run("Success_FieldLevelDiffs", func(t *testing.T) {
+		config := newTestConfig(planTestInputs)
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return true, nil
+			},
+			CheckDataInputExistsFunc: func(ctx context.Context, inputName string) (bool, error) {
+				return true, nil
+			},
+			GetDataInputFunc: func(ctx context.Context, inputName string) (*models.SFDCObjectInput, error) {
+				live := &models.SFDCObjectInput{
+					Name:         inputName,
+					Account:      "test_account",
+					Object:       "Account",
+					ObjectFields: "Name,Id",
+					OrderBy:      "LastModifiedDate",
+					StartDate:    "2024-01-01T00:00:00.000Z",
+					Interval:     300,
+					Delay:        60,
+					Index:        "test_index",
+				}
+				if inputName == "existing_input" {
+					live.Object = "Contact"
+					live.ObjectFields = "Id"
+				}
+				return live, nil
+			},
+		}
+
+		graph, err := workflows.NewMigrationPlanGraph(config, mockService, nil)
+		require.NoError(t, err)
+		require.NoError(t, graph.Execute(context.Background()))
+
+		plan := graph.GetPlan()
+		assert.Equal(t, 1, plan.Count(workflows.PlanActionUpdate))
+
+		var out bytes.Buffer
+		require.NoError(t, plan.Render(&out))
+		assert.Contains(t, out.String(), "sfdc_object.new_input is up to date")
+		assert.Contains(t, out.String(), `object_fields    = "Id" -> "Id,Email"`)
+		assert.NotContains(t, out.String(), "interval")
+	})
+}
+
+func TestMigrationPlanGraph_MultipleAccounts(t *testing.T) {
+	config := newTestConfig(withDataInputs(
+		map[string]interface{}{"name": "sandbox_input", "account": "sf_sandbox", "object": "Account", "object_fields": "Id"},
+	))
+	config.Extensions["SALESFORCE_ACCOUNTS"] = []interface{}{
+		map[string]interface{}{"name": "test_account", "endpoint": "login.salesforce.com", "client_id": "id1", "client_secret": "prod-secret"},
+		map[string]interface{}{"name": "sf_sandbox", "endpoint": "test.salesforce.com", "client_id": "id2", "client_secret": "sandbox-secret"},
+		map[string]interface{}{"name": "sf_uat", "endpoint": "uat.my.salesforce.com", "client_id": "id3", "client_secret": "uat-secret"},
+	}
+
+	mockService := &mocks.MockSplunkService{
+		CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) { return true, nil },
+		GetSalesforceAccountFunc: func(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error) {
+			switch accountName {
+			case "test_account":
+				return &models.SalesforceAccountSettings{Name: accountName, Endpoint: "login.salesforce.com", APIVersion: "58.0", AuthType: "oauth_client_credentials", ClientID: "id1"}, nil
+			case "sf_uat":
+				return &models.SalesforceAccountSettings{Name: accountName, Endpoint: "uat.my.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "id3"}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	graph, err := workflows.NewMigrationPlanGraph(config, mockService, nil)
+	require.NoError(t, err)
+	require.NoError(t, graph.Execute(context.Background()))
+
+	changes := make(map[string]workflows.ResourceChange)
+	for _, change := range graph.GetPlan().Changes() {
+		if change.Type == workflows.ResourceAccount {
+			changes[change.Name] = change
+		}
+	}
+	require.Len(t, changes, 3)
+	assert.Equal(t, workflows.PlanActionUpdate, changes["test_account"].Action)
+	assert.Equal(t, []workflows.FieldDiff{
+		{Field: "sfdc_api_version", Old: "58.0", New: "64.0"},
+		{Field: "client_secret", Old: "(sensitive value)", New: "(sensitive value)"},
+	}, changes["test_account"].Diffs)
+	assert.Equal(t, workflows.PlanActionCreate, changes["sf_sandbox"].Action)
+	assert.Equal(t, workflows.PlanActionUnchanged, changes["sf_uat"].Action)
+	assert.Empty(t, changes["sf_uat"].Diffs)
+
+	var out bytes.Buffer
+	require.NoError(t, graph.GetPlan().Render(&out))
+	rendered := out.String()
+	assert.Contains(t, rendered, "~ account.test_account will be updated in-place")
+	assert.Contains(t, rendered, "+ account.sf_sandbox will be created")
+	assert.Contains(t, rendered, "account.sf_uat is up to date")
+	assert.Contains(t, rendered, "test.salesforce.com")
+	assert.NotContains(t, rendered, "sandbox-secret")
+	assert.Equal(t, 3, mockService.GetSalesforceAccountCalls)
+	assert.Equal(t, 0, mockService.CheckSalesforceAccountExistsCalls)
+}
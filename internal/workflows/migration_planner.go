@@ -0,0 +1,339 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+// NewMigrationPlanProcessor creates a node processor that only reads Splunk state
+// and records the changes an apply run would make into plan
+func NewMigrationPlanProcessor(config *utils.Config, splunkService services.SplunkServiceInterface, dashboardService services.DashboardServiceInterface, plan *Plan) *MigrationNodeProcessor {
+	processor := NewMigrationNodeProcessor(config, splunkService, dashboardService)
+	processor.plan = plan
+	return processor
+}
+
+// planNode executes the read-only variant of a migration node
+func (p *MigrationNodeProcessor) planNode(ctx context.Context, nodeID string) error {
+	switch nodeID {
+	case "lint_dashboards":
+		return p.lintDashboardsNode(ctx)
+	case "authenticate":
+		// Token creation is a POST; plan mode relies on basic auth for its reads instead
+		p.logger.Info("🔐 Plan: skipping token creation, using read-only requests")
+		return nil
+	case "check_salesforce_addon":
+		return p.checkSalesforceAddonNode(ctx)
+	case "create_index":
+		return p.planIndexNode(ctx)
+	case "configure_addon_settings":
+		return p.planAddonSettingsNode(ctx)
+	case "create_account":
+		return p.planAccountNode(ctx)
+	case "load_data_inputs":
+		return p.loadDataInputsNode(ctx)
+	case "create_data_inputs":
+		return p.planDataInputsNode(ctx)
+	case "create_event_log_inputs":
+		return p.planEventLogInputsNode(ctx)
+	case "verify_inputs":
+		return p.planUnmanagedInputsNode(ctx)
+	case "prune_inputs":
+		// Prune candidates are reported by planUnmanagedInputsNode
+		return nil
+	case "create_dashboards":
+		p.logger.Info("📊 Plan: dashboards are not diffed, skipping...")
+		return nil
+	default:
+		p.logger.Error("Unknown migration node", utils.String("node_id", nodeID))
+		return fmt.Errorf("unknown migration node: %s", nodeID)
+	}
+}
+
+// planIndexNode plans SPLUNK_INDEX_NAME and the index of every input
+func (p *MigrationNodeProcessor) planIndexNode(ctx context.Context) error {
+	indexes, err := p.config.GetIndexes()
+	if err != nil {
+		return fmt.Errorf("failed to plan indexes: %w", err)
+	}
+
+	for _, index := range indexes {
+		exists, err := p.splunkService.CheckIndexExists(ctx, index.Name)
+		if err != nil {
+			return fmt.Errorf("failed to plan index %s: %w", index.Name, err)
+		}
+
+		change := ResourceChange{Type: ResourceIndex, Name: index.Name, Action: PlanActionUnchanged}
+		if !exists {
+			change.Action = PlanActionCreate
+			change.Diffs = indexFields(index)
+		}
+		p.plan.Add(change)
+	}
+	return nil
+}
+
+// indexFields lists the settings an index is created with
+func indexFields(index utils.IndexSpec) []FieldDiff {
+	diffs := []FieldDiff{{Field: "datatype", New: index.Datatype}}
+	if index.SearchableDays > 0 {
+		diffs = append(diffs, FieldDiff{Field: "searchableDays", New: strconv.Itoa(index.SearchableDays)})
+	}
+	if index.MaxDataSizeMB > 0 {
+		diffs = append(diffs, FieldDiff{Field: "maxDataSizeMB", New: strconv.Itoa(index.MaxDataSizeMB)})
+	}
+	return diffs
+}
+
+// planAccountNode plans every configured Salesforce account
+func (p *MigrationNodeProcessor) planAccountNode(ctx context.Context) error {
+	accounts, err := p.config.GetSalesforceAccounts()
+	if err != nil {
+		return fmt.Errorf("failed to plan Salesforce accounts: %w", err)
+	}
+
+	for _, account := range accounts {
+		live, err := p.splunkService.GetSalesforceAccount(ctx, account.Name)
+		if err != nil {
+			return fmt.Errorf("failed to plan Salesforce account %s: %w", account.Name, err)
+		}
+		p.plan.Add(accountChange(account, live))
+	}
+	return nil
+}
+
+// accountChange diffs a configured Salesforce account against its live settings, or
+// plans its creation when live is nil. The client secret cannot be read back, so it is
+// only listed, as a sensitive value, when the account is created or updated.
+func accountChange(account utils.SalesforceAccount, live *models.SalesforceAccountSettings) ResourceChange {
+	change := ResourceChange{Type: ResourceAccount, Name: account.Name}
+	if live == nil {
+		change.Action = PlanActionCreate
+		change.Diffs = []FieldDiff{
+			{Field: "endpoint", New: account.Endpoint},
+			{Field: "sfdc_api_version", New: account.APIVersion},
+			{Field: "auth_type", New: account.AuthType},
+			{Field: "client_id", New: account.ClientID},
+			{Field: "client_secret", New: planSensitiveValue},
+		}
+		return change
+	}
+
+	compare := func(field, liveValue, desiredValue string) {
+		if liveValue != desiredValue {
+			change.Diffs = append(change.Diffs, FieldDiff{Field: field, Old: liveValue, New: desiredValue})
+		}
+	}
+	compare("endpoint", live.Endpoint, account.Endpoint)
+	compare("sfdc_api_version", live.APIVersion, account.APIVersion)
+	compare("auth_type", live.AuthType, account.AuthType)
+	compare("client_id", live.ClientID, account.ClientID)
+
+	if len(change.Diffs) == 0 {
+		change.Action = PlanActionUnchanged
+		return change
+	}
+	change.Action = PlanActionUpdate
+	change.Diffs = append(change.Diffs, FieldDiff{Field: "client_secret", Old: planSensitiveValue, New: planSensitiveValue})
+	return change
+}
+
+// planDataInputsNode plans every configured data input
+func (p *MigrationNodeProcessor) planDataInputsNode(ctx context.Context) error {
+	p.logger.Info("🔄 Plan: checking data inputs", utils.Int("count", len(p.dataInputs)))
+
+	for _, input := range p.dataInputs {
+		exists, err := p.splunkService.CheckDataInputExists(ctx, input.Name)
+		if err != nil {
+			return fmt.Errorf("failed to plan data input %s: %w", input.Name, err)
+		}
+
+		change := ResourceChange{
+			Type:   ResourceDataInput,
+			Name:   input.Name,
+			Action: PlanActionCreate,
+			Diffs:  p.dataInputFields(input),
+		}
+		if exists {
+			change, err = p.planExistingDataInput(ctx, input, change)
+			if err != nil {
+				return err
+			}
+		}
+		p.plan.Add(change)
+	}
+
+	return nil
+}
+
+// planExistingDataInput diffs an existing data input against its configuration.
+// When the live input cannot be read back, every field is shown as an update.
+func (p *MigrationNodeProcessor) planExistingDataInput(ctx context.Context, input utils.DataInput, change ResourceChange) (ResourceChange, error) {
+	live, err := p.splunkService.GetDataInput(ctx, input.Name)
+	if err != nil {
+		return change, fmt.Errorf("failed to read data input %s: %w", input.Name, err)
+	}
+
+	change.Action = PlanActionUpdate
+	if live == nil {
+		for i := range change.Diffs {
+			change.Diffs[i].Old = planUnreadValue
+		}
+		return change, nil
+	}
+
+	drift := services.DiffDataInput(live, &input, p.inputAccount(input.Account), p.config.Splunk.DefaultIndex)
+	if len(drift) == 0 {
+		change.Action = PlanActionUnchanged
+		change.Diffs = nil
+		return change, nil
+	}
+
+	change.Diffs = make([]FieldDiff, 0, len(drift))
+	for _, field := range drift {
+		change.Diffs = append(change.Diffs, FieldDiff{Field: field.Field, Old: field.Live, New: field.Desired})
+	}
+	return change, nil
+}
+
+// planEventLogInputsNode plans every configured event log input
+func (p *MigrationNodeProcessor) planEventLogInputsNode(ctx context.Context) error {
+	for _, input := range p.eventLogInputs {
+		exists, err := p.splunkService.CheckEventLogInputExists(ctx, input.Name)
+		if err != nil {
+			return fmt.Errorf("failed to plan event log input %s: %w", input.Name, err)
+		}
+
+		change := ResourceChange{
+			Type:   ResourceEventLog,
+			Name:   input.Name,
+			Action: PlanActionCreate,
+			Diffs:  p.eventLogInputFields(input),
+		}
+		if exists {
+			change, err = p.planExistingEventLogInput(ctx, input, change)
+			if err != nil {
+				return err
+			}
+		}
+		p.plan.Add(change)
+	}
+
+	return nil
+}
+
+// planExistingEventLogInput diffs an existing event log input against its configuration.
+// When the live input cannot be read back, every field is shown as an update.
+func (p *MigrationNodeProcessor) planExistingEventLogInput(ctx context.Context, input utils.EventLogInput, change ResourceChange) (ResourceChange, error) {
+	live, err := p.splunkService.GetEventLogInput(ctx, input.Name)
+	if err != nil {
+		return change, fmt.Errorf("failed to read event log input %s: %w", input.Name, err)
+	}
+
+	change.Action = PlanActionUpdate
+	if live == nil {
+		for i := range change.Diffs {
+			change.Diffs[i].Old = planUnreadValue
+		}
+		return change, nil
+	}
+
+	drift := services.DiffEventLogInput(live, &input, p.inputAccount(input.Account), p.config.Splunk.DefaultIndex)
+	if len(drift) == 0 {
+		change.Action = PlanActionUnchanged
+		change.Diffs = nil
+		return change, nil
+	}
+
+	change.Diffs = make([]FieldDiff, 0, len(drift))
+	for _, field := range drift {
+		change.Diffs = append(change.Diffs, FieldDiff{Field: field.Field, Old: field.Live, New: field.Desired})
+	}
+	return change, nil
+}
+
+// planUnmanagedInputsNode reports inputs in Splunk that are not in DATA_INPUTS
+func (p *MigrationNodeProcessor) planUnmanagedInputsNode(ctx context.Context) error {
+	existingInputs, err := p.splunkService.ListDataInputs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list data inputs for plan: %w", err)
+	}
+
+	configured := make(map[string]bool)
+	for _, input := range p.dataInputs {
+		configured[input.Name] = true
+	}
+
+	pruneAction := PlanActionUnmanaged
+	pruned := make(map[string]bool)
+	if p.config.Migration.PruneMode != "" {
+		pruneAction = PlanActionDisable
+		if p.config.Migration.PruneMode == utils.PruneModeDelete {
+			pruneAction = PlanActionDelete
+		}
+
+		candidates, err := p.findPruneCandidates(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to plan prune: %w", err)
+		}
+		for _, name := range candidates {
+			pruned[name] = true
+		}
+	}
+
+	for _, name := range existingInputs {
+		if configured[name] {
+			continue
+		}
+		action := PlanActionUnmanaged
+		if pruned[name] {
+			action = pruneAction
+		}
+		p.plan.Add(ResourceChange{Type: ResourceDataInput, Name: name, Action: action})
+	}
+	return nil
+}
+
+// dataInputFields returns the fields sent to Splunk for a data input
+func (p *MigrationNodeProcessor) dataInputFields(input utils.DataInput) []FieldDiff {
+	index := input.Index
+	if index == "" {
+		index = p.config.Splunk.DefaultIndex
+	}
+
+	fields := []FieldDiff{
+		{Field: "account", New: p.inputAccount(input.Account)},
+		{Field: "object", New: input.Object},
+		{Field: "object_fields", New: input.ObjectFields},
+		{Field: "order_by", New: input.OrderBy},
+		{Field: "start_date", New: input.StartDate},
+		{Field: "interval", New: fmt.Sprintf("%d", input.Interval)},
+		{Field: "delay", New: fmt.Sprintf("%d", input.Delay)},
+		{Field: "index", New: index},
+	}
+	if input.Disabled != nil {
+		fields = append(fields, FieldDiff{Field: "disabled", New: fmt.Sprintf("%t", *input.Disabled)})
+	}
+	return fields
+}
+
+// eventLogInputFields returns the fields sent to Splunk for an event log input
+func (p *MigrationNodeProcessor) eventLogInputFields(input utils.EventLogInput) []FieldDiff {
+	index := input.Index
+	if index == "" {
+		index = p.config.Splunk.DefaultIndex
+	}
+
+	return []FieldDiff{
+		{Field: "account", New: p.inputAccount(input.Account)},
+		{Field: "monitoring_interval", New: input.MonitoringInterval},
+		{Field: "start_date", New: input.StartDate},
+		{Field: "interval", New: fmt.Sprintf("%d", input.Interval)},
+		{Field: "index", New: index},
+	}
+}
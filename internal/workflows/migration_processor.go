@@ -2,6 +2,7 @@ package workflows
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -17,9 +18,19 @@ type MigrationNodeProcessor struct {
 	splunkService    services.SplunkServiceInterface
 	dashboardService services.DashboardServiceInterface
 	dataInputs       []utils.DataInput
+	eventLogInputs   []utils.EventLogInput
 	successCount     int
 	failedCount      int
 	failedInputs     []string
+	failedEventLogs  []string
+	unchangedInputs  []string
+	plan             *Plan
+	nodeReports      []NodeReport
+	inputReports     []InputReport
+	dashboardReport  *DashboardReport
+	run              *RunState
+	runStore         *RunStore
+	runMu            sync.Mutex
 	mu               sync.RWMutex
 	logger           utils.Logger
 }
@@ -30,6 +41,8 @@ func NewMigrationNodeProcessor(config *utils.Config, splunkService services.Splu
 		splunkService:    splunkService,
 		dashboardService: dashboardService,
 		failedInputs:     make([]string, 0),
+		failedEventLogs:  make([]string, 0),
+		unchangedInputs:  make([]string, 0),
 		logger:           utils.GetLogger(),
 	}
 }
@@ -43,33 +56,68 @@ func (p *MigrationNodeProcessor) Process(ctx context.Context, node *flowgraph.No
 		output[k] = v
 	}
 
+	// Plan mode only reads Splunk state and records the intended changes
+	if p.plan != nil {
+		if err := p.planNode(ctx, node.ID); err != nil {
+			return nil, err
+		}
+		output["last_completed_step"] = node.ID
+		output["timestamp"] = time.Now().Format(time.RFC3339)
+		return output, nil
+	}
+
+	startTime := time.Now()
+
+	// Nodes completed by an earlier attempt of a resumed run are not repeated
+	if p.run != nil && p.run.nodeCompleted(node.ID) && !resumeRerunNodes[node.ID] {
+		p.logger.Info("⏭️  Skipping node completed in a previous attempt",
+			utils.String("node_id", node.ID),
+			utils.String("run_id", p.run.RunID))
+		p.recordNodeReport(node, NodeSkipped, startTime, nil)
+		output["last_completed_step"] = node.ID
+		output["timestamp"] = time.Now().Format(time.RFC3339)
+		return output, nil
+	}
+
 	// Execute the appropriate migration node based on node ID
 	var err error
 	switch node.ID {
+	case "lint_dashboards":
+		err = p.lintDashboardsNode(ctx)
 	case "authenticate":
 		err = p.authenticateNode(ctx)
 	case "check_salesforce_addon":
 		err = p.checkSalesforceAddonNode(ctx)
 	case "create_index":
 		err = p.createIndexNode(ctx)
+	case "configure_addon_settings":
+		err = p.configureAddonSettingsNode(ctx)
 	case "create_account":
 		err = p.createAccountNode(ctx)
 	case "load_data_inputs":
 		err = p.loadDataInputsNode(ctx)
 	case "create_data_inputs":
 		err = p.createDataInputsNode(ctx)
+	case "create_event_log_inputs":
+		err = p.createEventLogInputsNode(ctx)
 	case "verify_inputs":
 		err = p.verifyInputsNode(ctx)
+	case "prune_inputs":
+		err = p.pruneInputsNode(ctx)
 	case "create_dashboards":
 		err = p.createDashboardsNode(ctx)
 	default:
 		p.logger.Error("Unknown migration node", utils.String("node_id", node.ID))
-		return nil, fmt.Errorf("unknown migration node: %s", node.ID)
+		err = fmt.Errorf("unknown migration node: %s", node.ID)
 	}
 
 	if err != nil {
+		p.recordNodeReport(node, NodeFailed, startTime, err)
+		p.saveRun(ctx)
 		return nil, err
 	}
+	p.recordNodeReport(node, NodeSucceeded, startTime, nil)
+	p.recordNode(ctx, node.ID)
 
 	// Add step completion marker
 	output["last_completed_step"] = node.ID
@@ -110,72 +158,135 @@ func (p *MigrationNodeProcessor) checkSalesforceAddonNode(ctx context.Context) e
 	return nil
 }
 
-// createIndexNode handles index creation
+// createIndexNode creates SPLUNK_INDEX_NAME and the index of every input when they do
+// not exist, through ACS on Splunk Cloud and /data/indexes on Splunk Enterprise
 func (p *MigrationNodeProcessor) createIndexNode(ctx context.Context) error {
-	p.logger.Info("📊 Node 3: Verifying Splunk index...")
+	indexes, err := p.config.GetIndexes()
+	if err != nil {
+		p.logger.Error("Failed to load indexes", utils.Err(err))
+		return err
+	}
 
-	// BYPASSED: Index creation skipped for Splunk Cloud
-	// Splunk Cloud requires indexes to be created manually via UI or support ticket
-	// The REST API cannot create indexes on indexer clusters
+	p.logger.Info("📊 Node 3: Provisioning Splunk indexes...",
+		utils.Int("count", len(indexes)),
+		utils.String("platform", p.config.Splunk.EffectivePlatform()))
 
-	// Check if index exists
-	exists, err := p.splunkService.CheckIndexExists(ctx, p.config.Splunk.IndexName)
-	if err != nil {
-		p.logger.Warn("Could not verify index exists",
-			utils.String("index_name", p.config.Splunk.IndexName),
-			utils.Err(err))
-		// Continue anyway - index might exist but not be visible via this endpoint
+	var errs []error
+	for _, index := range indexes {
+		if err := p.provisionIndex(ctx, index.Name); err != nil {
+			p.logger.Error("Failed to provision index", utils.String("index_name", index.Name), utils.Err(err))
+			errs = append(errs, fmt.Errorf("index %s: %w", index.Name, err))
+		}
 	}
 
-	if exists {
-		p.logger.Info("✅ Index verified successfully", utils.String("index_name", p.config.Splunk.IndexName))
-	} else {
-		p.logger.Warn("⚠️  Index not found - must be created manually via Splunk Web UI",
-			utils.String("index_name", p.config.Splunk.IndexName),
-			utils.String("action", "Create index at Settings → Indexes → New Index"))
-		// Don't fail - allow workflow to continue
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d indexes failed to provision: %w", len(errs), len(indexes), errors.Join(errs...))
 	}
+	return nil
+}
 
+// provisionIndex creates an index unless it already exists
+func (p *MigrationNodeProcessor) provisionIndex(ctx context.Context, indexName string) error {
+	exists, err := p.splunkService.CheckIndexExists(ctx, indexName)
+	switch {
+	case err != nil && isAuthError(err):
+		return err
+	case err != nil:
+		// The index may exist without being visible; creating it again is not an error
+		p.logger.Warn("Could not verify index exists, creating it",
+			utils.String("index_name", indexName),
+			utils.Err(err))
+	case exists:
+		p.logger.Info("✅ Index verified successfully", utils.String("index_name", indexName))
+		return nil
+	}
+
+	if err := p.splunkService.CreateIndex(ctx, indexName); err != nil {
+		return err
+	}
+	p.logger.Info("✅ Index created", utils.String("index_name", indexName))
 	return nil
 }
 
-// createAccountNode handles Salesforce account creation
+// createAccountNode creates or updates every configured Salesforce account in parallel
 func (p *MigrationNodeProcessor) createAccountNode(ctx context.Context) error {
-	p.logger.Info("🔗 Node 4: Creating Salesforce account in Splunk...")
+	accounts, err := p.config.GetSalesforceAccounts()
+	if err != nil {
+		p.logger.Error("Failed to load Salesforce accounts", utils.Err(err))
+		return err
+	}
+
+	maxParallelism := p.config.Migration.ConcurrentRequests
+	p.logger.Info("🔗 Node 5: Creating Salesforce accounts in Splunk...",
+		utils.Int("count", len(accounts)),
+		utils.Int("max_workers", maxParallelism))
+
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	semaphore := make(chan struct{}, maxParallelism)
+	var errs []error
+
+	for _, account := range accounts {
+		wg.Add(1)
+		go func(acct utils.SalesforceAccount) {
+			defer wg.Done()
+
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			if err := p.applySalesforceAccount(ctx, &acct); err != nil {
+				p.logger.Error("Failed to provision Salesforce account",
+					utils.String("account", acct.Name),
+					utils.Err(err))
+
+				mu.Lock()
+				errs = append(errs, fmt.Errorf("account %s: %w", acct.Name, err))
+				mu.Unlock()
+			}
+		}(account)
+	}
+
+	wg.Wait()
+
+	if len(errs) > 0 {
+		return fmt.Errorf("%d of %d Salesforce accounts failed to provision: %w", len(errs), len(accounts), errors.Join(errs...))
+	}
+
+	p.logger.Info("✅ Salesforce accounts provisioned successfully", utils.Int("count", len(accounts)))
+	return nil
+}
 
-	// Check if account already exists
-	exists, err := p.splunkService.CheckSalesforceAccountExists(ctx)
+// applySalesforceAccount updates a Salesforce account when it exists and creates it otherwise
+func (p *MigrationNodeProcessor) applySalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error {
+	exists, err := p.splunkService.CheckSalesforceAccountExists(ctx, account.Name)
+	if isAuthError(err) {
+		return err
+	}
 	if err != nil {
 		// Only log warning for actual errors (404 is handled gracefully by CheckSalesforceAccountExists)
 		p.logger.Warn("Could not check if Salesforce account exists, will attempt to create",
-			utils.String("account", p.config.Salesforce.AccountName),
+			utils.String("account", account.Name),
 			utils.Err(err))
 		exists = false
 	}
 
 	if exists {
-		// Account exists, update it
-		p.logger.Info("Salesforce account exists, updating...",
-			utils.String("account", p.config.Salesforce.AccountName))
-
-		if err := p.splunkService.UpdateSalesforceAccount(ctx); err != nil {
-			p.logger.Error("Failed to update Salesforce account", utils.Err(err))
+		p.logger.Info("Salesforce account exists, updating...", utils.String("account", account.Name))
+		if err := p.splunkService.UpdateSalesforceAccount(ctx, account); err != nil {
 			return err
 		}
-		p.logger.Info("✅ Salesforce account updated successfully")
-	} else {
-		// Account doesn't exist, create it
-		if err := p.splunkService.CreateSalesforceAccount(ctx); err != nil {
-			p.logger.Error("Failed to create Salesforce account", utils.Err(err))
-			return err
-		}
-		p.logger.Info("✅ Salesforce account created successfully")
+		p.logger.Info("Salesforce account updated successfully", utils.String("account", account.Name))
+		return nil
 	}
 
+	if err := p.splunkService.CreateSalesforceAccount(ctx, account); err != nil {
+		return err
+	}
+	p.logger.Info("Salesforce account created successfully", utils.String("account", account.Name))
 	return nil
 }
 
-// loadDataInputsNode loads data inputs from configuration
+// loadDataInputsNode loads data inputs and event log inputs from configuration
 func (p *MigrationNodeProcessor) loadDataInputsNode(ctx context.Context) error {
 	dataInputs, err := p.config.GetDataInputs()
 	if err != nil {
@@ -183,10 +294,18 @@ func (p *MigrationNodeProcessor) loadDataInputsNode(ctx context.Context) error {
 		return err
 	}
 
+	eventLogInputs, err := p.config.GetEventLogInputs()
+	if err != nil {
+		p.logger.Error("Failed to load event log inputs", utils.Err(err))
+		return err
+	}
+
 	p.dataInputs = dataInputs
+	p.eventLogInputs = eventLogInputs
 
-	p.logger.Info("📥 Node 5: Loaded data inputs for creation",
-		utils.Int("count", len(dataInputs)))
+	p.logger.Info("📥 Node 6: Loaded data inputs for creation",
+		utils.Int("count", len(dataInputs)),
+		utils.Int("event_log_count", len(eventLogInputs)))
 	return nil
 }
 
@@ -198,10 +317,16 @@ func (p *MigrationNodeProcessor) createDataInputsNode(ctx context.Context) error
 	}
 
 	maxParallelism := p.config.Migration.ConcurrentRequests
-	p.logger.Info("🔄 Node 6: Creating data inputs in parallel",
+	p.logger.Info("🔄 Node 7: Creating data inputs in parallel",
 		utils.Int("count", len(p.dataInputs)),
 		utils.Int("max_workers", maxParallelism))
 
+	if p.run != nil && len(p.run.FailedInputs) > 0 {
+		p.logger.Info("🔁 Retrying data inputs that failed in a previous attempt",
+			utils.String("run_id", p.run.RunID),
+			utils.Int("count", len(p.run.FailedInputs)))
+	}
+
 	var wg sync.WaitGroup
 	semaphore := make(chan struct{}, maxParallelism)
 	startTime := time.Now()
@@ -214,8 +339,27 @@ func (p *MigrationNodeProcessor) createDataInputsNode(ctx context.Context) error
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
+			if p.inputCompleted(inp.Name) {
+				p.logger.Info("Data input completed in a previous attempt, skipping",
+					utils.String("name", inp.Name),
+					utils.String("run_id", p.run.RunID))
+				p.incrementSuccess()
+				p.recordInputReport(InputTypeObject, inp.Name, ActionSkipped, "completed in a previous attempt", nil)
+				return
+			}
+
 			// Check if data input already exists
 			exists, err := p.splunkService.CheckDataInputExists(ctx, inp.Name)
+			if isAuthError(err) {
+				// Creating the input blindly would hide a missing permission
+				p.logger.Error("Failed to check data input",
+					utils.String("name", inp.Name),
+					utils.String("object", inp.Object),
+					utils.Err(err))
+				p.incrementFailed(inp.Name)
+				p.recordInputReport(InputTypeObject, inp.Name, ActionFailed, "", err)
+				return
+			}
 			if err != nil {
 				// Only log warning for actual errors (404 is handled gracefully by CheckDataInputExists)
 				p.logger.Warn("Could not check if data input exists, will attempt to create",
@@ -224,7 +368,15 @@ func (p *MigrationNodeProcessor) createDataInputsNode(ctx context.Context) error
 				exists = false
 			}
 
-			if exists {
+			if exists && !p.dataInputDrifted(ctx, &inp) {
+				// Data input already matches the configuration, skip the no-op update
+				p.logger.Info("Data input unchanged, skipping update",
+					utils.String("name", inp.Name),
+					utils.String("object", inp.Object))
+				p.incrementUnchanged(inp.Name)
+				p.recordInputReport(InputTypeObject, inp.Name, ActionSkipped, "unchanged", nil)
+				p.recordInput(ctx, inp.Name)
+			} else if exists {
 				// Data input exists, update it
 				p.logger.Info("Data input exists, updating...",
 					utils.String("name", inp.Name),
@@ -236,11 +388,14 @@ func (p *MigrationNodeProcessor) createDataInputsNode(ctx context.Context) error
 						utils.String("object", inp.Object),
 						utils.Err(err))
 					p.incrementFailed(inp.Name)
+					p.recordInputReport(InputTypeObject, inp.Name, ActionFailed, "", err)
 				} else {
 					p.logger.Info("Data input updated successfully",
 						utils.String("name", inp.Name),
 						utils.String("object", inp.Object))
 					p.incrementSuccess()
+					p.recordInputReport(InputTypeObject, inp.Name, ActionUpdated, "", nil)
+					p.recordInput(ctx, inp.Name)
 				}
 			} else {
 				// Data input doesn't exist, create it
@@ -250,11 +405,14 @@ func (p *MigrationNodeProcessor) createDataInputsNode(ctx context.Context) error
 						utils.String("object", inp.Object),
 						utils.Err(err))
 					p.incrementFailed(inp.Name)
+					p.recordInputReport(InputTypeObject, inp.Name, ActionFailed, "", err)
 				} else {
 					p.logger.Info("Data input created successfully",
 						utils.String("name", inp.Name),
 						utils.String("object", inp.Object))
 					p.incrementSuccess()
+					p.recordInputReport(InputTypeObject, inp.Name, ActionCreated, "", nil)
+					p.recordInput(ctx, inp.Name)
 				}
 			}
 		}(i, input)
@@ -275,14 +433,49 @@ func (p *MigrationNodeProcessor) createDataInputsNode(ctx context.Context) error
 
 	p.logger.Info("✅ All data inputs created successfully",
 		utils.Int("count", success),
+		utils.Int("unchanged", len(p.GetUnchangedInputs())),
 		utils.Duration("duration", duration))
 
 	return nil
 }
 
+// dataInputDrifted reports whether an existing data input differs from its configuration,
+// logging each drifted field. If the live input cannot be read it is treated as drifted
+// so that the update is still applied.
+func (p *MigrationNodeProcessor) dataInputDrifted(ctx context.Context, input *utils.DataInput) bool {
+	live, err := p.splunkService.GetDataInput(ctx, input.Name)
+	if err != nil {
+		p.logger.Warn("Could not read data input for drift detection, will update",
+			utils.String("name", input.Name),
+			utils.Err(err))
+		return true
+	}
+	if live == nil {
+		return true
+	}
+
+	drift := services.DiffDataInput(live, input, p.inputAccount(input.Account), p.config.Splunk.DefaultIndex)
+	for _, field := range drift {
+		p.logger.Info("Data input drift detected",
+			utils.String("name", input.Name),
+			utils.String("field", field.Field),
+			utils.String("live", field.Live),
+			utils.String("desired", field.Desired))
+	}
+	return len(drift) > 0
+}
+
+// inputAccount returns the Salesforce account an input reads from
+func (p *MigrationNodeProcessor) inputAccount(account string) string {
+	if account == "" {
+		return p.config.DefaultAccountName()
+	}
+	return account
+}
+
 // verifyInputsNode verifies created data inputs
 func (p *MigrationNodeProcessor) verifyInputsNode(ctx context.Context) error {
-	p.logger.Info("🔍 Node 7: Verifying created data inputs...")
+	p.logger.Info("🔍 Node 9: Verifying created data inputs...")
 
 	existingInputs, err := p.splunkService.ListDataInputs(ctx)
 	if err != nil {
@@ -315,15 +508,17 @@ func (p *MigrationNodeProcessor) verifyInputsNode(ctx context.Context) error {
 	return nil
 }
 
-// createDashboardsNode creates dashboards from XML files
+// createDashboardsNode deploys the dashboard directory: new dashboards are created,
+// changed ones updated and, when configured, removed ones deleted
 func (p *MigrationNodeProcessor) createDashboardsNode(ctx context.Context) error {
 	dashboardDir := p.config.Migration.DashboardDirectory
 
-	p.logger.Info("📊 Node 8: Creating Splunk dashboards...",
+	p.logger.Info("📊 Node 11: Deploying Splunk dashboards...",
 		utils.String("directory", dashboardDir))
 
 	if dashboardDir == "" {
 		p.logger.Warn("⚠️  Dashboard directory not configured. Skipping dashboard creation...")
+		p.recordDashboardReport(dashboardDir, ActionSkipped, "dashboard directory not configured", nil, nil)
 		return nil
 	}
 
@@ -331,20 +526,33 @@ func (p *MigrationNodeProcessor) createDashboardsNode(ctx context.Context) error
 	if exists, err := utils.FileExists(dashboardDir); err != nil || !exists {
 		p.logger.Warn("⚠️  Dashboard directory not found. Skipping dashboard creation...",
 			utils.String("directory", dashboardDir))
+		p.recordDashboardReport(dashboardDir, ActionSkipped, "dashboard directory not found", nil, nil)
 		return nil
 	}
 
-	if err := p.dashboardService.CreateDashboardsFromDirectory(ctx, dashboardDir); err != nil {
-		p.logger.Error("Failed to create dashboards",
+	result, err := p.dashboardService.ReconcileDashboards(ctx, dashboardDir)
+	if err != nil {
+		p.logger.Error("Failed to deploy dashboards",
 			utils.String("directory", dashboardDir),
 			utils.Err(err))
+		p.recordDashboardReport(dashboardDir, ActionFailed, "", result, err)
 		return err
 	}
 
-	p.logger.Info("✅ Dashboards created successfully")
+	action, reason := dashboardDirectoryAction(result)
+	p.recordDashboardReport(dashboardDir, action, reason, result, nil)
+	p.logger.Info("✅ Dashboards deployed successfully",
+		utils.Int("count", len(result.Outcomes)),
+		utils.String("action", action))
 	return nil
 }
 
+// isAuthError reports whether Splunk rejected the credential or its permissions.
+// Such a failure is reported instead of being treated as a missing object.
+func isAuthError(err error) bool {
+	return errors.Is(err, utils.ErrUnauthorized) || errors.Is(err, utils.ErrForbidden)
+}
+
 // Helper methods for thread-safe counter management
 func (p *MigrationNodeProcessor) incrementSuccess() {
 	p.mu.Lock()
@@ -359,6 +567,23 @@ func (p *MigrationNodeProcessor) incrementFailed(inputName string) {
 	p.failedInputs = append(p.failedInputs, inputName)
 }
 
+// incrementFailedEventLog records an sfdc_event_log input that failed. It is kept
+// apart from the failed data inputs, whose names it may share.
+func (p *MigrationNodeProcessor) incrementFailedEventLog(inputName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failedCount++
+	p.failedEventLogs = append(p.failedEventLogs, inputName)
+}
+
+// incrementUnchanged records an input that needed no update; it counts as a success
+func (p *MigrationNodeProcessor) incrementUnchanged(inputName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.successCount++
+	p.unchangedInputs = append(p.unchangedInputs, inputName)
+}
+
 func (p *MigrationNodeProcessor) getCounters() (success, failed int) {
 	p.mu.RLock()
 	defer p.mu.RUnlock()
@@ -369,3 +594,13 @@ func (p *MigrationNodeProcessor) getCounters() (success, failed int) {
 func (p *MigrationNodeProcessor) GetCounters() (success, failed int) {
 	return p.getCounters()
 }
+
+// GetUnchangedInputs returns the names of inputs whose update was skipped because
+// they already matched the configuration
+func (p *MigrationNodeProcessor) GetUnchangedInputs() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, len(p.unchangedInputs))
+	copy(names, p.unchangedInputs)
+	return names
+}
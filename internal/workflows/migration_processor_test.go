@@ -10,11 +10,67 @@ import (
 
 	"salesforce-splunk-migration/internal/workflows"
 	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
 	"salesforce-splunk-migration/utils"
 
 	"github.com/flowgraph/flowgraph/pkg/flowgraph"
 )
 
+// newTestConfig returns a configuration for the test_account Salesforce account with
+// one data input, sf_accounts, writing to test_index. Options adjust it per test.
+func newTestConfig(options ...func(*utils.Config)) *utils.Config {
+	config := &utils.Config{
+		Splunk: utils.SplunkConfig{IndexName: "test_index", DefaultIndex: "test_index"},
+		Salesforce: utils.SalesforceConfig{
+			Endpoint:     "https://login.salesforce.com",
+			APIVersion:   "64.0",
+			AuthType:     "oauth_client_credentials",
+			ClientID:     "client-id",
+			ClientSecret: "super-secret",
+			AccountName:  "test_account",
+		},
+		Migration: utils.MigrationConfig{ConcurrentRequests: 1},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "sf_accounts", "object": "Account", "object_fields": "Id"},
+			},
+		},
+	}
+	for _, option := range options {
+		option(config)
+	}
+	return config
+}
+
+// withDataInputs replaces the DATA_INPUTS of a test configuration
+func withDataInputs(inputs ...map[string]interface{}) func(*utils.Config) {
+	return func(config *utils.Config) {
+		config.Extensions["DATA_INPUTS"] = toInterfaces(inputs)
+	}
+}
+
+// withEventLogInputs sets the EVENT_LOG_INPUTS of a test configuration
+func withEventLogInputs(inputs ...map[string]interface{}) func(*utils.Config) {
+	return func(config *utils.Config) {
+		config.Extensions["EVENT_LOG_INPUTS"] = toInterfaces(inputs)
+	}
+}
+
+// withDashboardDirectory sets the dashboard directory of a test configuration
+func withDashboardDirectory(dir string) func(*utils.Config) {
+	return func(config *utils.Config) {
+		config.Migration.DashboardDirectory = dir
+	}
+}
+
+func toInterfaces(inputs []map[string]interface{}) []interface{} {
+	values := make([]interface{}, len(inputs))
+	for i, input := range inputs {
+		values[i] = input
+	}
+	return values
+}
+
 func TestMigrationNodeProcessor_Process(t *testing.T) {
 	config := &utils.Config{
 		Splunk: utils.SplunkConfig{
@@ -113,101 +169,127 @@ func TestMigrationNodeProcessor_Process(t *testing.T) {
 		assert.Equal(t, 1, mockService.CheckSalesforceAddonCalls)
 	})
 
-	// COMMENTED OUT: Test expectations don't match implementation
-	// t.Run("Success_CreateIndex", func(t *testing.T) {
-	// 	mockService := &mocks.MockSplunkService{
-	// 		CreateIndexFunc: func(ctx context.Context, indexName string) error {
-	// 			return nil
-	// 		},
-	// 	}
-
-	// 	mockDashboardService := &mocks.MockDashboardService{}
-	// 	processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
-	// 	node := &flowgraph.Node{
-	// 		ID:   "create_index",
-	// 		Name: "Create Index",
-	// 		Type: flowgraph.NodeTypeFunction,
-	// 	}
-
-	// 	output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
-
-	// 	require.NoError(t, err)
-	// 	require.NotNil(t, output)
-	// 	assert.Equal(t, 1, mockService.CreateIndexCalls)
-	// 	assert.Equal(t, "create_index", output["last_completed_step"])
-	// })
-
-	// COMMENTED OUT: Test expectations don't match implementation
-	// t.Run("Error_CreateIndex", func(t *testing.T) {
-	// 	mockService := &mocks.MockSplunkService{
-	// 		CreateIndexFunc: func(ctx context.Context, indexName string) error {
-	// 			return fmt.Errorf("index creation failed")
-	// 		},
-	// 	}
-
-	// 	mockDashboardService := &mocks.MockDashboardService{}
-	// 	processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
-	// 	node := &flowgraph.Node{ID: "create_index"}
-
-	// 	output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
-
-	// 	require.Error(t, err)
-	// 	assert.Contains(t, err.Error(), "index creation failed")
-	// 	assert.Nil(t, output)
-	// 	assert.Equal(t, 1, mockService.CreateIndexCalls)
-	// })
-
-	// COMMENTED OUT: Test expectations don't match implementation
-	// t.Run("Success_IndexExists_Update", func(t *testing.T) {
-	// 	mockService := &mocks.MockSplunkService{
-	// 		CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
-	// 			return true, nil
-	// 		},
-	// 		UpdateIndexFunc: func(ctx context.Context, indexName string) error {
-	// 			return nil
-	// 		},
-	// 	}
-
-	// 	mockDashboardService := &mocks.MockDashboardService{}
-	// 	processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
-	// 	node := &flowgraph.Node{ID: "create_index", Type: flowgraph.NodeTypeFunction}
-
-	// 	output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
-
-	// 	require.NoError(t, err)
-	// 	require.NotNil(t, output)
-	// 	assert.Equal(t, 1, mockService.CheckIndexExistsCalls)
-	// 	assert.Equal(t, 1, mockService.UpdateIndexCalls)
-	// 	assert.Equal(t, 0, mockService.CreateIndexCalls)
-	// 	assert.Equal(t, "create_index", output["last_completed_step"])
-	// })
-
-	// COMMENTED OUT: Test expectations don't match implementation
-	// t.Run("Error_IndexUpdate", func(t *testing.T) {
-	// 	mockService := &mocks.MockSplunkService{
-	// 		CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
-	// 			return true, nil
-	// 		},
-	// 		UpdateIndexFunc: func(ctx context.Context, indexName string) error {
-	// 			return fmt.Errorf("update failed")
-	// 		},
-	// 	}
-
-	// 	mockDashboardService := &mocks.MockDashboardService{}
-	// 	processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
-	// 	node := &flowgraph.Node{ID: "create_index"}
-
-	// 	output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
-
-	// 	require.Error(t, err)
-	// 	assert.Contains(t, err.Error(), "update failed")
-	// 	assert.Nil(t, output)
-	// 	assert.Equal(t, 1, mockService.UpdateIndexCalls)
-	// })
+	t.Run("Success_CreateIndex", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CreateIndexFunc: func(ctx context.Context, indexName string) error {
+				return nil
+			},
+		}
+
+		mockDashboardService := &mocks.MockDashboardService{}
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
+		node := &flowgraph.Node{
+			ID:   "create_index",
+			Name: "Create Index",
+			Type: flowgraph.NodeTypeFunction,
+		}
+
+		output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
+
+		require.NoError(t, err)
+		require.NotNil(t, output)
+		assert.Equal(t, 1, mockService.CreateIndexCalls)
+		assert.Equal(t, "create_index", output["last_completed_step"])
+	})
+
+	t.Run("Error_CreateIndex", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CreateIndexFunc: func(ctx context.Context, indexName string) error {
+				return fmt.Errorf("index creation failed")
+			},
+		}
+
+		mockDashboardService := &mocks.MockDashboardService{}
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
+		node := &flowgraph.Node{ID: "create_index"}
+
+		output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "index creation failed")
+		assert.Nil(t, output)
+		assert.Equal(t, 1, mockService.CreateIndexCalls)
+	})
+
+	t.Run("Success_IndexExists_NotCreated", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return true, nil
+			},
+		}
+
+		mockDashboardService := &mocks.MockDashboardService{}
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
+		node := &flowgraph.Node{ID: "create_index", Type: flowgraph.NodeTypeFunction}
+
+		output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
+
+		require.NoError(t, err)
+		require.NotNil(t, output)
+		assert.Equal(t, 1, mockService.CheckIndexExistsCalls)
+		assert.Equal(t, 0, mockService.CreateIndexCalls)
+	})
+
+	t.Run("Success_CreatesInputIndexes", func(t *testing.T) {
+		inputConfig := *config
+		inputConfig.Extensions = map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "Account_Input", "object": "Account", "index": "test_index"},
+				map[string]interface{}{"name": "Case_Input", "object": "Case", "index": "sfdc_support"},
+			},
+			"EVENT_LOG_INPUTS": []interface{}{map[string]interface{}{"name": "EventLog_Daily", "index": "sfdc_events"}},
+		}
+		var created []string
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return indexName == "test_index", nil
+			},
+			CreateIndexFunc: func(ctx context.Context, indexName string) error {
+				created = append(created, indexName)
+				return nil
+			},
+		}
+
+		processor := workflows.NewMigrationNodeProcessor(&inputConfig, mockService, &mocks.MockDashboardService{})
+		_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "create_index"}, make(map[string]interface{}))
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, mockService.CheckIndexExistsCalls)
+		assert.Equal(t, []string{"sfdc_support", "sfdc_events"}, created)
+	})
+
+	t.Run("Error_IndexCheckForbidden", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return false, utils.NewHTTPError(403, []byte(`{"messages":[{"type":"ERROR","text":"forbidden"}]}`))
+			},
+		}
+
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, &mocks.MockDashboardService{})
+		_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "create_index"}, make(map[string]interface{}))
+
+		require.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrForbidden)
+		assert.Equal(t, 0, mockService.CreateIndexCalls)
+	})
+
+	t.Run("Success_IndexCheckFailureFallsBackToCreate", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckIndexExistsFunc: func(ctx context.Context, indexName string) (bool, error) {
+				return false, fmt.Errorf("connection reset")
+			},
+		}
+
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, &mocks.MockDashboardService{})
+		_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "create_index"}, make(map[string]interface{}))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, mockService.CreateIndexCalls)
+	})
 
 	t.Run("Success_CreateAccount", func(t *testing.T) {
 		mockService := &mocks.MockSplunkService{
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 		}
@@ -230,7 +312,7 @@ func TestMigrationNodeProcessor_Process(t *testing.T) {
 
 	t.Run("Error_CreateAccount", func(t *testing.T) {
 		mockService := &mocks.MockSplunkService{
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return fmt.Errorf("account creation failed")
 			},
 		}
@@ -247,12 +329,30 @@ func TestMigrationNodeProcessor_Process(t *testing.T) {
 		assert.Equal(t, 1, mockService.CreateSalesforceAccountCalls)
 	})
 
+	t.Run("Error_AccountCheckForbidden", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
+				return false, fmt.Errorf("failed to check Salesforce account existence: %w", utils.NewHTTPError(403, []byte("Forbidden")))
+			},
+		}
+
+		mockDashboardService := &mocks.MockDashboardService{}
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, mockDashboardService)
+		node := &flowgraph.Node{ID: "create_account"}
+
+		output, err := processor.Process(context.Background(), node, make(map[string]interface{}))
+
+		require.ErrorIs(t, err, utils.ErrForbidden)
+		assert.Nil(t, output)
+		assert.Equal(t, 0, mockService.CreateSalesforceAccountCalls)
+	})
+
 	t.Run("Success_AccountExists_Update", func(t *testing.T) {
 		mockService := &mocks.MockSplunkService{
-			CheckSalesforceAccountExistsFunc: func(ctx context.Context) (bool, error) {
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
 				return true, nil
 			},
-			UpdateSalesforceAccountFunc: func(ctx context.Context) error {
+			UpdateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 		}
@@ -273,10 +373,10 @@ func TestMigrationNodeProcessor_Process(t *testing.T) {
 
 	t.Run("Error_AccountUpdate", func(t *testing.T) {
 		mockService := &mocks.MockSplunkService{
-			CheckSalesforceAccountExistsFunc: func(ctx context.Context) (bool, error) {
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
 				return true, nil
 			},
-			UpdateSalesforceAccountFunc: func(ctx context.Context) error {
+			UpdateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return fmt.Errorf("update failed")
 			},
 		}
@@ -446,6 +546,40 @@ func TestMigrationNodeProcessor_Process(t *testing.T) {
 		assert.Equal(t, 1, mockService.CreateDataInputCalls)
 	})
 
+	t.Run("Error_DataInputCheckForbidden", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckDataInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+				return false, fmt.Errorf("failed to check data input existence: %w", utils.NewHTTPError(403, []byte("Forbidden")))
+			},
+		}
+		configWithInputs := &utils.Config{
+			Splunk:     utils.SplunkConfig{IndexName: "test_index"},
+			Salesforce: utils.SalesforceConfig{AccountName: "test_account"},
+			Migration:  utils.MigrationConfig{ConcurrentRequests: 5},
+			Extensions: map[string]interface{}{
+				"DATA_INPUTS": []interface{}{
+					map[string]interface{}{"name": "test_input", "object": "Account", "index": "test_index"},
+				},
+			},
+		}
+
+		mockDashboardService := &mocks.MockDashboardService{}
+		processor := workflows.NewMigrationNodeProcessor(configWithInputs, mockService, mockDashboardService)
+		loadNode := &flowgraph.Node{ID: "load_data_inputs"}
+		_, _ = processor.Process(context.Background(), loadNode, make(map[string]interface{}))
+
+		node := &flowgraph.Node{ID: "create_data_inputs"}
+
+		_, err := processor.Process(context.Background(), node, make(map[string]interface{}))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "data inputs failed")
+		assert.Equal(t, 0, mockService.CreateDataInputCalls)
+		success, failed := processor.GetCounters()
+		assert.Equal(t, 0, success)
+		assert.Equal(t, 1, failed)
+	})
+
 	t.Run("Success_DataInputExists_Update", func(t *testing.T) {
 		mockService := &mocks.MockSplunkService{
 			CheckDataInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
@@ -705,7 +839,7 @@ func TestMigrationNodeProcessor_CreateDashboards(t *testing.T) {
 
 		require.NoError(t, err)
 		require.NotNil(t, output)
-		assert.Equal(t, 0, mockDashboardService.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mockDashboardService.ReconcileDashboardsCalls)
 		assert.Equal(t, "create_dashboards", output["last_completed_step"])
 	})
 
@@ -728,7 +862,7 @@ func TestMigrationNodeProcessor_CreateDashboards(t *testing.T) {
 
 		require.NoError(t, err)
 		require.NotNil(t, output)
-		assert.Equal(t, 0, mockDashboardService.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mockDashboardService.ReconcileDashboardsCalls)
 		assert.Equal(t, "create_dashboards", output["last_completed_step"])
 	})
 }
@@ -854,3 +988,143 @@ func TestMigrationNodeProcessor_GetCounters(t *testing.T) {
 		assert.Equal(t, 1, failed)
 	})
 }
+
+func TestMigrationNodeProcessor_DataInputDrift(t *testing.T) {
+	config := &utils.Config{
+		Splunk:     utils.SplunkConfig{IndexName: "test_index", DefaultIndex: "test_index"},
+		Salesforce: utils.SalesforceConfig{AccountName: "test_account"},
+		Migration:  utils.MigrationConfig{ConcurrentRequests: 2},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "test_input", "object": "Account", "object_fields": "Id,Name", "index": "test_index"},
+			},
+		},
+	}
+	liveInput := func() *models.SFDCObjectInput {
+		return &models.SFDCObjectInput{
+			Name:         "test_input",
+			Account:      "test_account",
+			Object:       "Account",
+			ObjectFields: "Name,Id",
+			OrderBy:      "LastModifiedDate",
+			StartDate:    "2024-01-01T00:00:00.000Z",
+			Interval:     300,
+			Delay:        60,
+			Index:        "test_index",
+		}
+	}
+	runCreateInputs := func(t *testing.T, mockService *mocks.MockSplunkService) *workflows.MigrationNodeProcessor {
+		processor := workflows.NewMigrationNodeProcessor(config, mockService, &mocks.MockDashboardService{})
+		_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "load_data_inputs"}, make(map[string]interface{}))
+		require.NoError(t, err)
+		_, err = processor.Process(context.Background(), &flowgraph.Node{ID: "create_data_inputs"}, make(map[string]interface{}))
+		require.NoError(t, err)
+		return processor
+	}
+
+	t.Run("Success_UnchangedInputSkipsUpdate", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckDataInputExistsFunc: func(ctx context.Context, name string) (bool, error) { return true, nil },
+			GetDataInputFunc: func(ctx context.Context, name string) (*models.SFDCObjectInput, error) {
+				return liveInput(), nil
+			},
+		}
+
+		processor := runCreateInputs(t, mockService)
+
+		assert.Equal(t, 0, mockService.UpdateDataInputCalls)
+		assert.Equal(t, []string{"test_input"}, processor.GetUnchangedInputs())
+		success, failed := processor.GetCounters()
+		assert.Equal(t, 1, success)
+		assert.Equal(t, 0, failed)
+	})
+
+	t.Run("Success_DriftedInputUpdated", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckDataInputExistsFunc: func(ctx context.Context, name string) (bool, error) { return true, nil },
+			GetDataInputFunc: func(ctx context.Context, name string) (*models.SFDCObjectInput, error) {
+				live := liveInput()
+				live.Interval = 900
+				return live, nil
+			},
+		}
+
+		processor := runCreateInputs(t, mockService)
+
+		assert.Equal(t, 1, mockService.UpdateDataInputCalls)
+		assert.Empty(t, processor.GetUnchangedInputs())
+	})
+
+	t.Run("Success_UnreadableInputUpdated", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CheckDataInputExistsFunc: func(ctx context.Context, name string) (bool, error) { return true, nil },
+			GetDataInputFunc: func(ctx context.Context, name string) (*models.SFDCObjectInput, error) {
+				return nil, fmt.Errorf("read failed")
+			},
+		}
+
+		runCreateInputs(t, mockService)
+
+		assert.Equal(t, 1, mockService.UpdateDataInputCalls)
+	})
+}
+
+func TestMigrationNodeProcessor_CreateAccounts(t *testing.T) {
+	newConfig := func() *utils.Config {
+		return &utils.Config{
+			Salesforce: utils.SalesforceConfig{AccountName: "sf_prod"},
+			Migration:  utils.MigrationConfig{ConcurrentRequests: 1},
+			Extensions: map[string]interface{}{
+				"SALESFORCE_ACCOUNTS": []interface{}{
+					map[string]interface{}{"name": "sf_prod", "endpoint": "login.salesforce.com", "client_id": "id1", "client_secret": "secret1"},
+					map[string]interface{}{"name": "sf_sandbox", "endpoint": "test.salesforce.com", "client_id": "id2", "client_secret": "secret2"},
+					map[string]interface{}{"name": "sf_acquired", "endpoint": "acquired.my.salesforce.com", "client_id": "id3", "client_secret": "secret3"},
+				},
+			},
+		}
+	}
+
+	t.Run("Success_ProvisionsEveryAccount", func(t *testing.T) {
+		var created, updated []string
+		mockService := &mocks.MockSplunkService{
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
+				return accountName == "sf_prod", nil
+			},
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
+				created = append(created, account.Name+"@"+account.Endpoint)
+				return nil
+			},
+			UpdateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
+				updated = append(updated, account.Name)
+				return nil
+			},
+		}
+
+		processor := workflows.NewMigrationNodeProcessor(newConfig(), mockService, &mocks.MockDashboardService{})
+		_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "create_account"}, make(map[string]interface{}))
+
+		require.NoError(t, err)
+		assert.Equal(t, 3, mockService.CheckSalesforceAccountExistsCalls)
+		assert.ElementsMatch(t, []string{"sf_sandbox@test.salesforce.com", "sf_acquired@acquired.my.salesforce.com"}, created)
+		assert.Equal(t, []string{"sf_prod"}, updated)
+	})
+
+	t.Run("Error_OneAccountFails", func(t *testing.T) {
+		mockService := &mocks.MockSplunkService{
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
+				if account.Name == "sf_sandbox" {
+					return fmt.Errorf("status 400")
+				}
+				return nil
+			},
+		}
+
+		processor := workflows.NewMigrationNodeProcessor(newConfig(), mockService, &mocks.MockDashboardService{})
+		_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "create_account"}, make(map[string]interface{}))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "1 of 3 Salesforce accounts failed to provision")
+		assert.Contains(t, err.Error(), "account sf_sandbox: status 400")
+		assert.Equal(t, 3, mockService.CreateSalesforceAccountCalls)
+	})
+}
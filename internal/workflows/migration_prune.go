@@ -0,0 +1,133 @@
+package workflows
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// pruneInputsNode disables or deletes sfdc_object inputs that belong to the configured
+// account and ownership prefix but are no longer listed in DATA_INPUTS
+func (p *MigrationNodeProcessor) pruneInputsNode(ctx context.Context) error {
+	mode := p.config.Migration.PruneMode
+	if mode == "" {
+		p.logger.Debug("Prune mode not enabled, skipping unmanaged input cleanup")
+		return nil
+	}
+
+	p.logger.Info("🧹 Node 10: Finding unmanaged data inputs to prune...",
+		utils.String("mode", mode),
+		utils.String("prefix", p.config.Migration.PrunePrefix))
+
+	candidates, err := p.findPruneCandidates(ctx)
+	if err != nil {
+		p.logger.Error("Failed to find unmanaged data inputs", utils.Err(err))
+		return err
+	}
+
+	if len(candidates) == 0 {
+		p.logger.Info("✅ No unmanaged data inputs to prune")
+		return nil
+	}
+
+	// Confirmation summary: always list what is (or would be) pruned
+	p.logger.Warn("Unmanaged data inputs selected for pruning",
+		utils.String("mode", mode),
+		utils.Int("count", len(candidates)),
+		utils.String("inputs", strings.Join(candidates, ",")))
+
+	if !p.config.Migration.PruneConfirm {
+		p.logger.Warn("⚠️  Prune not confirmed, no inputs were changed. Set MIGRATION_PRUNE_CONFIRM=true to apply",
+			utils.String("mode", mode),
+			utils.Int("count", len(candidates)))
+		return nil
+	}
+
+	failed := 0
+	for _, name := range candidates {
+		var err error
+		if mode == utils.PruneModeDelete {
+			err = p.splunkService.DeleteDataInput(ctx, name)
+		} else {
+			err = p.splunkService.DisableDataInput(ctx, name)
+		}
+
+		if err != nil {
+			p.logger.Error("Failed to prune data input",
+				utils.String("name", name),
+				utils.String("mode", mode),
+				utils.Err(err))
+			failed++
+			continue
+		}
+		p.logger.Info("Pruned data input",
+			utils.String("name", name),
+			utils.String("mode", mode))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d unmanaged data inputs failed to prune", failed, len(candidates))
+	}
+
+	p.logger.Info("✅ Unmanaged data inputs pruned",
+		utils.String("mode", mode),
+		utils.Int("count", len(candidates)))
+	return nil
+}
+
+// findPruneCandidates returns the unmanaged inputs that are safe to prune: they must not be
+// in DATA_INPUTS, must match the ownership prefix and must use one of the configured accounts.
+// Inputs that are already disabled are skipped in disable mode.
+func (p *MigrationNodeProcessor) findPruneCandidates(ctx context.Context) ([]string, error) {
+	existingInputs, err := p.splunkService.ListDataInputs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list data inputs: %w", err)
+	}
+
+	configured := make(map[string]bool)
+	for _, input := range p.dataInputs {
+		configured[input.Name] = true
+	}
+
+	accounts, err := p.config.GetSalesforceAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load Salesforce accounts: %w", err)
+	}
+	managedAccounts := make(map[string]bool, len(accounts))
+	for _, account := range accounts {
+		managedAccounts[account.Name] = true
+	}
+
+	prefix := p.config.Migration.PrunePrefix
+	candidates := make([]string, 0)
+	for _, name := range existingInputs {
+		if configured[name] {
+			continue
+		}
+		// Ownership guard: never touch inputs outside our naming prefix
+		if prefix == "" || !strings.HasPrefix(name, prefix) {
+			p.logger.Debug("Unmanaged input outside ownership prefix, leaving untouched",
+				utils.String("name", name))
+			continue
+		}
+
+		live, err := p.splunkService.GetDataInput(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data input %s: %w", name, err)
+		}
+		if live == nil || !managedAccounts[live.Account] {
+			continue
+		}
+		if p.config.Migration.PruneMode == utils.PruneModeDisable && live.Disabled {
+			continue
+		}
+
+		candidates = append(candidates, name)
+	}
+
+	sort.Strings(candidates)
+	return candidates, nil
+}
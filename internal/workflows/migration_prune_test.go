@@ -0,0 +1,143 @@
+package workflows_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+
+	"github.com/flowgraph/flowgraph/pkg/flowgraph"
+)
+
+// withPrune enables pruning of inputs starting with sf_
+func withPrune(mode string, confirm bool) func(*utils.Config) {
+	return func(config *utils.Config) {
+		config.Migration.PruneMode = mode
+		config.Migration.PrunePrefix = "sf_"
+		config.Migration.PruneConfirm = confirm
+	}
+}
+
+// newPruneMockService lists one managed input, one stale owned input, one input on another
+// account and one input outside the ownership prefix
+func newPruneMockService() *mocks.MockSplunkService {
+	accounts := map[string]string{
+		"sf_accounts":    "test_account",
+		"sf_old_leads":   "test_account",
+		"sf_other_org":   "other_account",
+		"manual_contact": "test_account",
+	}
+	return &mocks.MockSplunkService{
+		ListDataInputsFunc: func(ctx context.Context) ([]string, error) {
+			return []string{"sf_accounts", "sf_old_leads", "sf_other_org", "manual_contact"}, nil
+		},
+		GetDataInputFunc: func(ctx context.Context, name string) (*models.SFDCObjectInput, error) {
+			return &models.SFDCObjectInput{Name: name, Account: accounts[name]}, nil
+		},
+	}
+}
+
+func runPruneNode(t *testing.T, config *utils.Config, mockService *mocks.MockSplunkService) error {
+	processor := workflows.NewMigrationNodeProcessor(config, mockService, &mocks.MockDashboardService{})
+	_, err := processor.Process(context.Background(), &flowgraph.Node{ID: "load_data_inputs"}, make(map[string]interface{}))
+	require.NoError(t, err)
+	_, err = processor.Process(context.Background(), &flowgraph.Node{ID: "prune_inputs"}, make(map[string]interface{}))
+	return err
+}
+
+func TestMigrationNodeProcessor_PruneInputs(t *testing.T) {
+	t.Run("Success_DisabledByDefault", func(t *testing.T) {
+		mockService := newPruneMockService()
+		require.NoError(t, runPruneNode(t, newTestConfig(withPrune("", true)), mockService))
+		assert.Equal(t, 0, mockService.ListDataInputsCalls)
+	})
+
+	t.Run("Success_UnconfirmedOnlyReports", func(t *testing.T) {
+		mockService := newPruneMockService()
+		require.NoError(t, runPruneNode(t, newTestConfig(withPrune(utils.PruneModeDelete, false)), mockService))
+		assert.Equal(t, 1, mockService.ListDataInputsCalls)
+		assert.Equal(t, 0, mockService.DeleteDataInputCalls)
+		assert.Equal(t, 0, mockService.DisableDataInputCalls)
+	})
+
+	t.Run("Success_DeleteOwnedInputsOnly", func(t *testing.T) {
+		mockService := newPruneMockService()
+		var deleted []string
+		mockService.DeleteDataInputFunc = func(ctx context.Context, name string) error {
+			deleted = append(deleted, name)
+			return nil
+		}
+
+		require.NoError(t, runPruneNode(t, newTestConfig(withPrune(utils.PruneModeDelete, true)), mockService))
+		assert.Equal(t, []string{"sf_old_leads"}, deleted)
+		assert.Equal(t, 0, mockService.DisableDataInputCalls)
+	})
+
+	t.Run("Success_DisableSkipsAlreadyDisabled", func(t *testing.T) {
+		mockService := newPruneMockService()
+		mockService.GetDataInputFunc = func(ctx context.Context, name string) (*models.SFDCObjectInput, error) {
+			return &models.SFDCObjectInput{Name: name, Account: "test_account", Disabled: name == "sf_old_leads"}, nil
+		}
+		var disabled []string
+		mockService.DisableDataInputFunc = func(ctx context.Context, name string) error {
+			disabled = append(disabled, name)
+			return nil
+		}
+
+		require.NoError(t, runPruneNode(t, newTestConfig(withPrune(utils.PruneModeDisable, true)), mockService))
+		assert.Equal(t, []string{"sf_other_org"}, disabled)
+	})
+
+	t.Run("Error_PruneFails", func(t *testing.T) {
+		mockService := newPruneMockService()
+		mockService.DisableDataInputFunc = func(ctx context.Context, name string) error {
+			return fmt.Errorf("permission denied")
+		}
+
+		err := runPruneNode(t, newTestConfig(withPrune(utils.PruneModeDisable, true)), mockService)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to prune")
+	})
+
+	t.Run("Error_ListFails", func(t *testing.T) {
+		mockService := newPruneMockService()
+		mockService.ListDataInputsFunc = func(ctx context.Context) ([]string, error) {
+			return nil, fmt.Errorf("list failed")
+		}
+
+		err := runPruneNode(t, newTestConfig(withPrune(utils.PruneModeDelete, true)), mockService)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "list failed")
+	})
+}
+
+func TestMigrationPlanGraph_Prune(t *testing.T) {
+	t.Run("Success_PlanShowsDeletions", func(t *testing.T) {
+		mockService := newPruneMockService()
+		mockService.CheckIndexExistsFunc = func(ctx context.Context, indexName string) (bool, error) {
+			return true, nil
+		}
+
+		graph, err := workflows.NewMigrationPlanGraph(newTestConfig(withPrune(utils.PruneModeDelete, false)), mockService, nil)
+		require.NoError(t, err)
+		require.NoError(t, graph.Execute(context.Background()))
+
+		plan := graph.GetPlan()
+		assert.Equal(t, 1, plan.Count(workflows.PlanActionDelete))
+		assert.Equal(t, 2, plan.Count(workflows.PlanActionUnmanaged))
+		assert.Equal(t, 0, mockService.DeleteDataInputCalls)
+
+		var out bytes.Buffer
+		require.NoError(t, plan.Render(&out))
+		assert.Contains(t, out.String(), "- sfdc_object.sf_old_leads will be deleted")
+		assert.Contains(t, out.String(), "1 to delete")
+	})
+}
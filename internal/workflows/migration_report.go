@@ -0,0 +1,269 @@
+package workflows
+
+import (
+	"time"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+
+	"github.com/flowgraph/flowgraph/pkg/flowgraph"
+)
+
+// Node statuses recorded in the run report
+const (
+	NodeSucceeded = "succeeded"
+	NodeFailed    = "failed"
+	NodeSkipped   = "skipped"
+	NodeNotRun    = "not_run"
+)
+
+// Input and dashboard actions recorded in the run report
+const (
+	ActionCreated = "created"
+	ActionUpdated = "updated"
+	ActionSkipped = "skipped"
+	ActionDeleted = "deleted"
+	ActionFailed  = "failed"
+)
+
+// Input types recorded in the run report
+const (
+	InputTypeObject   = "sfdc_object"
+	InputTypeEventLog = "sfdc_event_log"
+)
+
+// Report describes the outcome of one migration graph execution
+type Report struct {
+	StartTime  time.Time        `json:"start_time"`
+	EndTime    time.Time        `json:"end_time"`
+	DurationMS int64            `json:"duration_ms"`
+	Nodes      []NodeReport     `json:"nodes"`
+	Inputs     []InputReport    `json:"inputs"`
+	Dashboards *DashboardReport `json:"dashboards,omitempty"`
+}
+
+// NodeReport is the timing and outcome of one graph node
+type NodeReport struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Status     string     `json:"status"`
+	StartTime  *time.Time `json:"start_time,omitempty"`
+	EndTime    *time.Time `json:"end_time,omitempty"`
+	DurationMS int64      `json:"duration_ms"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// InputReport is the action taken for one sfdc_object or sfdc_event_log input
+type InputReport struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Action     string `json:"action"`
+	Reason     string `json:"reason,omitempty"`
+	Error      string `json:"error,omitempty"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+}
+
+// DashboardReport is the outcome of deploying the dashboard directory. Action summarizes
+// the actions in Files, which lists the action taken for each dashboard.
+type DashboardReport struct {
+	Directory  string                `json:"directory"`
+	Action     string                `json:"action"`
+	Reason     string                `json:"reason,omitempty"`
+	Error      string                `json:"error,omitempty"`
+	HTTPStatus int                   `json:"http_status,omitempty"`
+	Files      []DashboardFileReport `json:"files,omitempty"`
+}
+
+// DashboardFileReport is the action taken for one dashboard. Versions are content
+// digests of the deployed definition and of the definition it replaced.
+type DashboardFileReport struct {
+	Name            string `json:"name"`
+	File            string `json:"file,omitempty"`
+	Action          string `json:"action"`
+	Reason          string `json:"reason,omitempty"`
+	Version         string `json:"version,omitempty"`
+	PreviousVersion string `json:"previous_version,omitempty"`
+	Error           string `json:"error,omitempty"`
+	HTTPStatus      int    `json:"http_status,omitempty"`
+}
+
+// FailedInputs returns the number of inputs whose action failed
+func (r *Report) FailedInputs() int {
+	failed := 0
+	for _, input := range r.Inputs {
+		if input.Action == ActionFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// HTTPStatus returns the status of the *utils.HTTPError carried by an error, or 0 when
+// the error did not come from a Splunk response
+func HTTPStatus(err error) int {
+	return utils.HTTPStatusCode(err)
+}
+
+// recordNodeReport appends the timing and outcome of a node to the report
+func (p *MigrationNodeProcessor) recordNodeReport(node *flowgraph.Node, status string, start time.Time, err error) {
+	end := time.Now()
+	report := NodeReport{
+		ID:         node.ID,
+		Name:       node.Name,
+		Status:     status,
+		StartTime:  &start,
+		EndTime:    &end,
+		DurationMS: end.Sub(start).Milliseconds(),
+	}
+	if err != nil {
+		report.Error = utils.Redact(err.Error())
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.nodeReports = append(p.nodeReports, report)
+}
+
+// recordInputReport appends the action taken for an input to the report
+func (p *MigrationNodeProcessor) recordInputReport(inputType, name, action, reason string, err error) {
+	report := InputReport{
+		Type:   inputType,
+		Name:   name,
+		Action: action,
+		Reason: reason,
+	}
+	if err != nil {
+		report.Error = utils.Redact(err.Error())
+		report.HTTPStatus = HTTPStatus(err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.inputReports = append(p.inputReports, report)
+}
+
+// recordDashboardReport records the outcome of the dashboard node and, when the
+// directory was reconciled, the action taken for each dashboard
+func (p *MigrationNodeProcessor) recordDashboardReport(directory, action, reason string, result *models.DashboardReconcileResult, err error) {
+	report := &DashboardReport{
+		Directory: directory,
+		Action:    action,
+		Reason:    reason,
+	}
+	if err != nil {
+		report.Error = utils.Redact(err.Error())
+		report.HTTPStatus = HTTPStatus(err)
+	}
+	if result != nil {
+		for _, outcome := range result.Outcomes {
+			report.Files = append(report.Files, dashboardFileReport(outcome))
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.dashboardReport = report
+}
+
+// dashboardDirectoryAction summarizes the outcomes of a successful reconcile: created
+// when every dashboard was created, updated when any dashboard changed and skipped when
+// none did
+func dashboardDirectoryAction(result *models.DashboardReconcileResult) (action, reason string) {
+	created, changed := 0, 0
+	for _, outcome := range result.Outcomes {
+		switch outcome.Action {
+		case models.DashboardCreated:
+			created++
+			changed++
+		case models.DashboardUpdated, models.DashboardDeleted:
+			changed++
+		}
+	}
+
+	switch {
+	case changed == 0:
+		return ActionSkipped, "unchanged"
+	case created == len(result.Outcomes):
+		return ActionCreated, ""
+	default:
+		return ActionUpdated, ""
+	}
+}
+
+// dashboardFileReport converts a reconcile outcome to the report actions; an unchanged
+// dashboard is reported as skipped
+func dashboardFileReport(outcome models.DashboardOutcome) DashboardFileReport {
+	report := DashboardFileReport{
+		Name:            outcome.Name,
+		File:            outcome.File,
+		Action:          outcome.Action,
+		Version:         outcome.Version,
+		PreviousVersion: outcome.PreviousVersion,
+	}
+	switch outcome.Action {
+	case models.DashboardCreated:
+		report.Action = ActionCreated
+	case models.DashboardUpdated:
+		report.Action = ActionUpdated
+	case models.DashboardUnchanged:
+		report.Action, report.Reason = ActionSkipped, "unchanged"
+	case models.DashboardDeleted:
+		report.Action, report.Reason = ActionDeleted, "file removed from the dashboard directory"
+	case models.DashboardFailed:
+		report.Action = ActionFailed
+	}
+	if outcome.Err != nil {
+		report.Error = utils.Redact(outcome.Err.Error())
+		report.HTTPStatus = HTTPStatus(outcome.Err)
+	}
+	return report
+}
+
+// Report returns the report of the last execution. Nodes that were not reached are
+// listed with status not_run, in graph order.
+func (mg *MigrationGraph) Report() *Report {
+	p := mg.processor
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	report := &Report{
+		StartTime:  mg.startTime,
+		EndTime:    mg.endTime,
+		DurationMS: mg.endTime.Sub(mg.startTime).Milliseconds(),
+		Nodes:      append([]NodeReport(nil), p.nodeReports...),
+		Inputs:     append([]InputReport{}, p.inputReports...),
+	}
+	if p.dashboardReport != nil {
+		dashboards := *p.dashboardReport
+		report.Dashboards = &dashboards
+	}
+
+	reached := make(map[string]bool, len(report.Nodes))
+	for _, node := range report.Nodes {
+		reached[node.ID] = true
+	}
+	for _, node := range mg.orderedNodes() {
+		if !reached[node.ID] {
+			report.Nodes = append(report.Nodes, NodeReport{ID: node.ID, Name: node.Name, Status: NodeNotRun})
+		}
+	}
+	return report
+}
+
+// orderedNodes returns the graph nodes by following the edges from the entry point
+func (mg *MigrationGraph) orderedNodes() []*flowgraph.Node {
+	next := make(map[string]string, len(mg.graph.Edges))
+	for _, edge := range mg.graph.Edges {
+		next[edge.Source] = edge.Target
+	}
+
+	var nodes []*flowgraph.Node
+	seen := make(map[string]bool)
+	for id := mg.graph.EntryPoint; id != "" && !seen[id]; id = next[id] {
+		seen[id] = true
+		if node, ok := mg.graph.Nodes[id]; ok {
+			nodes = append(nodes, node)
+		}
+	}
+	return nodes
+}
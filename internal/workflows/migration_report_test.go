@@ -0,0 +1,199 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// reportTestInputs are three data inputs and an event log input
+func reportTestInputs(config *utils.Config) {
+	withDataInputs(
+		map[string]interface{}{"name": "sf_accounts", "object": "Account", "object_fields": "Id,Name"},
+		map[string]interface{}{"name": "sf_contacts", "object": "Contact", "object_fields": "Id,Name"},
+		map[string]interface{}{"name": "sf_leads", "object": "Lead", "object_fields": "Id,Name"},
+	)(config)
+	withEventLogInputs(map[string]interface{}{"name": "sf_event_logs"})(config)
+}
+
+func TestMigrationGraph_Report(t *testing.T) {
+	t.Run("Success_AllNodesAndActions", func(t *testing.T) {
+		splunkService := &mocks.MockSplunkService{
+			CheckDataInputExistsFunc: func(ctx context.Context, name string) (bool, error) {
+				return name != "sf_accounts", nil
+			},
+			GetDataInputFunc: func(ctx context.Context, name string) (*models.SFDCObjectInput, error) {
+				if name == "sf_leads" {
+					return nil, errors.New("read failed")
+				}
+				return &models.SFDCObjectInput{
+					Name:         name,
+					Account:      "test_account",
+					Object:       "Contact",
+					ObjectFields: "Id,Name",
+					OrderBy:      "LastModifiedDate",
+					StartDate:    "2024-01-01T00:00:00.000Z",
+					Interval:     300,
+					Delay:        60,
+					Index:        "test_index",
+				}, nil
+			},
+		}
+
+		graph, err := workflows.NewMigrationGraph(newTestConfig(reportTestInputs, withDashboardDirectory(t.TempDir())), splunkService, &mocks.MockDashboardService{})
+		require.NoError(t, err)
+		require.NoError(t, graph.Execute(context.Background()))
+
+		report := graph.Report()
+		require.Len(t, report.Nodes, 12)
+		for _, node := range report.Nodes {
+			assert.Equal(t, workflows.NodeSucceeded, node.Status, node.ID)
+			assert.NotNil(t, node.StartTime, node.ID)
+		}
+		assert.Equal(t, "lint_dashboards", report.Nodes[0].ID)
+		assert.Equal(t, "create_dashboards", report.Nodes[11].ID)
+
+		actions := make(map[string]string)
+		for _, input := range report.Inputs {
+			actions[input.Name] = input.Action
+		}
+		assert.Equal(t, map[string]string{
+			"sf_accounts":   workflows.ActionCreated,
+			"sf_contacts":   workflows.ActionSkipped,
+			"sf_leads":      workflows.ActionUpdated,
+			"sf_event_logs": workflows.ActionCreated,
+		}, actions)
+
+		require.NotNil(t, report.Dashboards)
+		assert.Equal(t, workflows.ActionSkipped, report.Dashboards.Action)
+		assert.Equal(t, "unchanged", report.Dashboards.Reason)
+		assert.Equal(t, 0, report.FailedInputs())
+	})
+
+	t.Run("Success_DashboardActionFromOutcomes", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			outcomes []string
+			want     string
+		}{
+			{name: "AllCreated", outcomes: []string{models.DashboardCreated, models.DashboardCreated}, want: workflows.ActionCreated},
+			{name: "Mixed", outcomes: []string{models.DashboardCreated, models.DashboardUnchanged}, want: workflows.ActionUpdated},
+			{name: "Deleted", outcomes: []string{models.DashboardUnchanged, models.DashboardDeleted}, want: workflows.ActionUpdated},
+			{name: "AllUnchanged", outcomes: []string{models.DashboardUnchanged}, want: workflows.ActionSkipped},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				dashboardService := &mocks.MockDashboardService{
+					ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+						result := &models.DashboardReconcileResult{Directory: dashboardDir}
+						for i, action := range tt.outcomes {
+							result.Outcomes = append(result.Outcomes, models.DashboardOutcome{Name: fmt.Sprintf("dashboard_%d", i), Action: action})
+						}
+						return result, nil
+					},
+				}
+
+				graph, err := workflows.NewMigrationGraph(newTestConfig(withDashboardDirectory(t.TempDir())), &mocks.MockSplunkService{}, dashboardService)
+				require.NoError(t, err)
+				require.NoError(t, graph.Execute(context.Background()))
+
+				report := graph.Report()
+				require.NotNil(t, report.Dashboards)
+				assert.Equal(t, tt.want, report.Dashboards.Action)
+			})
+		}
+	})
+
+	t.Run("Error_FailedInputStopsGraph", func(t *testing.T) {
+		splunkService := &mocks.MockSplunkService{
+			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
+				if input.Name == "sf_contacts" {
+					return fmt.Errorf("failed to create data input: %w", utils.NewHTTPError(403, []byte(`{"messages":[{"type":"ERROR","text":"forbidden"}]}`)))
+				}
+				return nil
+			},
+		}
+
+		graph, err := workflows.NewMigrationGraph(newTestConfig(reportTestInputs), splunkService, &mocks.MockDashboardService{})
+		require.NoError(t, err)
+		require.Error(t, graph.Execute(context.Background()))
+
+		report := graph.Report()
+		statuses := make(map[string]string)
+		for _, node := range report.Nodes {
+			statuses[node.ID] = node.Status
+		}
+		assert.Equal(t, workflows.NodeSucceeded, statuses["load_data_inputs"])
+		assert.Equal(t, workflows.NodeFailed, statuses["create_data_inputs"])
+		assert.Equal(t, workflows.NodeNotRun, statuses["create_dashboards"])
+		assert.Nil(t, report.Dashboards)
+
+		require.Equal(t, 1, report.FailedInputs())
+		for _, input := range report.Inputs {
+			if input.Action == workflows.ActionFailed {
+				assert.Equal(t, "sf_contacts", input.Name)
+				assert.Equal(t, workflows.InputTypeObject, input.Type)
+				assert.Equal(t, 403, input.HTTPStatus)
+				assert.Contains(t, input.Error, "forbidden")
+			}
+		}
+	})
+
+	t.Run("Error_DashboardOutcomesReported", func(t *testing.T) {
+		dashboardService := &mocks.MockDashboardService{
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				result := &models.DashboardReconcileResult{Directory: dashboardDir, Outcomes: []models.DashboardOutcome{
+					{Name: "home", File: "home.xml", Action: models.DashboardUnchanged, Version: "aaa", PreviousVersion: "aaa"},
+					{Name: "analytics", File: "analytics.xml", Action: models.DashboardUpdated, Version: "bbb", PreviousVersion: "ccc"},
+					{Name: "sfdc_old", Action: models.DashboardDeleted, PreviousVersion: "ddd"},
+					{Name: "broken", File: "broken.xml", Action: models.DashboardFailed, Err: utils.NewHTTPError(400, []byte("bad XML"))},
+				}}
+				return result, errors.New("1 of 4 dashboards failed to reconcile")
+			},
+		}
+
+		graph, err := workflows.NewMigrationGraph(newTestConfig(reportTestInputs, withDashboardDirectory(t.TempDir())), &mocks.MockSplunkService{}, dashboardService)
+		require.NoError(t, err)
+		require.Error(t, graph.Execute(context.Background()))
+
+		report := graph.Report()
+		require.NotNil(t, report.Dashboards)
+		assert.Equal(t, workflows.ActionFailed, report.Dashboards.Action)
+		assert.Equal(t, []workflows.DashboardFileReport{
+			{Name: "home", File: "home.xml", Action: workflows.ActionSkipped, Reason: "unchanged", Version: "aaa", PreviousVersion: "aaa"},
+			{Name: "analytics", File: "analytics.xml", Action: workflows.ActionUpdated, Version: "bbb", PreviousVersion: "ccc"},
+			{Name: "sfdc_old", Action: workflows.ActionDeleted, Reason: "file removed from the dashboard directory", PreviousVersion: "ddd"},
+			{Name: "broken", File: "broken.xml", Action: workflows.ActionFailed, Error: "status 400 - bad XML", HTTPStatus: 400},
+		}, report.Dashboards.Files)
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "Nil", err: nil, want: 0},
+		{name: "HTTPError", err: fmt.Errorf("failed to check data input existence: %w", utils.NewHTTPError(403, []byte("denied"))), want: 403},
+		{name: "Wrapped", err: fmt.Errorf("account sf_prod: %w", fmt.Errorf("token authentication failed: %w", utils.NewHTTPError(401, nil))), want: 401},
+		{name: "StatusInMessageOnly", err: errors.New("failed to update data input: status 500 - internal error"), want: 0},
+		{name: "NoStatus", err: errors.New("connection refused"), want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, workflows.HTTPStatus(tt.err))
+		})
+	}
+}
@@ -0,0 +1,288 @@
+package workflows
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"salesforce-splunk-migration/utils"
+
+	"github.com/flowgraph/flowgraph/pkg/flowgraph"
+)
+
+// migrationGraphID identifies the migration graph in the checkpoint database
+const migrationGraphID = "salesforce-splunk-migration"
+
+// resumeRerunNodes are executed again on resume even when a previous run completed
+// them, because they only rebuild in-process state (the Splunk session and the
+// loaded data inputs) that later nodes depend on, or check local files that may have
+// been fixed since
+var resumeRerunNodes = map[string]bool{
+	"lint_dashboards":  true,
+	"authenticate":     true,
+	"load_data_inputs": true,
+}
+
+// ErrRunNotFound is returned when resuming a run that has no saved state
+var ErrRunNotFound = errors.New("migration run not found")
+
+// RunState records the progress of one migration run against one Splunk target
+// so that a failed run can be resumed
+type RunState struct {
+	RunID                   string
+	Target                  string
+	CompletedNodes          []string
+	SucceededInputs         []string // sfdc_object data inputs
+	FailedInputs            []string
+	SucceededEventLogInputs []string // sfdc_event_log inputs
+	FailedEventLogInputs    []string
+}
+
+// NewRunState creates the state for a fresh run
+func NewRunState(runID, target string) *RunState {
+	return &RunState{RunID: runID, Target: target}
+}
+
+// NewRunID returns a sortable, unique identifier for a migration run
+func NewRunID() string {
+	suffix := make([]byte, 3)
+	if _, err := rand.Read(suffix); err != nil {
+		return time.Now().UTC().Format("20060102T150405.000000000Z")
+	}
+	return time.Now().UTC().Format("20060102T150405Z") + "-" + hex.EncodeToString(suffix)
+}
+
+// threadID is the FlowGraph thread the run's checkpoints are stored under
+func (r *RunState) threadID() string {
+	return r.RunID + "/" + r.Target
+}
+
+// checkpointID is the ID of the checkpoint holding the run state
+func (r *RunState) checkpointID() string {
+	return "run-" + r.threadID()
+}
+
+// nodeCompleted reports whether a previous attempt of the run completed a node
+func (r *RunState) nodeCompleted(nodeID string) bool {
+	return slices.Contains(r.CompletedNodes, nodeID)
+}
+
+// inputSucceeded reports whether a previous attempt of the run created or updated a data input
+func (r *RunState) inputSucceeded(name string) bool {
+	return slices.Contains(r.SucceededInputs, name)
+}
+
+// eventLogInputSucceeded reports whether a previous attempt of the run created or
+// updated an event log input
+func (r *RunState) eventLogInputSucceeded(name string) bool {
+	return slices.Contains(r.SucceededEventLogInputs, name)
+}
+
+// clone returns a deep copy of the run state
+func (r *RunState) clone() *RunState {
+	return &RunState{
+		RunID:                   r.RunID,
+		Target:                  r.Target,
+		CompletedNodes:          slices.Clone(r.CompletedNodes),
+		SucceededInputs:         slices.Clone(r.SucceededInputs),
+		FailedInputs:            slices.Clone(r.FailedInputs),
+		SucceededEventLogInputs: slices.Clone(r.SucceededEventLogInputs),
+		FailedEventLogInputs:    slices.Clone(r.FailedEventLogInputs),
+	}
+}
+
+// RunStore persists run states and graph checkpoints in a FlowGraph checkpoint saver
+type RunStore struct {
+	saver flowgraph.CheckpointSaver
+}
+
+// NewRunStore creates a run store backed by saver
+func NewRunStore(saver flowgraph.CheckpointSaver) *RunStore {
+	return &RunStore{saver: saver}
+}
+
+// OpenRunStore opens the SQLite state database at path, creating it when needed.
+// The caller must Close the returned store.
+func OpenRunStore(ctx context.Context, path string) (*RunStore, error) {
+	saver, err := flowgraph.OpenSQLiteCheckpointSaver(ctx, path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open run state %s: %w", path, err)
+	}
+	return NewRunStore(saver), nil
+}
+
+// Close closes the underlying database when the saver holds one
+func (s *RunStore) Close() error {
+	if closer, ok := s.saver.(interface{ Close() error }); ok {
+		return closer.Close()
+	}
+	return nil
+}
+
+// Save writes the run state, replacing any earlier state of the same run and target
+func (s *RunStore) Save(ctx context.Context, run *RunState) error {
+	cp := &flowgraph.Checkpoint{
+		ID:       run.checkpointID(),
+		GraphID:  migrationGraphID,
+		ThreadID: run.threadID(),
+		State: map[string]interface{}{
+			"run_id":                     run.RunID,
+			"target":                     run.Target,
+			"completed_nodes":            run.CompletedNodes,
+			"succeeded_inputs":           run.SucceededInputs,
+			"failed_inputs":              run.FailedInputs,
+			"succeeded_event_log_inputs": run.SucceededEventLogInputs,
+			"failed_event_log_inputs":    run.FailedEventLogInputs,
+		},
+		Metadata: flowgraph.CheckpointMetadata{
+			Step:      len(run.CompletedNodes),
+			Source:    "migration_run",
+			CreatedBy: "salesforce-splunk-migration",
+		},
+		Timestamp: time.Now(),
+		Version:   "1.0",
+	}
+
+	if err := s.saver.Save(ctx, cp); err != nil {
+		return fmt.Errorf("failed to save run %s: %w", run.RunID, err)
+	}
+	return nil
+}
+
+// Load reads the state of a run for one target. ErrRunNotFound is returned when
+// the run was never started against the target.
+func (s *RunStore) Load(ctx context.Context, runID, target string) (*RunState, error) {
+	run := NewRunState(runID, target)
+
+	cp, err := s.saver.Load(ctx, run.checkpointID())
+	if errors.Is(err, flowgraph.ErrCheckpointNotFound) {
+		return nil, fmt.Errorf("%w: %s (target %s)", ErrRunNotFound, runID, target)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to load run %s: %w", runID, err)
+	}
+
+	run.CompletedNodes = stateStrings(cp.State["completed_nodes"])
+	run.SucceededInputs = stateStrings(cp.State["succeeded_inputs"])
+	run.FailedInputs = stateStrings(cp.State["failed_inputs"])
+	run.SucceededEventLogInputs = stateStrings(cp.State["succeeded_event_log_inputs"])
+	run.FailedEventLogInputs = stateStrings(cp.State["failed_event_log_inputs"])
+	return run, nil
+}
+
+// stateStrings converts a deserialized checkpoint value back into a string slice
+func stateStrings(value interface{}) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// recordNode marks a node as completed in the run state and persists it
+func (p *MigrationNodeProcessor) recordNode(ctx context.Context, nodeID string) {
+	if p.run == nil {
+		return
+	}
+
+	p.mu.Lock()
+	if !p.run.nodeCompleted(nodeID) {
+		p.run.CompletedNodes = append(p.run.CompletedNodes, nodeID)
+	}
+	p.mu.Unlock()
+
+	p.saveRun(ctx)
+}
+
+// inputCompleted reports whether a previous attempt of the run already created or
+// updated a data input
+func (p *MigrationNodeProcessor) inputCompleted(name string) bool {
+	if p.run == nil {
+		return false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.run.inputSucceeded(name)
+}
+
+// recordInput marks a data input as created or updated in the run state and persists it,
+// so that an input is not repeated even if the rest of its node fails
+func (p *MigrationNodeProcessor) recordInput(ctx context.Context, name string) {
+	if p.run == nil {
+		return
+	}
+
+	p.mu.Lock()
+	if !p.run.inputSucceeded(name) {
+		p.run.SucceededInputs = append(p.run.SucceededInputs, name)
+	}
+	p.mu.Unlock()
+
+	p.saveRun(ctx)
+}
+
+// eventLogInputCompleted reports whether a previous attempt of the run already created
+// or updated an event log input
+func (p *MigrationNodeProcessor) eventLogInputCompleted(name string) bool {
+	if p.run == nil {
+		return false
+	}
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.run.eventLogInputSucceeded(name)
+}
+
+// recordEventLogInput marks an event log input as created or updated in the run state
+// and persists it
+func (p *MigrationNodeProcessor) recordEventLogInput(ctx context.Context, name string) {
+	if p.run == nil {
+		return
+	}
+
+	p.mu.Lock()
+	if !p.run.eventLogInputSucceeded(name) {
+		p.run.SucceededEventLogInputs = append(p.run.SucceededEventLogInputs, name)
+	}
+	p.mu.Unlock()
+
+	p.saveRun(ctx)
+}
+
+// saveRun persists a snapshot of the run state together with the inputs that failed
+// in this attempt. Failures are logged rather than returned: losing run state only
+// means a later resume repeats more work.
+func (p *MigrationNodeProcessor) saveRun(ctx context.Context) {
+	if p.run == nil || p.runStore == nil {
+		return
+	}
+
+	// runMu keeps snapshots from being written out of order by concurrent inputs
+	p.runMu.Lock()
+	defer p.runMu.Unlock()
+
+	p.mu.RLock()
+	snapshot := p.run.clone()
+	snapshot.FailedInputs = slices.Clone(p.failedInputs)
+	snapshot.FailedEventLogInputs = slices.Clone(p.failedEventLogs)
+	p.mu.RUnlock()
+
+	// Save even when the run was cancelled, so the progress made so far is kept
+	if err := p.runStore.Save(context.WithoutCancel(ctx), snapshot); err != nil {
+		p.logger.Warn("Could not save run state", utils.String("run_id", snapshot.RunID), utils.Err(err))
+	}
+}
@@ -0,0 +1,200 @@
+package workflows_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/workflows"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+func openTestRunStore(t *testing.T, path string) *workflows.RunStore {
+	t.Helper()
+	store, err := workflows.OpenRunStore(context.Background(), path)
+	require.NoError(t, err)
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+func TestRunStore(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success_SurvivesReopen", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "state.db")
+		store := openTestRunStore(t, path)
+
+		run := workflows.NewRunState("run-1", "dev")
+		run.CompletedNodes = []string{"authenticate", "create_index"}
+		run.SucceededInputs = []string{"sf_accounts"}
+		run.FailedInputs = []string{"sf_contacts"}
+		run.SucceededEventLogInputs = []string{"EventLog_Daily"}
+		run.FailedEventLogInputs = []string{"EventLog_Hourly"}
+		require.NoError(t, store.Save(ctx, run))
+		require.NoError(t, store.Close())
+
+		loaded, err := openTestRunStore(t, path).Load(ctx, "run-1", "dev")
+		require.NoError(t, err)
+		assert.Equal(t, run, loaded)
+	})
+
+	t.Run("Error_UnknownRun", func(t *testing.T) {
+		store := openTestRunStore(t, filepath.Join(t.TempDir(), "state.db"))
+		require.NoError(t, store.Save(ctx, workflows.NewRunState("run-1", "dev")))
+
+		_, err := store.Load(ctx, "run-1", "prod")
+		require.Error(t, err)
+		assert.True(t, errors.Is(err, workflows.ErrRunNotFound))
+	})
+
+	t.Run("Success_NewRunIDsAreUnique", func(t *testing.T) {
+		assert.NotEqual(t, workflows.NewRunID(), workflows.NewRunID())
+	})
+}
+
+func TestMigrationGraph_Resume(t *testing.T) {
+	ctx := context.Background()
+	store := openTestRunStore(t, filepath.Join(t.TempDir(), "state.db"))
+
+	config := &utils.Config{
+		Splunk:     utils.SplunkConfig{IndexName: "test_index"},
+		Salesforce: utils.SalesforceConfig{AccountName: "test_account"},
+		Migration: utils.MigrationConfig{
+			ConcurrentRequests: 1,
+			DashboardDirectory: t.TempDir(),
+		},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "input1", "object": "Account", "object_fields": "Id,Name"},
+				map[string]interface{}{"name": "input2", "object": "Contact", "object_fields": "Id,Name"},
+				map[string]interface{}{"name": "input3", "object": "Lead", "object_fields": "Id,Name"},
+			},
+		},
+	}
+
+	newSplunkService := func(failInput string) *mocks.MockSplunkService {
+		return &mocks.MockSplunkService{
+			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
+				if input.Name == failInput {
+					return errors.New("connection reset")
+				}
+				return nil
+			},
+		}
+	}
+
+	runAttempt := func(run *workflows.RunState, splunkService *mocks.MockSplunkService, dashboardErr error) (*mocks.MockDashboardService, error) {
+		dashboardService := &mocks.MockDashboardService{
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, dashboardErr
+			},
+		}
+		graph, err := workflows.NewResumableMigrationGraph(config, splunkService, dashboardService, store, run)
+		require.NoError(t, err)
+		return dashboardService, graph.Execute(ctx)
+	}
+
+	// Attempt 1: input2 fails, so the graph stops in create_data_inputs
+	first := newSplunkService("input2")
+	_, err := runAttempt(workflows.NewRunState("run-1", "dev"), first, nil)
+	require.Error(t, err)
+	assert.Equal(t, 3, first.CreateDataInputCalls)
+
+	run, err := store.Load(ctx, "run-1", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"lint_dashboards", "authenticate", "check_salesforce_addon", "create_index", "configure_addon_settings", "create_account", "load_data_inputs"}, run.CompletedNodes)
+	assert.ElementsMatch(t, []string{"input1", "input3"}, run.SucceededInputs)
+	assert.Equal(t, []string{"input2"}, run.FailedInputs)
+
+	// Attempt 2: only the failed input is retried; the run then crashes at create_dashboards
+	second := newSplunkService("")
+	dashboards, err := runAttempt(run, second, errors.New("dashboard upload failed"))
+	require.Error(t, err)
+	assert.Equal(t, 1, second.AuthenticateCalls)
+	assert.Equal(t, 0, second.CreateSalesforceAccountCalls)
+	assert.Equal(t, 1, second.CreateDataInputCalls)
+	assert.Equal(t, 1, dashboards.ReconcileDashboardsCalls)
+
+	run, err = store.Load(ctx, "run-1", "dev")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"input1", "input2", "input3"}, run.SucceededInputs)
+	assert.Empty(t, run.FailedInputs)
+	assert.NotContains(t, run.CompletedNodes, "create_dashboards")
+
+	// Attempt 3: everything but create_dashboards is skipped
+	third := newSplunkService("")
+	dashboards, err = runAttempt(run, third, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 0, third.CreateDataInputCalls)
+	assert.Equal(t, 0, third.CheckSalesforceAddonCalls)
+	assert.Equal(t, 1, dashboards.ReconcileDashboardsCalls)
+
+	run, err = store.Load(ctx, "run-1", "dev")
+	require.NoError(t, err)
+	assert.Contains(t, run.CompletedNodes, "create_dashboards")
+}
+
+func TestMigrationGraph_ResumeEventLogInputs(t *testing.T) {
+	ctx := context.Background()
+	store := openTestRunStore(t, filepath.Join(t.TempDir(), "state.db"))
+
+	config := &utils.Config{
+		Splunk:     utils.SplunkConfig{IndexName: "test_index"},
+		Salesforce: utils.SalesforceConfig{AccountName: "test_account"},
+		Migration:  utils.MigrationConfig{ConcurrentRequests: 1},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "input1", "object": "Account", "object_fields": "Id,Name"},
+			},
+			"EVENT_LOG_INPUTS": []interface{}{
+				map[string]interface{}{"name": "EventLog_Daily"},
+				map[string]interface{}{"name": "EventLog_Hourly"},
+			},
+		},
+	}
+
+	newSplunkService := func(failInput string) *mocks.MockSplunkService {
+		return &mocks.MockSplunkService{
+			CreateEventLogInputFunc: func(ctx context.Context, input *utils.EventLogInput) error {
+				if input.Name == failInput {
+					return errors.New("connection reset")
+				}
+				return nil
+			},
+		}
+	}
+	runAttempt := func(run *workflows.RunState, splunkService *mocks.MockSplunkService) error {
+		graph, err := workflows.NewResumableMigrationGraph(config, splunkService, &mocks.MockDashboardService{}, store, run)
+		require.NoError(t, err)
+		return graph.Execute(ctx)
+	}
+
+	// Attempt 1: an event log input fails; it is not saved as a failed data input
+	first := newSplunkService("EventLog_Hourly")
+	require.Error(t, runAttempt(workflows.NewRunState("run-1", "dev"), first))
+	assert.Equal(t, 2, first.CreateEventLogInputCalls)
+
+	run, err := store.Load(ctx, "run-1", "dev")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"input1"}, run.SucceededInputs)
+	assert.Empty(t, run.FailedInputs)
+	assert.Equal(t, []string{"EventLog_Daily"}, run.SucceededEventLogInputs)
+	assert.Equal(t, []string{"EventLog_Hourly"}, run.FailedEventLogInputs)
+
+	// Attempt 2: only the failed event log input is retried
+	second := newSplunkService("")
+	require.NoError(t, runAttempt(run, second))
+	assert.Equal(t, 0, second.CreateDataInputCalls)
+	assert.Equal(t, 1, second.CreateEventLogInputCalls)
+
+	run, err = store.Load(ctx, "run-1", "dev")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"EventLog_Daily", "EventLog_Hourly"}, run.SucceededEventLogInputs)
+	assert.Empty(t, run.FailedEventLogInputs)
+}
@@ -13,10 +13,8 @@ func main() {
 		panic("Failed to initialize logger: " + err.Error())
 	}
 
-	logger := utils.GetLogger()
-
-	if err := cmd.Execute(); err != nil {
-		logger.Error("Migration failed", utils.Err(err))
-		os.Exit(1)
+	if err := cmd.Run(os.Args[1:], os.Stdout); err != nil {
+		utils.GetLogger().Error("Command failed", utils.Err(err), utils.Int("exit_code", cmd.ExitCode(err)))
+		os.Exit(cmd.ExitCode(err))
 	}
 }
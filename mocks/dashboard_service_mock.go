@@ -3,30 +3,48 @@ package mocks
 import (
 	"context"
 	"sync"
+
+	"salesforce-splunk-migration/models"
 )
 
 // MockDashboardService is a mock implementation of DashboardServiceInterface
 type MockDashboardService struct {
-	CreateDashboardsFromDirectoryFunc  func(ctx context.Context, dashboardDir string) error
-	CreateDashboardsFromDirectoryCalls int
-	mu                                 sync.RWMutex
+	ReconcileDashboardsFunc  func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error)
+	ReconcileDashboardsCalls int
+	LintDashboardsFunc       func(ctx context.Context, dashboardDir string) (*models.DashboardLintResult, error)
+	LintDashboardsCalls      int
+	mu                       sync.RWMutex
+}
+
+// ReconcileDashboards mocks dashboard reconciliation. Without a custom func every
+// dashboard is reported unchanged, so the result has no outcomes.
+func (m *MockDashboardService) ReconcileDashboards(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+	m.mu.Lock()
+	m.ReconcileDashboardsCalls++
+	m.mu.Unlock()
+
+	if m.ReconcileDashboardsFunc != nil {
+		return m.ReconcileDashboardsFunc(ctx, dashboardDir)
+	}
+	return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
 }
 
-// CreateDashboardsFromDirectory mocks dashboard creation from directory
-func (m *MockDashboardService) CreateDashboardsFromDirectory(ctx context.Context, dashboardDir string) error {
+// LintDashboards mocks dashboard linting. Without a custom func no problems are found.
+func (m *MockDashboardService) LintDashboards(ctx context.Context, dashboardDir string) (*models.DashboardLintResult, error) {
 	m.mu.Lock()
-	m.CreateDashboardsFromDirectoryCalls++
+	m.LintDashboardsCalls++
 	m.mu.Unlock()
 
-	if m.CreateDashboardsFromDirectoryFunc != nil {
-		return m.CreateDashboardsFromDirectoryFunc(ctx, dashboardDir)
+	if m.LintDashboardsFunc != nil {
+		return m.LintDashboardsFunc(ctx, dashboardDir)
 	}
-	return nil
+	return &models.DashboardLintResult{Directory: dashboardDir}, nil
 }
 
 // Reset resets all call counters
 func (m *MockDashboardService) Reset() {
 	m.mu.Lock()
 	defer m.mu.Unlock()
-	m.CreateDashboardsFromDirectoryCalls = 0
+	m.ReconcileDashboardsCalls = 0
+	m.LintDashboardsCalls = 0
 }
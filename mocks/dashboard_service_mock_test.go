@@ -6,63 +6,67 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"salesforce-splunk-migration/models"
 )
 
-func TestMockDashboardService_CreateDashboardsFromDirectory(t *testing.T) {
+func TestMockDashboardService_ReconcileDashboards(t *testing.T) {
 	t.Run("Success_WithCustomFunc", func(t *testing.T) {
 		mock := &MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
 				assert.Equal(t, "/path/to/dashboards", dashboardDir)
-				return nil
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
 			},
 		}
 
-		err := mock.CreateDashboardsFromDirectory(context.Background(), "/path/to/dashboards")
+		_, err := mock.ReconcileDashboards(context.Background(), "/path/to/dashboards")
 		assert.NoError(t, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
 		mock := &MockDashboardService{}
 
-		err := mock.CreateDashboardsFromDirectory(context.Background(), "/path/to/dashboards")
+		result, err := mock.ReconcileDashboards(context.Background(), "/path/to/dashboards")
 		assert.NoError(t, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, "/path/to/dashboards", result.Directory)
+		assert.Empty(t, result.Outcomes)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Error_DirectoryNotFound", func(t *testing.T) {
 		expectedErr := errors.New("directory not found")
 		mock := &MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
-				return expectedErr
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return nil, expectedErr
 			},
 		}
 
-		err := mock.CreateDashboardsFromDirectory(context.Background(), "/nonexistent")
+		_, err := mock.ReconcileDashboards(context.Background(), "/nonexistent")
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_EmptyDirectory", func(t *testing.T) {
 		mock := &MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
 				assert.Empty(t, dashboardDir)
-				return nil
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
 			},
 		}
 
-		err := mock.CreateDashboardsFromDirectory(context.Background(), "")
+		_, err := mock.ReconcileDashboards(context.Background(), "")
 		assert.NoError(t, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_NilContext", func(t *testing.T) {
 		mock := &MockDashboardService{}
 
-		err := mock.CreateDashboardsFromDirectory(nil, "/path/to/dashboards")
+		_, err := mock.ReconcileDashboards(nil, "/path/to/dashboards")
 		assert.NoError(t, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_MultipleCalls_IncrementCounter", func(t *testing.T) {
@@ -70,24 +74,24 @@ func TestMockDashboardService_CreateDashboardsFromDirectory(t *testing.T) {
 
 		directories := []string{"/path1", "/path2", "/path3"}
 		for i, dir := range directories {
-			err := mock.CreateDashboardsFromDirectory(context.Background(), dir)
+			_, err := mock.ReconcileDashboards(context.Background(), dir)
 			assert.NoError(t, err)
-			assert.Equal(t, i+1, mock.CreateDashboardsFromDirectoryCalls)
+			assert.Equal(t, i+1, mock.ReconcileDashboardsCalls)
 		}
 	})
 
 	t.Run("Error_CustomFuncReturnsError", func(t *testing.T) {
-		expectedErr := errors.New("failed to create dashboards")
+		expectedErr := errors.New("failed to reconcile dashboards")
 		mock := &MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
-				return expectedErr
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return nil, expectedErr
 			},
 		}
 
-		err := mock.CreateDashboardsFromDirectory(context.Background(), "/path")
+		_, err := mock.ReconcileDashboards(context.Background(), "/path")
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_ConcurrentCalls_ThreadSafe", func(t *testing.T) {
@@ -96,7 +100,7 @@ func TestMockDashboardService_CreateDashboardsFromDirectory(t *testing.T) {
 		done := make(chan bool)
 		for i := 0; i < 10; i++ {
 			go func() {
-				_ = mock.CreateDashboardsFromDirectory(context.Background(), "/path")
+				_, _ = mock.ReconcileDashboards(context.Background(), "/path")
 				done <- true
 			}()
 		}
@@ -105,7 +109,36 @@ func TestMockDashboardService_CreateDashboardsFromDirectory(t *testing.T) {
 			<-done
 		}
 
-		assert.Equal(t, 10, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 10, mock.ReconcileDashboardsCalls)
+	})
+}
+
+func TestMockDashboardService_LintDashboards(t *testing.T) {
+	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
+		mock := &MockDashboardService{}
+
+		result, err := mock.LintDashboards(context.Background(), "/path/to/dashboards")
+		assert.NoError(t, err)
+		assert.Equal(t, "/path/to/dashboards", result.Directory)
+		assert.Empty(t, result.Problems)
+		assert.Equal(t, 1, mock.LintDashboardsCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.LintDashboardsCalls)
+	})
+
+	t.Run("Success_CustomFunc", func(t *testing.T) {
+		mock := &MockDashboardService{
+			LintDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardLintResult, error) {
+				return &models.DashboardLintResult{Directory: dashboardDir, Problems: []models.DashboardLintProblem{
+					{File: "home.xml", Line: 3, Severity: models.LintError, Message: "invalid XML"},
+				}}, nil
+			},
+		}
+
+		result, err := mock.LintDashboards(context.Background(), "/path")
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.Errors())
 	})
 }
 
@@ -115,32 +148,32 @@ func TestMockDashboardService_Reset(t *testing.T) {
 
 		// Call multiple times
 		for i := 0; i < 5; i++ {
-			_ = mock.CreateDashboardsFromDirectory(context.Background(), "/path")
+			_, _ = mock.ReconcileDashboards(context.Background(), "/path")
 		}
 
-		assert.Equal(t, 5, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 5, mock.ReconcileDashboardsCalls)
 
 		// Reset
 		mock.Reset()
 
 		// Verify counter is reset
-		assert.Equal(t, 0, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_MultipleResets", func(t *testing.T) {
 		mock := &MockDashboardService{}
 
 		// First call and reset
-		_ = mock.CreateDashboardsFromDirectory(context.Background(), "/path")
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		_, _ = mock.ReconcileDashboards(context.Background(), "/path")
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 		mock.Reset()
-		assert.Equal(t, 0, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mock.ReconcileDashboardsCalls)
 
 		// Second call and reset
-		_ = mock.CreateDashboardsFromDirectory(context.Background(), "/path")
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		_, _ = mock.ReconcileDashboards(context.Background(), "/path")
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 		mock.Reset()
-		assert.Equal(t, 0, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_ResetWithoutPriorCalls", func(t *testing.T) {
@@ -150,7 +183,7 @@ func TestMockDashboardService_Reset(t *testing.T) {
 		mock.Reset()
 
 		// Verify counter is zero
-		assert.Equal(t, 0, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mock.ReconcileDashboardsCalls)
 	})
 
 	t.Run("Success_ConcurrentReset_ThreadSafe", func(t *testing.T) {
@@ -158,7 +191,7 @@ func TestMockDashboardService_Reset(t *testing.T) {
 
 		// Call multiple times
 		for i := 0; i < 5; i++ {
-			_ = mock.CreateDashboardsFromDirectory(context.Background(), "/path")
+			_, _ = mock.ReconcileDashboards(context.Background(), "/path")
 		}
 
 		done := make(chan bool)
@@ -174,7 +207,7 @@ func TestMockDashboardService_Reset(t *testing.T) {
 			<-done
 		}
 
-		assert.Equal(t, 0, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 0, mock.ReconcileDashboardsCalls)
 	})
 }
 
@@ -182,33 +215,33 @@ func TestMockDashboardService_Integration(t *testing.T) {
 	t.Run("Success_CompleteWorkflow", func(t *testing.T) {
 		callOrder := []string{}
 		mock := &MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
 				callOrder = append(callOrder, dashboardDir)
-				return nil
+				return &models.DashboardReconcileResult{Directory: dashboardDir}, nil
 			},
 		}
 
 		ctx := context.Background()
 
 		// Execute workflow
-		_ = mock.CreateDashboardsFromDirectory(ctx, "/resources/dashboards")
-		_ = mock.CreateDashboardsFromDirectory(ctx, "/additional/dashboards")
+		_, _ = mock.ReconcileDashboards(ctx, "/resources/dashboards")
+		_, _ = mock.ReconcileDashboards(ctx, "/additional/dashboards")
 
-		assert.Equal(t, 2, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 2, mock.ReconcileDashboardsCalls)
 		assert.Equal(t, []string{"/resources/dashboards", "/additional/dashboards"}, callOrder)
 	})
 
 	t.Run("Error_WorkflowFailsOnFirstCall", func(t *testing.T) {
 		expectedErr := errors.New("first call failed")
 		mock := &MockDashboardService{
-			CreateDashboardsFromDirectoryFunc: func(ctx context.Context, dashboardDir string) error {
-				return expectedErr
+			ReconcileDashboardsFunc: func(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+				return nil, expectedErr
 			},
 		}
 
-		err := mock.CreateDashboardsFromDirectory(context.Background(), "/path")
+		_, err := mock.ReconcileDashboards(context.Background(), "/path")
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
-		assert.Equal(t, 1, mock.CreateDashboardsFromDirectoryCalls)
+		assert.Equal(t, 1, mock.ReconcileDashboardsCalls)
 	})
 }
@@ -4,6 +4,7 @@ package mocks
 import (
 	"context"
 
+	"salesforce-splunk-migration/models"
 	"salesforce-splunk-migration/utils"
 )
 
@@ -11,17 +12,39 @@ import (
 type MockSplunkService struct {
 	AuthenticateFunc                 func(ctx context.Context) error
 	GetAuthTokenFunc                 func() string
+	LogoutFunc                       func(ctx context.Context) error
+	HTTPStatsFunc                    func() utils.HTTPStats
 	CheckSalesforceAddonFunc         func(ctx context.Context) error
 	CreateIndexFunc                  func(ctx context.Context, indexName string) error
 	CheckIndexExistsFunc             func(ctx context.Context, indexName string) (bool, error)
 	UpdateIndexFunc                  func(ctx context.Context, indexName string) error
-	CreateSalesforceAccountFunc      func(ctx context.Context) error
-	CheckSalesforceAccountExistsFunc func(ctx context.Context) (bool, error)
-	UpdateSalesforceAccountFunc      func(ctx context.Context) error
+	CreateSalesforceAccountFunc      func(ctx context.Context, account *utils.SalesforceAccount) error
+	CheckSalesforceAccountExistsFunc func(ctx context.Context, accountName string) (bool, error)
+	GetSalesforceAccountFunc         func(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error)
+	UpdateSalesforceAccountFunc      func(ctx context.Context, account *utils.SalesforceAccount) error
+	ListSalesforceAccountsFunc       func(ctx context.Context) ([]*models.SalesforceAccountSettings, error)
 	CreateDataInputFunc              func(ctx context.Context, input *utils.DataInput) error
 	UpdateDataInputFunc              func(ctx context.Context, input *utils.DataInput) error
 	CheckDataInputExistsFunc         func(ctx context.Context, inputName string) (bool, error)
+	GetDataInputFunc                 func(ctx context.Context, inputName string) (*models.SFDCObjectInput, error)
+	DisableDataInputFunc             func(ctx context.Context, inputName string) error
+	DeleteDataInputFunc              func(ctx context.Context, inputName string) error
 	ListDataInputsFunc               func(ctx context.Context) ([]string, error)
+	ListDataInputDetailsFunc         func(ctx context.Context) ([]*models.SFDCObjectInput, error)
+	CreateEventLogInputFunc          func(ctx context.Context, input *utils.EventLogInput) error
+	UpdateEventLogInputFunc          func(ctx context.Context, input *utils.EventLogInput) error
+	CheckEventLogInputExistsFunc     func(ctx context.Context, inputName string) (bool, error)
+	GetEventLogInputFunc             func(ctx context.Context, inputName string) (*models.SFDCEventLogInput, error)
+	ListEventLogInputsFunc           func(ctx context.Context) ([]string, error)
+	GetProxySettingsFunc             func(ctx context.Context) (*models.ProxySettings, error)
+	UpdateProxySettingsFunc          func(ctx context.Context, proxy *utils.SalesforceProxyConfig) error
+	GetAddonLogLevelFunc             func(ctx context.Context) (string, error)
+	UpdateAddonLogLevelFunc          func(ctx context.Context, level string) error
+	ListDashboardsFunc               func(ctx context.Context, app string) ([]*models.Dashboard, error)
+	CreateDashboardFunc              func(ctx context.Context, dashboard *models.Dashboard) error
+	UpdateDashboardFunc              func(ctx context.Context, dashboard *models.Dashboard) error
+	DeleteDashboardFunc              func(ctx context.Context, dashboard *models.Dashboard) error
+	SetDashboardACLFunc              func(ctx context.Context, dashboard *models.Dashboard, acl utils.DashboardACL) error
 
 	// Mock data
 	AuthTokenValue    string
@@ -30,17 +53,39 @@ type MockSplunkService struct {
 	// Call tracking
 	AuthenticateCalls                 int
 	GetAuthTokenCalls                 int
+	LogoutCalls                       int
+	HTTPStatsCalls                    int
 	CheckSalesforceAddonCalls         int
 	CreateIndexCalls                  int
 	CheckIndexExistsCalls             int
 	UpdateIndexCalls                  int
 	CreateSalesforceAccountCalls      int
 	CheckSalesforceAccountExistsCalls int
+	GetSalesforceAccountCalls         int
 	UpdateSalesforceAccountCalls      int
+	ListSalesforceAccountsCalls       int
 	CreateDataInputCalls              int
 	UpdateDataInputCalls              int
 	CheckDataInputExistsCalls         int
+	GetDataInputCalls                 int
+	DisableDataInputCalls             int
+	DeleteDataInputCalls              int
 	ListDataInputsCalls               int
+	ListDataInputDetailsCalls         int
+	CreateEventLogInputCalls          int
+	UpdateEventLogInputCalls          int
+	CheckEventLogInputExistsCalls     int
+	GetEventLogInputCalls             int
+	ListEventLogInputsCalls           int
+	GetProxySettingsCalls             int
+	UpdateProxySettingsCalls          int
+	GetAddonLogLevelCalls             int
+	UpdateAddonLogLevelCalls          int
+	ListDashboardsCalls               int
+	CreateDashboardCalls              int
+	UpdateDashboardCalls              int
+	DeleteDashboardCalls              int
+	SetDashboardACLCalls              int
 }
 
 // Authenticate mocks authentication
@@ -64,6 +109,24 @@ func (m *MockSplunkService) GetAuthToken() string {
 	return "mock-token"
 }
 
+// Logout mocks token revocation
+func (m *MockSplunkService) Logout(ctx context.Context) error {
+	m.LogoutCalls++
+	if m.LogoutFunc != nil {
+		return m.LogoutFunc(ctx)
+	}
+	return nil
+}
+
+// HTTPStats mocks the HTTP client counters
+func (m *MockSplunkService) HTTPStats() utils.HTTPStats {
+	m.HTTPStatsCalls++
+	if m.HTTPStatsFunc != nil {
+		return m.HTTPStatsFunc()
+	}
+	return utils.HTTPStats{}
+}
+
 // CheckSalesforceAddon mocks addon check
 func (m *MockSplunkService) CheckSalesforceAddon(ctx context.Context) error {
 	m.CheckSalesforceAddonCalls++
@@ -101,32 +164,50 @@ func (m *MockSplunkService) UpdateIndex(ctx context.Context, indexName string) e
 }
 
 // CreateSalesforceAccount mocks account creation
-func (m *MockSplunkService) CreateSalesforceAccount(ctx context.Context) error {
+func (m *MockSplunkService) CreateSalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error {
 	m.CreateSalesforceAccountCalls++
 	if m.CreateSalesforceAccountFunc != nil {
-		return m.CreateSalesforceAccountFunc(ctx)
+		return m.CreateSalesforceAccountFunc(ctx, account)
 	}
 	return nil
 }
 
 // CheckSalesforceAccountExists mocks account existence check
-func (m *MockSplunkService) CheckSalesforceAccountExists(ctx context.Context) (bool, error) {
+func (m *MockSplunkService) CheckSalesforceAccountExists(ctx context.Context, accountName string) (bool, error) {
 	m.CheckSalesforceAccountExistsCalls++
 	if m.CheckSalesforceAccountExistsFunc != nil {
-		return m.CheckSalesforceAccountExistsFunc(ctx)
+		return m.CheckSalesforceAccountExistsFunc(ctx, accountName)
 	}
 	return false, nil
 }
 
+// GetSalesforceAccount mocks fetching a Salesforce account's settings
+func (m *MockSplunkService) GetSalesforceAccount(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error) {
+	m.GetSalesforceAccountCalls++
+	if m.GetSalesforceAccountFunc != nil {
+		return m.GetSalesforceAccountFunc(ctx, accountName)
+	}
+	return nil, nil
+}
+
 // UpdateSalesforceAccount mocks account update
-func (m *MockSplunkService) UpdateSalesforceAccount(ctx context.Context) error {
+func (m *MockSplunkService) UpdateSalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error {
 	m.UpdateSalesforceAccountCalls++
 	if m.UpdateSalesforceAccountFunc != nil {
-		return m.UpdateSalesforceAccountFunc(ctx)
+		return m.UpdateSalesforceAccountFunc(ctx, account)
 	}
 	return nil
 }
 
+// ListSalesforceAccounts mocks listing Salesforce accounts
+func (m *MockSplunkService) ListSalesforceAccounts(ctx context.Context) ([]*models.SalesforceAccountSettings, error) {
+	m.ListSalesforceAccountsCalls++
+	if m.ListSalesforceAccountsFunc != nil {
+		return m.ListSalesforceAccountsFunc(ctx)
+	}
+	return []*models.SalesforceAccountSettings{}, nil
+}
+
 // CreateDataInput mocks data input creation
 func (m *MockSplunkService) CreateDataInput(ctx context.Context, input *utils.DataInput) error {
 	m.CreateDataInputCalls++
@@ -154,6 +235,33 @@ func (m *MockSplunkService) CheckDataInputExists(ctx context.Context, inputName
 	return false, nil
 }
 
+// GetDataInput mocks fetching a data input's configuration
+func (m *MockSplunkService) GetDataInput(ctx context.Context, inputName string) (*models.SFDCObjectInput, error) {
+	m.GetDataInputCalls++
+	if m.GetDataInputFunc != nil {
+		return m.GetDataInputFunc(ctx, inputName)
+	}
+	return nil, nil
+}
+
+// DisableDataInput mocks disabling a data input
+func (m *MockSplunkService) DisableDataInput(ctx context.Context, inputName string) error {
+	m.DisableDataInputCalls++
+	if m.DisableDataInputFunc != nil {
+		return m.DisableDataInputFunc(ctx, inputName)
+	}
+	return nil
+}
+
+// DeleteDataInput mocks deleting a data input
+func (m *MockSplunkService) DeleteDataInput(ctx context.Context, inputName string) error {
+	m.DeleteDataInputCalls++
+	if m.DeleteDataInputFunc != nil {
+		return m.DeleteDataInputFunc(ctx, inputName)
+	}
+	return nil
+}
+
 // ListDataInputs mocks listing data inputs
 func (m *MockSplunkService) ListDataInputs(ctx context.Context) ([]string, error) {
 	m.ListDataInputsCalls++
@@ -163,15 +271,172 @@ func (m *MockSplunkService) ListDataInputs(ctx context.Context) ([]string, error
 	return []string{}, nil
 }
 
+// ListDataInputDetails mocks listing data inputs with their configuration
+func (m *MockSplunkService) ListDataInputDetails(ctx context.Context) ([]*models.SFDCObjectInput, error) {
+	m.ListDataInputDetailsCalls++
+	if m.ListDataInputDetailsFunc != nil {
+		return m.ListDataInputDetailsFunc(ctx)
+	}
+	return []*models.SFDCObjectInput{}, nil
+}
+
+// CreateEventLogInput mocks Event Log File input creation
+func (m *MockSplunkService) CreateEventLogInput(ctx context.Context, input *utils.EventLogInput) error {
+	m.CreateEventLogInputCalls++
+	if m.CreateEventLogInputFunc != nil {
+		return m.CreateEventLogInputFunc(ctx, input)
+	}
+	return nil
+}
+
+// UpdateEventLogInput mocks Event Log File input update
+func (m *MockSplunkService) UpdateEventLogInput(ctx context.Context, input *utils.EventLogInput) error {
+	m.UpdateEventLogInputCalls++
+	if m.UpdateEventLogInputFunc != nil {
+		return m.UpdateEventLogInputFunc(ctx, input)
+	}
+	return nil
+}
+
+// CheckEventLogInputExists mocks Event Log File input existence check
+func (m *MockSplunkService) CheckEventLogInputExists(ctx context.Context, inputName string) (bool, error) {
+	m.CheckEventLogInputExistsCalls++
+	if m.CheckEventLogInputExistsFunc != nil {
+		return m.CheckEventLogInputExistsFunc(ctx, inputName)
+	}
+	return false, nil
+}
+
+// GetEventLogInput mocks fetching an Event Log File input's configuration
+func (m *MockSplunkService) GetEventLogInput(ctx context.Context, inputName string) (*models.SFDCEventLogInput, error) {
+	m.GetEventLogInputCalls++
+	if m.GetEventLogInputFunc != nil {
+		return m.GetEventLogInputFunc(ctx, inputName)
+	}
+	return nil, nil
+}
+
+// ListEventLogInputs mocks listing Event Log File inputs
+func (m *MockSplunkService) ListEventLogInputs(ctx context.Context) ([]string, error) {
+	m.ListEventLogInputsCalls++
+	if m.ListEventLogInputsFunc != nil {
+		return m.ListEventLogInputsFunc(ctx)
+	}
+	return []string{}, nil
+}
+
+// GetProxySettings mocks reading the add-on proxy settings
+func (m *MockSplunkService) GetProxySettings(ctx context.Context) (*models.ProxySettings, error) {
+	m.GetProxySettingsCalls++
+	if m.GetProxySettingsFunc != nil {
+		return m.GetProxySettingsFunc(ctx)
+	}
+	return &models.ProxySettings{}, nil
+}
+
+// UpdateProxySettings mocks updating the add-on proxy settings
+func (m *MockSplunkService) UpdateProxySettings(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+	m.UpdateProxySettingsCalls++
+	if m.UpdateProxySettingsFunc != nil {
+		return m.UpdateProxySettingsFunc(ctx, proxy)
+	}
+	return nil
+}
+
+// GetAddonLogLevel mocks reading the add-on log level
+func (m *MockSplunkService) GetAddonLogLevel(ctx context.Context) (string, error) {
+	m.GetAddonLogLevelCalls++
+	if m.GetAddonLogLevelFunc != nil {
+		return m.GetAddonLogLevelFunc(ctx)
+	}
+	return "INFO", nil
+}
+
+// UpdateAddonLogLevel mocks updating the add-on log level
+func (m *MockSplunkService) UpdateAddonLogLevel(ctx context.Context, level string) error {
+	m.UpdateAddonLogLevelCalls++
+	if m.UpdateAddonLogLevelFunc != nil {
+		return m.UpdateAddonLogLevelFunc(ctx, level)
+	}
+	return nil
+}
+
+// ListDashboards mocks listing the dashboards of an app
+func (m *MockSplunkService) ListDashboards(ctx context.Context, app string) ([]*models.Dashboard, error) {
+	m.ListDashboardsCalls++
+	if m.ListDashboardsFunc != nil {
+		return m.ListDashboardsFunc(ctx, app)
+	}
+	return []*models.Dashboard{}, nil
+}
+
+// CreateDashboard mocks dashboard creation
+func (m *MockSplunkService) CreateDashboard(ctx context.Context, dashboard *models.Dashboard) error {
+	m.CreateDashboardCalls++
+	if m.CreateDashboardFunc != nil {
+		return m.CreateDashboardFunc(ctx, dashboard)
+	}
+	return nil
+}
+
+// UpdateDashboard mocks dashboard update
+func (m *MockSplunkService) UpdateDashboard(ctx context.Context, dashboard *models.Dashboard) error {
+	m.UpdateDashboardCalls++
+	if m.UpdateDashboardFunc != nil {
+		return m.UpdateDashboardFunc(ctx, dashboard)
+	}
+	return nil
+}
+
+// DeleteDashboard mocks dashboard deletion
+func (m *MockSplunkService) DeleteDashboard(ctx context.Context, dashboard *models.Dashboard) error {
+	m.DeleteDashboardCalls++
+	if m.DeleteDashboardFunc != nil {
+		return m.DeleteDashboardFunc(ctx, dashboard)
+	}
+	return nil
+}
+
+// SetDashboardACL mocks setting the permissions of a dashboard
+func (m *MockSplunkService) SetDashboardACL(ctx context.Context, dashboard *models.Dashboard, acl utils.DashboardACL) error {
+	m.SetDashboardACLCalls++
+	if m.SetDashboardACLFunc != nil {
+		return m.SetDashboardACLFunc(ctx, dashboard, acl)
+	}
+	return nil
+}
+
 // Reset resets all call counters
 func (m *MockSplunkService) Reset() {
 	m.AuthenticateCalls = 0
+	m.LogoutCalls = 0
+	m.HTTPStatsCalls = 0
 	m.CheckSalesforceAddonCalls = 0
 	m.CreateIndexCalls = 0
 	m.CreateSalesforceAccountCalls = 0
 	m.UpdateSalesforceAccountCalls = 0
+	m.ListSalesforceAccountsCalls = 0
+	m.GetSalesforceAccountCalls = 0
 	m.CreateDataInputCalls = 0
 	m.UpdateDataInputCalls = 0
 	m.CheckDataInputExistsCalls = 0
+	m.GetDataInputCalls = 0
+	m.DisableDataInputCalls = 0
+	m.DeleteDataInputCalls = 0
 	m.ListDataInputsCalls = 0
+	m.ListDataInputDetailsCalls = 0
+	m.CreateEventLogInputCalls = 0
+	m.UpdateEventLogInputCalls = 0
+	m.CheckEventLogInputExistsCalls = 0
+	m.GetEventLogInputCalls = 0
+	m.ListEventLogInputsCalls = 0
+	m.GetProxySettingsCalls = 0
+	m.UpdateProxySettingsCalls = 0
+	m.GetAddonLogLevelCalls = 0
+	m.UpdateAddonLogLevelCalls = 0
+	m.ListDashboardsCalls = 0
+	m.CreateDashboardCalls = 0
+	m.UpdateDashboardCalls = 0
+	m.DeleteDashboardCalls = 0
+	m.SetDashboardACLCalls = 0
 }
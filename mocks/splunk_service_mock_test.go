@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"salesforce-splunk-migration/models"
 	"salesforce-splunk-migration/utils"
 )
 
@@ -191,12 +192,12 @@ func TestMockSplunkService_CreateIndex(t *testing.T) {
 func TestMockSplunkService_CreateSalesforceAccount(t *testing.T) {
 	t.Run("Success_WithCustomFunc", func(t *testing.T) {
 		mock := &MockSplunkService{
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 		}
 
-		err := mock.CreateSalesforceAccount(context.Background())
+		err := mock.CreateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, mock.CreateSalesforceAccountCalls)
 	})
@@ -204,7 +205,7 @@ func TestMockSplunkService_CreateSalesforceAccount(t *testing.T) {
 	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
 		mock := &MockSplunkService{}
 
-		err := mock.CreateSalesforceAccount(context.Background())
+		err := mock.CreateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, mock.CreateSalesforceAccountCalls)
 	})
@@ -212,12 +213,12 @@ func TestMockSplunkService_CreateSalesforceAccount(t *testing.T) {
 	t.Run("Error_AccountCreationFailed", func(t *testing.T) {
 		expectedErr := errors.New("account creation failed")
 		mock := &MockSplunkService{
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return expectedErr
 			},
 		}
 
-		err := mock.CreateSalesforceAccount(context.Background())
+		err := mock.CreateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 		assert.Equal(t, 1, mock.CreateSalesforceAccountCalls)
@@ -226,7 +227,7 @@ func TestMockSplunkService_CreateSalesforceAccount(t *testing.T) {
 	t.Run("NilContext_HandledGracefully", func(t *testing.T) {
 		mock := &MockSplunkService{}
 
-		err := mock.CreateSalesforceAccount(nil)
+		err := mock.CreateSalesforceAccount(nil, &utils.SalesforceAccount{Name: "test_account"})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, mock.CreateSalesforceAccountCalls)
 	})
@@ -235,7 +236,7 @@ func TestMockSplunkService_CreateSalesforceAccount(t *testing.T) {
 		mock := &MockSplunkService{}
 
 		for i := 1; i <= 4; i++ {
-			err := mock.CreateSalesforceAccount(context.Background())
+			err := mock.CreateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 			assert.NoError(t, err)
 			assert.Equal(t, i, mock.CreateSalesforceAccountCalls)
 		}
@@ -416,7 +417,7 @@ func TestMockSplunkService_Reset(t *testing.T) {
 		_ = mock.CreateIndex(context.Background(), "test")
 		_ = mock.CreateIndex(context.Background(), "test2")
 		_ = mock.CreateIndex(context.Background(), "test3")
-		_ = mock.CreateSalesforceAccount(context.Background())
+		_ = mock.CreateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		_ = mock.CreateDataInput(context.Background(), &utils.DataInput{})
 		_, _ = mock.ListDataInputs(context.Background())
 		_, _ = mock.ListDataInputs(context.Background())
@@ -491,7 +492,7 @@ func TestMockSplunkService_Integration(t *testing.T) {
 				require.NotEmpty(t, indexName)
 				return nil
 			},
-			CreateSalesforceAccountFunc: func(ctx context.Context) error {
+			CreateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 			CreateDataInputFunc: func(ctx context.Context, input *utils.DataInput) error {
@@ -515,7 +516,7 @@ func TestMockSplunkService_Integration(t *testing.T) {
 		err = mock.CreateIndex(ctx, "salesforce_index")
 		assert.NoError(t, err)
 
-		err = mock.CreateSalesforceAccount(ctx)
+		err = mock.CreateSalesforceAccount(ctx, &utils.SalesforceAccount{Name: "test_account"})
 		assert.NoError(t, err)
 
 		err = mock.CreateDataInput(ctx, &utils.DataInput{Name: "Test", Object: "Account"})
@@ -728,12 +729,12 @@ func TestMockSplunkService_UpdateIndex(t *testing.T) {
 func TestMockSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 	t.Run("Success_AccountExists", func(t *testing.T) {
 		mock := &MockSplunkService{
-			CheckSalesforceAccountExistsFunc: func(ctx context.Context) (bool, error) {
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
 				return true, nil
 			},
 		}
 
-		exists, err := mock.CheckSalesforceAccountExists(context.Background())
+		exists, err := mock.CheckSalesforceAccountExists(context.Background(), "test_account")
 		assert.NoError(t, err)
 		assert.True(t, exists)
 		assert.Equal(t, 1, mock.CheckSalesforceAccountExistsCalls)
@@ -741,12 +742,12 @@ func TestMockSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 
 	t.Run("Success_AccountDoesNotExist", func(t *testing.T) {
 		mock := &MockSplunkService{
-			CheckSalesforceAccountExistsFunc: func(ctx context.Context) (bool, error) {
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
 				return false, nil
 			},
 		}
 
-		exists, err := mock.CheckSalesforceAccountExists(context.Background())
+		exists, err := mock.CheckSalesforceAccountExists(context.Background(), "test_account")
 		assert.NoError(t, err)
 		assert.False(t, exists)
 		assert.Equal(t, 1, mock.CheckSalesforceAccountExistsCalls)
@@ -755,7 +756,7 @@ func TestMockSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 	t.Run("Success_WithoutCustomFunc_ReturnsFalse", func(t *testing.T) {
 		mock := &MockSplunkService{}
 
-		exists, err := mock.CheckSalesforceAccountExists(context.Background())
+		exists, err := mock.CheckSalesforceAccountExists(context.Background(), "test_account")
 		assert.NoError(t, err)
 		assert.False(t, exists)
 		assert.Equal(t, 1, mock.CheckSalesforceAccountExistsCalls)
@@ -764,12 +765,12 @@ func TestMockSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 	t.Run("Error_CheckFailed", func(t *testing.T) {
 		expectedErr := errors.New("check failed")
 		mock := &MockSplunkService{
-			CheckSalesforceAccountExistsFunc: func(ctx context.Context) (bool, error) {
+			CheckSalesforceAccountExistsFunc: func(ctx context.Context, accountName string) (bool, error) {
 				return false, expectedErr
 			},
 		}
 
-		exists, err := mock.CheckSalesforceAccountExists(context.Background())
+		exists, err := mock.CheckSalesforceAccountExists(context.Background(), "test_account")
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 		assert.False(t, exists)
@@ -780,7 +781,7 @@ func TestMockSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 		mock := &MockSplunkService{}
 
 		for i := 1; i <= 4; i++ {
-			exists, err := mock.CheckSalesforceAccountExists(context.Background())
+			exists, err := mock.CheckSalesforceAccountExists(context.Background(), "test_account")
 			assert.NoError(t, err)
 			assert.False(t, exists)
 			assert.Equal(t, i, mock.CheckSalesforceAccountExistsCalls)
@@ -791,12 +792,12 @@ func TestMockSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 func TestMockSplunkService_UpdateSalesforceAccount(t *testing.T) {
 	t.Run("Success_WithCustomFunc", func(t *testing.T) {
 		mock := &MockSplunkService{
-			UpdateSalesforceAccountFunc: func(ctx context.Context) error {
+			UpdateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return nil
 			},
 		}
 
-		err := mock.UpdateSalesforceAccount(context.Background())
+		err := mock.UpdateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, mock.UpdateSalesforceAccountCalls)
 	})
@@ -804,7 +805,7 @@ func TestMockSplunkService_UpdateSalesforceAccount(t *testing.T) {
 	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
 		mock := &MockSplunkService{}
 
-		err := mock.UpdateSalesforceAccount(context.Background())
+		err := mock.UpdateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		assert.NoError(t, err)
 		assert.Equal(t, 1, mock.UpdateSalesforceAccountCalls)
 	})
@@ -812,12 +813,12 @@ func TestMockSplunkService_UpdateSalesforceAccount(t *testing.T) {
 	t.Run("Error_UpdateFailed", func(t *testing.T) {
 		expectedErr := errors.New("update account failed")
 		mock := &MockSplunkService{
-			UpdateSalesforceAccountFunc: func(ctx context.Context) error {
+			UpdateSalesforceAccountFunc: func(ctx context.Context, account *utils.SalesforceAccount) error {
 				return expectedErr
 			},
 		}
 
-		err := mock.UpdateSalesforceAccount(context.Background())
+		err := mock.UpdateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 		assert.Error(t, err)
 		assert.Equal(t, expectedErr, err)
 		assert.Equal(t, 1, mock.UpdateSalesforceAccountCalls)
@@ -827,7 +828,7 @@ func TestMockSplunkService_UpdateSalesforceAccount(t *testing.T) {
 		mock := &MockSplunkService{}
 
 		for i := 1; i <= 3; i++ {
-			err := mock.UpdateSalesforceAccount(context.Background())
+			err := mock.UpdateSalesforceAccount(context.Background(), &utils.SalesforceAccount{Name: "test_account"})
 			assert.NoError(t, err)
 			assert.Equal(t, i, mock.UpdateSalesforceAccountCalls)
 		}
@@ -977,3 +978,294 @@ func TestMockSplunkService_CheckDataInputExists(t *testing.T) {
 		}
 	})
 }
+
+func TestMockSplunkService_GetDataInput(t *testing.T) {
+	t.Run("Success_WithCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{
+			GetDataInputFunc: func(ctx context.Context, inputName string) (*models.SFDCObjectInput, error) {
+				return &models.SFDCObjectInput{Name: inputName, Interval: 300}, nil
+			},
+		}
+
+		input, err := mock.GetDataInput(context.Background(), "Test_Input")
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Equal(t, "Test_Input", input.Name)
+		assert.Equal(t, 1, mock.GetDataInputCalls)
+	})
+
+	t.Run("Success_WithoutCustomFunc_ReturnsNil", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		input, err := mock.GetDataInput(context.Background(), "Test_Input")
+		assert.NoError(t, err)
+		assert.Nil(t, input)
+		assert.Equal(t, 1, mock.GetDataInputCalls)
+	})
+
+	t.Run("Error_GetFailed", func(t *testing.T) {
+		expectedErr := errors.New("get failed")
+		mock := &MockSplunkService{
+			GetDataInputFunc: func(ctx context.Context, inputName string) (*models.SFDCObjectInput, error) {
+				return nil, expectedErr
+			},
+		}
+
+		input, err := mock.GetDataInput(context.Background(), "Test_Input")
+		assert.Equal(t, expectedErr, err)
+		assert.Nil(t, input)
+	})
+}
+
+func TestMockSplunkService_GetSalesforceAccount(t *testing.T) {
+	t.Run("Success_WithoutCustomFunc_ReturnsNil", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		account, err := mock.GetSalesforceAccount(context.Background(), "sf_prod")
+		assert.NoError(t, err)
+		assert.Nil(t, account)
+		assert.Equal(t, 1, mock.GetSalesforceAccountCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.GetSalesforceAccountCalls)
+	})
+
+	t.Run("Success_WithCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{
+			GetSalesforceAccountFunc: func(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error) {
+				return &models.SalesforceAccountSettings{Name: accountName, ClientID: "client-id"}, nil
+			},
+		}
+
+		account, err := mock.GetSalesforceAccount(context.Background(), "sf_prod")
+		require.NoError(t, err)
+		assert.Equal(t, "client-id", account.ClientID)
+	})
+}
+
+func TestMockSplunkService_GetEventLogInput(t *testing.T) {
+	t.Run("Success_WithoutCustomFunc_ReturnsNil", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		input, err := mock.GetEventLogInput(context.Background(), "sf_event_logs")
+		assert.NoError(t, err)
+		assert.Nil(t, input)
+		assert.Equal(t, 1, mock.GetEventLogInputCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.GetEventLogInputCalls)
+	})
+
+	t.Run("Success_WithCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{
+			GetEventLogInputFunc: func(ctx context.Context, inputName string) (*models.SFDCEventLogInput, error) {
+				return &models.SFDCEventLogInput{Name: inputName, Interval: 3600}, nil
+			},
+		}
+
+		input, err := mock.GetEventLogInput(context.Background(), "sf_event_logs")
+		require.NoError(t, err)
+		assert.Equal(t, 3600, input.Interval)
+	})
+}
+
+func TestMockSplunkService_ExportMethods(t *testing.T) {
+	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		inputs, err := mock.ListDataInputDetails(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, inputs)
+		accounts, err := mock.ListSalesforceAccounts(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, accounts)
+		assert.Equal(t, 1, mock.ListDataInputDetailsCalls)
+		assert.Equal(t, 1, mock.ListSalesforceAccountsCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.ListDataInputDetailsCalls)
+		assert.Equal(t, 0, mock.ListSalesforceAccountsCalls)
+	})
+
+	t.Run("Success_WithCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{
+			ListDataInputDetailsFunc: func(ctx context.Context) ([]*models.SFDCObjectInput, error) {
+				return []*models.SFDCObjectInput{{Name: "Test_Input"}}, nil
+			},
+			ListSalesforceAccountsFunc: func(ctx context.Context) ([]*models.SalesforceAccountSettings, error) {
+				return nil, errors.New("list failed")
+			},
+		}
+
+		inputs, err := mock.ListDataInputDetails(context.Background())
+		require.NoError(t, err)
+		require.Len(t, inputs, 1)
+		assert.Equal(t, "Test_Input", inputs[0].Name)
+
+		_, err = mock.ListSalesforceAccounts(context.Background())
+		assert.EqualError(t, err, "list failed")
+	})
+}
+
+func TestMockSplunkService_PruneMethods(t *testing.T) {
+	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		assert.NoError(t, mock.DisableDataInput(context.Background(), "Test_Input"))
+		assert.NoError(t, mock.DeleteDataInput(context.Background(), "Test_Input"))
+		assert.Equal(t, 1, mock.DisableDataInputCalls)
+		assert.Equal(t, 1, mock.DeleteDataInputCalls)
+	})
+
+	t.Run("Error_CustomFuncReturnsError", func(t *testing.T) {
+		expectedErr := errors.New("prune failed")
+		mock := &MockSplunkService{
+			DisableDataInputFunc: func(ctx context.Context, inputName string) error { return expectedErr },
+			DeleteDataInputFunc:  func(ctx context.Context, inputName string) error { return expectedErr },
+		}
+
+		assert.Equal(t, expectedErr, mock.DisableDataInput(context.Background(), "Test_Input"))
+		assert.Equal(t, expectedErr, mock.DeleteDataInput(context.Background(), "Test_Input"))
+	})
+}
+
+func TestMockSplunkService_EventLogInputMethods(t *testing.T) {
+	input := &utils.EventLogInput{Name: "EventLog_Daily", MonitoringInterval: "Daily"}
+
+	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		assert.NoError(t, mock.CreateEventLogInput(context.Background(), input))
+		assert.NoError(t, mock.UpdateEventLogInput(context.Background(), input))
+		exists, err := mock.CheckEventLogInputExists(context.Background(), input.Name)
+		assert.NoError(t, err)
+		assert.False(t, exists)
+		names, err := mock.ListEventLogInputs(context.Background())
+		assert.NoError(t, err)
+		assert.Empty(t, names)
+
+		assert.Equal(t, 1, mock.CreateEventLogInputCalls)
+		assert.Equal(t, 1, mock.UpdateEventLogInputCalls)
+		assert.Equal(t, 1, mock.CheckEventLogInputExistsCalls)
+		assert.Equal(t, 1, mock.ListEventLogInputsCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.CreateEventLogInputCalls)
+		assert.Equal(t, 0, mock.UpdateEventLogInputCalls)
+		assert.Equal(t, 0, mock.CheckEventLogInputExistsCalls)
+		assert.Equal(t, 0, mock.ListEventLogInputsCalls)
+	})
+
+	t.Run("Error_CustomFuncReturnsError", func(t *testing.T) {
+		expectedErr := errors.New("event log failed")
+		mock := &MockSplunkService{
+			CreateEventLogInputFunc: func(ctx context.Context, input *utils.EventLogInput) error { return expectedErr },
+			UpdateEventLogInputFunc: func(ctx context.Context, input *utils.EventLogInput) error { return expectedErr },
+			CheckEventLogInputExistsFunc: func(ctx context.Context, inputName string) (bool, error) {
+				return false, expectedErr
+			},
+			ListEventLogInputsFunc: func(ctx context.Context) ([]string, error) { return nil, expectedErr },
+		}
+
+		assert.Equal(t, expectedErr, mock.CreateEventLogInput(context.Background(), input))
+		assert.Equal(t, expectedErr, mock.UpdateEventLogInput(context.Background(), input))
+		_, err := mock.CheckEventLogInputExists(context.Background(), input.Name)
+		assert.Equal(t, expectedErr, err)
+		_, err = mock.ListEventLogInputs(context.Background())
+		assert.Equal(t, expectedErr, err)
+	})
+}
+
+func TestMockSplunkService_AddonSettingsMethods(t *testing.T) {
+	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		settings, err := mock.GetProxySettings(context.Background())
+		assert.NoError(t, err)
+		assert.NotNil(t, settings)
+		assert.NoError(t, mock.UpdateProxySettings(context.Background(), &utils.SalesforceProxyConfig{}))
+		level, err := mock.GetAddonLogLevel(context.Background())
+		assert.NoError(t, err)
+		assert.Equal(t, "INFO", level)
+		assert.NoError(t, mock.UpdateAddonLogLevel(context.Background(), "DEBUG"))
+
+		assert.Equal(t, 1, mock.GetProxySettingsCalls)
+		assert.Equal(t, 1, mock.UpdateProxySettingsCalls)
+		assert.Equal(t, 1, mock.GetAddonLogLevelCalls)
+		assert.Equal(t, 1, mock.UpdateAddonLogLevelCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.GetProxySettingsCalls)
+		assert.Equal(t, 0, mock.UpdateProxySettingsCalls)
+		assert.Equal(t, 0, mock.GetAddonLogLevelCalls)
+		assert.Equal(t, 0, mock.UpdateAddonLogLevelCalls)
+	})
+
+	t.Run("Error_CustomFuncReturnsError", func(t *testing.T) {
+		expectedErr := errors.New("settings failed")
+		mock := &MockSplunkService{
+			GetProxySettingsFunc: func(ctx context.Context) (*models.ProxySettings, error) { return nil, expectedErr },
+			UpdateProxySettingsFunc: func(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+				return expectedErr
+			},
+			GetAddonLogLevelFunc:    func(ctx context.Context) (string, error) { return "", expectedErr },
+			UpdateAddonLogLevelFunc: func(ctx context.Context, level string) error { return expectedErr },
+		}
+
+		_, err := mock.GetProxySettings(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, expectedErr, mock.UpdateProxySettings(context.Background(), &utils.SalesforceProxyConfig{}))
+		_, err = mock.GetAddonLogLevel(context.Background())
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, expectedErr, mock.UpdateAddonLogLevel(context.Background(), "DEBUG"))
+	})
+}
+
+func TestMockSplunkService_DashboardMethods(t *testing.T) {
+	dashboard := &models.Dashboard{Name: "home", App: "search", Data: "<dashboard/>"}
+
+	t.Run("Success_WithoutCustomFunc", func(t *testing.T) {
+		mock := &MockSplunkService{}
+
+		dashboards, err := mock.ListDashboards(context.Background(), "search")
+		assert.NoError(t, err)
+		assert.Empty(t, dashboards)
+		assert.NoError(t, mock.CreateDashboard(context.Background(), dashboard))
+		assert.NoError(t, mock.UpdateDashboard(context.Background(), dashboard))
+		assert.NoError(t, mock.DeleteDashboard(context.Background(), dashboard))
+		assert.NoError(t, mock.SetDashboardACL(context.Background(), dashboard, utils.DashboardACL{Owner: "nobody", Sharing: utils.SharingApp}))
+
+		assert.Equal(t, 1, mock.ListDashboardsCalls)
+		assert.Equal(t, 1, mock.CreateDashboardCalls)
+		assert.Equal(t, 1, mock.UpdateDashboardCalls)
+		assert.Equal(t, 1, mock.DeleteDashboardCalls)
+		assert.Equal(t, 1, mock.SetDashboardACLCalls)
+
+		mock.Reset()
+		assert.Equal(t, 0, mock.ListDashboardsCalls)
+		assert.Equal(t, 0, mock.CreateDashboardCalls)
+		assert.Equal(t, 0, mock.UpdateDashboardCalls)
+		assert.Equal(t, 0, mock.DeleteDashboardCalls)
+		assert.Equal(t, 0, mock.SetDashboardACLCalls)
+	})
+
+	t.Run("Error_CustomFuncReturnsError", func(t *testing.T) {
+		expectedErr := errors.New("dashboard failed")
+		mock := &MockSplunkService{
+			ListDashboardsFunc:  func(ctx context.Context, app string) ([]*models.Dashboard, error) { return nil, expectedErr },
+			CreateDashboardFunc: func(ctx context.Context, dashboard *models.Dashboard) error { return expectedErr },
+			UpdateDashboardFunc: func(ctx context.Context, dashboard *models.Dashboard) error { return expectedErr },
+			DeleteDashboardFunc: func(ctx context.Context, dashboard *models.Dashboard) error { return expectedErr },
+			SetDashboardACLFunc: func(ctx context.Context, dashboard *models.Dashboard, acl utils.DashboardACL) error {
+				return expectedErr
+			},
+		}
+
+		_, err := mock.ListDashboards(context.Background(), "search")
+		assert.Equal(t, expectedErr, err)
+		assert.Equal(t, expectedErr, mock.CreateDashboard(context.Background(), dashboard))
+		assert.Equal(t, expectedErr, mock.UpdateDashboard(context.Background(), dashboard))
+		assert.Equal(t, expectedErr, mock.DeleteDashboard(context.Background(), dashboard))
+		assert.Equal(t, expectedErr, mock.SetDashboardACL(context.Background(), dashboard, utils.DashboardACL{}))
+	})
+}
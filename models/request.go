@@ -24,10 +24,13 @@ func (r *AuthRequest) Validate() error {
 	return nil
 }
 
-// IndexRequest represents a Splunk index creation request
+// IndexRequest represents a Splunk index creation request. It is the request body of
+// the Splunk Cloud Admin Config Service (ACS) indexes endpoint.
 type IndexRequest struct {
-	Name     string `json:"name"`
-	DataType string `json:"datatype"`
+	Name           string `json:"name"`
+	DataType       string `json:"datatype"`
+	SearchableDays int    `json:"searchableDays,omitempty"`
+	MaxDataSizeMB  int    `json:"maxDataSizeMB,omitempty"`
 }
 
 // Validate validates the IndexRequest
@@ -41,6 +44,9 @@ func (r *IndexRequest) Validate() error {
 	if r.DataType != "" && r.DataType != "event" && r.DataType != "metric" {
 		return fmt.Errorf("datatype must be 'event' or 'metric'")
 	}
+	if r.SearchableDays < 0 || r.MaxDataSizeMB < 0 {
+		return fmt.Errorf("searchableDays and maxDataSizeMB must not be negative")
+	}
 	return nil
 }
 
@@ -71,18 +77,19 @@ func (r *SalesforceAccountRequest) Validate() error {
 	if strings.TrimSpace(r.AuthType) == "" {
 		return fmt.Errorf("auth_type is required")
 	}
-	validAuthTypes := map[string]bool{"basic": true, "oauth2": true}
+	// Auth types accepted by Splunk_TA_salesforce (see openapi.json)
+	validAuthTypes := map[string]bool{"basic": true, "oauth": true, "oauth_client_credentials": true}
 	if !validAuthTypes[r.AuthType] {
-		return fmt.Errorf("auth_type must be 'basic' or 'oauth2'")
+		return fmt.Errorf("auth_type must be 'basic', 'oauth' or 'oauth_client_credentials'")
 	}
 
 	// Validate credentials based on auth type
-	if r.AuthType == "oauth2" {
+	if r.AuthType == "oauth_client_credentials" {
 		if strings.TrimSpace(r.ClientIDOAuthCredentials) == "" {
-			return fmt.Errorf("client_id is required for oauth2 auth")
+			return fmt.Errorf("client_id_oauth_credentials is required for oauth_client_credentials auth")
 		}
 		if strings.TrimSpace(r.ClientSecretOAuthCredentials) == "" {
-			return fmt.Errorf("client_secret is required for oauth2 auth")
+			return fmt.Errorf("client_secret_oauth_credentials is required for oauth_client_credentials auth")
 		}
 	}
 	return nil
@@ -118,11 +125,11 @@ func (r *DataInputRequest) Validate() error {
 	if r.Delay < 0 {
 		return fmt.Errorf("delay must be non-negative")
 	}
-	// Validate start date format if provided
+	// Validate start date format if provided; the add-on expects an ISO-8601 timestamp
+	// such as 2024-01-01T00:00:00.000Z
 	if r.StartDate != "" {
-		_, err := time.Parse("2006-01-02", r.StartDate)
-		if err != nil {
-			return fmt.Errorf("start_date must be in YYYY-MM-DD format: %w", err)
+		if _, err := time.Parse(time.RFC3339, r.StartDate); err != nil {
+			return fmt.Errorf("start_date must be an ISO-8601 timestamp (YYYY-MM-DDThh:mm:ss.000Z)")
 		}
 	}
 	return nil
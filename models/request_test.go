@@ -121,6 +121,16 @@ func TestIndexRequest_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "datatype must be 'event' or 'metric'",
 		},
+		{
+			name: "negative retention",
+			request: models.IndexRequest{
+				Name:           "test_index",
+				DataType:       "event",
+				SearchableDays: -1,
+			},
+			wantErr: true,
+			errMsg:  "searchableDays and maxDataSizeMB must not be negative",
+		},
 	}
 
 	for _, tt := range tests {
@@ -145,16 +155,35 @@ func TestSalesforceAccountRequest_Validate(t *testing.T) {
 		errMsg  string
 	}{
 		{
-			name: "valid oauth2 account",
+			name: "valid oauth_client_credentials account",
 			request: models.SalesforceAccountRequest{
 				Name:                         "test_account",
 				Endpoint:                     "https://login.salesforce.com",
-				AuthType:                     "oauth2",
+				AuthType:                     "oauth_client_credentials",
 				ClientIDOAuthCredentials:     "client123",
 				ClientSecretOAuthCredentials: "secret456",
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid oauth account",
+			request: models.SalesforceAccountRequest{
+				Name:     "test_account",
+				Endpoint: "https://login.salesforce.com",
+				AuthType: "oauth",
+			},
+			wantErr: false,
+		},
+		{
+			name: "legacy oauth2 auth type",
+			request: models.SalesforceAccountRequest{
+				Name:     "test",
+				Endpoint: "https://login.salesforce.com",
+				AuthType: "oauth2",
+			},
+			wantErr: true,
+			errMsg:  "auth_type must be 'basic', 'oauth' or 'oauth_client_credentials'",
+		},
 		{
 			name: "valid basic auth account",
 			request: models.SalesforceAccountRequest{
@@ -169,7 +198,7 @@ func TestSalesforceAccountRequest_Validate(t *testing.T) {
 			request: models.SalesforceAccountRequest{
 				Name:     "",
 				Endpoint: "https://login.salesforce.com",
-				AuthType: "oauth2",
+				AuthType: "oauth_client_credentials",
 			},
 			wantErr: true,
 			errMsg:  "account name is required",
@@ -179,7 +208,7 @@ func TestSalesforceAccountRequest_Validate(t *testing.T) {
 			request: models.SalesforceAccountRequest{
 				Name:     "test",
 				Endpoint: "",
-				AuthType: "oauth2",
+				AuthType: "oauth_client_credentials",
 			},
 			wantErr: true,
 			errMsg:  "endpoint is required",
@@ -189,7 +218,7 @@ func TestSalesforceAccountRequest_Validate(t *testing.T) {
 			request: models.SalesforceAccountRequest{
 				Name:     "test",
 				Endpoint: "http://login.salesforce.com",
-				AuthType: "oauth2",
+				AuthType: "oauth_client_credentials",
 			},
 			wantErr: true,
 			errMsg:  "endpoint must start with https://",
@@ -212,29 +241,29 @@ func TestSalesforceAccountRequest_Validate(t *testing.T) {
 				AuthType: "invalid",
 			},
 			wantErr: true,
-			errMsg:  "auth_type must be 'basic' or 'oauth2'",
+			errMsg:  "auth_type must be 'basic', 'oauth' or 'oauth_client_credentials'",
 		},
 		{
-			name: "oauth2 missing client id",
+			name: "oauth_client_credentials missing client id",
 			request: models.SalesforceAccountRequest{
 				Name:                         "test",
 				Endpoint:                     "https://login.salesforce.com",
-				AuthType:                     "oauth2",
+				AuthType:                     "oauth_client_credentials",
 				ClientSecretOAuthCredentials: "secret",
 			},
 			wantErr: true,
-			errMsg:  "client_id is required for oauth2 auth",
+			errMsg:  "client_id_oauth_credentials is required for oauth_client_credentials auth",
 		},
 		{
-			name: "oauth2 missing client secret",
+			name: "oauth_client_credentials missing client secret",
 			request: models.SalesforceAccountRequest{
 				Name:                     "test",
 				Endpoint:                 "https://login.salesforce.com",
-				AuthType:                 "oauth2",
+				AuthType:                 "oauth_client_credentials",
 				ClientIDOAuthCredentials: "client",
 			},
 			wantErr: true,
-			errMsg:  "client_secret is required for oauth2 auth",
+			errMsg:  "client_secret_oauth_credentials is required for oauth_client_credentials auth",
 		},
 	}
 
@@ -265,12 +294,23 @@ func TestDataInputRequest_Validate(t *testing.T) {
 				Name:      "Account_Input",
 				Account:   "test_account",
 				Object:    "Account",
-				StartDate: "2024-01-01",
+				StartDate: "2024-01-01T00:00:00.000Z",
 				Interval:  300,
 				Delay:     60,
 			},
 			wantErr: false,
 		},
+		{
+			name: "bare start date",
+			request: models.DataInputRequest{
+				Name:      "Account_Input",
+				Account:   "test_account",
+				Object:    "Account",
+				StartDate: "2024-01-01",
+			},
+			wantErr: true,
+			errMsg:  "start_date must be an ISO-8601 timestamp (YYYY-MM-DDThh:mm:ss.000Z)",
+		},
 		{
 			name: "empty name",
 			request: models.DataInputRequest{
@@ -332,7 +372,7 @@ func TestDataInputRequest_Validate(t *testing.T) {
 				StartDate: "01-01-2024",
 			},
 			wantErr: true,
-			errMsg:  "start_date must be in YYYY-MM-DD format",
+			errMsg:  "start_date must be an ISO-8601 timestamp (YYYY-MM-DDThh:mm:ss.000Z)",
 		},
 	}
 
@@ -1,6 +1,12 @@
 // Package models contains request/response data structures
 package models
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
 // SplunkResponse represents a generic Splunk API response
 type SplunkResponse struct {
 	Links    map[string]string `json:"links"`
@@ -18,9 +24,24 @@ type Entry struct {
 	Updated string                 `json:"updated"`
 	Links   map[string]string      `json:"links"`
 	Author  string                 `json:"author"`
+	ACL     EntryACL               `json:"acl"`
 	Content map[string]interface{} `json:"content"`
 }
 
+// EntryACL is the access control list of a knowledge object entry
+type EntryACL struct {
+	App     string     `json:"app"`
+	Owner   string     `json:"owner"`
+	Sharing string     `json:"sharing"`
+	Perms   EntryPerms `json:"perms"`
+}
+
+// EntryPerms lists the roles that may read and write a knowledge object
+type EntryPerms struct {
+	Read  []string `json:"read"`
+	Write []string `json:"write"`
+}
+
 // Paging represents pagination information
 type Paging struct {
 	Total   int `json:"total"`
@@ -57,3 +78,325 @@ type TokenAuthResponse struct {
 	Paging   Paging    `json:"paging"`
 	Messages []Message `json:"messages"`
 }
+
+// SessionLoginResponse represents the response from /services/auth/login
+type SessionLoginResponse struct {
+	SessionKey string `json:"sessionKey"`
+}
+
+// SFDCObjectInput represents a Splunk_TA_salesforce_sfdc_object data input as stored in Splunk
+type SFDCObjectInput struct {
+	Name         string `json:"name"`
+	Account      string `json:"account"`
+	Object       string `json:"object"`
+	ObjectFields string `json:"object_fields"`
+	OrderBy      string `json:"order_by"`
+	StartDate    string `json:"start_date"`
+	Interval     int    `json:"interval"`
+	Delay        int    `json:"delay"`
+	Index        string `json:"index"`
+	Disabled     bool   `json:"disabled"`
+}
+
+// ParseSFDCObjectInput converts a generic sfdc_object entry into a typed input.
+// Splunk serializes numeric and boolean settings inconsistently (e.g. "300" or 300,
+// "0", false or "False"), so content values are normalized here.
+func ParseSFDCObjectInput(entry Entry) (*SFDCObjectInput, error) {
+	interval, err := contentInt(entry.Content, "interval")
+	if err != nil {
+		return nil, fmt.Errorf("data input %s: %w", entry.Name, err)
+	}
+	delay, err := contentInt(entry.Content, "delay")
+	if err != nil {
+		return nil, fmt.Errorf("data input %s: %w", entry.Name, err)
+	}
+	disabled, err := contentBool(entry.Content, "disabled")
+	if err != nil {
+		return nil, fmt.Errorf("data input %s: %w", entry.Name, err)
+	}
+
+	return &SFDCObjectInput{
+		Name:         entry.Name,
+		Account:      contentString(entry.Content, "account"),
+		Object:       contentString(entry.Content, "object"),
+		ObjectFields: contentString(entry.Content, "object_fields"),
+		OrderBy:      contentString(entry.Content, "order_by"),
+		StartDate:    contentString(entry.Content, "start_date"),
+		Interval:     interval,
+		Delay:        delay,
+		Index:        contentString(entry.Content, "index"),
+		Disabled:     disabled,
+	}, nil
+}
+
+// SFDCEventLogInput represents a Splunk_TA_salesforce_sfdc_event_log data input as stored in Splunk
+type SFDCEventLogInput struct {
+	Name               string `json:"name"`
+	Account            string `json:"account"`
+	MonitoringInterval string `json:"monitoring_interval"`
+	StartDate          string `json:"start_date"`
+	Interval           int    `json:"interval"`
+	Index              string `json:"index"`
+	Disabled           bool   `json:"disabled"`
+}
+
+// ParseSFDCEventLogInput converts a generic sfdc_event_log entry into a typed input,
+// normalizing content values like ParseSFDCObjectInput
+func ParseSFDCEventLogInput(entry Entry) (*SFDCEventLogInput, error) {
+	interval, err := contentInt(entry.Content, "interval")
+	if err != nil {
+		return nil, fmt.Errorf("event log input %s: %w", entry.Name, err)
+	}
+	disabled, err := contentBool(entry.Content, "disabled")
+	if err != nil {
+		return nil, fmt.Errorf("event log input %s: %w", entry.Name, err)
+	}
+
+	return &SFDCEventLogInput{
+		Name:               entry.Name,
+		Account:            contentString(entry.Content, "account"),
+		MonitoringInterval: contentString(entry.Content, "monitoring_interval"),
+		StartDate:          contentString(entry.Content, "start_date"),
+		Interval:           interval,
+		Index:              contentString(entry.Content, "index"),
+		Disabled:           disabled,
+	}, nil
+}
+
+// ProxySettings represents the Splunk_TA_salesforce proxy settings as stored in Splunk.
+// The proxy password is intentionally not exposed.
+type ProxySettings struct {
+	Enabled  bool
+	Type     string
+	URL      string
+	Port     int
+	Username string
+	RDNS     bool
+}
+
+// ParseProxySettings converts the settings/proxy entry into typed settings
+func ParseProxySettings(entry Entry) (*ProxySettings, error) {
+	enabled, err := contentBool(entry.Content, "proxy_enabled")
+	if err != nil {
+		return nil, fmt.Errorf("proxy settings: %w", err)
+	}
+	port, err := contentInt(entry.Content, "proxy_port")
+	if err != nil {
+		return nil, fmt.Errorf("proxy settings: %w", err)
+	}
+	rdns, err := contentBool(entry.Content, "proxy_rdns")
+	if err != nil {
+		return nil, fmt.Errorf("proxy settings: %w", err)
+	}
+
+	return &ProxySettings{
+		Enabled:  enabled,
+		Type:     contentString(entry.Content, "proxy_type"),
+		URL:      contentString(entry.Content, "proxy_url"),
+		Port:     port,
+		Username: contentString(entry.Content, "proxy_username"),
+		RDNS:     rdns,
+	}, nil
+}
+
+// SalesforceAccountSettings represents a Splunk_TA_salesforce account as stored in Splunk.
+// Client secrets, passwords and tokens are intentionally not exposed.
+type SalesforceAccountSettings struct {
+	Name       string
+	Endpoint   string
+	APIVersion string
+	AuthType   string
+	ClientID   string
+}
+
+// ParseSalesforceAccountSettings converts an account entry into typed settings. The
+// client ID is stored under client_id_oauth_credentials for the client credentials flow
+// and under client_id for the other auth types.
+func ParseSalesforceAccountSettings(entry Entry) *SalesforceAccountSettings {
+	clientID := contentString(entry.Content, "client_id_oauth_credentials")
+	if clientID == "" {
+		clientID = contentString(entry.Content, "client_id")
+	}
+
+	return &SalesforceAccountSettings{
+		Name:       entry.Name,
+		Endpoint:   contentString(entry.Content, "endpoint"),
+		APIVersion: contentString(entry.Content, "sfdc_api_version"),
+		AuthType:   contentString(entry.Content, "auth_type"),
+		ClientID:   clientID,
+	}
+}
+
+// Dashboard is a view stored under data/ui/views
+type Dashboard struct {
+	Name    string
+	App     string
+	Owner   string
+	Sharing string
+	Read    []string // Roles that may view the dashboard
+	Write   []string // Roles that may edit the dashboard
+	Data    string   // eai:data, the Simple XML or Dashboard Studio source
+}
+
+// ParseDashboard converts a data/ui/views entry into a dashboard
+func ParseDashboard(entry Entry) *Dashboard {
+	return &Dashboard{
+		Name:    entry.Name,
+		App:     entry.ACL.App,
+		Owner:   entry.ACL.Owner,
+		Sharing: entry.ACL.Sharing,
+		Read:    entry.ACL.Perms.Read,
+		Write:   entry.ACL.Perms.Write,
+		Data:    contentString(entry.Content, "eai:data"),
+	}
+}
+
+// Shared reports whether the dashboard is shared with its app or globally, rather than
+// private to its owner
+func (d *Dashboard) Shared() bool {
+	return d.Sharing == "app" || d.Sharing == "global"
+}
+
+// Dashboard reconcile actions
+const (
+	DashboardCreated   = "created"
+	DashboardUpdated   = "updated"
+	DashboardUnchanged = "unchanged"
+	DashboardDeleted   = "deleted"
+	DashboardFailed    = "failed"
+)
+
+// DashboardOutcome is the action taken for one dashboard of the dashboard directory.
+// Versions are short content digests of the definitions, so an update records which
+// definition it replaced.
+type DashboardOutcome struct {
+	Name            string
+	File            string // Empty for dashboards deleted because their file was removed
+	Action          string
+	Version         string // Digest of the deployed definition
+	PreviousVersion string // Digest of the definition found in Splunk, if any
+	Err             error
+}
+
+// DashboardReconcileResult lists the outcome of every dashboard of a directory
+type DashboardReconcileResult struct {
+	Directory string
+	Outcomes  []DashboardOutcome
+}
+
+// Failed returns the number of dashboards whose action failed
+func (r *DashboardReconcileResult) Failed() int {
+	failed := 0
+	for _, outcome := range r.Outcomes {
+		if outcome.Action == DashboardFailed {
+			failed++
+		}
+	}
+	return failed
+}
+
+// Dashboard lint severities. Errors make the dashboard undeployable; warnings flag
+// searches that will probably return nothing.
+const (
+	LintError   = "error"
+	LintWarning = "warning"
+)
+
+// DashboardLintProblem is one problem found in a dashboard file. Line is 0 when the
+// problem concerns the whole file.
+type DashboardLintProblem struct {
+	File     string `json:"file"`
+	Line     int    `json:"line,omitempty"`
+	Severity string `json:"severity"`
+	Message  string `json:"message"`
+}
+
+// String formats the problem as file:line: severity: message
+func (p DashboardLintProblem) String() string {
+	if p.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", p.File, p.Severity, p.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", p.File, p.Line, p.Severity, p.Message)
+}
+
+// DashboardLintResult lists the problems found in the dashboards of a directory
+type DashboardLintResult struct {
+	Directory string                 `json:"directory"`
+	Files     int                    `json:"files"`
+	Problems  []DashboardLintProblem `json:"problems,omitempty"`
+}
+
+// Errors returns the number of problems with error severity
+func (r *DashboardLintResult) Errors() int {
+	return r.count(LintError)
+}
+
+// Warnings returns the number of problems with warning severity
+func (r *DashboardLintResult) Warnings() int {
+	return r.count(LintWarning)
+}
+
+func (r *DashboardLintResult) count(severity string) int {
+	n := 0
+	for _, problem := range r.Problems {
+		if problem.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// contentString reads a string value from entry content
+func contentString(content map[string]interface{}, key string) string {
+	switch v := content[key].(type) {
+	case string:
+		return v
+	case nil:
+		return ""
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// contentInt reads an integer value that may be serialized as a number or string
+func contentInt(content map[string]interface{}, key string) (int, error) {
+	switch v := content[key].(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return int(v), nil
+	case string:
+		if strings.TrimSpace(v) == "" {
+			return 0, nil
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(v))
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s value %q", key, v)
+		}
+		return n, nil
+	default:
+		return 0, fmt.Errorf("invalid %s value %v", key, v)
+	}
+}
+
+// contentBool reads a boolean value that may be serialized as a bool, number or string
+func contentBool(content map[string]interface{}, key string) (bool, error) {
+	switch v := content[key].(type) {
+	case nil:
+		return false, nil
+	case bool:
+		return v, nil
+	case float64:
+		return v != 0, nil
+	case string:
+		switch strings.ToLower(strings.TrimSpace(v)) {
+		case "", "0", "false", "f", "no":
+			return false, nil
+		case "1", "true", "t", "yes":
+			return true, nil
+		}
+		return false, fmt.Errorf("invalid %s value %q", key, v)
+	default:
+		return false, fmt.Errorf("invalid %s value %v", key, v)
+	}
+}
@@ -230,3 +230,200 @@ func TestMessage(t *testing.T) {
 		assert.True(t, len(message.Text) > 50)
 	})
 }
+
+func TestParseSFDCObjectInput(t *testing.T) {
+	t.Run("Success_StringValues", func(t *testing.T) {
+		entry := Entry{
+			Name: "sf_accounts",
+			Content: map[string]interface{}{
+				"account":       "prod",
+				"object":        "Account",
+				"object_fields": "Id,Name",
+				"order_by":      "LastModifiedDate",
+				"start_date":    "2024-01-01T00:00:00.000Z",
+				"interval":      "300",
+				"delay":         "60",
+				"index":         "salesforce",
+				"disabled":      "False",
+			},
+		}
+
+		input, err := ParseSFDCObjectInput(entry)
+		require.NoError(t, err)
+		assert.Equal(t, "sf_accounts", input.Name)
+		assert.Equal(t, "prod", input.Account)
+		assert.Equal(t, "Id,Name", input.ObjectFields)
+		assert.Equal(t, 300, input.Interval)
+		assert.Equal(t, 60, input.Delay)
+		assert.False(t, input.Disabled)
+	})
+
+	t.Run("Success_NativeValues", func(t *testing.T) {
+		entry := Entry{
+			Name: "sf_contacts",
+			Content: map[string]interface{}{
+				"interval": float64(600),
+				"delay":    float64(0),
+				"disabled": true,
+			},
+		}
+
+		input, err := ParseSFDCObjectInput(entry)
+		require.NoError(t, err)
+		assert.Equal(t, 600, input.Interval)
+		assert.Equal(t, 0, input.Delay)
+		assert.True(t, input.Disabled)
+	})
+
+	t.Run("Success_NumericDisabledString", func(t *testing.T) {
+		input, err := ParseSFDCObjectInput(Entry{Name: "x", Content: map[string]interface{}{"disabled": "1"}})
+		require.NoError(t, err)
+		assert.True(t, input.Disabled)
+	})
+
+	t.Run("Error_InvalidInterval", func(t *testing.T) {
+		_, err := ParseSFDCObjectInput(Entry{Name: "x", Content: map[string]interface{}{"interval": "often"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interval")
+	})
+
+	t.Run("Error_InvalidDisabled", func(t *testing.T) {
+		_, err := ParseSFDCObjectInput(Entry{Name: "x", Content: map[string]interface{}{"disabled": "maybe"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "disabled")
+	})
+}
+
+func TestParseSFDCEventLogInput(t *testing.T) {
+	t.Run("Success_StringValues", func(t *testing.T) {
+		entry := Entry{
+			Name: "sf_event_logs",
+			Content: map[string]interface{}{
+				"account":             "prod",
+				"monitoring_interval": "Hourly",
+				"start_date":          "2024-01-01T00:00:00.000Z",
+				"interval":            "3600",
+				"index":               "salesforce",
+				"disabled":            "0",
+			},
+		}
+
+		input, err := ParseSFDCEventLogInput(entry)
+		require.NoError(t, err)
+		assert.Equal(t, &SFDCEventLogInput{
+			Name:               "sf_event_logs",
+			Account:            "prod",
+			MonitoringInterval: "Hourly",
+			StartDate:          "2024-01-01T00:00:00.000Z",
+			Interval:           3600,
+			Index:              "salesforce",
+		}, input)
+	})
+
+	t.Run("Error_InvalidInterval", func(t *testing.T) {
+		_, err := ParseSFDCEventLogInput(Entry{Name: "x", Content: map[string]interface{}{"interval": "hourly"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interval")
+	})
+}
+
+func TestParseProxySettings(t *testing.T) {
+	t.Run("Success_StringValues", func(t *testing.T) {
+		entry := Entry{
+			Name: "proxy",
+			Content: map[string]interface{}{
+				"proxy_enabled":  "1",
+				"proxy_type":     "socks5",
+				"proxy_url":      "proxy.corp.local",
+				"proxy_port":     "1080",
+				"proxy_username": "svc",
+				"proxy_password": "********",
+				"proxy_rdns":     "0",
+			},
+		}
+
+		settings, err := ParseProxySettings(entry)
+		require.NoError(t, err)
+		assert.Equal(t, &ProxySettings{
+			Enabled:  true,
+			Type:     "socks5",
+			URL:      "proxy.corp.local",
+			Port:     1080,
+			Username: "svc",
+			RDNS:     false,
+		}, settings)
+	})
+
+	t.Run("Success_EmptyStanza", func(t *testing.T) {
+		settings, err := ParseProxySettings(Entry{Name: "proxy", Content: map[string]interface{}{"proxy_port": ""}})
+		require.NoError(t, err)
+		assert.False(t, settings.Enabled)
+		assert.Equal(t, 0, settings.Port)
+	})
+
+	t.Run("Error_InvalidPort", func(t *testing.T) {
+		_, err := ParseProxySettings(Entry{Name: "proxy", Content: map[string]interface{}{"proxy_port": "http"}})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "proxy_port")
+	})
+}
+
+func TestParseSalesforceAccountSettings(t *testing.T) {
+	t.Run("Success_ClientCredentials", func(t *testing.T) {
+		entry := Entry{
+			Name: "sf_prod",
+			Content: map[string]interface{}{
+				"endpoint":                        "login.salesforce.com",
+				"sfdc_api_version":                "64.0",
+				"auth_type":                       "oauth_client_credentials",
+				"client_id_oauth_credentials":     "client-id",
+				"client_secret_oauth_credentials": "********",
+			},
+		}
+
+		assert.Equal(t, &SalesforceAccountSettings{
+			Name:       "sf_prod",
+			Endpoint:   "login.salesforce.com",
+			APIVersion: "64.0",
+			AuthType:   "oauth_client_credentials",
+			ClientID:   "client-id",
+		}, ParseSalesforceAccountSettings(entry))
+	})
+
+	t.Run("Success_OAuthClientID", func(t *testing.T) {
+		entry := Entry{
+			Name:    "sf_sandbox",
+			Content: map[string]interface{}{"auth_type": "oauth", "client_id": "sandbox-id"},
+		}
+
+		settings := ParseSalesforceAccountSettings(entry)
+		assert.Equal(t, "sandbox-id", settings.ClientID)
+		assert.Empty(t, settings.Endpoint)
+	})
+}
+
+func TestParseDashboard(t *testing.T) {
+	entry := Entry{
+		Name:    "analytics_dashboard",
+		ACL:     EntryACL{App: "search", Owner: "nobody", Sharing: "app"},
+		Content: map[string]interface{}{"eai:data": "<dashboard><label>Analytics</label></dashboard>"},
+	}
+
+	assert.Equal(t, &Dashboard{
+		Name:    "analytics_dashboard",
+		App:     "search",
+		Owner:   "nobody",
+		Sharing: "app",
+		Data:    "<dashboard><label>Analytics</label></dashboard>",
+	}, ParseDashboard(entry))
+}
+
+func TestDashboardReconcileResult_Failed(t *testing.T) {
+	result := &DashboardReconcileResult{Outcomes: []DashboardOutcome{
+		{Name: "home", Action: DashboardUnchanged},
+		{Name: "analytics", Action: DashboardFailed},
+		{Name: "old", Action: DashboardDeleted},
+	}}
+
+	assert.Equal(t, 1, result.Failed())
+}
@@ -0,0 +1,360 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// Layout of the generated dashboards, in Dashboard Studio grid units
+const (
+	generatedLayoutWidth   = 1440
+	generatedChartHeight   = 300
+	generatedTableHeight   = 400
+	generatedPicklistMax   = 4  // Picklist panels per object dashboard
+	generatedRecentRecords = 20 // Rows of the recently modified records table
+)
+
+// picklistFields are standard Salesforce picklist fields. Other fields are treated as
+// picklists when their name ends with one of picklistSuffixes; object_fields carries
+// no field types, so this is a heuristic the generated dashboards are reviewed for.
+var picklistFields = map[string]bool{
+	"AccountSource":        true,
+	"ForecastCategoryName": true,
+	"Industry":             true,
+	"LeadSource":           true,
+	"Origin":               true,
+	"Ownership":            true,
+	"Priority":             true,
+	"Rating":               true,
+	"Reason":               true,
+	"StageName":            true,
+	"Status":               true,
+	"Type":                 true,
+}
+
+var picklistSuffixes = []string{"Category", "Priority", "Rating", "Reason", "Source", "Stage", "Status", "Type"}
+
+// dashboardPrefixPattern restricts name prefixes to characters valid in a view name
+var dashboardPrefixPattern = regexp.MustCompile(`^[A-Za-z0-9_]*$`)
+
+// studioIDPattern matches characters that cannot appear in a Dashboard Studio ID
+var studioIDPattern = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// GeneratedDashboard is a starter Dashboard Studio definition generated from DATA_INPUTS
+type GeneratedDashboard struct {
+	Name       string // Dashboard name; the file is Name + ".json"
+	Object     string // Salesforce object, empty for the overview
+	Definition []byte // Indented Dashboard Studio JSON
+}
+
+// generatedObject is a Salesforce object with the settings of all inputs reading it
+type generatedObject struct {
+	object  string
+	inputs  []string
+	indexes []string // Template actions rendering the index of each input
+	fields  []string
+}
+
+// generatedDefinition is a Dashboard Studio definition. Its fields are in the order
+// Dashboard Studio writes them, so generated files read like exported ones.
+type generatedDefinition struct {
+	Title          string                    `json:"title"`
+	Description    string                    `json:"description"`
+	DataSources    map[string]generatedPanel `json:"dataSources"`
+	Visualizations map[string]generatedPanel `json:"visualizations"`
+	Inputs         map[string]generatedPanel `json:"inputs"`
+	Defaults       map[string]interface{}    `json:"defaults"`
+	Layout         map[string]interface{}    `json:"layout"`
+}
+
+// generatedPanel is a data source, visualization or input of a generated definition
+type generatedPanel struct {
+	Type        string                 `json:"type"`
+	Name        string                 `json:"name,omitempty"`
+	Title       string                 `json:"title,omitempty"`
+	DataSources map[string]string      `json:"dataSources,omitempty"`
+	Options     map[string]interface{} `json:"options,omitempty"`
+}
+
+// GenerateDashboards builds one starter dashboard per Salesforce object of inputs, with
+// record counts over time, the top values of picklist fields and the recently modified
+// records, plus an overview of all objects. Searches filter on the sfdc:<object>
+// sourcetype and on the indexes of the inputs, written as template actions so that each
+// target renders its own indexes: {{.Index}} for inputs writing to index, the
+// SPLUNK_INDEX_NAME the dashboards are generated for, and the index of the input in
+// .Inputs otherwise. Dashboards are named prefix + object and prefix + "overview";
+// objects are ordered as their first input.
+func GenerateDashboards(inputs []utils.DataInput, index, prefix string) ([]GeneratedDashboard, error) {
+	if !dashboardPrefixPattern.MatchString(prefix) {
+		return nil, fmt.Errorf("invalid dashboard prefix %q: only letters, digits and underscores are allowed", prefix)
+	}
+	objects := groupInputsByObject(inputs, index)
+	if len(objects) == 0 {
+		return nil, errors.New("no data inputs to generate dashboards from")
+	}
+
+	var dashboards []GeneratedDashboard
+	names := make(map[string]string)
+	add := func(name, object, label string, definition *generatedDefinition) error {
+		if other, exists := names[name]; exists {
+			return fmt.Errorf("dashboard %s would be generated for both %s and %s", name, other, label)
+		}
+		names[name] = label
+		data, err := json.MarshalIndent(definition, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to encode dashboard %s: %w", name, err)
+		}
+		dashboards = append(dashboards, GeneratedDashboard{Name: name, Object: object, Definition: append(data, '\n')})
+		return nil
+	}
+
+	for _, object := range objects {
+		if err := add(prefix+studioID(object.object), object.object, object.object, objectDashboard(object)); err != nil {
+			return nil, err
+		}
+	}
+	if err := add(prefix+"overview", "", "the overview", overviewDashboard(objects)); err != nil {
+		return nil, err
+	}
+	return dashboards, nil
+}
+
+// WriteGeneratedDashboards writes each dashboard to dir as <name>.json, creating dir if
+// needed. Existing files are kept unless overwrite is set, so regenerating does not
+// discard reviewed edits. It returns the paths written and the paths kept.
+func WriteGeneratedDashboards(dir string, dashboards []GeneratedDashboard, overwrite bool) (written, kept []string, err error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, fmt.Errorf("failed to create dashboard directory: %w", err)
+	}
+
+	for _, dashboard := range dashboards {
+		path := filepath.Join(dir, dashboard.Name+".json")
+		if !overwrite {
+			exists, err := utils.FileExists(path)
+			if err != nil {
+				return written, kept, fmt.Errorf("failed to check %s: %w", path, err)
+			}
+			if exists {
+				kept = append(kept, path)
+				continue
+			}
+		}
+		if err := os.WriteFile(path, dashboard.Definition, 0o644); err != nil {
+			return written, kept, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, path)
+	}
+	return written, kept, nil
+}
+
+// groupInputsByObject merges the inputs of each object. Objects are matched case
+// insensitively, like the sourcetypes the add-on derives from them.
+func groupInputsByObject(inputs []utils.DataInput, index string) []*generatedObject {
+	var objects []*generatedObject
+	byObject := make(map[string]*generatedObject)
+	for _, input := range inputs {
+		key := strings.ToLower(input.Object)
+		object, exists := byObject[key]
+		if !exists {
+			object = &generatedObject{object: input.Object}
+			byObject[key] = object
+			objects = append(objects, object)
+		}
+		object.inputs = append(object.inputs, input.Name)
+		object.indexes = appendUnique(object.indexes, indexAction(input, index))
+		for _, field := range strings.Split(input.ObjectFields, ",") {
+			object.fields = appendUnique(object.fields, strings.TrimSpace(field))
+		}
+	}
+	return objects
+}
+
+// objectDashboard builds the dashboard of one Salesforce object
+func objectDashboard(object *generatedObject) *generatedDefinition {
+	search := searchFilter("index", object.indexes) + " " + searchFilter("sourcetype", []string{sourcetype(object.object)})
+	latest := search
+	if slices.Contains(object.fields, "Id") {
+		// Each modification of a record is indexed again; keep its latest version
+		latest += " | dedup Id"
+	}
+
+	definition := newGeneratedDefinition(
+		"Salesforce "+object.object,
+		fmt.Sprintf("Starter dashboard generated from the %s data input(s)", strings.Join(object.inputs, ", ")))
+	y := 0
+
+	definition.addPanel("records_over_time", "Records over time", "splunk.line",
+		search+" | timechart count as records", 0, y, generatedLayoutWidth, generatedChartHeight)
+	y += generatedChartHeight
+
+	picklists := picklistFieldsOf(object.fields)
+	for i, field := range picklists {
+		width := generatedLayoutWidth / len(picklists)
+		definition.addPanel("top_"+studioID(field), "Top "+field+" values", "splunk.bar",
+			fmt.Sprintf("%s | top limit=10 %s", latest, field), i*width, y, width, generatedChartHeight)
+	}
+	if len(picklists) > 0 {
+		y += generatedChartHeight
+	}
+
+	columns := []string{"_time"}
+	for _, field := range object.fields {
+		columns = appendUnique(columns, field)
+	}
+	definition.addPanel("recent_records", "Recently modified records", "splunk.table",
+		fmt.Sprintf("%s | head %d | table %s", latest, generatedRecentRecords, strings.Join(columns, ", ")),
+		0, y, generatedLayoutWidth, generatedTableHeight)
+
+	return definition
+}
+
+// overviewDashboard builds the dashboard summarizing every object
+func overviewDashboard(objects []*generatedObject) *generatedDefinition {
+	var indexes, sourcetypes, inputs []string
+	for _, object := range objects {
+		for _, index := range object.indexes {
+			indexes = appendUnique(indexes, index)
+		}
+		sourcetypes = appendUnique(sourcetypes, sourcetype(object.object))
+		inputs = append(inputs, object.inputs...)
+	}
+	search := searchFilter("index", indexes) + " " + searchFilter("sourcetype", sourcetypes)
+
+	definition := newGeneratedDefinition(
+		"Salesforce overview",
+		fmt.Sprintf("Starter dashboard generated from the %s data input(s)", strings.Join(inputs, ", ")))
+	definition.addPanel("events_by_object", "Events by object", "splunk.line",
+		search+" | timechart count by sourcetype", 0, 0, generatedLayoutWidth, generatedChartHeight)
+	definition.addPanel("objects", "Latest events per object", "splunk.table",
+		search+" | stats count as events, latest(_time) as last_event by sourcetype | convert ctime(last_event)",
+		0, generatedChartHeight, generatedLayoutWidth, generatedTableHeight)
+	return definition
+}
+
+// newGeneratedDefinition returns an empty grid definition with a global time range
+// input that every search of the dashboard uses
+func newGeneratedDefinition(title, description string) *generatedDefinition {
+	return &generatedDefinition{
+		Title:          title,
+		Description:    description,
+		DataSources:    make(map[string]generatedPanel),
+		Visualizations: make(map[string]generatedPanel),
+		Inputs: map[string]generatedPanel{
+			"input_global_trp": {
+				Type:    "input.timerange",
+				Title:   "Time range",
+				Options: map[string]interface{}{"token": "global_time", "defaultValue": "-7d@h,now"},
+			},
+		},
+		Defaults: map[string]interface{}{
+			"dataSources": map[string]interface{}{
+				"ds.search": map[string]interface{}{
+					"options": map[string]interface{}{
+						"queryParameters": map[string]string{
+							"earliest": "$global_time.earliest$",
+							"latest":   "$global_time.latest$",
+						},
+					},
+				},
+			},
+		},
+		Layout: map[string]interface{}{
+			"type":         "grid",
+			"options":      map[string]int{"width": generatedLayoutWidth},
+			"globalInputs": []string{"input_global_trp"},
+			"structure":    []interface{}{},
+		},
+	}
+}
+
+// addPanel adds a search, the visualization showing it and its place in the layout
+func (d *generatedDefinition) addPanel(id, title, vizType, query string, x, y, w, h int) {
+	d.DataSources["ds_"+id] = generatedPanel{
+		Type:    "ds.search",
+		Name:    title,
+		Options: map[string]interface{}{"query": query},
+	}
+	d.Visualizations["viz_"+id] = generatedPanel{
+		Type:        vizType,
+		Title:       title,
+		DataSources: map[string]string{"primary": "ds_" + id},
+	}
+	d.Layout["structure"] = append(d.Layout["structure"].([]interface{}), map[string]interface{}{
+		"item":     "viz_" + id,
+		"type":     "block",
+		"position": map[string]int{"x": x, "y": y, "w": w, "h": h},
+	})
+}
+
+// picklistFieldsOf returns the fields that look like picklists, at most
+// generatedPicklistMax of them
+func picklistFieldsOf(fields []string) []string {
+	var picklists []string
+	for _, field := range fields {
+		if len(picklists) == generatedPicklistMax {
+			break
+		}
+		if picklistFields[field] {
+			picklists = append(picklists, field)
+			continue
+		}
+		for _, suffix := range picklistSuffixes {
+			if strings.HasSuffix(field, suffix) || strings.HasSuffix(field, suffix+"__c") {
+				picklists = append(picklists, field)
+				break
+			}
+		}
+	}
+	return picklists
+}
+
+// indexAction returns the template action rendering the index of input. Input names
+// are quoted as raw strings, which the JSON encoding of the definition leaves intact; a
+// name containing a backquote keeps its literal index.
+func indexAction(input utils.DataInput, index string) string {
+	switch {
+	case input.Index == "":
+		return ""
+	case input.Index == index:
+		return "{{.Index}}"
+	case strings.Contains(input.Name, "`"):
+		return input.Index
+	}
+	return fmt.Sprintf("{{(index .Inputs `%s`).Index}}", input.Name)
+}
+
+// searchFilter matches field against any of values, or any value when there are none
+func searchFilter(field string, values []string) string {
+	if len(values) == 0 {
+		return field + "=*"
+	}
+	terms := make([]string, len(values))
+	for i, value := range values {
+		terms[i] = field + "=" + value
+	}
+	if len(terms) == 1 {
+		return terms[0]
+	}
+	return "(" + strings.Join(terms, " OR ") + ")"
+}
+
+// studioID turns a Salesforce name into a lowercase dashboard or component ID
+func studioID(name string) string {
+	return strings.Trim(studioIDPattern.ReplaceAllString(strings.ToLower(name), "_"), "_")
+}
+
+// appendUnique appends value unless it is empty or already present
+func appendUnique(values []string, value string) []string {
+	if value == "" || slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
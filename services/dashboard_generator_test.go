@@ -0,0 +1,189 @@
+package services_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+var generatorTestInputs = []utils.DataInput{
+	{Name: "Account_Input", Object: "Account", ObjectFields: "Id, Name, Industry, Type, LastModifiedDate", Index: "salesforce"},
+	{Name: "Opportunity_Input", Object: "Opportunity", ObjectFields: "Id,Name,StageName,Amount,Delivery_Status__c", Index: "sfdc_sales"},
+	{Name: "Opportunity_EMEA", Object: "opportunity", ObjectFields: "Id,Region__c", Index: "sfdc_emea"},
+}
+
+// generatedSearches maps the data sources of a generated definition to their queries
+func generatedSearches(t *testing.T, definition []byte) map[string]string {
+	t.Helper()
+	var parsed struct {
+		DataSources map[string]struct {
+			Options struct {
+				Query string `json:"query"`
+			} `json:"options"`
+		} `json:"dataSources"`
+	}
+	require.NoError(t, json.Unmarshal(definition, &parsed))
+	searches := make(map[string]string, len(parsed.DataSources))
+	for id, source := range parsed.DataSources {
+		searches[id] = source.Options.Query
+	}
+	return searches
+}
+
+func TestGenerateDashboards(t *testing.T) {
+	t.Run("Success_OnePerObjectAndOverview", func(t *testing.T) {
+		dashboards, err := services.GenerateDashboards(generatorTestInputs, "salesforce", "sfdc_")
+		require.NoError(t, err)
+		require.Len(t, dashboards, 3)
+
+		assert.Equal(t, "sfdc_account", dashboards[0].Name)
+		assert.Equal(t, "Account", dashboards[0].Object)
+		assert.Equal(t, map[string]string{
+			"ds_records_over_time": "index={{.Index}} sourcetype=sfdc:account | timechart count as records",
+			"ds_top_industry":      "index={{.Index}} sourcetype=sfdc:account | dedup Id | top limit=10 Industry",
+			"ds_top_type":          "index={{.Index}} sourcetype=sfdc:account | dedup Id | top limit=10 Type",
+			"ds_recent_records":    "index={{.Index}} sourcetype=sfdc:account | dedup Id | head 20 | table _time, Id, Name, Industry, Type, LastModifiedDate",
+		}, generatedSearches(t, dashboards[0].Definition))
+
+		// Both inputs of the object are searched, whatever the case of its name. Indexes
+		// other than SPLUNK_INDEX_NAME are read from the inputs when the file is rendered.
+		assert.Equal(t, "sfdc_opportunity", dashboards[1].Name)
+		assert.Equal(t, map[string]string{
+			"ds_records_over_time":      "(index={{(index .Inputs `Opportunity_Input`).Index}} OR index={{(index .Inputs `Opportunity_EMEA`).Index}}) sourcetype=sfdc:opportunity | timechart count as records",
+			"ds_top_stagename":          "(index={{(index .Inputs `Opportunity_Input`).Index}} OR index={{(index .Inputs `Opportunity_EMEA`).Index}}) sourcetype=sfdc:opportunity | dedup Id | top limit=10 StageName",
+			"ds_top_delivery_status__c": "(index={{(index .Inputs `Opportunity_Input`).Index}} OR index={{(index .Inputs `Opportunity_EMEA`).Index}}) sourcetype=sfdc:opportunity | dedup Id | top limit=10 Delivery_Status__c",
+			"ds_recent_records":         "(index={{(index .Inputs `Opportunity_Input`).Index}} OR index={{(index .Inputs `Opportunity_EMEA`).Index}}) sourcetype=sfdc:opportunity | dedup Id | head 20 | table _time, Id, Name, StageName, Amount, Delivery_Status__c, Region__c",
+		}, generatedSearches(t, dashboards[1].Definition))
+
+		assert.Equal(t, "sfdc_overview", dashboards[2].Name)
+		assert.Empty(t, dashboards[2].Object)
+		assert.Equal(t, map[string]string{
+			"ds_events_by_object": "(index={{.Index}} OR index={{(index .Inputs `Opportunity_Input`).Index}} OR index={{(index .Inputs `Opportunity_EMEA`).Index}}) (sourcetype=sfdc:account OR sourcetype=sfdc:opportunity) | timechart count by sourcetype",
+			"ds_objects":          "(index={{.Index}} OR index={{(index .Inputs `Opportunity_Input`).Index}} OR index={{(index .Inputs `Opportunity_EMEA`).Index}}) (sourcetype=sfdc:account OR sourcetype=sfdc:opportunity) | stats count as events, latest(_time) as last_event by sourcetype | convert ctime(last_event)",
+		}, generatedSearches(t, dashboards[2].Definition))
+	})
+
+	t.Run("Success_WithoutIdOrPicklists", func(t *testing.T) {
+		dashboards, err := services.GenerateDashboards([]utils.DataInput{
+			{Name: "Contact_Input", Object: "Contact", ObjectFields: "Email", Index: "salesforce"},
+		}, "salesforce", "")
+		require.NoError(t, err)
+		require.Len(t, dashboards, 2)
+		assert.Equal(t, []string{"contact", "overview"}, []string{dashboards[0].Name, dashboards[1].Name})
+		assert.Equal(t, map[string]string{
+			"ds_records_over_time": "index={{.Index}} sourcetype=sfdc:contact | timechart count as records",
+			"ds_recent_records":    "index={{.Index}} sourcetype=sfdc:contact | head 20 | table _time, Email",
+		}, generatedSearches(t, dashboards[0].Definition))
+	})
+
+	t.Run("Success_LiteralIndexForUnquotableName", func(t *testing.T) {
+		dashboards, err := services.GenerateDashboards([]utils.DataInput{
+			{Name: "Case`Input", Object: "Case", ObjectFields: "Email", Index: "sfdc_support"},
+		}, "salesforce", "")
+		require.NoError(t, err)
+		assert.Equal(t, "index=sfdc_support sourcetype=sfdc:case | timechart count as records",
+			generatedSearches(t, dashboards[0].Definition)["ds_records_over_time"])
+	})
+
+	t.Run("Success_PassesLint", func(t *testing.T) {
+		dashboards, err := services.GenerateDashboards(generatorTestInputs, "salesforce", "sfdc_")
+		require.NoError(t, err)
+		dir := t.TempDir()
+		_, _, err = services.WriteGeneratedDashboards(dir, dashboards, false)
+		require.NoError(t, err)
+
+		config := &utils.Config{Splunk: utils.SplunkConfig{IndexName: "salesforce"}, Extensions: map[string]interface{}{"DATA_INPUTS": []interface{}{
+			map[string]interface{}{"name": "Account_Input", "object": "Account", "index": "salesforce"},
+			map[string]interface{}{"name": "Opportunity_Input", "object": "Opportunity", "index": "sfdc_sales"},
+			map[string]interface{}{"name": "Opportunity_EMEA", "object": "Opportunity", "index": "sfdc_emea"},
+		}}}
+		result, err := services.LintDashboards(config, dir)
+		require.NoError(t, err)
+		assert.Equal(t, 3, result.Files)
+		assert.Empty(t, result.Problems)
+	})
+
+	t.Run("Error_InvalidPrefix", func(t *testing.T) {
+		_, err := services.GenerateDashboards(generatorTestInputs, "salesforce", "sfdc-")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid dashboard prefix")
+	})
+
+	t.Run("Error_NoInputs", func(t *testing.T) {
+		_, err := services.GenerateDashboards(nil, "salesforce", "sfdc_")
+		require.Error(t, err)
+	})
+
+	t.Run("Error_ObjectNamedOverview", func(t *testing.T) {
+		_, err := services.GenerateDashboards([]utils.DataInput{
+			{Name: "Overview_Input", Object: "Overview", Index: "salesforce"},
+		}, "salesforce", "sfdc_")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dashboard sfdc_overview would be generated for both Overview and the overview")
+	})
+}
+
+func TestWriteGeneratedDashboards(t *testing.T) {
+	dashboards, err := services.GenerateDashboards(generatorTestInputs[:1], "salesforce", "sfdc_")
+	require.NoError(t, err)
+
+	t.Run("Success_KeepsReviewedFiles", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "dashboards")
+		edited := filepath.Join(dir, "sfdc_account.json")
+
+		written, kept, err := services.WriteGeneratedDashboards(dir, dashboards, false)
+		require.NoError(t, err)
+		assert.Equal(t, []string{edited, filepath.Join(dir, "sfdc_overview.json")}, written)
+		assert.Empty(t, kept)
+		require.NoError(t, os.WriteFile(edited, []byte(`{"title": "Reviewed"}`), 0o644))
+
+		written, kept, err = services.WriteGeneratedDashboards(dir, dashboards, false)
+		require.NoError(t, err)
+		assert.Empty(t, written)
+		assert.Len(t, kept, 2)
+		data, err := os.ReadFile(edited)
+		require.NoError(t, err)
+		assert.Equal(t, `{"title": "Reviewed"}`, string(data))
+
+		written, _, err = services.WriteGeneratedDashboards(dir, dashboards, true)
+		require.NoError(t, err)
+		assert.Len(t, written, 2)
+		data, err = os.ReadFile(edited)
+		require.NoError(t, err)
+		assert.Equal(t, string(dashboards[0].Definition), string(data))
+	})
+
+	t.Run("Success_DeployedAsStudioDashboards", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Splunk.IndexName = "sfdc_prod"
+		})
+		dir := t.TempDir()
+		_, _, err := services.WriteGeneratedDashboards(dir, dashboards, false)
+		require.NoError(t, err)
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"sfdc_account":  models.DashboardCreated,
+			"sfdc_overview": models.DashboardCreated,
+		}, outcomeActions(result))
+		dashboard, _ := server.Dashboard("search", "sfdc_account")
+		assert.Contains(t, dashboard.Data, `<dashboard version="2" theme="light">`)
+		assert.Contains(t, dashboard.Data, "<label>Salesforce Account</label>")
+		// The index is rendered for the target the dashboards are deployed to
+		assert.Contains(t, dashboard.Data, "index=sfdc_prod sourcetype=sfdc:account")
+		assert.NotContains(t, dashboard.Data, "{{")
+	})
+}
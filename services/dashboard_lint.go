@@ -0,0 +1,542 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// searchSourcePattern matches the index=... and sourcetype=... terms of a search
+var searchSourcePattern = regexp.MustCompile(`(?i)\b(index|sourcetype)\s*=\s*(?:"([^"]*)"|([^\s"|()\[\]]+))`)
+
+// templateLinePattern extracts the line number from a text/template error
+var templateLinePattern = regexp.MustCompile(`template: [^:]+:(\d+)`)
+
+// dashboardSources are the indexes and sourcetypes the configured inputs write
+type dashboardSources struct {
+	indexes     []string
+	sourcetypes []string
+}
+
+// studioDashboard is the part of a Dashboard Studio definition the linter checks
+type studioDashboard struct {
+	DataSources    map[string]studioDataSource `json:"dataSources"`
+	Visualizations map[string]studioComponent  `json:"visualizations"`
+	Inputs         map[string]studioComponent  `json:"inputs"`
+	Layout         *studioLayout               `json:"layout"`
+}
+
+type studioDataSource struct {
+	Type    string `json:"type"`
+	Options struct {
+		Query  string `json:"query"`
+		Extend string `json:"extend"`
+	} `json:"options"`
+}
+
+type studioComponent struct {
+	Type        string            `json:"type"`
+	DataSources map[string]string `json:"dataSources"`
+}
+
+type studioLayout struct {
+	Type              string                        `json:"type"`
+	Structure         []studioLayoutItem            `json:"structure"`
+	GlobalInputs      []string                      `json:"globalInputs"`
+	LayoutDefinitions map[string]studioLayoutStruct `json:"layoutDefinitions"`
+}
+
+type studioLayoutStruct struct {
+	Structure []studioLayoutItem `json:"structure"`
+}
+
+type studioLayoutItem struct {
+	Item string `json:"item"`
+}
+
+// dashboardLinter collects the problems of one dashboard file
+type dashboardLinter struct {
+	file     string
+	sources  *dashboardSources
+	reported map[string]bool // Unknown indexes and sourcetypes already reported for the file
+	problems []models.DashboardLintProblem
+}
+
+// LintDashboards checks the dashboards of dashboardDir without contacting Splunk. Each
+// file is rendered as ReconcileDashboards renders it, then its Simple XML structure or
+// Dashboard Studio definition is validated, and the indexes and sourcetypes its
+// searches use are compared with those the configured inputs write.
+//
+// Line numbers refer to the rendered file, which matches the source unless a template
+// action adds or removes lines. The returned error is only set when the directory or
+// the configuration cannot be read.
+func LintDashboards(config *utils.Config, dashboardDir string) (*models.DashboardLintResult, error) {
+	result := &models.DashboardLintResult{Directory: dashboardDir}
+
+	data, err := newDashboardTemplateData(config)
+	if err != nil {
+		return result, err
+	}
+	sources, err := newDashboardSources(config)
+	if err != nil {
+		return result, err
+	}
+
+	entries, err := os.ReadDir(dashboardDir)
+	if err != nil {
+		return result, fmt.Errorf("failed to read dashboard directory: %w", err)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name() < entries[j].Name() })
+
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !dashboardExtensions[ext] {
+			continue
+		}
+		result.Files++
+
+		l := &dashboardLinter{
+			file:     filepath.Join(dashboardDir, entry.Name()),
+			sources:  sources,
+			reported: make(map[string]bool),
+		}
+		name := strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name()))
+		if previous, ok := seen[name]; ok {
+			l.errorf(0, "dashboard %s is also defined by %s", name, previous)
+		} else {
+			seen[name] = l.file
+			l.lintFile(ext, data)
+		}
+		sort.SliceStable(l.problems, func(i, j int) bool { return l.problems[i].Line < l.problems[j].Line })
+		result.Problems = append(result.Problems, l.problems...)
+	}
+
+	return result, nil
+}
+
+// LintDashboards checks the dashboards of dashboardDir against the configuration of
+// the service without contacting Splunk
+func (ds *DashboardService) LintDashboards(ctx context.Context, dashboardDir string) (*models.DashboardLintResult, error) {
+	return LintDashboards(ds.config, dashboardDir)
+}
+
+// newDashboardSources collects the indexes and sourcetypes written by the configured
+// data inputs and event log inputs
+func newDashboardSources(config *utils.Config) (*dashboardSources, error) {
+	sources := &dashboardSources{}
+
+	indexes, err := config.GetIndexes()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dashboard lint sources: %w", err)
+	}
+	for _, index := range indexes {
+		sources.indexes = append(sources.indexes, index.Name)
+	}
+
+	if _, exists := config.Extensions["DATA_INPUTS"]; exists {
+		inputs, err := config.GetDataInputs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dashboard lint sources: %w", err)
+		}
+		for _, input := range inputs {
+			sources.sourcetypes = append(sources.sourcetypes, sourcetype(input.Object))
+		}
+	}
+
+	eventLogInputs, err := config.GetEventLogInputs()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dashboard lint sources: %w", err)
+	}
+	if len(eventLogInputs) > 0 {
+		sources.sourcetypes = append(sources.sourcetypes, "sfdc:logfile")
+	}
+
+	return sources, nil
+}
+
+// lintFile renders the file and checks it as a Studio definition (.json) or XML source
+func (l *dashboardLinter) lintFile(ext string, data *dashboardTemplateData) {
+	source, err := os.ReadFile(l.file)
+	if err != nil {
+		l.errorf(0, "failed to read dashboard file: %v", err)
+		return
+	}
+
+	rendered, err := renderDashboard(filepath.Base(l.file), string(source), data)
+	if err != nil {
+		line := 0
+		if match := templateLinePattern.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+		}
+		l.errorf(line, "%v", err)
+		return
+	}
+
+	if ext == ".json" {
+		l.lintStudio([]byte(rendered), 1)
+		return
+	}
+	l.lintXML(rendered)
+}
+
+// lintXML checks a Simple XML dashboard or form, or the Dashboard Studio source that
+// wraps a definition
+func (l *dashboardLinter) lintXML(source string) {
+	type search struct {
+		line  int
+		base  string
+		ref   string
+		query strings.Builder
+		// queryLine is the line the query text starts on
+		queryLine int
+	}
+
+	decoder := xml.NewDecoder(strings.NewReader(source))
+	var (
+		stack    []string
+		root     bool
+		current  *search
+		inQuery  bool
+		studio   bool
+		ids      = make(map[string]bool)
+		searches []*search
+	)
+
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			var syntaxErr *xml.SyntaxError
+			if errors.As(err, &syntaxErr) {
+				l.errorf(syntaxErr.Line, "invalid XML: %s", syntaxErr.Msg)
+			} else {
+				l.errorf(0, "invalid XML: %v", err)
+			}
+			return
+		}
+		line, _ := decoder.InputPos()
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			parent := ""
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			}
+			stack = append(stack, name)
+
+			switch {
+			case parent == "":
+				root = true
+				if name != "dashboard" && name != "form" {
+					l.errorf(line, "root element must be <dashboard> or <form>, found <%s>", name)
+					return
+				}
+				studio = name == "dashboard" && xmlAttr(t, "version") == "2"
+			case name == "row" && len(stack) != 2:
+				l.errorf(line, "<row> must be a direct child of <%s>", stack[0])
+			case name == "panel" && parent != "row":
+				l.errorf(line, "<panel> must be inside a <row>")
+			case name == "search":
+				current = &search{line: line, base: xmlAttr(t, "base"), ref: xmlAttr(t, "ref")}
+				if id := xmlAttr(t, "id"); id != "" {
+					ids[id] = true
+				}
+				searches = append(searches, current)
+			case name == "query" && current != nil:
+				inQuery = true
+				current.queryLine = line
+			}
+		case xml.CharData:
+			if inQuery {
+				current.query.Write(t)
+			}
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+			switch t.Name.Local {
+			case "query":
+				inQuery = false
+			case "search":
+				current = nil
+			}
+		}
+	}
+
+	if !root {
+		l.errorf(0, "file has no <dashboard> or <form> element")
+		return
+	}
+
+	if studio {
+		match := studioDefinitionPattern.FindStringSubmatchIndex(source)
+		if match == nil {
+			l.errorf(0, "Dashboard Studio source has no <definition><![CDATA[...]]></definition>")
+			return
+		}
+		l.lintStudio([]byte(source[match[2]:match[3]]), 1+strings.Count(source[:match[2]], "\n"))
+		return
+	}
+
+	for _, s := range searches {
+		query := s.query.String()
+		switch {
+		case s.ref != "":
+			// Saved search; its query is defined in Splunk
+		case strings.TrimSpace(query) == "":
+			l.errorf(s.line, "<search> has no <query>")
+		default:
+			l.checkSearch(query, s.queryLine, true)
+		}
+		if s.base != "" && !ids[s.base] {
+			l.errorf(s.line, "<search base=%q> does not match the id of any <search>", s.base)
+		}
+	}
+}
+
+// lintStudio checks that a Dashboard Studio definition is valid JSON and that every
+// data source, visualization, input and layout reference resolves. firstLine is the
+// line of the file the definition starts on.
+func (l *dashboardLinter) lintStudio(definition []byte, firstLine int) {
+	var dashboard studioDashboard
+	if err := json.Unmarshal(definition, &dashboard); err != nil {
+		var syntaxErr *json.SyntaxError
+		var typeErr *json.UnmarshalTypeError
+		line := firstLine
+		switch {
+		case errors.As(err, &syntaxErr):
+			line += bytes.Count(definition[:syntaxErr.Offset], []byte("\n"))
+		case errors.As(err, &typeErr):
+			line += bytes.Count(definition[:typeErr.Offset], []byte("\n"))
+		}
+		l.errorf(line, "invalid Dashboard Studio definition: %v", err)
+		return
+	}
+
+	lines := jsonLines(definition, firstLine)
+	lineOf := func(path string) int {
+		for path != "" {
+			if line, ok := lines[path]; ok {
+				return line
+			}
+			i := strings.LastIndexAny(path, ".[")
+			if i < 0 {
+				break
+			}
+			path = path[:i]
+		}
+		return firstLine
+	}
+
+	if dashboard.DataSources == nil {
+		l.errorf(firstLine, "definition has no dataSources")
+	}
+	if dashboard.Visualizations == nil {
+		l.errorf(firstLine, "definition has no visualizations")
+	}
+	if dashboard.Layout == nil {
+		l.errorf(firstLine, "definition has no layout")
+	}
+
+	for _, id := range sortedKeys(dashboard.DataSources) {
+		source := dashboard.DataSources[id]
+		p := "dataSources." + id
+		switch source.Type {
+		case "":
+			l.errorf(lineOf(p), "data source %s has no type", id)
+		case "ds.search":
+			if strings.TrimSpace(source.Options.Query) == "" {
+				l.errorf(lineOf(p), "data source %s has no options.query", id)
+			}
+		case "ds.chain":
+			if _, ok := dashboard.DataSources[source.Options.Extend]; !ok {
+				l.errorf(lineOf(p+".options.extend"), "data source %s extends unknown data source %q", id, source.Options.Extend)
+			}
+		}
+		if source.Options.Query != "" {
+			l.checkSearch(source.Options.Query, lineOf(p+".options.query"), false)
+		}
+	}
+
+	components := map[string]map[string]studioComponent{"visualizations": dashboard.Visualizations, "inputs": dashboard.Inputs}
+	for _, kind := range []string{"visualizations", "inputs"} {
+		for _, id := range sortedKeys(components[kind]) {
+			component := components[kind][id]
+			p := kind + "." + id
+			if component.Type == "" {
+				l.errorf(lineOf(p), "%s %s has no type", strings.TrimSuffix(kind, "s"), id)
+			}
+			for _, role := range sortedKeys(component.DataSources) {
+				ref := component.DataSources[role]
+				if _, ok := dashboard.DataSources[ref]; !ok {
+					l.errorf(lineOf(p+".dataSources."+role), "%s %s uses unknown data source %q", strings.TrimSuffix(kind, "s"), id, ref)
+				}
+			}
+		}
+	}
+
+	if dashboard.Layout == nil {
+		return
+	}
+	checkStructure := func(p string, structure []studioLayoutItem) {
+		for i, item := range structure {
+			_, isVisualization := dashboard.Visualizations[item.Item]
+			_, isInput := dashboard.Inputs[item.Item]
+			if !isVisualization && !isInput {
+				l.errorf(lineOf(fmt.Sprintf("%s[%d].item", p, i)), "layout places unknown visualization %q", item.Item)
+			}
+		}
+	}
+	checkStructure("layout.structure", dashboard.Layout.Structure)
+	for _, id := range sortedKeys(dashboard.Layout.LayoutDefinitions) {
+		checkStructure("layout.layoutDefinitions."+id+".structure", dashboard.Layout.LayoutDefinitions[id].Structure)
+	}
+	for i, input := range dashboard.Layout.GlobalInputs {
+		if _, ok := dashboard.Inputs[input]; !ok {
+			l.errorf(lineOf(fmt.Sprintf("layout.globalInputs[%d]", i)), "layout uses unknown input %q", input)
+		}
+	}
+}
+
+// checkSearch warns about indexes and sourcetypes that no configured input writes.
+// Terms using tokens ($token$) and internal indexes (_internal) are not checked, and
+// each unknown value is reported once per file. When multiline is set, query is text
+// of the file that starts on line, as in Simple XML, and each term is reported on its
+// own line; otherwise, as for decoded Dashboard Studio JSON, every term is reported on
+// line.
+func (l *dashboardLinter) checkSearch(query string, line int, multiline bool) {
+	for _, match := range searchSourcePattern.FindAllStringSubmatchIndex(query, -1) {
+		field := strings.ToLower(query[match[2]:match[3]])
+		var value string
+		if match[4] >= 0 {
+			value = query[match[4]:match[5]]
+		} else {
+			value = query[match[6]:match[7]]
+		}
+		if value == "" || strings.Contains(value, "$") || (field == "index" && strings.HasPrefix(value, "_")) {
+			continue
+		}
+
+		known, what := l.sources.indexes, "no configured input writes to"
+		if field == "sourcetype" {
+			known, what = l.sources.sourcetypes, "no configured DATA_INPUTS object produces"
+		}
+		key := field + "=" + strings.ToLower(value)
+		if matchesSource(known, value) || l.reported[key] {
+			continue
+		}
+		l.reported[key] = true
+		termLine := line
+		if multiline {
+			termLine += strings.Count(query[:match[0]], "\n")
+		}
+		l.warnf(termLine, "search uses %s %q, which %s", field, value, what)
+	}
+}
+
+// matchesSource reports whether value, which may contain * wildcards, matches one of
+// known. Splunk compares index and sourcetype names case-insensitively.
+func matchesSource(known []string, value string) bool {
+	value = strings.ToLower(value)
+	for _, name := range known {
+		if ok, _ := path.Match(value, strings.ToLower(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonLines maps the path of every object member of a JSON document, such as
+// "visualizations.viz_1.dataSources.primary", to the line of its key
+func jsonLines(data []byte, firstLine int) map[string]int {
+	lines := make(map[string]int)
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	var walk func(p string) error
+	walk = func(p string) error {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		delim, ok := token.(json.Delim)
+		if !ok {
+			return nil
+		}
+		for i := 0; decoder.More(); i++ {
+			child := fmt.Sprintf("%s[%d]", p, i)
+			if delim == '{' {
+				key, err := decoder.Token()
+				if err != nil {
+					return err
+				}
+				child = fmt.Sprint(key)
+				if p != "" {
+					child = p + "." + child
+				}
+				lines[child] = firstLine + bytes.Count(data[:decoder.InputOffset()], []byte("\n"))
+			}
+			if err := walk(child); err != nil {
+				return err
+			}
+		}
+		_, err = decoder.Token()
+		return err
+	}
+
+	// Syntax errors are reported by the caller; the lines found so far are still useful
+	_ = walk("")
+	return lines
+}
+
+// xmlAttr returns the value of an attribute of an XML element
+func xmlAttr(element xml.StartElement, name string) string {
+	for _, attr := range element.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// sortedKeys returns the keys of m in order, so problems are reported deterministically
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func (l *dashboardLinter) errorf(line int, format string, args ...interface{}) {
+	l.add(models.LintError, line, format, args...)
+}
+
+func (l *dashboardLinter) warnf(line int, format string, args ...interface{}) {
+	l.add(models.LintWarning, line, format, args...)
+}
+
+func (l *dashboardLinter) add(severity string, line int, format string, args ...interface{}) {
+	l.problems = append(l.problems, models.DashboardLintProblem{
+		File:     l.file,
+		Line:     line,
+		Severity: severity,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
@@ -0,0 +1,235 @@
+package services_test
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+func newLintTestConfig() *utils.Config {
+	return &utils.Config{
+		Splunk: utils.SplunkConfig{IndexName: "salesforce", DefaultIndex: "salesforce"},
+		Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "Account_Input", "object": "Account"},
+				map[string]interface{}{"name": "Opportunity_Input", "object": "Opportunity", "index": "sfdc_sales"},
+			},
+			"DASHBOARD_VARS": map[string]interface{}{"env": "prod"},
+		},
+	}
+}
+
+// lintProblems lints files and returns their problems without the directory prefix
+func lintProblems(t *testing.T, files map[string]string) []models.DashboardLintProblem {
+	t.Helper()
+	dir := writeDashboards(t, files)
+	result, err := services.LintDashboards(newLintTestConfig(), dir)
+	require.NoError(t, err)
+	assert.Equal(t, len(files), result.Files)
+	for i := range result.Problems {
+		result.Problems[i].File = filepath.Base(result.Problems[i].File)
+	}
+	return result.Problems
+}
+
+func TestLintDashboards(t *testing.T) {
+	t.Run("Success_ValidDashboards", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"accounts.xml": `<form>
+  <label>Accounts ({{.Vars.env}})</label>
+  <search id="base"><query>index="{{.Index}}" sourcetype="sfdc:account" | fields Name, Owner</query></search>
+  <row>
+    <panel>
+      <table><search base="base"><query>| stats count by Owner</query></search></table>
+    </panel>
+    <panel>
+      <chart><search ref="Weekly accounts"></search></chart>
+    </panel>
+    <panel>
+      <single><search><query>index=sfdc_* sourcetype=SFDC:Opportunity owner="$owner$" | stats count</query></search></single>
+    </panel>
+    <panel>
+      <event><search><query>index=_internal sourcetype=splunkd component=ExecProcessor</query></search></event>
+    </panel>
+  </row>
+</form>`,
+			"sales.json": `{
+  "title": "Sales",
+  "dataSources": {
+    "ds_base": {"type": "ds.search", "options": {"query": "index={{.Objects.Opportunity.Index}} sourcetype={{.Objects.Opportunity.Object | sourcetype}}"}},
+    "ds_total": {"type": "ds.chain", "options": {"extend": "ds_base", "query": "| stats sum(Amount)"}}
+  },
+  "visualizations": {"viz_total": {"type": "splunk.singlevalue", "dataSources": {"primary": "ds_total"}}},
+  "inputs": {"input_time": {"type": "input.timerange"}},
+  "layout": {"type": "absolute", "globalInputs": ["input_time"], "structure": [{"item": "viz_total"}]}
+}`,
+		})
+
+		// sourcetype=splunkd is the only reference no configured input produces
+		assert.Equal(t, []models.DashboardLintProblem{
+			{File: "accounts.xml", Line: 15, Severity: models.LintWarning, Message: `search uses sourcetype "splunkd", which no configured DATA_INPUTS object produces`},
+		}, problems)
+	})
+
+	t.Run("Error_SimpleXMLStructure", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"structure.xml": `<dashboard>
+  <label>Structure</label>
+  <panel><single><search><query>index=salesforce</query></search></single></panel>
+  <row>
+    <panel>
+      <table><search></search></table>
+      <chart><search base="missing"><query>| stats count</query></search></chart>
+    </panel>
+  </row>
+</dashboard>`,
+			"syntax.xml": "<dashboard>\n  <label>Broken</label>\n  <row>\n</dashboard>\n",
+			"root.xml":   "<html><body/></html>",
+			"empty.xml":  "",
+		})
+
+		assert.Equal(t, []models.DashboardLintProblem{
+			{File: "empty.xml", Severity: models.LintError, Message: "file has no <dashboard> or <form> element"},
+			{File: "root.xml", Line: 1, Severity: models.LintError, Message: "root element must be <dashboard> or <form>, found <html>"},
+			{File: "structure.xml", Line: 3, Severity: models.LintError, Message: "<panel> must be inside a <row>"},
+			{File: "structure.xml", Line: 6, Severity: models.LintError, Message: "<search> has no <query>"},
+			{File: "structure.xml", Line: 7, Severity: models.LintError, Message: `<search base="missing"> does not match the id of any <search>`},
+			{File: "syntax.xml", Line: 4, Severity: models.LintError, Message: "invalid XML: element <row> closed by </dashboard>"},
+		}, problems)
+	})
+
+	t.Run("Error_StudioReferences", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"sales.json": `{
+  "title": "Sales",
+  "dataSources": {
+    "ds_empty": {"type": "ds.search", "options": {}},
+    "ds_chain": {
+      "type": "ds.chain",
+      "options": {"extend": "ds_missing", "query": "| stats count"}
+    }
+  },
+  "visualizations": {
+    "viz_table": {
+      "type": "splunk.table",
+      "dataSources": {"primary": "ds_unknown"}
+    },
+    "viz_untyped": {}
+  },
+  "layout": {
+    "type": "absolute",
+    "globalInputs": ["input_missing"],
+    "structure": [
+      {"item": "viz_table"},
+      {"item": "viz_gone"}
+    ]
+  }
+}`,
+			"partial.json": `{"dataSources": {}}`,
+		})
+
+		assert.Equal(t, []models.DashboardLintProblem{
+			{File: "partial.json", Line: 1, Severity: models.LintError, Message: "definition has no visualizations"},
+			{File: "partial.json", Line: 1, Severity: models.LintError, Message: "definition has no layout"},
+			{File: "sales.json", Line: 4, Severity: models.LintError, Message: "data source ds_empty has no options.query"},
+			{File: "sales.json", Line: 7, Severity: models.LintError, Message: `data source ds_chain extends unknown data source "ds_missing"`},
+			{File: "sales.json", Line: 13, Severity: models.LintError, Message: `visualization viz_table uses unknown data source "ds_unknown"`},
+			{File: "sales.json", Line: 15, Severity: models.LintError, Message: "visualization viz_untyped has no type"},
+			{File: "sales.json", Line: 19, Severity: models.LintError, Message: `layout uses unknown input "input_missing"`},
+			{File: "sales.json", Line: 22, Severity: models.LintError, Message: `layout places unknown visualization "viz_gone"`},
+		}, problems)
+	})
+
+	t.Run("Error_StudioDefinitionInXML", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"studio.xml": `<dashboard version="2" theme="light">
+  <label>Studio</label>
+  <definition><![CDATA[
+{
+  "dataSources": {"ds_1": {"type": "ds.search", "options": {"query": "index=salesforce sourcetype=sfdc:lead"}}},
+  "visualizations": {"viz_1": {"type": "splunk.table", "dataSources": {"primary": "ds_2"}}},
+  "layout": {"type": "absolute", "structure": [{"item": "viz_1"}]}
+}
+]]></definition>
+</dashboard>`,
+			"nodefinition.xml": `<dashboard version="2"><label>Studio</label></dashboard>`,
+			"badjson.xml":      "<dashboard version=\"2\">\n  <definition><![CDATA[\n{\n  \"dataSources\": {,}\n}\n]]></definition>\n</dashboard>",
+		})
+
+		assert.Equal(t, []models.DashboardLintProblem{
+			{File: "badjson.xml", Line: 4, Severity: models.LintError, Message: "invalid Dashboard Studio definition: invalid character ',' looking for beginning of object key string"},
+			{File: "nodefinition.xml", Severity: models.LintError, Message: "Dashboard Studio source has no <definition><![CDATA[...]]></definition>"},
+			{File: "studio.xml", Line: 5, Severity: models.LintWarning, Message: `search uses sourcetype "sfdc:lead", which no configured DATA_INPUTS object produces`},
+			{File: "studio.xml", Line: 6, Severity: models.LintError, Message: `visualization viz_1 uses unknown data source "ds_2"`},
+		}, problems)
+	})
+
+	t.Run("Warning_UnknownSourcesReportedOncePerFile", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"legacy.xml": `<dashboard>
+  <row>
+    <panel><single><search><query>index="devsecops_hub_qa" sourcetype="sfdc:app__c" | stats count</query></search></single></panel>
+    <panel><table><search><query>
+index="devsecops_hub_qa" sourcetype="sfdc:account"
+| join Id [search index=archive]
+</query></search></table></panel>
+  </row>
+</dashboard>`,
+		})
+
+		assert.Equal(t, []models.DashboardLintProblem{
+			{File: "legacy.xml", Line: 3, Severity: models.LintWarning, Message: `search uses index "devsecops_hub_qa", which no configured input writes to`},
+			{File: "legacy.xml", Line: 3, Severity: models.LintWarning, Message: `search uses sourcetype "sfdc:app__c", which no configured DATA_INPUTS object produces`},
+			{File: "legacy.xml", Line: 6, Severity: models.LintWarning, Message: `search uses index "archive", which no configured input writes to`},
+		}, problems)
+	})
+
+	t.Run("Warning_MultilineStudioQueryReportsQueryLine", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"studio.json": `{
+  "dataSources": {
+    "ds_1": {"type": "ds.search", "options": {"query": "index=salesforce\n| search x\n| search y\n| append [search sourcetype=bogus]"}}
+  },
+  "visualizations": {},
+  "layout": {"type": "absolute"}
+}`,
+		})
+
+		assert.Equal(t, []models.DashboardLintProblem{
+			{File: "studio.json", Line: 3, Severity: models.LintWarning, Message: `search uses sourcetype "bogus", which no configured DATA_INPUTS object produces`},
+		}, problems)
+	})
+
+	t.Run("Error_TemplateReportsLine", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"region.xml": "<dashboard>\n  <label>Sales</label>\n  <description>{{.Vars.region}}</description>\n</dashboard>",
+		})
+
+		require.Len(t, problems, 1)
+		assert.Equal(t, 3, problems[0].Line)
+		assert.Equal(t, models.LintError, problems[0].Severity)
+		assert.Contains(t, problems[0].Message, `map has no entry for key "region"`)
+	})
+
+	t.Run("Error_DuplicateDashboardName", func(t *testing.T) {
+		problems := lintProblems(t, map[string]string{
+			"home.json": `{"dataSources": {}, "visualizations": {}, "layout": {"type": "absolute"}}`,
+			"home.xml":  homeDashboard,
+		})
+
+		require.Len(t, problems, 1)
+		assert.Equal(t, "home.xml", problems[0].File)
+		assert.Contains(t, problems[0].Message, "dashboard home is also defined by")
+	})
+
+	t.Run("Error_MissingDirectory", func(t *testing.T) {
+		_, err := services.LintDashboards(newLintTestConfig(), filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+	})
+}
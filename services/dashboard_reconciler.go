@@ -0,0 +1,378 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// dashboardExtensions are the file types deployed from the dashboard directory: Simple
+// XML or Dashboard Studio sources (.xml) and bare Dashboard Studio definitions (.json)
+var dashboardExtensions = map[string]bool{".xml": true, ".json": true}
+
+// studioDefinitionPattern matches the JSON definition embedded in a Dashboard Studio source
+var studioDefinitionPattern = regexp.MustCompile(`(?s)<definition>\s*<!\[CDATA\[(.*?)\]\]>\s*</definition>`)
+
+// dashboardFile is a dashboard read from the dashboard directory
+type dashboardFile struct {
+	name string // View name: the file name without extension
+	path string
+	data string // Source deployed as eai:data
+	err  error  // Set when the file cannot be deployed
+}
+
+// ReconcileDashboards makes the dashboards of the configured apps match the files of
+// dashboardDir, rendered as templates: missing dashboards are created, changed ones
+// updated and identical ones left alone. Each dashboard is deployed to the app of its
+// DASHBOARD_ACL entry, or MIGRATION_DASHBOARD_APP, and gets the sharing and roles
+// configured for it. With MIGRATION_DASHBOARD_PRUNE, dashboards whose file was removed are
+// deleted, as long as their name starts with MIGRATION_DASHBOARD_PRUNE_PREFIX.
+//
+// Only views owned by the configured owner, or shared at app or global level, are
+// reconciled or pruned. Private views of other users are never touched.
+//
+// Every dashboard gets an outcome. A failed dashboard does not stop the others; the
+// returned error then summarizes the failures.
+func (ds *DashboardService) ReconcileDashboards(ctx context.Context, dashboardDir string) (*models.DashboardReconcileResult, error) {
+	result := &models.DashboardReconcileResult{Directory: dashboardDir}
+	defaultACL := ds.config.DefaultDashboardACL()
+
+	ds.logger.Info("Reconciling dashboards",
+		utils.String("directory", dashboardDir),
+		utils.String("app", defaultACL.App))
+
+	data, err := newDashboardTemplateData(ds.config)
+	if err != nil {
+		return result, err
+	}
+
+	files, err := loadDashboardFiles(dashboardDir, data)
+	if err != nil {
+		return result, err
+	}
+
+	acls, err := ds.config.GetDashboardACLs()
+	if err != nil {
+		return result, err
+	}
+	fileACLs := make([]utils.DashboardACL, len(files))
+	apps := []string{defaultACL.App}
+	for i, file := range files {
+		acl, ok := acls[file.name]
+		if !ok {
+			acl = defaultACL
+		}
+		fileACLs[i] = acl
+		if !slices.Contains(apps, acl.App) {
+			apps = append(apps, acl.App)
+		}
+	}
+
+	// Dashboards are listed in every app the directory deploys to. The listing holds the
+	// views of every owner, so a name can appear once per owner.
+	existing := make(map[string][]*models.Dashboard, len(apps))
+	for _, app := range apps {
+		dashboards, err := ds.splunkService.ListDashboards(ctx, app)
+		if err != nil {
+			return result, err
+		}
+		existing[app] = dashboards
+	}
+
+	local := make(map[string]map[string]bool, len(apps))
+	for i, file := range files {
+		acl := fileACLs[i]
+		if local[acl.App] == nil {
+			local[acl.App] = make(map[string]bool)
+		}
+		local[acl.App][file.name] = true
+		outcome := ds.reconcileDashboard(ctx, acl, file, managedDashboard(existing[acl.App], file.name, acl.Owner))
+		ds.logOutcome(outcome)
+		result.Outcomes = append(result.Outcomes, outcome)
+	}
+
+	if ds.config.Migration.DashboardPrune {
+		for _, app := range apps {
+			for _, dashboard := range existing[app] {
+				if local[app][dashboard.Name] || !strings.HasPrefix(dashboard.Name, ds.config.Migration.DashboardPrunePrefix) {
+					continue
+				}
+				owner := defaultACL.Owner
+				if acl, ok := acls[dashboard.Name]; ok {
+					owner = acl.Owner
+				}
+				if !managedBy(dashboard, owner) {
+					continue
+				}
+				outcome := models.DashboardOutcome{
+					Name:            dashboard.Name,
+					Action:          models.DashboardDeleted,
+					PreviousVersion: dashboardVersion(dashboard.Data),
+				}
+				if err := ds.splunkService.DeleteDashboard(ctx, dashboard); err != nil {
+					outcome.Action, outcome.Err = models.DashboardFailed, err
+				}
+				ds.logOutcome(outcome)
+				result.Outcomes = append(result.Outcomes, outcome)
+			}
+		}
+	}
+
+	if failed := result.Failed(); failed > 0 {
+		var errs []error
+		for _, outcome := range result.Outcomes {
+			if outcome.Err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", outcome.Name, outcome.Err))
+			}
+		}
+		return result, fmt.Errorf("%d of %d dashboards failed to reconcile: %w", failed, len(result.Outcomes), errors.Join(errs...))
+	}
+	return result, nil
+}
+
+// reconcileDashboard creates, updates or skips the dashboard of one file and applies
+// its sharing and roles. current is the dashboard found in the app of acl, or nil.
+func (ds *DashboardService) reconcileDashboard(ctx context.Context, acl utils.DashboardACL, file dashboardFile, current *models.Dashboard) models.DashboardOutcome {
+	outcome := models.DashboardOutcome{Name: file.name, File: file.path}
+	if file.err != nil {
+		outcome.Action, outcome.Err = models.DashboardFailed, file.err
+		return outcome
+	}
+	outcome.Version = dashboardVersion(file.data)
+
+	if current == nil {
+		dashboard := &models.Dashboard{Name: file.name, App: acl.App, Owner: acl.Owner, Data: file.data}
+		outcome.Action = models.DashboardCreated
+		if err := ds.splunkService.CreateDashboard(ctx, dashboard); err != nil {
+			outcome.Action, outcome.Err = models.DashboardFailed, err
+			return outcome
+		}
+		// If the permissions fail, the next run finds the dashboard with the wrong
+		// ACL and sets them again
+		if acl.HasPermissions() {
+			if err := ds.splunkService.SetDashboardACL(ctx, dashboard, acl); err != nil {
+				outcome.Action, outcome.Err = models.DashboardFailed, err
+			}
+		}
+		return outcome
+	}
+
+	// The view is updated in the context of its owner, so a dashboard created by
+	// another user is replaced rather than shadowed by a second copy
+	dashboard := &models.Dashboard{Name: file.name, App: acl.App, Owner: current.Owner, Sharing: current.Sharing, Data: file.data}
+	outcome.PreviousVersion = dashboardVersion(current.Data)
+	outcome.Action = models.DashboardUnchanged
+	if !sameDashboard(file.data, current.Data) {
+		outcome.Action = models.DashboardUpdated
+		if err := ds.splunkService.UpdateDashboard(ctx, dashboard); err != nil {
+			outcome.Action, outcome.Err = models.DashboardFailed, err
+			return outcome
+		}
+	}
+
+	if acl.HasPermissions() && !sameACL(current, acl) {
+		outcome.Action = models.DashboardUpdated
+		if err := ds.splunkService.SetDashboardACL(ctx, dashboard, acl); err != nil {
+			outcome.Action, outcome.Err = models.DashboardFailed, err
+		}
+	}
+	return outcome
+}
+
+// managedDashboard returns the view of dashboards that the file name deploys to: the
+// view of owner, or else a shared view of another owner. It returns nil when there is
+// neither, in which case the dashboard is created.
+func managedDashboard(dashboards []*models.Dashboard, name, owner string) *models.Dashboard {
+	var shared *models.Dashboard
+	for _, dashboard := range dashboards {
+		if dashboard.Name != name {
+			continue
+		}
+		if dashboard.Owner == owner {
+			return dashboard
+		}
+		if shared == nil && dashboard.Shared() {
+			shared = dashboard
+		}
+	}
+	return shared
+}
+
+// managedBy reports whether a view belongs to the dashboards managed for owner
+func managedBy(dashboard *models.Dashboard, owner string) bool {
+	return dashboard.Owner == owner || dashboard.Shared()
+}
+
+// logOutcome logs the action taken for a dashboard
+func (ds *DashboardService) logOutcome(outcome models.DashboardOutcome) {
+	fields := []utils.Field{
+		utils.String("name", outcome.Name),
+		utils.String("action", outcome.Action),
+	}
+	if outcome.Version != "" {
+		fields = append(fields, utils.String("version", outcome.Version))
+	}
+	if outcome.PreviousVersion != "" {
+		fields = append(fields, utils.String("previous_version", outcome.PreviousVersion))
+	}
+
+	if outcome.Err != nil {
+		ds.logger.Error("Failed to reconcile dashboard", append(fields, utils.Err(outcome.Err))...)
+		return
+	}
+	ds.logger.Info("Reconciled dashboard", fields...)
+}
+
+// dashboardApp returns the app dashboards without a DASHBOARD_ACL entry are deployed to
+func dashboardApp(config *utils.Config) string {
+	return config.DefaultDashboardACL().App
+}
+
+// sameACL reports whether a deployed dashboard already has the owner, sharing and
+// roles of acl. Roles acl leaves empty are not compared, and role order is ignored.
+func sameACL(dashboard *models.Dashboard, acl utils.DashboardACL) bool {
+	sameRoles := func(current, desired []string) bool {
+		if len(desired) == 0 {
+			return true
+		}
+		a, b := slices.Clone(current), slices.Clone(desired)
+		slices.Sort(a)
+		slices.Sort(b)
+		return slices.Equal(a, b)
+	}
+	return dashboard.Owner == acl.Owner && dashboard.Sharing == acl.Sharing &&
+		sameRoles(dashboard.Read, acl.Read) && sameRoles(dashboard.Write, acl.Write)
+}
+
+// loadDashboardFiles reads and renders the dashboards of dir, sorted by file name. A
+// file that cannot be read, rendered or converted is returned with its error so that
+// it gets an outcome.
+func loadDashboardFiles(dir string, data *dashboardTemplateData) ([]dashboardFile, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read dashboard directory: %w", err)
+	}
+
+	var files []dashboardFile
+	seen := make(map[string]string)
+	for _, entry := range entries {
+		ext := strings.ToLower(filepath.Ext(entry.Name()))
+		if entry.IsDir() || !dashboardExtensions[ext] {
+			continue
+		}
+
+		file := dashboardFile{
+			name: strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())),
+			path: filepath.Join(dir, entry.Name()),
+		}
+		if previous, ok := seen[file.name]; ok {
+			file.err = fmt.Errorf("dashboard %s is also defined by %s", file.name, previous)
+			files = append(files, file)
+			continue
+		}
+		seen[file.name] = file.path
+
+		source, err := os.ReadFile(file.path)
+		if err != nil {
+			file.err = fmt.Errorf("failed to read dashboard file: %w", err)
+			files = append(files, file)
+			continue
+		}
+
+		file.data, file.err = renderDashboard(entry.Name(), string(source), data)
+		if file.err == nil && ext == ".json" {
+			file.data, file.err = studioSource(file.name, []byte(file.data))
+		}
+		files = append(files, file)
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].path < files[j].path })
+	return files, nil
+}
+
+// studioSource wraps a bare Dashboard Studio definition in the XML source Splunk stores
+// for Studio dashboards. The label is the definition title, or the dashboard name.
+func studioSource(name string, definition []byte) (string, error) {
+	var header struct {
+		Title       string `json:"title"`
+		Description string `json:"description"`
+	}
+	if err := json.Unmarshal(definition, &header); err != nil {
+		return "", fmt.Errorf("invalid Dashboard Studio definition: %w", err)
+	}
+	if strings.Contains(string(definition), "]]>") {
+		return "", fmt.Errorf("invalid Dashboard Studio definition: must not contain \"]]>\"")
+	}
+	if header.Title == "" {
+		header.Title = name
+	}
+
+	var label, description strings.Builder
+	xml.EscapeText(&label, []byte(header.Title))
+	xml.EscapeText(&description, []byte(header.Description))
+
+	return fmt.Sprintf("<dashboard version=\"2\" theme=\"light\">\n  <label>%s</label>\n  <description>%s</description>\n  <definition><![CDATA[\n%s\n]]></definition>\n</dashboard>\n",
+		label.String(), description.String(), strings.TrimSpace(string(definition))), nil
+}
+
+// sameDashboard reports whether two dashboard sources are equivalent. Line endings
+// and trailing whitespace are ignored, and Dashboard Studio definitions are compared
+// as JSON so that Splunk reformatting them does not count as a change.
+func sameDashboard(a, b string) bool {
+	a, b = normalizeDashboard(a), normalizeDashboard(b)
+	if a == b {
+		return true
+	}
+
+	defA, okA := studioDefinition(a)
+	defB, okB := studioDefinition(b)
+	if !okA || !okB {
+		return false
+	}
+	if studioDefinitionPattern.ReplaceAllString(a, "") != studioDefinitionPattern.ReplaceAllString(b, "") {
+		return false
+	}
+	return reflect.DeepEqual(defA, defB)
+}
+
+// studioDefinition decodes the JSON definition of a Dashboard Studio source
+func studioDefinition(source string) (interface{}, bool) {
+	match := studioDefinitionPattern.FindStringSubmatch(source)
+	if match == nil {
+		return nil, false
+	}
+	var definition interface{}
+	if err := json.Unmarshal([]byte(match[1]), &definition); err != nil {
+		return nil, false
+	}
+	return definition, true
+}
+
+// normalizeDashboard removes line ending and trailing whitespace differences
+func normalizeDashboard(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// dashboardVersion returns a short digest identifying a dashboard definition
+func dashboardVersion(source string) string {
+	sum := sha256.Sum256([]byte(normalizeDashboard(source)))
+	return hex.EncodeToString(sum[:])[:12]
+}
@@ -0,0 +1,448 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+const (
+	homeDashboard      = "<dashboard>\n  <label>Home</label>\n</dashboard>\n"
+	analyticsDashboard = "<dashboard>\n  <label>Analytics</label>\n  <row><panel><single><search><query>index=salesforce | stats count</query></search></single></panel></row>\n</dashboard>\n"
+	studioDefinition   = `{"title": "Accounts", "dataSources": {}, "visualizations": {}, "layout": {"type": "absolute", "structure": []}}`
+)
+
+// newDashboardTestService creates a dashboard service that deploys to the search app
+// of server
+func newDashboardTestService(t *testing.T, server *splunktest.Server, configure func(*utils.Config)) *services.DashboardService {
+	t.Helper()
+	require.NoError(t, utils.InitializeGlobalLogger("test", "dashboard_reconciler", false))
+
+	config := &utils.Config{
+		Splunk: utils.SplunkConfig{URL: server.URL, Username: splunktest.Username, Password: splunktest.Password},
+		Migration: utils.MigrationConfig{
+			DashboardApp:   utils.DefaultDashboardApp,
+			DashboardOwner: utils.DefaultDashboardOwner,
+		},
+	}
+	if configure != nil {
+		configure(config)
+	}
+
+	splunkService, err := services.NewSplunkService(config)
+	require.NoError(t, err)
+	require.NoError(t, splunkService.Authenticate(context.Background()))
+	dashboardService, err := services.NewDashboardService(config, splunkService)
+	require.NoError(t, err)
+	return dashboardService
+}
+
+// writeDashboards writes dashboard files to a new directory
+func writeDashboards(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	return dir
+}
+
+// outcomeActions maps dashboard names to the action taken
+func outcomeActions(result *models.DashboardReconcileResult) map[string]string {
+	actions := make(map[string]string, len(result.Outcomes))
+	for _, outcome := range result.Outcomes {
+		actions[outcome.Name] = outcome.Action
+	}
+	return actions
+}
+
+func TestDashboardService_ReconcileDashboards(t *testing.T) {
+	t.Run("Success_CreatesThenLeavesUnchanged", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, nil)
+		dir := writeDashboards(t, map[string]string{
+			"home_dashboard.xml":      homeDashboard,
+			"analytics_dashboard.xml": analyticsDashboard,
+			"README.md":               "not a dashboard",
+		})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"analytics_dashboard": models.DashboardCreated,
+			"home_dashboard":      models.DashboardCreated,
+		}, outcomeActions(result))
+		assert.Equal(t, []string{"analytics_dashboard", "home_dashboard"}, server.Dashboards("search"))
+		dashboard, _ := server.Dashboard("search", "home_dashboard")
+		assert.Equal(t, homeDashboard, dashboard.Data)
+		assert.Equal(t, "app", dashboard.ACL.Sharing)
+
+		result, err = service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"analytics_dashboard": models.DashboardUnchanged,
+			"home_dashboard":      models.DashboardUnchanged,
+		}, outcomeActions(result))
+		assert.Equal(t, 2, server.CountRequests(http.MethodPost, "/servicesNS/nobody/search/data/ui/views"))
+	})
+
+	t.Run("Success_UpdatesChangedDashboard", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddDashboard(splunktest.Dashboard{
+			Name: "analytics_dashboard",
+			Data: "<dashboard><label>Broken</label></dashboard>",
+			ACL:  splunktest.ACL{App: "search"},
+		})
+		service := newDashboardTestService(t, server, nil)
+		dir := writeDashboards(t, map[string]string{"analytics_dashboard.xml": analyticsDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		require.Len(t, result.Outcomes, 1)
+		outcome := result.Outcomes[0]
+		assert.Equal(t, models.DashboardUpdated, outcome.Action)
+		assert.Equal(t, filepath.Join(dir, "analytics_dashboard.xml"), outcome.File)
+		assert.NotEmpty(t, outcome.Version)
+		assert.NotEmpty(t, outcome.PreviousVersion)
+		assert.NotEqual(t, outcome.Version, outcome.PreviousVersion)
+
+		assert.Equal(t, []string{"analytics_dashboard"}, server.Dashboards("search"), "dashboard must not be duplicated")
+		dashboard, _ := server.Dashboard("search", "analytics_dashboard")
+		assert.Equal(t, analyticsDashboard, dashboard.Data)
+	})
+
+	t.Run("Success_IgnoresFormattingDifferences", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddDashboard(splunktest.Dashboard{
+			Name: "home_dashboard",
+			Data: "<dashboard>  \r\n  <label>Home</label>\r\n</dashboard>",
+			ACL:  splunktest.ACL{App: "search"},
+		})
+		server.AddDashboard(splunktest.Dashboard{
+			Name: "accounts",
+			Data: "<dashboard version=\"2\" theme=\"light\">\n  <label>Accounts</label>\n  <description></description>\n  <definition><![CDATA[{\"layout\":{\"structure\":[],\"type\":\"absolute\"},\"visualizations\":{},\"dataSources\":{},\"title\":\"Accounts\"}]]></definition>\n</dashboard>",
+			ACL:  splunktest.ACL{App: "search"},
+		})
+		service := newDashboardTestService(t, server, nil)
+		dir := writeDashboards(t, map[string]string{
+			"home_dashboard.xml": homeDashboard,
+			"accounts.json":      studioDefinition,
+		})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"accounts":       models.DashboardUnchanged,
+			"home_dashboard": models.DashboardUnchanged,
+		}, outcomeActions(result))
+	})
+
+	t.Run("Success_CreatesStudioDashboardFromJSON", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, nil)
+		dir := writeDashboards(t, map[string]string{"accounts.json": studioDefinition})
+
+		_, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+
+		dashboard, ok := server.Dashboard("search", "accounts")
+		require.True(t, ok)
+		assert.Contains(t, dashboard.Data, `<dashboard version="2"`)
+		assert.Contains(t, dashboard.Data, "<label>Accounts</label>")
+		assert.Contains(t, dashboard.Data, "<![CDATA[\n"+studioDefinition+"\n]]>")
+	})
+
+	t.Run("Success_PrunesRemovedDashboardsWithPrefix", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddDashboard(splunktest.Dashboard{Name: "sfdc_retired", Data: homeDashboard, ACL: splunktest.ACL{App: "search"}})
+		server.AddDashboard(splunktest.Dashboard{Name: "team_dashboard", Data: homeDashboard, ACL: splunktest.ACL{App: "search"}})
+		server.AddDashboard(splunktest.Dashboard{Name: "sfdc_jdoe_copy", Data: homeDashboard, ACL: splunktest.ACL{App: "search", Owner: "jdoe", Sharing: "user"}})
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Migration.DashboardPrune = true
+			c.Migration.DashboardPrunePrefix = "sfdc_"
+		})
+		dir := writeDashboards(t, map[string]string{"sfdc_home.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"sfdc_home":    models.DashboardCreated,
+			"sfdc_retired": models.DashboardDeleted,
+		}, outcomeActions(result))
+		// The private view of another user is not managed by the migration
+		assert.Equal(t, []string{"sfdc_home", "sfdc_jdoe_copy", "team_dashboard"}, server.Dashboards("search"))
+	})
+
+	t.Run("Error_PruneErrorMessage", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddDashboard(splunktest.Dashboard{Name: "sfdc_retired", Data: homeDashboard, ACL: splunktest.ACL{App: "search"}})
+		server.Inject(splunktest.Fault{
+			Method: http.MethodDelete,
+			Path:   "/servicesNS/nobody/search/data/ui/views/sfdc_retired",
+			Status: http.StatusOK,
+			Body:   `{"messages": [{"type": "ERROR", "text": "Object is locked"}]}`,
+		})
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Migration.DashboardPrune = true
+			c.Migration.DashboardPrunePrefix = "sfdc_"
+		})
+
+		result, err := service.ReconcileDashboards(context.Background(), writeDashboards(t, nil))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "Object is locked")
+		assert.Equal(t, map[string]string{"sfdc_retired": models.DashboardFailed}, outcomeActions(result))
+	})
+
+	t.Run("Success_KeepsRemovedDashboardsWithoutPrune", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddDashboard(splunktest.Dashboard{Name: "sfdc_retired", Data: homeDashboard, ACL: splunktest.ACL{App: "search"}})
+		service := newDashboardTestService(t, server, nil)
+		dir := writeDashboards(t, map[string]string{"sfdc_home.xml": homeDashboard})
+
+		_, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"sfdc_home", "sfdc_retired"}, server.Dashboards("search"))
+	})
+
+	t.Run("Success_AppliesPermissionsOnce", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Migration.DashboardReadRoles = "sales_user, power"
+			c.Migration.DashboardWriteRoles = "admin"
+		})
+		dir := writeDashboards(t, map[string]string{"home_dashboard.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"home_dashboard": models.DashboardCreated}, outcomeActions(result))
+		dashboard, _ := server.Dashboard("search", "home_dashboard")
+		assert.Equal(t, splunktest.ACL{App: "search", Owner: "nobody", Sharing: "app", Read: []string{"sales_user", "power"}, Write: []string{"admin"}}, dashboard.ACL)
+
+		result, err = service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"home_dashboard": models.DashboardUnchanged}, outcomeActions(result))
+		assert.Equal(t, 1, server.CountRequests(http.MethodPost, "/servicesNS/nobody/search/data/ui/views/home_dashboard/acl"))
+	})
+
+	t.Run("Success_PerDashboardACL", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Extensions = map[string]interface{}{
+				"DASHBOARD_ACL": map[string]interface{}{
+					"sales_overview": map[string]interface{}{"app": "sales", "sharing": "global", "read": []interface{}{"sales_user"}},
+				},
+			}
+		})
+		dir := writeDashboards(t, map[string]string{
+			"home_dashboard.xml": homeDashboard,
+			"sales_overview.xml": analyticsDashboard,
+		})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{
+			"home_dashboard": models.DashboardCreated,
+			"sales_overview": models.DashboardCreated,
+		}, outcomeActions(result))
+		assert.Equal(t, []string{"home_dashboard"}, server.Dashboards("search"))
+		assert.Equal(t, []string{"sales_overview"}, server.Dashboards("sales"))
+
+		home, _ := server.Dashboard("search", "home_dashboard")
+		assert.Equal(t, splunktest.ACL{App: "search", Owner: "nobody", Sharing: "app"}, home.ACL, "dashboards without permissions keep the ACL they are created with")
+		sales, _ := server.Dashboard("sales", "sales_overview")
+		assert.Equal(t, splunktest.ACL{App: "sales", Owner: "nobody", Sharing: "global", Read: []string{"sales_user"}}, sales.ACL)
+	})
+
+	t.Run("Success_RestoresChangedPermissions", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddDashboard(splunktest.Dashboard{
+			Name: "home_dashboard",
+			Data: homeDashboard,
+			ACL:  splunktest.ACL{App: "search", Owner: "jdoe", Sharing: "app"},
+		})
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Migration.DashboardSharing = utils.SharingGlobal
+		})
+		dir := writeDashboards(t, map[string]string{"home_dashboard.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		require.Len(t, result.Outcomes, 1)
+		assert.Equal(t, models.DashboardUpdated, result.Outcomes[0].Action)
+		assert.Equal(t, result.Outcomes[0].PreviousVersion, result.Outcomes[0].Version, "only the permissions changed")
+		assert.Equal(t, 1, server.CountRequests(http.MethodPost, "/servicesNS/jdoe/search/data/ui/views/home_dashboard"), "only the acl endpoint is posted to")
+		assert.Equal(t, 1, server.CountRequests(http.MethodPost, "/servicesNS/jdoe/search/data/ui/views/home_dashboard/acl"))
+
+		dashboard, _ := server.Dashboard("search", "home_dashboard")
+		assert.Equal(t, "nobody", dashboard.ACL.Owner)
+		assert.Equal(t, "global", dashboard.ACL.Sharing)
+	})
+
+	t.Run("Error_PermissionsFailure", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, func(c *utils.Config) {
+			c.Migration.DashboardSharing = utils.SharingGlobal
+		})
+		server.Inject(splunktest.Fault{Method: http.MethodPost, Path: "/servicesNS/nobody/search/data/ui/views/home_dashboard/acl", Status: http.StatusBadRequest, Times: 1})
+		dir := writeDashboards(t, map[string]string{"home_dashboard.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to set dashboard permissions")
+		assert.Equal(t, map[string]string{"home_dashboard": models.DashboardFailed}, outcomeActions(result))
+
+		// The next run finds the dashboard and sets its permissions
+		result, err = service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"home_dashboard": models.DashboardUpdated}, outcomeActions(result))
+		dashboard, _ := server.Dashboard("search", "home_dashboard")
+		assert.Equal(t, "global", dashboard.ACL.Sharing)
+	})
+
+	t.Run("Error_FailedDashboardDoesNotStopOthers", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, nil)
+		server.Inject(splunktest.Fault{Method: http.MethodPost, Path: "/servicesNS/nobody/search/data/ui/views", Status: http.StatusBadRequest, Times: 1})
+		dir := writeDashboards(t, map[string]string{
+			"analytics_dashboard.xml": analyticsDashboard,
+			"home_dashboard.xml":      homeDashboard,
+			"broken.json":             "{not json",
+		})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2 of 3 dashboards failed to reconcile")
+		assert.Equal(t, map[string]string{
+			"analytics_dashboard": models.DashboardFailed,
+			"broken":              models.DashboardFailed,
+			"home_dashboard":      models.DashboardCreated,
+		}, outcomeActions(result))
+		assert.Equal(t, 2, result.Failed())
+		assert.Equal(t, []string{"home_dashboard"}, server.Dashboards("search"))
+	})
+
+	t.Run("Error_MissingDirectory", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, nil)
+
+		result, err := service.ReconcileDashboards(context.Background(), filepath.Join(t.TempDir(), "missing"))
+		require.Error(t, err)
+		assert.Empty(t, result.Outcomes)
+	})
+}
+
+func TestDashboardService_ReconcileDashboards_Owners(t *testing.T) {
+	require.NoError(t, utils.InitializeGlobalLogger("test", "dashboard_reconciler", false))
+	config := &utils.Config{
+		Splunk: utils.SplunkConfig{URL: "https://localhost:8089"},
+		Migration: utils.MigrationConfig{
+			DashboardApp:         utils.DefaultDashboardApp,
+			DashboardOwner:       utils.DefaultDashboardOwner,
+			DashboardPrune:       true,
+			DashboardPrunePrefix: "sfdc_",
+		},
+	}
+	newService := func(t *testing.T, listed ...*models.Dashboard) (*services.DashboardService, *mocks.MockSplunkService) {
+		mockService := &mocks.MockSplunkService{
+			ListDashboardsFunc: func(ctx context.Context, app string) ([]*models.Dashboard, error) {
+				return listed, nil
+			},
+		}
+		service, err := services.NewDashboardService(config, mockService)
+		require.NoError(t, err)
+		return service, mockService
+	}
+
+	t.Run("Success_PrefersViewOfConfiguredOwner", func(t *testing.T) {
+		service, mockService := newService(t,
+			&models.Dashboard{Name: "sfdc_home", App: "search", Owner: "jdoe", Sharing: "user", Data: analyticsDashboard},
+			&models.Dashboard{Name: "sfdc_home", App: "search", Owner: "nobody", Sharing: "app", Data: homeDashboard},
+		)
+		dir := writeDashboards(t, map[string]string{"sfdc_home.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"sfdc_home": models.DashboardUnchanged}, outcomeActions(result))
+		assert.Equal(t, 0, mockService.UpdateDashboardCalls)
+		assert.Equal(t, 0, mockService.DeleteDashboardCalls)
+	})
+
+	t.Run("Success_CreatesBesidePrivateViewOfOtherOwner", func(t *testing.T) {
+		service, mockService := newService(t,
+			&models.Dashboard{Name: "sfdc_home", App: "search", Owner: "jdoe", Sharing: "user", Data: analyticsDashboard},
+		)
+		var created *models.Dashboard
+		mockService.CreateDashboardFunc = func(ctx context.Context, dashboard *models.Dashboard) error {
+			created = dashboard
+			return nil
+		}
+		dir := writeDashboards(t, map[string]string{"sfdc_home.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"sfdc_home": models.DashboardCreated}, outcomeActions(result))
+		require.NotNil(t, created)
+		assert.Equal(t, "nobody", created.Owner)
+		assert.Equal(t, 0, mockService.UpdateDashboardCalls)
+	})
+
+	t.Run("Success_UpdatesSharedViewOfOtherOwner", func(t *testing.T) {
+		service, mockService := newService(t,
+			&models.Dashboard{Name: "sfdc_home", App: "search", Owner: "jdoe", Sharing: "global", Data: analyticsDashboard},
+		)
+		var updated *models.Dashboard
+		mockService.UpdateDashboardFunc = func(ctx context.Context, dashboard *models.Dashboard) error {
+			updated = dashboard
+			return nil
+		}
+		dir := writeDashboards(t, map[string]string{"sfdc_home.xml": homeDashboard})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"sfdc_home": models.DashboardUpdated}, outcomeActions(result))
+		require.NotNil(t, updated)
+		assert.Equal(t, "jdoe", updated.Owner)
+		assert.Equal(t, 0, mockService.CreateDashboardCalls)
+	})
+
+	t.Run("Success_PrunesOnlyManagedViews", func(t *testing.T) {
+		service, mockService := newService(t,
+			&models.Dashboard{Name: "sfdc_retired", App: "search", Owner: "jdoe", Sharing: "user", Data: homeDashboard},
+			&models.Dashboard{Name: "sfdc_retired", App: "search", Owner: "nobody", Sharing: "app", Data: homeDashboard},
+			&models.Dashboard{Name: "sfdc_old", App: "search", Owner: "nobody", Sharing: "user", Data: homeDashboard},
+		)
+		var deleted []string
+		mockService.DeleteDashboardFunc = func(ctx context.Context, dashboard *models.Dashboard) error {
+			deleted = append(deleted, dashboard.Owner+"/"+dashboard.Name)
+			return nil
+		}
+
+		_, err := service.ReconcileDashboards(context.Background(), writeDashboards(t, nil))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"nobody/sfdc_retired", "nobody/sfdc_old"}, deleted)
+	})
+}
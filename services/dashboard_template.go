@@ -0,0 +1,86 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// dashboardTemplateData is the data dashboard sources are rendered with. A dashboard
+// refers to it with text/template actions such as {{.Index}}, {{.Vars.env}} or
+// {{.Objects.Account.Index}}.
+type dashboardTemplateData struct {
+	Index        string                     // SPLUNK_INDEX_NAME
+	DefaultIndex string                     // SPLUNK_DEFAULT_INDEX, used by inputs that do not name an index
+	Account      string                     // Default Salesforce account
+	App          string                     // App the dashboards are deployed to
+	DataInputs   []utils.DataInput          // DATA_INPUTS in configuration order
+	Inputs       map[string]utils.DataInput // DATA_INPUTS by input name
+	Objects      map[string]utils.DataInput // First data input of each Salesforce object
+	Vars         map[string]string          // DASHBOARD_VARS
+}
+
+// dashboardTemplateFuncs are the functions available to dashboard templates
+var dashboardTemplateFuncs = template.FuncMap{
+	"sourcetype": sourcetype,
+}
+
+// sourcetype returns the sourcetype Splunk_TA_salesforce gives the events of a
+// Salesforce object
+func sourcetype(object string) string {
+	return "sfdc:" + strings.ToLower(object)
+}
+
+// newDashboardTemplateData collects the values of config dashboards are rendered with
+func newDashboardTemplateData(config *utils.Config) (*dashboardTemplateData, error) {
+	data := &dashboardTemplateData{
+		Index:        config.Splunk.IndexName,
+		DefaultIndex: config.Splunk.DefaultIndex,
+		Account:      config.DefaultAccountName(),
+		App:          dashboardApp(config),
+		Inputs:       make(map[string]utils.DataInput),
+		Objects:      make(map[string]utils.DataInput),
+	}
+	if data.DefaultIndex == "" {
+		data.DefaultIndex = data.Index
+	}
+
+	if _, exists := config.Extensions["DATA_INPUTS"]; exists {
+		inputs, err := config.GetDataInputs()
+		if err != nil {
+			return nil, fmt.Errorf("failed to load dashboard template data: %w", err)
+		}
+		data.DataInputs = inputs
+		for _, input := range inputs {
+			data.Inputs[input.Name] = input
+			if _, seen := data.Objects[input.Object]; !seen {
+				data.Objects[input.Object] = input
+			}
+		}
+	}
+
+	vars, err := config.GetDashboardVars()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load dashboard template data: %w", err)
+	}
+	data.Vars = vars
+
+	return data, nil
+}
+
+// renderDashboard executes a dashboard source as a template. Referring to a variable,
+// data input or object that is not configured is an error rather than an empty value.
+func renderDashboard(name, source string, data *dashboardTemplateData) (string, error) {
+	tmpl, err := template.New(name).Funcs(dashboardTemplateFuncs).Option("missingkey=error").Parse(source)
+	if err != nil {
+		return "", fmt.Errorf("invalid dashboard template: %w", err)
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, data); err != nil {
+		return "", fmt.Errorf("failed to render dashboard template: %w", err)
+	}
+	return rendered.String(), nil
+}
@@ -0,0 +1,113 @@
+package services_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// withTemplateConfig configures the index, account, data inputs and variables the
+// template tests render with
+func withTemplateConfig(index string, vars map[string]interface{}) func(*utils.Config) {
+	return func(c *utils.Config) {
+		c.Splunk.IndexName = index
+		c.Splunk.DefaultIndex = index
+		c.Salesforce.AccountName = "sf_prod"
+		c.Extensions = map[string]interface{}{
+			"DATA_INPUTS": []interface{}{
+				map[string]interface{}{"name": "Account_Input", "object": "Account", "index": "sfdc_accounts"},
+				map[string]interface{}{"name": "Opportunity_Input", "object": "Opportunity"},
+			},
+			"DASHBOARD_VARS": vars,
+		}
+	}
+}
+
+func TestDashboardService_RenderDashboards(t *testing.T) {
+	t.Run("Success_RendersSimpleXMLPerEnvironment", func(t *testing.T) {
+		source := `<dashboard><label>Accounts ({{.Vars.env}})</label><row><panel><table><search>` +
+			`<query>index="{{.Index}}" OR index="{{.Objects.Account.Index}}" sourcetype="{{sourcetype "Account"}}" account="{{.Account}}" | where Owner="$owner$"</query>` +
+			`</search></table></panel></row></dashboard>`
+		dir := writeDashboards(t, map[string]string{"accounts.xml": source})
+
+		for env, index := range map[string]string{"uat": "sfdc_uat", "prod": "sfdc_prod"} {
+			server := splunktest.NewServer()
+			defer server.Close()
+			service := newDashboardTestService(t, server, withTemplateConfig(index, map[string]interface{}{"env": env}))
+
+			_, err := service.ReconcileDashboards(context.Background(), dir)
+			require.NoError(t, err)
+
+			dashboard, ok := server.Dashboard("search", "accounts")
+			require.True(t, ok)
+			assert.Equal(t, `<dashboard><label>Accounts (`+env+`)</label><row><panel><table><search>`+
+				`<query>index="`+index+`" OR index="sfdc_accounts" sourcetype="sfdc:account" account="sf_prod" | where Owner="$owner$"</query>`+
+				`</search></table></panel></row></dashboard>`, dashboard.Data)
+		}
+	})
+
+	t.Run("Success_RendersStudioDefinition", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, withTemplateConfig("sfdc_prod", map[string]interface{}{"env": "prod"}))
+		dir := writeDashboards(t, map[string]string{"inputs.json": `{"title": "Inputs ({{.Vars.env}})", "dataSources": { ` +
+			`{{range $i, $input := .DataInputs}}{{if $i}}, {{end}}"ds_{{$input.Name}}": {"type": "ds.search", "options": {"query": "index={{$input.Index}} sourcetype={{sourcetype $input.Object}}"}}{{end}} ` +
+			`}, "visualizations": {}, "layout": {"type": "absolute", "structure": []}}`})
+
+		_, err := service.ReconcileDashboards(context.Background(), dir)
+		require.NoError(t, err)
+
+		dashboard, ok := server.Dashboard("search", "inputs")
+		require.True(t, ok)
+		assert.Contains(t, dashboard.Data, "<label>Inputs (prod)</label>")
+		assert.Contains(t, dashboard.Data, `"ds_Account_Input": {"type": "ds.search", "options": {"query": "index=sfdc_accounts sourcetype=sfdc:account"}}`)
+		assert.Contains(t, dashboard.Data, `"ds_Opportunity_Input": {"type": "ds.search", "options": {"query": "index=sfdc_prod sourcetype=sfdc:opportunity"}}`)
+	})
+
+	t.Run("Error_UndefinedVariables", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, withTemplateConfig("sfdc_prod", map[string]interface{}{"env": "prod"}))
+		dir := writeDashboards(t, map[string]string{
+			"var.xml":     `<dashboard><label>{{.Vars.region}}</label></dashboard>`,
+			"object.xml":  `<dashboard><label>{{.Objects.Lead.Index}}</label></dashboard>`,
+			"field.xml":   `<dashboard><label>{{.Environment}}</label></dashboard>`,
+			"syntax.json": `{"title": "{{.Vars.env"}`,
+			"valid.xml":   `<dashboard><label>{{.Vars.env}}</label></dashboard>`,
+		})
+
+		result, err := service.ReconcileDashboards(context.Background(), dir)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "4 of 5 dashboards failed to reconcile")
+		assert.Equal(t, map[string]string{
+			"field":  models.DashboardFailed,
+			"object": models.DashboardFailed,
+			"syntax": models.DashboardFailed,
+			"valid":  models.DashboardCreated,
+			"var":    models.DashboardFailed,
+		}, outcomeActions(result))
+		for _, outcome := range result.Outcomes {
+			if outcome.Name == "var" {
+				assert.Contains(t, outcome.Err.Error(), `map has no entry for key "region"`)
+			}
+		}
+		assert.Equal(t, []string{"valid"}, server.Dashboards("search"))
+	})
+
+	t.Run("Error_InvalidDashboardVars", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service := newDashboardTestService(t, server, withTemplateConfig("sfdc_prod", map[string]interface{}{"env": []interface{}{"uat", "prod"}}))
+
+		result, err := service.ReconcileDashboards(context.Background(), writeDashboards(t, map[string]string{"home.xml": homeDashboard}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "dashboard variable env must be a string, number or boolean")
+		assert.Empty(t, result.Outcomes)
+	})
+}
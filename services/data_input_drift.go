@@ -0,0 +1,107 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// FieldDrift describes a data input field whose live value differs from the configuration
+type FieldDrift struct {
+	Field   string
+	Live    string
+	Desired string
+}
+
+// DiffDataInput compares a live sfdc_object input with the desired configuration and
+// returns one entry per drifted field. object_fields is compared as an unordered,
+// case-insensitive set since Salesforce field names are case-insensitive. disabled is
+// only compared when the configuration sets it.
+func DiffDataInput(live *models.SFDCObjectInput, desired *utils.DataInput, account, defaultIndex string) []FieldDrift {
+	desiredIndex := desired.Index
+	if desiredIndex == "" {
+		desiredIndex = defaultIndex
+	}
+
+	var drift []FieldDrift
+	compare := func(field, liveValue, desiredValue string) {
+		if liveValue != desiredValue {
+			drift = append(drift, FieldDrift{Field: field, Live: liveValue, Desired: desiredValue})
+		}
+	}
+
+	compare("account", live.Account, account)
+	compare("object", live.Object, desired.Object)
+	if !sameFieldSet(live.ObjectFields, desired.ObjectFields) {
+		drift = append(drift, FieldDrift{Field: "object_fields", Live: live.ObjectFields, Desired: desired.ObjectFields})
+	}
+	compare("order_by", live.OrderBy, desired.OrderBy)
+	compare("start_date", live.StartDate, desired.StartDate)
+	compare("interval", fmt.Sprintf("%d", live.Interval), fmt.Sprintf("%d", desired.Interval))
+	compare("delay", fmt.Sprintf("%d", live.Delay), fmt.Sprintf("%d", desired.Delay))
+	compare("index", live.Index, desiredIndex)
+	// Without a configured value the input stays as operators left it
+	if desired.Disabled != nil {
+		compare("disabled", fmt.Sprintf("%t", live.Disabled), fmt.Sprintf("%t", *desired.Disabled))
+	}
+
+	return drift
+}
+
+// DiffEventLogInput compares a live sfdc_event_log input with the desired configuration
+// and returns one entry per drifted field
+func DiffEventLogInput(live *models.SFDCEventLogInput, desired *utils.EventLogInput, account, defaultIndex string) []FieldDrift {
+	desiredIndex := desired.Index
+	if desiredIndex == "" {
+		desiredIndex = defaultIndex
+	}
+
+	var drift []FieldDrift
+	compare := func(field, liveValue, desiredValue string) {
+		if liveValue != desiredValue {
+			drift = append(drift, FieldDrift{Field: field, Live: liveValue, Desired: desiredValue})
+		}
+	}
+
+	compare("account", live.Account, account)
+	compare("monitoring_interval", live.MonitoringInterval, desired.MonitoringInterval)
+	compare("start_date", live.StartDate, desired.StartDate)
+	compare("interval", fmt.Sprintf("%d", live.Interval), fmt.Sprintf("%d", desired.Interval))
+	compare("index", live.Index, desiredIndex)
+
+	return drift
+}
+
+// sameFieldSet reports whether two comma-separated field lists contain the same fields
+func sameFieldSet(a, b string) bool {
+	setA := normalizeFieldList(a)
+	setB := normalizeFieldList(b)
+	if len(setA) != len(setB) {
+		return false
+	}
+	for i := range setA {
+		if setA[i] != setB[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// normalizeFieldList returns the sorted, de-duplicated, lower-cased fields of a list
+func normalizeFieldList(list string) []string {
+	seen := make(map[string]bool)
+	fields := make([]string, 0)
+	for _, field := range strings.Split(list, ",") {
+		field = strings.ToLower(strings.TrimSpace(field))
+		if field == "" || seen[field] {
+			continue
+		}
+		seen[field] = true
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	return fields
+}
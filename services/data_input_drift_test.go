@@ -0,0 +1,139 @@
+package services_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+func TestDiffDataInput(t *testing.T) {
+	desired := &utils.DataInput{
+		Name:         "sf_accounts",
+		Object:       "Account",
+		ObjectFields: "Id,Name,Industry",
+		OrderBy:      "LastModifiedDate",
+		StartDate:    "2024-01-01T00:00:00.000Z",
+		Interval:     300,
+		Delay:        60,
+		Index:        "salesforce",
+	}
+	matching := func() *models.SFDCObjectInput {
+		return &models.SFDCObjectInput{
+			Name:         "sf_accounts",
+			Account:      "prod",
+			Object:       "Account",
+			ObjectFields: "Id,Name,Industry",
+			OrderBy:      "LastModifiedDate",
+			StartDate:    "2024-01-01T00:00:00.000Z",
+			Interval:     300,
+			Delay:        60,
+			Index:        "salesforce",
+		}
+	}
+
+	t.Run("Success_NoDrift", func(t *testing.T) {
+		drift := services.DiffDataInput(matching(), desired, "prod", "default")
+		assert.Empty(t, drift)
+	})
+
+	t.Run("Success_ObjectFieldsUnordered", func(t *testing.T) {
+		live := matching()
+		live.ObjectFields = "industry, Id,NAME"
+		drift := services.DiffDataInput(live, desired, "prod", "default")
+		assert.Empty(t, drift)
+	})
+
+	t.Run("Success_ObjectFieldsChanged", func(t *testing.T) {
+		live := matching()
+		live.ObjectFields = "Id,Name"
+		drift := services.DiffDataInput(live, desired, "prod", "default")
+		require.Len(t, drift, 1)
+		assert.Equal(t, "object_fields", drift[0].Field)
+		assert.Equal(t, "Id,Name", drift[0].Live)
+		assert.Equal(t, "Id,Name,Industry", drift[0].Desired)
+	})
+
+	t.Run("Success_MultipleFieldsDrifted", func(t *testing.T) {
+		live := matching()
+		live.Interval = 600
+		live.Account = "sandbox"
+		live.Disabled = true
+		drift := services.DiffDataInput(live, desired, "prod", "default")
+
+		fields := make(map[string]services.FieldDrift)
+		for _, d := range drift {
+			fields[d.Field] = d
+		}
+		require.Len(t, drift, 2)
+		assert.Equal(t, "600", fields["interval"].Live)
+		assert.Equal(t, "300", fields["interval"].Desired)
+		assert.Equal(t, "sandbox", fields["account"].Live)
+	})
+
+	t.Run("Success_DisabledIgnoredWhenUnset", func(t *testing.T) {
+		live := matching()
+		live.Disabled = true
+		drift := services.DiffDataInput(live, desired, "prod", "default")
+		assert.Empty(t, drift)
+	})
+
+	t.Run("Success_DisabledDrifted", func(t *testing.T) {
+		enabled := false
+		withDisabled := *desired
+		withDisabled.Disabled = &enabled
+		live := matching()
+		live.Disabled = true
+		drift := services.DiffDataInput(live, &withDisabled, "prod", "default")
+		require.Len(t, drift, 1)
+		assert.Equal(t, services.FieldDrift{Field: "disabled", Live: "true", Desired: "false"}, drift[0])
+	})
+
+	t.Run("Success_DefaultIndexApplied", func(t *testing.T) {
+		noIndex := *desired
+		noIndex.Index = ""
+		live := matching()
+		live.Index = "default"
+		drift := services.DiffDataInput(live, &noIndex, "prod", "default")
+		assert.Empty(t, drift)
+	})
+}
+
+func TestDiffEventLogInput(t *testing.T) {
+	desired := &utils.EventLogInput{
+		Name:               "sf_event_logs",
+		MonitoringInterval: "Daily",
+		StartDate:          "2024-01-01T00:00:00.000Z",
+		Interval:           3600,
+	}
+	matching := func() *models.SFDCEventLogInput {
+		return &models.SFDCEventLogInput{
+			Name:               "sf_event_logs",
+			Account:            "prod",
+			MonitoringInterval: "Daily",
+			StartDate:          "2024-01-01T00:00:00.000Z",
+			Interval:           3600,
+			Index:              "default",
+			Disabled:           true,
+		}
+	}
+
+	t.Run("Success_NoDrift", func(t *testing.T) {
+		assert.Empty(t, services.DiffEventLogInput(matching(), desired, "prod", "default"))
+	})
+
+	t.Run("Success_FieldsDrifted", func(t *testing.T) {
+		live := matching()
+		live.MonitoringInterval = "Hourly"
+		live.Index = "salesforce"
+		drift := services.DiffEventLogInput(live, desired, "prod", "default")
+		assert.Equal(t, []services.FieldDrift{
+			{Field: "monitoring_interval", Live: "Hourly", Desired: "Daily"},
+			{Field: "index", Live: "salesforce", Desired: "default"},
+		}, drift)
+	})
+}
@@ -0,0 +1,171 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+// acsIndexesPath is the Admin Config Service indexes endpoint, relative to the stack URL
+const acsIndexesPath = "/adminconfig/v2/indexes"
+
+// secondsPerDay converts searchable days to frozenTimePeriodInSecs
+const secondsPerDay = 24 * 60 * 60
+
+// IndexProvisioner checks and creates indexes on one Splunk platform
+type IndexProvisioner interface {
+	// IndexExists reports whether the index exists
+	IndexExists(ctx context.Context, name string) (bool, error)
+	// CreateIndex creates the index; an index that already exists is not an error
+	CreateIndex(ctx context.Context, spec utils.IndexSpec) error
+}
+
+// newIndexProvisioner selects the provisioner of the platform configured for s
+func newIndexProvisioner(s *SplunkService) IndexProvisioner {
+	enterprise := &enterpriseIndexes{service: s}
+	if s.config.Splunk.EffectivePlatform() != utils.SplunkPlatformCloud {
+		return enterprise
+	}
+
+	acsURL := strings.TrimSuffix(s.config.Splunk.ACSURL, "/")
+	if acsURL == "" {
+		acsURL = utils.DefaultACSURL
+	}
+	client := newSplunkHTTPClient(s.config, acsURL+"/"+url.PathEscape(s.config.Splunk.EffectiveStack()))
+	return &acsIndexes{
+		client:  &reauthClient{HTTPClientInterface: client, auth: s.auth},
+		stats:   client,
+		headers: s.authHeaders,
+		search:  enterprise,
+	}
+}
+
+// enterpriseIndexes provisions indexes through the /data/indexes REST endpoints of
+// Splunk Enterprise
+type enterpriseIndexes struct {
+	service *SplunkService
+}
+
+// IndexExists implements IndexProvisioner
+func (p *enterpriseIndexes) IndexExists(ctx context.Context, name string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/services/data/indexes/%s?output_mode=json", url.PathEscape(name))
+	resp, err := p.service.httpClient.Get(ctx, path, p.service.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		// A missing index is not an error condition; any other failure, such as a
+		// missing permission, is
+		if errors.Is(err, utils.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateIndex implements IndexProvisioner. The index is created in the SPLUNK_INDEX_APP
+// app context; retention is set through frozenTimePeriodInSecs.
+func (p *enterpriseIndexes) CreateIndex(ctx context.Context, spec utils.IndexSpec) error {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	formData := map[string]string{
+		"name":        spec.Name,
+		"datatype":    spec.Datatype,
+		"output_mode": "json",
+	}
+	if spec.MaxDataSizeMB > 0 {
+		formData["maxTotalDataSizeMB"] = strconv.Itoa(spec.MaxDataSizeMB)
+	}
+	if spec.SearchableDays > 0 {
+		formData["frozenTimePeriodInSecs"] = strconv.Itoa(spec.SearchableDays * secondsPerDay)
+	}
+
+	app := p.service.config.Splunk.IndexApp
+	if app == "" {
+		app = utils.DefaultIndexApp
+	}
+
+	path := fmt.Sprintf("/servicesNS/nobody/%s/data/indexes", url.PathEscape(app))
+	resp, err := p.service.httpClient.PostForm(ctx, path, formData, p.service.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrAlreadyExists) {
+			return nil
+		}
+		return err
+	}
+	if err := p.service.checkResponseMessages(resp); err != nil && !errors.Is(err, utils.ErrAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// acsIndexes provisions indexes through the Admin Config Service of a Splunk Cloud
+// stack. ACS creates indexes asynchronously, so a new index can take a few minutes to
+// accept data.
+type acsIndexes struct {
+	client  utils.HTTPClientInterface // Rooted at {SPLUNK_ACS_URL}/{stack}
+	stats   utils.HTTPStatsReporter
+	headers func(ctx context.Context) map[string]string
+	// search checks indexes through the search head while the credential is HTTP
+	// Basic, which ACS does not accept (as in plan mode, which creates no token)
+	search IndexProvisioner
+}
+
+// Stats implements utils.HTTPStatsReporter for the requests sent to ACS
+func (p *acsIndexes) Stats() utils.HTTPStats {
+	return p.stats.Stats()
+}
+
+// IndexExists implements IndexProvisioner
+func (p *acsIndexes) IndexExists(ctx context.Context, name string) (bool, error) {
+	headers := p.headers(ctx)
+	if strings.HasPrefix(headers["Authorization"], "Basic ") {
+		return p.search.IndexExists(ctx, name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := p.client.Get(ctx, acsIndexesPath+"/"+url.PathEscape(name), headers)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// CreateIndex implements IndexProvisioner
+func (p *acsIndexes) CreateIndex(ctx context.Context, spec utils.IndexSpec) error {
+	request := models.IndexRequest{
+		Name:           spec.Name,
+		DataType:       spec.Datatype,
+		SearchableDays: spec.SearchableDays,
+		MaxDataSizeMB:  spec.MaxDataSizeMB,
+	}
+	if err := request.Validate(); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := p.client.Post(ctx, acsIndexesPath, request, p.headers(ctx))
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrAlreadyExists) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,133 @@
+package services_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/internal/splunktest"
+	"salesforce-splunk-migration/services"
+	"salesforce-splunk-migration/utils"
+)
+
+const acsIndexesPath = "/" + splunktest.Stack + "/adminconfig/v2/indexes"
+
+func newIndexTestConfig(server *splunktest.Server, platform string) *utils.Config {
+	return &utils.Config{Splunk: utils.SplunkConfig{
+		URL:                 server.URL,
+		Username:            splunktest.Username,
+		Password:            splunktest.Password,
+		Platform:            platform,
+		Stack:               splunktest.Stack,
+		ACSURL:              server.URL,
+		IndexSearchableDays: 30,
+		MaxTotalDataSizeMB:  1024,
+	}}
+}
+
+// recordingProvisioner records the indexes it is asked to create
+type recordingProvisioner struct {
+	created []utils.IndexSpec
+}
+
+func (p *recordingProvisioner) IndexExists(ctx context.Context, name string) (bool, error) {
+	return false, nil
+}
+
+func (p *recordingProvisioner) CreateIndex(ctx context.Context, spec utils.IndexSpec) error {
+	p.created = append(p.created, spec)
+	return nil
+}
+
+func TestIndexProvisioner_Enterprise(t *testing.T) {
+	server := splunktest.NewServer()
+	defer server.Close()
+	config := newIndexTestConfig(server, utils.SplunkPlatformEnterprise)
+	config.Splunk.IndexApp = "salesforce_app"
+	service, err := services.NewSplunkService(config)
+	require.NoError(t, err)
+
+	exists, err := service.CheckIndexExists(context.Background(), "salesforce")
+	require.NoError(t, err)
+	assert.False(t, exists)
+
+	require.NoError(t, service.CreateIndex(context.Background(), "salesforce"))
+	require.NoError(t, service.CreateIndex(context.Background(), "salesforce"), "existing index is not an error")
+
+	assert.Equal(t, 2, server.CountRequests(http.MethodPost, "/servicesNS/nobody/salesforce_app/data/indexes"))
+	index, ok := server.Index("salesforce")
+	require.True(t, ok)
+	assert.Equal(t, "event", index["datatype"])
+	assert.Equal(t, "2592000", index["frozenTimePeriodInSecs"])
+	assert.Equal(t, "1024", index["maxTotalDataSizeMB"])
+}
+
+func TestIndexProvisioner_Cloud(t *testing.T) {
+	t.Run("Success_CreatesThroughACS", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		service, err := services.NewSplunkService(newIndexTestConfig(server, utils.SplunkPlatformCloud))
+		require.NoError(t, err)
+		require.NoError(t, service.Authenticate(context.Background()))
+
+		exists, err := service.CheckIndexExists(context.Background(), "salesforce")
+		require.NoError(t, err)
+		assert.False(t, exists)
+
+		require.NoError(t, service.CreateIndex(context.Background(), "salesforce"))
+		require.NoError(t, service.CreateIndex(context.Background(), "salesforce"), "existing index is not an error")
+
+		exists, err = service.CheckIndexExists(context.Background(), "salesforce")
+		require.NoError(t, err)
+		assert.True(t, exists)
+
+		assert.Equal(t, 2, server.CountRequests(http.MethodPost, acsIndexesPath))
+		assert.Equal(t, 0, server.CountRequests(http.MethodPost, "/servicesNS"))
+
+		// The token request and the four ACS requests are all counted
+		assert.Equal(t, int64(5), service.HTTPStats().Requests)
+		index, _ := server.Index("salesforce")
+		assert.Equal(t, "2592000", index["frozenTimePeriodInSecs"])
+	})
+
+	t.Run("Success_BasicAuthenticationChecksSearchHead", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.AddIndex("salesforce")
+		service, err := services.NewSplunkService(newIndexTestConfig(server, utils.SplunkPlatformCloud))
+		require.NoError(t, err)
+
+		exists, err := service.CheckIndexExists(context.Background(), "salesforce")
+		require.NoError(t, err)
+		assert.True(t, exists)
+		assert.Equal(t, 0, server.CountRequests(http.MethodGet, acsIndexesPath))
+	})
+
+	t.Run("Error_ForbiddenIsReturned", func(t *testing.T) {
+		server := splunktest.NewServer()
+		defer server.Close()
+		server.Inject(splunktest.Fault{Method: http.MethodPost, Path: acsIndexesPath, Status: http.StatusForbidden})
+		service, err := services.NewSplunkService(newIndexTestConfig(server, utils.SplunkPlatformCloud))
+		require.NoError(t, err)
+		require.NoError(t, service.Authenticate(context.Background()))
+
+		err = service.CreateIndex(context.Background(), "salesforce")
+		require.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrForbidden)
+	})
+}
+
+func TestSplunkService_SetIndexProvisioner(t *testing.T) {
+	config := &utils.Config{Splunk: utils.SplunkConfig{IndexDatatype: "METRIC", IndexSearchableDays: 7}}
+	service, err := services.NewSplunkServiceWithClient(config, nil)
+	require.NoError(t, err)
+	provisioner := &recordingProvisioner{}
+	service.SetIndexProvisioner(provisioner)
+
+	require.NoError(t, service.CreateIndex(context.Background(), "metrics"))
+
+	assert.Equal(t, []utils.IndexSpec{{Name: "metrics", Datatype: "metric", SearchableDays: 7}}, provisioner.created)
+}
@@ -0,0 +1,450 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/utils"
+)
+
+const (
+	// authTimeout bounds each authentication request
+	authTimeout = 30 * time.Second
+	// maxRefreshMargin is the longest time before expiry at which a token is replaced.
+	// Shorter lifetimes are refreshed when a fifth of the lifetime remains.
+	maxRefreshMargin = 5 * time.Minute
+	// refreshRetryInterval is the pause after a failed proactive refresh
+	refreshRetryInterval = 30 * time.Second
+	// defaultTokenLifetime applies when SPLUNK_TOKEN_LIFETIME is not set
+	defaultTokenLifetime = time.Hour
+)
+
+// splunkAuth owns the credential used for Splunk management API requests. A token is
+// reused until it nears expiry, then replaced and the old one revoked. Tokens created
+// here are revoked by logout; static tokens and session keys are never revoked.
+type splunkAuth struct {
+	config *utils.SplunkConfig
+	client utils.HTTPClientInterface
+	now    func() time.Time
+
+	mu              sync.Mutex
+	token           string
+	tokenID         string // ID of a token created through /services/authorization/tokens
+	issuedAt        time.Time
+	expiresAt       time.Time // zero when the expiry is unknown
+	refreshFailedAt time.Time
+	refreshing      bool // a proactive refresh is in flight
+}
+
+// credential is a token or session key obtained from Splunk
+type credential struct {
+	token     string
+	tokenID   string
+	expiresAt time.Time
+}
+
+// newSplunkAuth creates the credential manager. client must not retry on 401.
+func newSplunkAuth(config *utils.SplunkConfig, client utils.HTTPClientInterface) *splunkAuth {
+	return &splunkAuth{config: config, client: client, now: time.Now}
+}
+
+// authenticate obtains a credential unless the current one is still valid
+func (a *splunkAuth) authenticate(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token != "" && !a.expiringLocked() {
+		return nil
+	}
+	return a.acquireLocked(ctx)
+}
+
+// currentToken returns the credential, replacing it first when it is about to expire
+func (a *splunkAuth) currentToken(ctx context.Context) string {
+	a.refreshIfExpiring(ctx)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.token
+}
+
+// headers returns the Authorization header for the current credential. Before
+// authenticate has run, requests fall back to HTTP Basic Authentication (or to the
+// static token) so read-only operations can inspect Splunk without creating a token.
+func (a *splunkAuth) headers(ctx context.Context) map[string]string {
+	a.refreshIfExpiring(ctx)
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.headersLocked()
+}
+
+// reauthenticate replaces a credential that Splunk rejected. stale is the
+// Authorization header of the rejected request; when another request has already
+// replaced it, the current header is returned without authenticating again.
+func (a *splunkAuth) reauthenticate(ctx context.Context, stale string) (map[string]string, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.token == "" || a.config.EffectiveAuthMethod() == utils.SplunkAuthStatic {
+		return nil, false
+	}
+	if current := a.headersLocked(); current["Authorization"] != stale {
+		return current, true
+	}
+
+	utils.GetLogger().Info("Splunk rejected the credential; authenticating again")
+	if err := a.acquireLocked(ctx); err != nil {
+		utils.GetLogger().Warn("Failed to authenticate again", utils.Err(err))
+		return nil, false
+	}
+	return a.headersLocked(), true
+}
+
+// logout revokes the token created by authenticate, if any
+func (a *splunkAuth) logout(ctx context.Context) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	token, tokenID := a.token, a.tokenID
+	a.token, a.tokenID = "", ""
+	a.issuedAt, a.expiresAt = time.Time{}, time.Time{}
+	if tokenID == "" {
+		return nil
+	}
+	return a.revoke(ctx, token, tokenID)
+}
+
+// refreshIfExpiring replaces an expiring credential with the caller's context. Only
+// one refresh runs at a time and a.mu is not held while it talks to Splunk; other
+// requests keep using the current credential, which is still valid, meanwhile. A
+// failed refresh is logged and the current credential is kept until it is rejected.
+func (a *splunkAuth) refreshIfExpiring(ctx context.Context) {
+	a.mu.Lock()
+	if a.refreshing || a.token == "" || !a.expiringLocked() ||
+		(!a.refreshFailedAt.IsZero() && a.now().Sub(a.refreshFailedAt) < refreshRetryInterval) {
+		a.mu.Unlock()
+		return
+	}
+	a.refreshing = true
+	expiring := a.token
+	a.mu.Unlock()
+
+	cred, err := a.obtain(ctx)
+
+	a.mu.Lock()
+	a.refreshing = false
+	if err != nil {
+		// A cancelled caller says nothing about Splunk, so the next request may retry
+		if ctx.Err() == nil {
+			a.refreshFailedAt = a.now()
+		}
+		a.mu.Unlock()
+		utils.GetLogger().Warn("Failed to refresh the Splunk token; using the current one", utils.Err(err))
+		return
+	}
+
+	// authenticate or reauthenticate may have replaced the credential meanwhile; the
+	// newer one is kept and the token created here revoked instead
+	unused := cred
+	if a.token == expiring {
+		unused = a.replaceLocked(cred)
+	}
+	expiresAt := a.expiresAt
+	a.mu.Unlock()
+
+	utils.GetLogger().Debug("Splunk token refreshed", utils.String("expires_at", expiresAt.Format(time.RFC3339)))
+	a.revokeReplaced(ctx, unused)
+}
+
+// expiringLocked reports whether the credential expires within the refresh margin
+func (a *splunkAuth) expiringLocked() bool {
+	if a.expiresAt.IsZero() || a.config.EffectiveAuthMethod() == utils.SplunkAuthStatic {
+		return false
+	}
+	margin := a.expiresAt.Sub(a.issuedAt) / 5
+	if margin > maxRefreshMargin {
+		margin = maxRefreshMargin
+	}
+	return !a.now().Add(margin).Before(a.expiresAt)
+}
+
+// acquireLocked obtains a new credential with the configured method and stores it
+func (a *splunkAuth) acquireLocked(ctx context.Context) error {
+	cred, err := a.obtain(ctx)
+	if err != nil {
+		return err
+	}
+	a.revokeReplaced(ctx, a.replaceLocked(cred))
+	return nil
+}
+
+// obtain requests a new credential with the configured method. It does not read or
+// change the stored credential, so it may run without holding a.mu.
+func (a *splunkAuth) obtain(ctx context.Context) (credential, error) {
+	ctx, cancel := context.WithTimeout(ctx, authTimeout)
+	defer cancel()
+
+	switch method := a.config.EffectiveAuthMethod(); method {
+	case utils.SplunkAuthStatic:
+		if a.config.Token == "" {
+			return credential{}, fmt.Errorf("SPLUNK_TOKEN is required with the static authentication method")
+		}
+		expiresAt := jwtExpiry(a.config.Token)
+		if !expiresAt.IsZero() && !a.now().Before(expiresAt) {
+			return credential{}, fmt.Errorf("SPLUNK_TOKEN expired at %s", expiresAt.Format(time.RFC3339))
+		}
+		utils.RegisterSecret(a.config.Token)
+		return credential{token: a.config.Token, expiresAt: expiresAt}, nil
+
+	case utils.SplunkAuthSession:
+		sessionKey, err := a.login(ctx)
+		if err != nil {
+			return credential{}, err
+		}
+		return credential{token: sessionKey}, nil
+
+	case utils.SplunkAuthToken:
+		token, tokenID, err := a.createToken(ctx)
+		if err != nil {
+			return credential{}, err
+		}
+		expiresAt := jwtExpiry(token)
+		if expiresAt.IsZero() {
+			expiresAt = a.now().Add(a.lifetime())
+		}
+		return credential{token: token, tokenID: tokenID, expiresAt: expiresAt}, nil
+
+	default:
+		return credential{}, fmt.Errorf("unsupported Splunk authentication method %q", method)
+	}
+}
+
+// replaceLocked stores a new credential and returns the one it replaced
+func (a *splunkAuth) replaceLocked(cred credential) credential {
+	previous := credential{token: a.token, tokenID: a.tokenID, expiresAt: a.expiresAt}
+	a.token = cred.token
+	a.tokenID = cred.tokenID
+	a.issuedAt = a.now()
+	a.expiresAt = cred.expiresAt
+	a.refreshFailedAt = time.Time{}
+	return previous
+}
+
+// revokeReplaced revokes a token created by createToken that is no longer used
+func (a *splunkAuth) revokeReplaced(ctx context.Context, cred credential) {
+	if cred.tokenID == "" {
+		return
+	}
+	if err := a.revoke(ctx, cred.token, cred.tokenID); err != nil {
+		utils.GetLogger().Warn("Failed to revoke the replaced Splunk token", utils.String("token_id", cred.tokenID), utils.Err(err))
+	}
+}
+
+// headersLocked returns the Authorization header for the current credential
+func (a *splunkAuth) headersLocked() map[string]string {
+	switch {
+	case a.token != "" && a.config.EffectiveAuthMethod() == utils.SplunkAuthSession:
+		return map[string]string{"Authorization": "Splunk " + a.token}
+	case a.token != "":
+		return map[string]string{"Authorization": "Bearer " + a.token}
+	case a.config.EffectiveAuthMethod() == utils.SplunkAuthStatic && a.config.Token != "":
+		return map[string]string{"Authorization": "Bearer " + a.config.Token}
+	default:
+		return map[string]string{
+			"Authorization": "Basic " + base64.StdEncoding.EncodeToString([]byte(a.config.Username+":"+a.config.Password)),
+		}
+	}
+}
+
+// createToken creates a token through /services/authorization/tokens that expires
+// after the configured lifetime
+func (a *splunkAuth) createToken(ctx context.Context) (string, string, error) {
+	formData := map[string]string{
+		"name":        a.tokenName(),
+		"audience":    a.tokenAudience(),
+		"expires_on":  fmt.Sprintf("+%ds", int(a.lifetime().Seconds())),
+		"output_mode": "json",
+	}
+	headers := map[string]string{
+		"Content-Type": "application/x-www-form-urlencoded",
+	}
+
+	resp, err := a.client.PostFormWithBasicAuth(ctx, "/services/authorization/tokens", formData, headers, a.config.Username, a.config.Password)
+	if err := responseError(resp, err); err != nil {
+		return "", "", fmt.Errorf("token authentication failed: %w", err)
+	}
+
+	var tokenResp models.TokenAuthResponse
+	if err := resp.JSON(&tokenResp); err != nil {
+		return "", "", fmt.Errorf("failed to parse token auth response: %w", err)
+	}
+	if len(tokenResp.Entry) == 0 || tokenResp.Entry[0].Content.Token == "" {
+		return "", "", fmt.Errorf("no token returned in authentication response")
+	}
+
+	content := tokenResp.Entry[0].Content
+	utils.RegisterSecret(content.Token)
+	return content.Token, content.ID, nil
+}
+
+// login obtains a session key through /services/auth/login
+func (a *splunkAuth) login(ctx context.Context) (string, error) {
+	formData := map[string]string{
+		"username":    a.config.Username,
+		"password":    a.config.Password,
+		"output_mode": "json",
+	}
+
+	resp, err := a.client.PostForm(ctx, "/services/auth/login", formData, nil)
+	if err := responseError(resp, err); err != nil {
+		return "", fmt.Errorf("session login failed: %w", err)
+	}
+
+	var loginResp models.SessionLoginResponse
+	if err := resp.JSON(&loginResp); err != nil {
+		return "", fmt.Errorf("failed to parse session login response: %w", err)
+	}
+	if loginResp.SessionKey == "" {
+		return "", fmt.Errorf("no session key returned in login response")
+	}
+
+	utils.RegisterSecret(loginResp.SessionKey)
+	return loginResp.SessionKey, nil
+}
+
+// revoke deletes a token created by createToken
+func (a *splunkAuth) revoke(ctx context.Context, token, tokenID string) error {
+	ctx, cancel := context.WithTimeout(ctx, authTimeout)
+	defer cancel()
+
+	path := fmt.Sprintf("/services/authorization/tokens/%s?id=%s&output_mode=json", url.PathEscape(a.tokenName()), url.QueryEscape(tokenID))
+	headers := map[string]string{"Authorization": "Bearer " + token}
+
+	resp, err := a.client.Delete(ctx, path, headers)
+	// The token may already be gone, for example after it expired
+	if err := responseError(resp, err); err != nil && !errors.Is(err, utils.ErrNotFound) {
+		return fmt.Errorf("failed to revoke token: %w", err)
+	}
+	return nil
+}
+
+// tokenName returns the user the token is created for, defaulting to the username
+func (a *splunkAuth) tokenName() string {
+	if a.config.TokenName != "" {
+		return a.config.TokenName
+	}
+	return a.config.Username
+}
+
+// tokenAudience returns the token audience, defaulting to Automation
+func (a *splunkAuth) tokenAudience() string {
+	if a.config.TokenAudience != "" {
+		return a.config.TokenAudience
+	}
+	return "Automation"
+}
+
+// lifetime returns the lifetime of created tokens
+func (a *splunkAuth) lifetime() time.Duration {
+	if a.config.TokenLifetime > 0 {
+		return time.Duration(a.config.TokenLifetime) * time.Second
+	}
+	return defaultTokenLifetime
+}
+
+// jwtExpiry returns the exp claim of a JSON Web Token, or the zero time when the
+// token is not a JWT or has no expiry
+func jwtExpiry(token string) time.Time {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return time.Time{}
+	}
+
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(claims.Exp, 0)
+}
+
+// reauthClient retries a request once with a new credential when Splunk answers 401
+type reauthClient struct {
+	utils.HTTPClientInterface
+	auth *splunkAuth
+}
+
+// Get implements utils.HTTPClientInterface
+func (c *reauthClient) Get(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+	return c.send(ctx, headers, func(h map[string]string) (*utils.HTTPResponse, error) {
+		return c.HTTPClientInterface.Get(ctx, path, h)
+	})
+}
+
+// Post implements utils.HTTPClientInterface
+func (c *reauthClient) Post(ctx context.Context, path string, body interface{}, headers map[string]string) (*utils.HTTPResponse, error) {
+	return c.send(ctx, headers, func(h map[string]string) (*utils.HTTPResponse, error) {
+		return c.HTTPClientInterface.Post(ctx, path, body, h)
+	})
+}
+
+// PostForm implements utils.HTTPClientInterface
+func (c *reauthClient) PostForm(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+	return c.send(ctx, headers, func(h map[string]string) (*utils.HTTPResponse, error) {
+		return c.HTTPClientInterface.PostForm(ctx, path, formData, h)
+	})
+}
+
+// Put implements utils.HTTPClientInterface
+func (c *reauthClient) Put(ctx context.Context, path string, body interface{}, headers map[string]string) (*utils.HTTPResponse, error) {
+	return c.send(ctx, headers, func(h map[string]string) (*utils.HTTPResponse, error) {
+		return c.HTTPClientInterface.Put(ctx, path, body, h)
+	})
+}
+
+// Delete implements utils.HTTPClientInterface
+func (c *reauthClient) Delete(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+	return c.send(ctx, headers, func(h map[string]string) (*utils.HTTPResponse, error) {
+		return c.HTTPClientInterface.Delete(ctx, path, h)
+	})
+}
+
+// send performs a request and repeats it once with a new credential after a 401.
+// Requests using Basic authentication are not repeated.
+func (c *reauthClient) send(ctx context.Context, headers map[string]string, do func(map[string]string) (*utils.HTTPResponse, error)) (*utils.HTTPResponse, error) {
+	resp, err := do(headers)
+	if resp == nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	stale := headers["Authorization"]
+	if stale == "" || strings.HasPrefix(stale, "Basic ") {
+		return resp, err
+	}
+	fresh, ok := c.auth.reauthenticate(ctx, stale)
+	if !ok {
+		return resp, err
+	}
+
+	retried := make(map[string]string, len(headers))
+	for key, value := range headers {
+		retried[key] = value
+	}
+	retried["Authorization"] = fresh["Authorization"]
+	return do(retried)
+}
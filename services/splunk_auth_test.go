@@ -0,0 +1,311 @@
+package services
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/utils"
+)
+
+// testJWT returns an unsigned JWT with the given ID and expiry (none when zero)
+func testJWT(id string, exp time.Time) string {
+	claims := map[string]interface{}{"sub": "admin", "jti": id}
+	if !exp.IsZero() {
+		claims["exp"] = exp.Unix()
+	}
+	payload, _ := json.Marshal(claims)
+	return "eyJhbGciOiJIUzUxMiJ9." + base64.RawURLEncoding.EncodeToString(payload) + ".c2lnbmF0dXJl"
+}
+
+// tokenServer is a mock HTTP client that creates numbered tokens and records revocations
+type tokenServer struct {
+	client   *mocks.MockHTTPClient
+	forms    []map[string]string
+	revoked  []string
+	lifetime time.Duration
+	now      func() time.Time
+}
+
+func newTokenServer(now func() time.Time, lifetime time.Duration) *tokenServer {
+	ts := &tokenServer{now: now, lifetime: lifetime}
+	ts.client = &mocks.MockHTTPClient{
+		PostFormWithBasicAuthFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string, username, password string) (*utils.HTTPResponse, error) {
+			ts.forms = append(ts.forms, formData)
+			id := fmt.Sprintf("tok-%d", len(ts.forms))
+			body := fmt.Sprintf(`{"entry":[{"content":{"id":%q,"token":%q}}]}`, id, testJWT(id, ts.now().Add(ts.lifetime)))
+			return &utils.HTTPResponse{StatusCode: http.StatusCreated, Body: []byte(body)}, nil
+		},
+		DeleteFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+			ts.revoked = append(ts.revoked, path)
+			return &utils.HTTPResponse{StatusCode: http.StatusOK, Body: []byte(`{}`)}, nil
+		},
+	}
+	return ts
+}
+
+func TestJWTExpiry(t *testing.T) {
+	exp := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.True(t, jwtExpiry(testJWT("tok-1", exp)).Equal(exp))
+	assert.True(t, jwtExpiry(testJWT("tok-1", time.Time{})).IsZero())
+	assert.True(t, jwtExpiry("opaque-token").IsZero())
+	assert.True(t, jwtExpiry("a.!!!.c").IsZero())
+}
+
+func TestSplunkAuth_Token(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	config := &utils.SplunkConfig{Username: "admin", Password: "changeme", TokenLifetime: 3600}
+
+	t.Run("Success_CreatesTokenWithExpiryAndReusesIt", func(t *testing.T) {
+		ts := newTokenServer(clock, time.Hour)
+		auth := newSplunkAuth(config, ts.client)
+		auth.now = clock
+
+		require.NoError(t, auth.authenticate(context.Background()))
+		require.NoError(t, auth.authenticate(context.Background()))
+		require.Len(t, ts.forms, 1)
+		assert.Equal(t, "+3600s", ts.forms[0]["expires_on"])
+		assert.Equal(t, "Automation", ts.forms[0]["audience"])
+		assert.True(t, auth.expiresAt.Equal(now.Add(time.Hour)))
+		assert.True(t, strings.HasPrefix(auth.headers(context.Background())["Authorization"], "Bearer eyJ"))
+	})
+
+	t.Run("Success_RefreshesBeforeExpiryAndRevokesOldToken", func(t *testing.T) {
+		current := now
+		ts := newTokenServer(func() time.Time { return current }, time.Hour)
+		auth := newSplunkAuth(config, ts.client)
+		auth.now = func() time.Time { return current }
+
+		require.NoError(t, auth.authenticate(context.Background()))
+		first := auth.currentToken(context.Background())
+
+		current = now.Add(50 * time.Minute)
+		assert.Equal(t, first, auth.currentToken(context.Background()), "token replaced too early")
+
+		current = now.Add(56 * time.Minute)
+		second := auth.currentToken(context.Background())
+		assert.NotEqual(t, first, second)
+		require.Len(t, ts.forms, 2)
+		assert.Equal(t, []string{"/services/authorization/tokens/admin?id=tok-1&output_mode=json"}, ts.revoked)
+	})
+
+	t.Run("Success_SingleRefreshWithoutHoldingLock", func(t *testing.T) {
+		current := now
+		ts := newTokenServer(func() time.Time { return current }, time.Hour)
+		auth := newSplunkAuth(config, ts.client)
+		auth.now = func() time.Time { return current }
+		require.NoError(t, auth.authenticate(context.Background()))
+		first := auth.currentToken(context.Background())
+
+		// Block the refresh request until the other callers have returned
+		started, release := make(chan struct{}), make(chan struct{})
+		createToken := ts.client.PostFormWithBasicAuthFunc
+		ts.client.PostFormWithBasicAuthFunc = func(ctx context.Context, path string, formData map[string]string, headers map[string]string, username, password string) (*utils.HTTPResponse, error) {
+			close(started)
+			<-release
+			return createToken(ctx, path, formData, headers, username, password)
+		}
+
+		current = now.Add(56 * time.Minute)
+		refreshed := make(chan string)
+		go func() { refreshed <- auth.currentToken(context.Background()) }()
+		<-started
+
+		for i := 0; i < 3; i++ {
+			assert.Equal(t, "Bearer "+first, auth.headers(context.Background())["Authorization"])
+		}
+		close(release)
+
+		assert.NotEqual(t, first, <-refreshed)
+		assert.Len(t, ts.forms, 2)
+		assert.Len(t, ts.revoked, 1)
+	})
+
+	t.Run("Success_CancelledRefreshIsRetried", func(t *testing.T) {
+		current := now
+		ts := newTokenServer(func() time.Time { return current }, time.Hour)
+		auth := newSplunkAuth(config, ts.client)
+		auth.now = func() time.Time { return current }
+		require.NoError(t, auth.authenticate(context.Background()))
+		first := auth.currentToken(context.Background())
+
+		createToken := ts.client.PostFormWithBasicAuthFunc
+		ts.client.PostFormWithBasicAuthFunc = func(ctx context.Context, path string, formData map[string]string, headers map[string]string, username, password string) (*utils.HTTPResponse, error) {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+			return createToken(ctx, path, formData, headers, username, password)
+		}
+
+		current = now.Add(56 * time.Minute)
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		assert.Equal(t, first, auth.currentToken(ctx))
+		assert.True(t, auth.refreshFailedAt.IsZero())
+
+		assert.NotEqual(t, first, auth.currentToken(context.Background()))
+	})
+
+	t.Run("Success_LogoutRevokesToken", func(t *testing.T) {
+		ts := newTokenServer(clock, time.Hour)
+		auth := newSplunkAuth(&utils.SplunkConfig{Username: "admin", Password: "changeme", TokenName: "svc migration"}, ts.client)
+		auth.now = clock
+
+		require.NoError(t, auth.authenticate(context.Background()))
+		require.NoError(t, auth.logout(context.Background()))
+		assert.Equal(t, []string{"/services/authorization/tokens/svc%20migration?id=tok-1&output_mode=json"}, ts.revoked)
+		assert.Empty(t, auth.currentToken(context.Background()))
+
+		require.NoError(t, auth.logout(context.Background()))
+		assert.Len(t, ts.revoked, 1)
+	})
+
+	t.Run("Success_LogoutIgnoresMissingToken", func(t *testing.T) {
+		ts := newTokenServer(clock, time.Hour)
+		ts.client.DeleteFunc = func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+			return &utils.HTTPResponse{StatusCode: http.StatusNotFound}, nil
+		}
+		auth := newSplunkAuth(config, ts.client)
+
+		require.NoError(t, auth.authenticate(context.Background()))
+		assert.NoError(t, auth.logout(context.Background()))
+	})
+
+	t.Run("Error_CreateTokenFails", func(t *testing.T) {
+		client := &mocks.MockHTTPClient{
+			PostFormWithBasicAuthFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string, username, password string) (*utils.HTTPResponse, error) {
+				return &utils.HTTPResponse{StatusCode: http.StatusUnauthorized, Body: []byte(`{"messages":[{"type":"WARN","text":"Login failed"}]}`)}, nil
+			},
+		}
+		auth := newSplunkAuth(config, client)
+
+		err := auth.authenticate(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 401")
+		assert.True(t, strings.HasPrefix(auth.headers(context.Background())["Authorization"], "Basic "))
+	})
+}
+
+func TestSplunkAuth_StaticToken(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("Success_UsesTokenWithoutCreatingOne", func(t *testing.T) {
+		client := &mocks.MockHTTPClient{}
+		token := testJWT("static", now.Add(time.Minute))
+		auth := newSplunkAuth(&utils.SplunkConfig{Token: token}, client)
+		auth.now = func() time.Time { return now }
+
+		assert.Equal(t, "Bearer "+token, auth.headers(context.Background())["Authorization"], "static token used before authenticate")
+		require.NoError(t, auth.authenticate(context.Background()))
+		assert.Equal(t, token, auth.currentToken(context.Background()))
+		require.NoError(t, auth.logout(context.Background()))
+		assert.Zero(t, client.PostFormWithBasicAuthCalls)
+		assert.Zero(t, client.DeleteCalls)
+
+		_, ok := auth.reauthenticate(context.Background(), "Bearer "+token)
+		assert.False(t, ok)
+	})
+
+	t.Run("Error_ExpiredToken", func(t *testing.T) {
+		auth := newSplunkAuth(&utils.SplunkConfig{Token: testJWT("static", now.Add(-time.Minute))}, &mocks.MockHTTPClient{})
+		auth.now = func() time.Time { return now }
+
+		err := auth.authenticate(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "SPLUNK_TOKEN expired")
+	})
+}
+
+func TestSplunkAuth_SessionKey(t *testing.T) {
+	var logins []map[string]string
+	client := &mocks.MockHTTPClient{
+		PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+			assert.Equal(t, "/services/auth/login", path)
+			logins = append(logins, formData)
+			return &utils.HTTPResponse{StatusCode: http.StatusOK, Body: []byte(fmt.Sprintf(`{"sessionKey":"session-key-%d"}`, len(logins)))}, nil
+		},
+	}
+	auth := newSplunkAuth(&utils.SplunkConfig{AuthMethod: utils.SplunkAuthSession, Username: "admin", Password: "changeme"}, client)
+
+	require.NoError(t, auth.authenticate(context.Background()))
+	require.Len(t, logins, 1)
+	assert.Equal(t, "admin", logins[0]["username"])
+	assert.Equal(t, "Splunk session-key-1", auth.headers(context.Background())["Authorization"])
+
+	fresh, ok := auth.reauthenticate(context.Background(), "Splunk session-key-1")
+	require.True(t, ok)
+	assert.Equal(t, "Splunk session-key-2", fresh["Authorization"])
+
+	require.NoError(t, auth.logout(context.Background()))
+	assert.Zero(t, client.DeleteCalls)
+}
+
+func TestReauthClient(t *testing.T) {
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+	config := &utils.SplunkConfig{Username: "admin", Password: "changeme"}
+
+	newClient := func(rejected map[string]bool) (*reauthClient, *tokenServer, *[]string) {
+		ts := newTokenServer(func() time.Time { return now }, time.Hour)
+		var seen []string
+		ts.client.GetFunc = func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+			seen = append(seen, headers["Authorization"])
+			if rejected[headers["Authorization"]] || strings.HasPrefix(headers["Authorization"], "Basic ") {
+				return &utils.HTTPResponse{StatusCode: http.StatusUnauthorized}, fmt.Errorf("client error: 401")
+			}
+			return &utils.HTTPResponse{StatusCode: http.StatusOK}, nil
+		}
+		auth := newSplunkAuth(config, ts.client)
+		auth.now = func() time.Time { return now }
+		return &reauthClient{HTTPClientInterface: ts.client, auth: auth}, ts, &seen
+	}
+
+	t.Run("Success_RetriesOnceWithNewToken", func(t *testing.T) {
+		rejected := map[string]bool{}
+		client, ts, seen := newClient(rejected)
+		require.NoError(t, client.auth.authenticate(context.Background()))
+		stale := client.auth.headers(context.Background())["Authorization"]
+		rejected[stale] = true
+
+		resp, err := client.Get(context.Background(), "/services/data/indexes", map[string]string{"Authorization": stale, "Accept": "application/json"})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		require.Len(t, *seen, 2)
+		assert.NotEqual(t, (*seen)[0], (*seen)[1])
+		assert.Len(t, ts.forms, 2)
+	})
+
+	t.Run("Error_SecondRejectionReturned", func(t *testing.T) {
+		rejectAll := map[string]bool{}
+		client, ts, seen := newClient(rejectAll)
+		require.NoError(t, client.auth.authenticate(context.Background()))
+		ts.client.GetFunc = func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+			*seen = append(*seen, headers["Authorization"])
+			return &utils.HTTPResponse{StatusCode: http.StatusUnauthorized}, fmt.Errorf("client error: 401")
+		}
+
+		resp, err := client.Get(context.Background(), "/services/data/indexes", client.auth.headers(context.Background()))
+		require.Error(t, err)
+		assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+		assert.Len(t, *seen, 2)
+	})
+
+	t.Run("Error_BasicAuthNotRetried", func(t *testing.T) {
+		client, ts, seen := newClient(map[string]bool{})
+
+		_, err := client.Get(context.Background(), "/services/data/indexes", client.auth.headers(context.Background()))
+		require.Error(t, err)
+		assert.Len(t, *seen, 1)
+		assert.Empty(t, ts.forms)
+	})
+}
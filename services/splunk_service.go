@@ -3,35 +3,64 @@ package services
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 
 	"salesforce-splunk-migration/models"
+	"salesforce-splunk-migration/tasalesforce"
 	"salesforce-splunk-migration/utils"
 )
 
 type SplunkServiceInterface interface {
 	Authenticate(ctx context.Context) error
 	GetAuthToken() string
+	Logout(ctx context.Context) error
+	HTTPStats() utils.HTTPStats
 	CheckSalesforceAddon(ctx context.Context) error
 	CreateIndex(ctx context.Context, indexName string) error
 	CheckIndexExists(ctx context.Context, indexName string) (bool, error)
 	UpdateIndex(ctx context.Context, indexName string) error
-	CreateSalesforceAccount(ctx context.Context) error
-	CheckSalesforceAccountExists(ctx context.Context) (bool, error)
-	UpdateSalesforceAccount(ctx context.Context) error
+	CreateSalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error
+	CheckSalesforceAccountExists(ctx context.Context, accountName string) (bool, error)
+	GetSalesforceAccount(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error)
+	ListSalesforceAccounts(ctx context.Context) ([]*models.SalesforceAccountSettings, error)
+	UpdateSalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error
 	CreateDataInput(ctx context.Context, input *utils.DataInput) error
 	UpdateDataInput(ctx context.Context, input *utils.DataInput) error
 	CheckDataInputExists(ctx context.Context, inputName string) (bool, error)
+	GetDataInput(ctx context.Context, inputName string) (*models.SFDCObjectInput, error)
+	DisableDataInput(ctx context.Context, inputName string) error
+	DeleteDataInput(ctx context.Context, inputName string) error
 	ListDataInputs(ctx context.Context) ([]string, error)
+	ListDataInputDetails(ctx context.Context) ([]*models.SFDCObjectInput, error)
+	CreateEventLogInput(ctx context.Context, input *utils.EventLogInput) error
+	UpdateEventLogInput(ctx context.Context, input *utils.EventLogInput) error
+	CheckEventLogInputExists(ctx context.Context, inputName string) (bool, error)
+	GetEventLogInput(ctx context.Context, inputName string) (*models.SFDCEventLogInput, error)
+	ListEventLogInputs(ctx context.Context) ([]string, error)
+	GetProxySettings(ctx context.Context) (*models.ProxySettings, error)
+	UpdateProxySettings(ctx context.Context, proxy *utils.SalesforceProxyConfig) error
+	GetAddonLogLevel(ctx context.Context) (string, error)
+	UpdateAddonLogLevel(ctx context.Context, level string) error
+	ListDashboards(ctx context.Context, app string) ([]*models.Dashboard, error)
+	CreateDashboard(ctx context.Context, dashboard *models.Dashboard) error
+	UpdateDashboard(ctx context.Context, dashboard *models.Dashboard) error
+	DeleteDashboard(ctx context.Context, dashboard *models.Dashboard) error
+	SetDashboardACL(ctx context.Context, dashboard *models.Dashboard, acl utils.DashboardACL) error
 }
 
 // SplunkService handles all Splunk API operations
 type SplunkService struct {
 	config     *utils.Config
 	httpClient utils.HTTPClientInterface
-	authToken  string
+	addon      *tasalesforce.Client
+	auth       *splunkAuth
+	stats      utils.HTTPStatsReporter // nil when the HTTP client does not count requests
+	indexes    IndexProvisioner
 }
 
 // NewSplunkService creates a new Splunk service instance with connection pooling
@@ -42,85 +71,90 @@ func NewSplunkService(config *utils.Config) (*SplunkService, error) {
 // NewSplunkServiceWithClient creates a new Splunk service with custom HTTP client (for testing)
 func NewSplunkServiceWithClient(config *utils.Config, httpClient utils.HTTPClientInterface) (*SplunkService, error) {
 	if httpClient == nil {
-		// Create HTTP client with connection pooling and retry configuration
-		httpClient = utils.NewHTTPClient(utils.HTTPClientConfig{
-			BaseURL: config.Splunk.URL,
-			Timeout: time.Duration(config.Splunk.RequestTimeout) * time.Second,
-			Headers: map[string]string{
-				"User-Agent": "Salesforce-Splunk-Migration/1.0",
-			},
-			RetryConfig: utils.RetryConfig{
-				MaxRetries: config.Splunk.MaxRetries,
-				RetryDelay: time.Duration(config.Splunk.RetryDelay) * time.Second,
-				BackoffExp: 2.0,
-			},
-			SkipSSLVerify:   config.Splunk.SkipSSLVerify,
-			MaxIdleConns:    100,
-			MaxConnsPerHost: 100,
-		})
-	}
-
-	return &SplunkService{
-		config:     config,
-		httpClient: httpClient,
-	}, nil
-}
-
-// Authenticate authenticates with Splunk and obtains a JWT token using /services/authorization/tokens
-func (s *SplunkService) Authenticate(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
-	// Set default values if not provided
-	tokenName := s.config.Splunk.TokenName
-	if tokenName == "" {
-		tokenName = s.config.Splunk.Username // Use username as token name if not specified
+		httpClient = newSplunkHTTPClient(config, config.Splunk.URL)
 	}
 
-	tokenAudience := s.config.Splunk.TokenAudience
-	if tokenAudience == "" {
-		tokenAudience = "Automation" // Default audience for automation
+	auth := newSplunkAuth(&config.Splunk, httpClient)
+	service := &SplunkService{
+		config:     config,
+		httpClient: &reauthClient{HTTPClientInterface: httpClient, auth: auth},
+		auth:       auth,
 	}
+	service.stats, _ = httpClient.(utils.HTTPStatsReporter)
+	// Splunk_TA_salesforce endpoints go through the client generated from openapi.json
+	service.addon = tasalesforce.NewClient(service.httpClient, service.authHeaders)
+	service.indexes = newIndexProvisioner(service)
 
-	formData := map[string]string{
-		"name":        tokenName,
-		"audience":    tokenAudience,
-		"output_mode": "json",
-	}
+	return service, nil
+}
 
-	// Create headers with Basic Authentication
-	headers := map[string]string{
-		"Content-Type": "application/x-www-form-urlencoded",
-	}
+// newSplunkHTTPClient creates an HTTP client for baseURL with connection pooling,
+// retries and the throttling configured for Splunk
+func newSplunkHTTPClient(config *utils.Config, baseURL string) *utils.HTTPClient {
+	return utils.NewHTTPClient(utils.HTTPClientConfig{
+		BaseURL: baseURL,
+		Timeout: time.Duration(config.Splunk.RequestTimeout) * time.Second,
+		Headers: map[string]string{
+			"User-Agent": "Salesforce-Splunk-Migration/1.0",
+		},
+		RetryConfig: utils.RetryConfig{
+			MaxRetries: config.Splunk.MaxRetries,
+			RetryDelay: time.Duration(config.Splunk.RetryDelay) * time.Second,
+			BackoffExp: 2.0,
+		},
+		SkipSSLVerify:           config.Splunk.SkipSSLVerify,
+		MaxIdleConns:            100,
+		MaxConnsPerHost:         100,
+		RateLimit:               config.Splunk.RateLimit,
+		RateBurst:               config.Splunk.RateLimitBurst,
+		MaxConcurrentRequests:   config.Splunk.MaxConcurrentRequests,
+		CircuitBreakerThreshold: config.Splunk.CircuitBreakerThreshold,
+		CircuitBreakerCooldown:  time.Duration(config.Splunk.CircuitBreakerCooldown) * time.Second,
+	})
+}
 
-	// Use PostForm with basic auth credentials
-	resp, err := s.httpClient.PostFormWithBasicAuth(ctx, "/services/authorization/tokens", formData, headers, s.config.Splunk.Username, s.config.Splunk.Password)
-	if err != nil {
-		return fmt.Errorf("token authentication request failed: %w", err)
-	}
+// SetIndexProvisioner replaces the provisioner selected from SPLUNK_PLATFORM
+func (s *SplunkService) SetIndexProvisioner(provisioner IndexProvisioner) {
+	s.indexes = provisioner
+}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("token authentication failed with status %d: %s", resp.StatusCode, resp.String())
-	}
+// Authenticate obtains the credential used for management API requests, as selected
+// by SPLUNK_AUTH_METHOD. A credential that is still valid is reused.
+func (s *SplunkService) Authenticate(ctx context.Context) error {
+	return s.auth.authenticate(ctx)
+}
 
-	// Parse response to extract JWT token
-	var tokenResp models.TokenAuthResponse
+// GetAuthToken returns the authentication token, replacing it first when it is about
+// to expire
+func (s *SplunkService) GetAuthToken() string {
+	return s.auth.currentToken(context.Background())
+}
 
-	if err := resp.JSON(&tokenResp); err != nil {
-		return fmt.Errorf("failed to parse token auth response: %w", err)
-	}
+// Logout revokes the token created by Authenticate. Static tokens and session keys
+// are left alone.
+func (s *SplunkService) Logout(ctx context.Context) error {
+	return s.auth.logout(ctx)
+}
 
-	if len(tokenResp.Entry) == 0 {
-		return fmt.Errorf("no token returned in authentication response")
+// HTTPStats returns the request, retry and throttling counters of the HTTP client,
+// including those of the Admin Config Service client on Splunk Cloud
+func (s *SplunkService) HTTPStats() utils.HTTPStats {
+	var stats utils.HTTPStats
+	if s.stats != nil {
+		stats = s.stats.Stats()
 	}
-
-	s.authToken = tokenResp.Entry[0].Content.Token
-	return nil
+	if acs, ok := s.indexes.(utils.HTTPStatsReporter); ok {
+		stats = stats.Add(acs.Stats())
+	}
+	return stats
 }
 
-// GetAuthToken returns the authentication token
-func (s *SplunkService) GetAuthToken() string {
-	return s.authToken
+// authHeaders returns the Authorization header for management API requests.
+// The credential from Authenticate is used once it has run; before that, requests
+// fall back to HTTP Basic Authentication so read-only operations (such as plan mode)
+// can inspect Splunk without minting a token.
+func (s *SplunkService) authHeaders(ctx context.Context) map[string]string {
+	return s.auth.headers(ctx)
 }
 
 // CheckSalesforceAddon checks if Splunk Add-on for Salesforce is installed
@@ -134,9 +168,7 @@ func (s *SplunkService) CheckSalesforceAddon(ctx context.Context) error {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
-	}
+	headers := s.authHeaders(ctx)
 
 	// List all installed apps
 	resp, err := s.httpClient.Get(ctx, "/services/apps/local?output_mode=json", headers)
@@ -145,7 +177,7 @@ func (s *SplunkService) CheckSalesforceAddon(ctx context.Context) error {
 	}
 
 	if !resp.IsSuccess() {
-		return fmt.Errorf("failed to list installed apps: status %d - %s", resp.StatusCode, resp.String())
+		return fmt.Errorf("failed to list installed apps: status %d - %s", resp.StatusCode, utils.Redact(resp.String()))
 	}
 
 	var result struct {
@@ -177,42 +209,17 @@ func (s *SplunkService) CheckSalesforceAddon(ctx context.Context) error {
 	*/
 }
 
-// CreateIndex creates a new Splunk index
+// CreateIndex creates a Splunk index with the SPLUNK_INDEX_* settings, through ACS on
+// Splunk Cloud and /data/indexes on Splunk Enterprise
 func (s *SplunkService) CreateIndex(ctx context.Context, indexName string) error {
 	if indexName == "" {
 		return fmt.Errorf("index name cannot be empty")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
-	formData := map[string]string{
-		"name":        indexName,
-		"datatype":    "event",
-		"output_mode": "json",
-	}
-
-	// Add maxTotalDataSizeMB if configured
-	if s.config.Splunk.MaxTotalDataSizeMB > 0 {
-		formData["maxTotalDataSizeMB"] = fmt.Sprintf("%d", s.config.Splunk.MaxTotalDataSizeMB)
-	}
-
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
-	}
-
-	// Use 000-self-service app context to match UAT configuration
-	resp, err := s.httpClient.PostForm(ctx, "/servicesNS/nobody/000-self-service/data/indexes", formData, headers)
-	if err != nil {
+	if err := s.indexes.CreateIndex(ctx, s.config.IndexSpec(indexName)); err != nil {
 		return fmt.Errorf("failed to create index: %w", err)
 	}
-
-	// The communication layer already handles 409 and 500 "already exists" responses
-	if !resp.IsSuccess() && resp.StatusCode != 409 && resp.StatusCode != 500 {
-		return fmt.Errorf("failed to create index: status %d - %s", resp.StatusCode, resp.String())
-	}
-
-	return s.checkResponseMessages(resp)
+	return nil
 }
 
 // CheckIndexExists checks if an index exists
@@ -221,28 +228,14 @@ func (s *SplunkService) CheckIndexExists(ctx context.Context, indexName string)
 		return false, fmt.Errorf("index name cannot be empty")
 	}
 
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
-	}
-
-	url := fmt.Sprintf("/services/data/indexes/%s?output_mode=json", indexName)
-	resp, err := s.httpClient.Get(ctx, url, headers)
+	exists, err := s.indexes.IndexExists(ctx, indexName)
 	if err != nil {
 		return false, fmt.Errorf("failed to check index existence: %w", err)
 	}
-
-	// 404 means index doesn't exist - this is not an error condition
-	if resp.StatusCode == 404 {
-		return false, nil
-	}
-
-	return resp.StatusCode == 200, nil
+	return exists, nil
 }
 
-// UpdateIndex updates an existing Splunk index
+// UpdateIndex updates the size of an existing Splunk Enterprise index
 func (s *SplunkService) UpdateIndex(ctx context.Context, indexName string) error {
 	if indexName == "" {
 		return fmt.Errorf("index name cannot be empty")
@@ -260,127 +253,150 @@ func (s *SplunkService) UpdateIndex(ctx context.Context, indexName string) error
 		formData["maxTotalDataSizeMB"] = fmt.Sprintf("%d", s.config.Splunk.MaxTotalDataSizeMB)
 	}
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
-	}
+	headers := s.authHeaders(ctx)
 
 	// Update uses POST to the specific index endpoint
 	url := fmt.Sprintf("/services/data/indexes/%s", indexName)
 	resp, err := s.httpClient.PostForm(ctx, url, formData, headers)
-	if err != nil {
+	if err := responseError(resp, err); err != nil {
 		return fmt.Errorf("failed to update index: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("failed to update index: status %d - %s", resp.StatusCode, resp.String())
-	}
-
 	return s.checkResponseMessages(resp)
 }
 
 // CreateSalesforceAccount creates a Salesforce account in Splunk
-func (s *SplunkService) CreateSalesforceAccount(ctx context.Context) error {
-	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
-	defer cancel()
-
-	formData := map[string]string{
-		"name":             s.config.Salesforce.AccountName,
-		"endpoint":         s.config.Salesforce.Endpoint,
-		"sfdc_api_version": s.config.Salesforce.APIVersion,
-		"auth_type":        s.config.Salesforce.AuthType,
-		"output_mode":      "json",
+func (s *SplunkService) CreateSalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error {
+	if account == nil {
+		return fmt.Errorf("salesforce account cannot be nil")
 	}
 
-	// Add OAuth client credentials
-	formData["client_id_oauth_credentials"] = s.config.Salesforce.ClientID
-	formData["client_secret_oauth_credentials"] = s.config.Salesforce.ClientSecret
-
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
-	}
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
 
-	resp, err := s.httpClient.PostForm(ctx, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_account", formData, headers)
-	if err != nil {
+	settings := accountSettings(account)
+	resp, err := s.addon.CreateAccount(ctx, tasalesforce.Account{
+		Name:                         account.Name,
+		Endpoint:                     settings.Endpoint,
+		SFDCAPIVersion:               settings.SFDCAPIVersion,
+		AuthType:                     settings.AuthType,
+		ClientIDOAuthCredentials:     settings.ClientIDOAuthCredentials,
+		ClientSecretOAuthCredentials: settings.ClientSecretOAuthCredentials,
+	})
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrAlreadyExists) {
+			return nil
+		}
 		return fmt.Errorf("failed to create Salesforce account: %w", err)
 	}
 
-	// The communication layer already handles 409 and 500 "already exists" responses
-	// Just check if response is successful or handled
-	if !resp.IsSuccess() && resp.StatusCode != 409 && resp.StatusCode != 500 {
-		return fmt.Errorf("failed to create Salesforce account: status %d - %s", resp.StatusCode, resp.String())
+	if err := s.checkResponseMessages(resp); err != nil && !errors.Is(err, utils.ErrAlreadyExists) {
+		return err
 	}
-
-	return s.checkResponseMessages(resp)
+	return nil
 }
 
 // CheckSalesforceAccountExists checks if a Salesforce account exists
-func (s *SplunkService) CheckSalesforceAccountExists(ctx context.Context) (bool, error) {
+func (s *SplunkService) CheckSalesforceAccountExists(ctx context.Context, accountName string) (bool, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
+	resp, err := s.addon.GetAccount(ctx, accountName)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check Salesforce account existence: %w", err)
 	}
 
-	url := fmt.Sprintf("/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_account/%s?output_mode=json", s.config.Salesforce.AccountName)
-	resp, err := s.httpClient.Get(ctx, url, headers)
-	if err != nil {
-		return false, fmt.Errorf("failed to check Salesforce account existence: %w", err)
+	return true, nil
+}
+
+// GetSalesforceAccount fetches the settings of a Salesforce account; secrets are never
+// returned. It returns nil without an error when the account does not exist.
+func (s *SplunkService) GetSalesforceAccount(ctx context.Context, accountName string) (*models.SalesforceAccountSettings, error) {
+	if accountName == "" {
+		return nil, fmt.Errorf("salesforce account name cannot be empty")
 	}
 
-	// Any 4xx error means account doesn't exist or we can't access it
-	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-		return false, nil
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.GetAccount(ctx, accountName)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get Salesforce account: %w", err)
 	}
 
-	// 500 error with "Not Found" message means account doesn't exist
-	// (Splunk returns 500 instead of 404 for some endpoints)
-	if resp.StatusCode == 500 {
-		bodyStr := string(resp.Body)
-		if strings.Contains(bodyStr, "Not Found") ||
-			strings.Contains(bodyStr, "Could not find object") ||
-			strings.Contains(bodyStr, "[404]") {
-			return false, nil
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse Salesforce account response: %w", err)
+	}
+
+	for _, entry := range result.Entry {
+		if entry.Name == accountName {
+			return models.ParseSalesforceAccountSettings(entry), nil
 		}
 	}
 
-	return resp.StatusCode == 200, nil
+	return nil, nil
 }
 
-// UpdateSalesforceAccount updates an existing Salesforce account in Splunk
-func (s *SplunkService) UpdateSalesforceAccount(ctx context.Context) error {
+// ListSalesforceAccounts lists the Salesforce accounts configured in the add-on.
+// Secrets are never returned.
+func (s *SplunkService) ListSalesforceAccounts(ctx context.Context) ([]*models.SalesforceAccountSettings, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	formData := map[string]string{
-		"endpoint":         s.config.Salesforce.Endpoint,
-		"sfdc_api_version": s.config.Salesforce.APIVersion,
-		"auth_type":        s.config.Salesforce.AuthType,
-		"output_mode":      "json",
+	resp, err := s.addon.ListAccounts(ctx)
+	if err := responseError(resp, err); err != nil {
+		return nil, fmt.Errorf("failed to list Salesforce accounts: %w", err)
+	}
+
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse Salesforce accounts response: %w", err)
 	}
 
-	// Add OAuth client credentials
-	formData["client_id_oauth_credentials"] = s.config.Salesforce.ClientID
-	formData["client_secret_oauth_credentials"] = s.config.Salesforce.ClientSecret
+	accounts := make([]*models.SalesforceAccountSettings, 0, len(result.Entry))
+	for _, entry := range result.Entry {
+		accounts = append(accounts, models.ParseSalesforceAccountSettings(entry))
+	}
+	return accounts, nil
+}
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
+// UpdateSalesforceAccount updates an existing Salesforce account in Splunk
+func (s *SplunkService) UpdateSalesforceAccount(ctx context.Context, account *utils.SalesforceAccount) error {
+	if account == nil {
+		return fmt.Errorf("salesforce account cannot be nil")
 	}
 
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
 	// Update uses POST to the specific account endpoint
-	url := fmt.Sprintf("/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_account/%s", s.config.Salesforce.AccountName)
-	resp, err := s.httpClient.PostForm(ctx, url, formData, headers)
-	if err != nil {
+	resp, err := s.addon.UpdateAccount(ctx, account.Name, accountSettings(account))
+	if err := responseError(resp, err); err != nil {
 		return fmt.Errorf("failed to update Salesforce account: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("failed to update Salesforce account: status %d - %s", resp.StatusCode, resp.String())
-	}
-
 	return s.checkResponseMessages(resp)
 }
 
+// accountSettings maps a configured Salesforce account onto the account schema.
+// Credentials are sent as OAuth client credentials.
+func accountSettings(account *utils.SalesforceAccount) tasalesforce.AccountWithoutName {
+	return tasalesforce.AccountWithoutName{
+		Endpoint:                     account.Endpoint,
+		SFDCAPIVersion:               tasalesforce.SFDCAPIVersion(account.APIVersion),
+		AuthType:                     tasalesforce.AuthType(account.AuthType),
+		ClientIDOAuthCredentials:     account.ClientID,
+		ClientSecretOAuthCredentials: account.ClientSecret,
+	}
+}
+
 // CreateDataInput creates a Salesforce object data input in Splunk
 func (s *SplunkService) CreateDataInput(ctx context.Context, input *utils.DataInput) error {
 	if input == nil {
@@ -390,39 +406,32 @@ func (s *SplunkService) CreateDataInput(ctx context.Context, input *utils.DataIn
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	formData := map[string]string{
-		"name":          input.Name,
-		"account":       s.config.Salesforce.AccountName,
-		"object":        input.Object,
-		"object_fields": input.ObjectFields,
-		"order_by":      input.OrderBy,
-		"start_date":    input.StartDate,
-		"interval":      fmt.Sprintf("%d", input.Interval),
-		"delay":         fmt.Sprintf("%d", input.Delay),
-		"index":         input.Index,
-		"output_mode":   "json",
-	}
-
-	// Use default index if not specified
-	if formData["index"] == "" {
-		formData["index"] = s.config.Splunk.DefaultIndex
-	}
-
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
-	}
-
-	resp, err := s.httpClient.PostForm(ctx, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_object", formData, headers)
-	if err != nil {
+	resp, err := s.addon.CreateSFDCObject(ctx, tasalesforce.SFDCObjectWithoutDisabled{
+		Name:         input.Name,
+		Account:      s.accountOrDefault(input.Account),
+		Object:       input.Object,
+		ObjectFields: input.ObjectFields,
+		OrderBy:      input.OrderBy,
+		StartDate:    input.StartDate,
+		Interval:     strconv.Itoa(input.Interval),
+		Delay:        strconv.Itoa(input.Delay),
+		Index:        s.indexOrDefault(input.Index),
+	})
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrAlreadyExists) {
+			return nil
+		}
 		return fmt.Errorf("failed to create data input: %w", err)
 	}
-
-	// The communication layer already handles 409 and 500 "already exists" responses
-	if !resp.IsSuccess() && resp.StatusCode != 409 && resp.StatusCode != 500 {
-		return fmt.Errorf("failed to create data input: status %d - %s", resp.StatusCode, resp.String())
+	if err := s.checkResponseMessages(resp); err != nil && !errors.Is(err, utils.ErrAlreadyExists) {
+		return err
 	}
 
-	return s.checkResponseMessages(resp)
+	// The add-on creates inputs enabled; disabling takes a second request
+	if input.Disabled != nil && *input.Disabled {
+		return s.DisableDataInput(ctx, input.Name)
+	}
+	return nil
 }
 
 // CheckDataInputExists checks if a data input exists
@@ -430,33 +439,47 @@ func (s *SplunkService) CheckDataInputExists(ctx context.Context, inputName stri
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
+	resp, err := s.addon.GetSFDCObject(ctx, inputName)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check data input existence: %w", err)
 	}
 
-	url := fmt.Sprintf("/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_object/%s?output_mode=json", inputName)
-	resp, err := s.httpClient.Get(ctx, url, headers)
-	if err != nil {
-		return false, fmt.Errorf("failed to check data input existence: %w", err)
+	return true, nil
+}
+
+// GetDataInput fetches the full configuration of a data input.
+// It returns nil without an error when the input does not exist.
+func (s *SplunkService) GetDataInput(ctx context.Context, inputName string) (*models.SFDCObjectInput, error) {
+	if inputName == "" {
+		return nil, fmt.Errorf("data input name cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.GetSFDCObject(ctx, inputName)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get data input: %w", err)
 	}
 
-	// Any 4xx error means data input doesn't exist or we can't access it
-	if resp.StatusCode >= 400 && resp.StatusCode < 500 {
-		return false, nil
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse data input response: %w", err)
 	}
 
-	// 500 error with "Not Found" message means data input doesn't exist
-	// (Splunk returns 500 instead of 404 for some endpoints)
-	if resp.StatusCode == 500 {
-		bodyStr := string(resp.Body)
-		if strings.Contains(bodyStr, "Not Found") ||
-			strings.Contains(bodyStr, "Could not find object") ||
-			strings.Contains(bodyStr, "[404]") {
-			return false, nil
+	for _, entry := range result.Entry {
+		if entry.Name == inputName {
+			return models.ParseSFDCObjectInput(entry)
 		}
 	}
 
-	return resp.StatusCode == 200, nil
+	return nil, nil
 }
 
 // UpdateDataInput updates an existing Salesforce object data input in Splunk
@@ -468,42 +491,73 @@ func (s *SplunkService) UpdateDataInput(ctx context.Context, input *utils.DataIn
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	formData := map[string]string{
-		"account":       s.config.Salesforce.AccountName,
-		"object":        input.Object,
-		"object_fields": input.ObjectFields,
-		"order_by":      input.OrderBy,
-		"start_date":    input.StartDate,
-		"interval":      fmt.Sprintf("%d", input.Interval),
-		"delay":         fmt.Sprintf("%d", input.Delay),
-		"index":         input.Index,
-		"output_mode":   "json",
+	form := tasalesforce.SFDCObjectWithoutName{
+		Account:      s.accountOrDefault(input.Account),
+		Object:       input.Object,
+		ObjectFields: input.ObjectFields,
+		OrderBy:      input.OrderBy,
+		StartDate:    input.StartDate,
+		Interval:     strconv.Itoa(input.Interval),
+		Delay:        strconv.Itoa(input.Delay),
+		Index:        s.indexOrDefault(input.Index),
+	}
+	// disabled is only sent when configured, so inputs disabled by operators stay disabled
+	if input.Disabled != nil {
+		form.Disabled = tasalesforce.DisabledFalse
+		if *input.Disabled {
+			form.Disabled = tasalesforce.DisabledTrue
+		}
 	}
 
-	// Use default index if not specified
-	if formData["index"] == "" {
-		formData["index"] = s.config.Splunk.DefaultIndex
+	// Update uses POST to the specific input endpoint
+	resp, err := s.addon.UpdateSFDCObject(ctx, input.Name, form)
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to update data input: %w", err)
 	}
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
+	return s.checkResponseMessages(resp)
+}
+
+// DisableDataInput disables a Salesforce object data input without removing it
+func (s *SplunkService) DisableDataInput(ctx context.Context, inputName string) error {
+	if inputName == "" {
+		return fmt.Errorf("data input name cannot be empty")
 	}
 
-	// Update uses POST to the specific input endpoint
-	url := fmt.Sprintf("/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_object/%s", input.Name)
-	resp, err := s.httpClient.PostForm(ctx, url, formData, headers)
-	if err != nil {
-		return fmt.Errorf("failed to update data input: %w", err)
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.UpdateSFDCObject(ctx, inputName, tasalesforce.SFDCObjectWithoutName{
+		Disabled: tasalesforce.DisabledTrue,
+	})
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to disable data input: %w", err)
 	}
 
-	if !resp.IsSuccess() {
-		return fmt.Errorf("failed to update data input: status %d - %s", resp.StatusCode, resp.String())
+	return s.checkResponseMessages(resp)
+}
+
+// DeleteDataInput deletes a Salesforce object data input
+func (s *SplunkService) DeleteDataInput(ctx context.Context, inputName string) error {
+	if inputName == "" {
+		return fmt.Errorf("data input name cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.DeleteSFDCObject(ctx, inputName)
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to delete data input: %w", err)
 	}
 
 	return s.checkResponseMessages(resp)
 }
 
-// checkResponseMessages checks Splunk API response for messages and errors
+// checkResponseMessages checks a successful Splunk API response for ERROR messages.
+// Such a message is returned as a *utils.HTTPError; a duplicate object is classified
+// as utils.ErrAlreadyExists, so callers that create objects can test for it with
+// errors.Is.
 func (s *SplunkService) checkResponseMessages(resp *utils.HTTPResponse) error {
 	var splunkResp models.SplunkResponse
 	if err := resp.JSON(&splunkResp); err != nil {
@@ -518,13 +572,7 @@ func (s *SplunkService) checkResponseMessages(resp *utils.HTTPResponse) error {
 	if len(splunkResp.Messages) > 0 {
 		for _, msg := range splunkResp.Messages {
 			if msg.Type == "ERROR" {
-				// Don't treat "already exists" errors as failures
-				msgText := strings.ToLower(msg.Text)
-				if strings.Contains(msgText, "already exists") || strings.Contains(msgText, "name is already in use") {
-					return nil
-				}
-				// Return actual error for any other ERROR message
-				return fmt.Errorf("splunk API error: %s", msg.Text)
+				return fmt.Errorf("splunk API error: %w", utils.NewMessageError(resp.StatusCode, msg.Text))
 			}
 		}
 	}
@@ -534,28 +582,445 @@ func (s *SplunkService) checkResponseMessages(resp *utils.HTTPResponse) error {
 
 // ListDataInputs lists all existing Salesforce object data inputs
 func (s *SplunkService) ListDataInputs(ctx context.Context) ([]string, error) {
+	entries, err := s.listDataInputEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name)
+	}
+	return names, nil
+}
+
+// ListDataInputDetails lists all existing Salesforce object data inputs with their
+// full configuration
+func (s *SplunkService) ListDataInputDetails(ctx context.Context) ([]*models.SFDCObjectInput, error) {
+	entries, err := s.listDataInputEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	inputs := make([]*models.SFDCObjectInput, 0, len(entries))
+	for _, entry := range entries {
+		input, err := models.ParseSFDCObjectInput(entry)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse data input: %w", err)
+		}
+		inputs = append(inputs, input)
+	}
+	return inputs, nil
+}
+
+// listDataInputEntries fetches the sfdc_object entries, including their content
+func (s *SplunkService) listDataInputEntries(ctx context.Context) ([]models.Entry, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.ListSFDCObjects(ctx)
+	if err := responseError(resp, err); err != nil {
+		return nil, fmt.Errorf("failed to list data inputs: %w", err)
+	}
+
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return result.Entry, nil
+}
+
+// accountOrDefault returns account, or the default Salesforce account when it is empty
+func (s *SplunkService) accountOrDefault(account string) string {
+	if account == "" {
+		return s.config.DefaultAccountName()
+	}
+	return account
+}
+
+// indexOrDefault returns index, or the configured default index when it is empty
+func (s *SplunkService) indexOrDefault(index string) string {
+	if index == "" {
+		return s.config.Splunk.DefaultIndex
+	}
+	return index
+}
+
+// CreateEventLogInput creates a Salesforce Event Log File data input in Splunk
+func (s *SplunkService) CreateEventLogInput(ctx context.Context, input *utils.EventLogInput) error {
+	if input == nil {
+		return fmt.Errorf("event log input cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.CreateSFDCEventLog(ctx, tasalesforce.SFDCEventLogWithoutDisabled{
+		Name:               input.Name,
+		Account:            s.accountOrDefault(input.Account),
+		MonitoringInterval: tasalesforce.MonitoringInterval(input.MonitoringInterval),
+		StartDate:          input.StartDate,
+		Interval:           strconv.Itoa(input.Interval),
+		Index:              s.indexOrDefault(input.Index),
+	})
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrAlreadyExists) {
+			return nil
+		}
+		return fmt.Errorf("failed to create event log input: %w", err)
+	}
+
+	if err := s.checkResponseMessages(resp); err != nil && !errors.Is(err, utils.ErrAlreadyExists) {
+		return err
+	}
+	return nil
+}
+
+// CheckEventLogInputExists checks if an Event Log File data input exists
+func (s *SplunkService) CheckEventLogInputExists(ctx context.Context, inputName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.GetSFDCEventLog(ctx, inputName)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to check event log input existence: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetEventLogInput fetches the full configuration of an Event Log File data input.
+// It returns nil without an error when the input does not exist.
+func (s *SplunkService) GetEventLogInput(ctx context.Context, inputName string) (*models.SFDCEventLogInput, error) {
+	if inputName == "" {
+		return nil, fmt.Errorf("event log input name cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.GetSFDCEventLog(ctx, inputName)
+	if err := responseError(resp, err); err != nil {
+		if errors.Is(err, utils.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event log input: %w", err)
+	}
+
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse event log input response: %w", err)
+	}
+
+	for _, entry := range result.Entry {
+		if entry.Name == inputName {
+			return models.ParseSFDCEventLogInput(entry)
+		}
+	}
+
+	return nil, nil
+}
+
+// UpdateEventLogInput updates an existing Salesforce Event Log File data input in Splunk
+func (s *SplunkService) UpdateEventLogInput(ctx context.Context, input *utils.EventLogInput) error {
+	if input == nil {
+		return fmt.Errorf("event log input cannot be nil")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	// Update uses POST to the specific input endpoint
+	resp, err := s.addon.UpdateSFDCEventLog(ctx, input.Name, tasalesforce.SFDCEventLogWithoutName{
+		Account:            s.accountOrDefault(input.Account),
+		MonitoringInterval: tasalesforce.MonitoringInterval(input.MonitoringInterval),
+		StartDate:          input.StartDate,
+		Interval:           strconv.Itoa(input.Interval),
+		Index:              s.indexOrDefault(input.Index),
+	})
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to update event log input: %w", err)
+	}
+
+	return s.checkResponseMessages(resp)
+}
+
+// ListEventLogInputs lists all existing Salesforce Event Log File data inputs
+func (s *SplunkService) ListEventLogInputs(ctx context.Context) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.ListSFDCEventLogs(ctx)
+	if err := responseError(resp, err); err != nil {
+		return nil, fmt.Errorf("failed to list event log inputs: %w", err)
+	}
+
+	return entryNames(resp)
+}
+
+// firstSettingsEntry returns the single entry of an add-on settings response
+func firstSettingsEntry(resp *utils.HTTPResponse, stanza string) (*models.Entry, error) {
+	if err := responseError(resp, nil); err != nil {
+		return nil, fmt.Errorf("failed to get %s settings: %w", stanza, err)
+	}
+
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse %s settings response: %w", stanza, err)
+	}
+
+	if len(result.Entry) == 0 {
+		return nil, fmt.Errorf("%s settings not found", stanza)
+	}
+
+	return &result.Entry[0], nil
+}
+
+// settingsUpdateResult interprets the response of an add-on settings update
+func (s *SplunkService) settingsUpdateResult(resp *utils.HTTPResponse, err error, stanza string) error {
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to update %s settings: %w", stanza, err)
+	}
+
+	return s.checkResponseMessages(resp)
+}
+
+// GetProxySettings reads the add-on proxy settings (the password is never returned)
+func (s *SplunkService) GetProxySettings(ctx context.Context) (*models.ProxySettings, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
 
-	headers := map[string]string{
-		"Authorization": fmt.Sprintf("Bearer %s", s.authToken),
+	resp, err := s.addon.GetProxySettings(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get proxy settings: %w", err)
 	}
 
-	resp, err := s.httpClient.Get(ctx, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_object?output_mode=json", headers)
+	entry, err := firstSettingsEntry(resp, "proxy")
 	if err != nil {
-		return nil, fmt.Errorf("failed to list data inputs: %w", err)
+		return nil, err
 	}
+	return models.ParseProxySettings(*entry)
+}
 
-	if !resp.IsSuccess() {
-		return nil, fmt.Errorf("failed to list data inputs: status %d - %s", resp.StatusCode, resp.String())
+// UpdateProxySettings applies the add-on proxy settings used to reach Salesforce.
+// The password is only sent when one is configured so an existing one is not cleared.
+func (s *SplunkService) UpdateProxySettings(ctx context.Context, proxy *utils.SalesforceProxyConfig) error {
+	if proxy == nil {
+		return fmt.Errorf("proxy settings cannot be nil")
 	}
 
-	var result struct {
-		Entry []struct {
-			Name string `json:"name"`
-		} `json:"entry"`
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.UpdateProxySettings(ctx, tasalesforce.Proxy{
+		ProxyEnabled:  boolFormValue(proxy.Enabled),
+		ProxyType:     tasalesforce.ProxyType(proxy.Type),
+		ProxyURL:      proxy.URL,
+		ProxyPort:     strconv.Itoa(proxy.Port),
+		ProxyUsername: proxy.Username,
+		ProxyPassword: proxy.Password,
+		ProxyRDNS:     boolFormValue(proxy.RDNS),
+	})
+	return s.settingsUpdateResult(resp, err, "proxy")
+}
+
+// GetAddonLogLevel reads the add-on log level
+func (s *SplunkService) GetAddonLogLevel(ctx context.Context) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.GetLoggingSettings(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get logging settings: %w", err)
 	}
 
+	entry, err := firstSettingsEntry(resp, "logging")
+	if err != nil {
+		return "", err
+	}
+
+	level, _ := entry.Content["loglevel"].(string)
+	return level, nil
+}
+
+// UpdateAddonLogLevel sets the add-on log level
+func (s *SplunkService) UpdateAddonLogLevel(ctx context.Context, level string) error {
+	if level == "" {
+		return fmt.Errorf("log level cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	resp, err := s.addon.UpdateLoggingSettings(ctx, tasalesforce.Logging{
+		LogLevel: tasalesforce.LogLevel(strings.ToUpper(level)),
+	})
+	return s.settingsUpdateResult(resp, err, "logging")
+}
+
+// ListDashboards lists the dashboards of app, including the private views of every
+// owner. Views that other apps share globally are left out.
+func (s *SplunkService) ListDashboards(ctx context.Context, app string) ([]*models.Dashboard, error) {
+	if app == "" {
+		return nil, fmt.Errorf("dashboard app cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	path := fmt.Sprintf("/servicesNS/-/%s/data/ui/views?output_mode=json&count=0", url.PathEscape(app))
+	resp, err := s.httpClient.Get(ctx, path, s.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		return nil, fmt.Errorf("failed to list dashboards: %w", err)
+	}
+
+	var result models.SplunkResponse
+	if err := resp.JSON(&result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var dashboards []*models.Dashboard
+	for _, entry := range result.Entry {
+		if entry.ACL.App == app {
+			dashboards = append(dashboards, models.ParseDashboard(entry))
+		}
+	}
+	return dashboards, nil
+}
+
+// CreateDashboard creates a dashboard in the app and owner context of dashboard
+func (s *SplunkService) CreateDashboard(ctx context.Context, dashboard *models.Dashboard) error {
+	if err := validateDashboard(dashboard); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	formData := map[string]string{
+		"name":        dashboard.Name,
+		"eai:data":    dashboard.Data,
+		"output_mode": "json",
+	}
+	resp, err := s.httpClient.PostForm(ctx, dashboardsPath(dashboard), formData, s.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to create dashboard: %w", err)
+	}
+
+	return s.checkResponseMessages(resp)
+}
+
+// UpdateDashboard replaces the definition of an existing dashboard
+func (s *SplunkService) UpdateDashboard(ctx context.Context, dashboard *models.Dashboard) error {
+	if err := validateDashboard(dashboard); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	formData := map[string]string{
+		"eai:data":    dashboard.Data,
+		"output_mode": "json",
+	}
+	path := dashboardsPath(dashboard) + "/" + url.PathEscape(dashboard.Name)
+	resp, err := s.httpClient.PostForm(ctx, path, formData, s.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to update dashboard: %w", err)
+	}
+
+	return s.checkResponseMessages(resp)
+}
+
+// DeleteDashboard deletes a dashboard
+func (s *SplunkService) DeleteDashboard(ctx context.Context, dashboard *models.Dashboard) error {
+	if err := validateDashboard(dashboard); err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	path := dashboardsPath(dashboard) + "/" + url.PathEscape(dashboard.Name) + "?output_mode=json"
+	resp, err := s.httpClient.Delete(ctx, path, s.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to delete dashboard: %w", err)
+	}
+
+	return s.checkResponseMessages(resp)
+}
+
+// SetDashboardACL sets the owner, sharing and roles of an existing dashboard through
+// its acl endpoint. dashboard locates the view by its current owner and app; roles
+// that acl leaves empty are not changed.
+func (s *SplunkService) SetDashboardACL(ctx context.Context, dashboard *models.Dashboard, acl utils.DashboardACL) error {
+	if err := validateDashboard(dashboard); err != nil {
+		return err
+	}
+	if acl.Owner == "" || acl.Sharing == "" {
+		return fmt.Errorf("dashboard owner and sharing cannot be empty")
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	formData := map[string]string{
+		"owner":       acl.Owner,
+		"sharing":     acl.Sharing,
+		"output_mode": "json",
+	}
+	if len(acl.Read) > 0 {
+		formData["perms.read"] = strings.Join(acl.Read, ",")
+	}
+	if len(acl.Write) > 0 {
+		formData["perms.write"] = strings.Join(acl.Write, ",")
+	}
+	path := dashboardsPath(dashboard) + "/" + url.PathEscape(dashboard.Name) + "/acl"
+	resp, err := s.httpClient.PostForm(ctx, path, formData, s.authHeaders(ctx))
+	if err := responseError(resp, err); err != nil {
+		return fmt.Errorf("failed to set dashboard permissions: %w", err)
+	}
+
+	return s.checkResponseMessages(resp)
+}
+
+// validateDashboard checks that a dashboard names the view and its app context
+func validateDashboard(dashboard *models.Dashboard) error {
+	switch {
+	case dashboard == nil:
+		return fmt.Errorf("dashboard cannot be nil")
+	case dashboard.Name == "":
+		return fmt.Errorf("dashboard name cannot be empty")
+	case dashboard.App == "":
+		return fmt.Errorf("dashboard app cannot be empty")
+	}
+	return nil
+}
+
+// dashboardsPath returns the data/ui/views endpoint of the owner and app of dashboard.
+// Without an owner the view is shared in the app by nobody.
+func dashboardsPath(dashboard *models.Dashboard) string {
+	owner := dashboard.Owner
+	if owner == "" {
+		owner = utils.DefaultDashboardOwner
+	}
+	return fmt.Sprintf("/servicesNS/%s/%s/data/ui/views", url.PathEscape(owner), url.PathEscape(dashboard.App))
+}
+
+// boolFormValue encodes a boolean the way the add-on REST handlers expect
+func boolFormValue(b bool) string {
+	if b {
+		return "1"
+	}
+	return "0"
+}
+
+// entryNames returns the entry names of a Splunk list response
+func entryNames(resp *utils.HTTPResponse) ([]string, error) {
+	var result models.SplunkResponse
 	if err := resp.JSON(&result); err != nil {
 		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
@@ -567,3 +1032,16 @@ func (s *SplunkService) ListDataInputs(ctx context.Context) ([]string, error) {
 
 	return names, nil
 }
+
+// responseError returns the error of a Splunk request: err when the request failed,
+// otherwise a *utils.HTTPError when the response status is not 2xx. Test the result
+// with errors.Is against utils.ErrNotFound, utils.ErrForbidden and the other kinds.
+func responseError(resp *utils.HTTPResponse, err error) error {
+	if err != nil {
+		return err
+	}
+	if resp != nil && !resp.IsSuccess() {
+		return utils.NewHTTPError(resp.StatusCode, resp.Body)
+	}
+	return nil
+}
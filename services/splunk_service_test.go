@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -264,7 +265,8 @@ func TestSplunkService_CreateIndex(t *testing.T) {
 }
 
 func TestSplunkService_CreateSalesforceAccount(t *testing.T) {
-	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account", Endpoint: "https://login.salesforce.com", ClientID: "client_id", ClientSecret: "client_secret"}}
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+	account := &utils.SalesforceAccount{Name: "test_account", Endpoint: "https://login.salesforce.com", ClientID: "client_id", ClientSecret: "client_secret"}
 
 	tests := []struct {
 		name      string
@@ -295,7 +297,7 @@ func TestSplunkService_CreateSalesforceAccount(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service, _ := services.NewSplunkServiceWithClient(config, tt.mockFn())
-			err := service.CreateSalesforceAccount(context.Background())
+			err := service.CreateSalesforceAccount(context.Background(), account)
 			if tt.expectErr {
 				require.Error(t, err)
 				if tt.errText != "" {
@@ -308,6 +310,94 @@ func TestSplunkService_CreateSalesforceAccount(t *testing.T) {
 	}
 }
 
+func TestSplunkService_ErrorsRedactSecrets(t *testing.T) {
+	config := &utils.Config{
+		Splunk:     utils.SplunkConfig{Username: "admin", Password: "splunk-Pa55word"},
+		Salesforce: utils.SalesforceConfig{AccountName: "test_account"},
+	}
+	utils.RegisterSecret(config.Splunk.Password)
+	account := &utils.SalesforceAccount{Name: "test_account", Endpoint: "login.salesforce.com", ClientID: "client_id", ClientSecret: "unregistered-secret"}
+
+	t.Run("Error_AuthenticateEchoesPassword", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(401, `{"messages":[{"type":"WARN","text":"bad password splunk-Pa55word"}]}`))
+		err := service.Authenticate(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 401")
+		assert.NotContains(t, err.Error(), "splunk-Pa55word")
+	})
+
+	t.Run("Error_AccountEchoesFormPayload", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(400, "invalid payload name=test_account&client_secret_oauth_credentials=unregistered-secret"))
+		err := service.CreateSalesforceAccount(context.Background(), account)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid payload")
+		assert.NotContains(t, err.Error(), "unregistered-secret")
+	})
+}
+
+func TestSplunkService_CreateSalesforceAccount_UsesAccountSettings(t *testing.T) {
+	t.Run("Success_SendsNamedAccount", func(t *testing.T) {
+		var capturedForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedForm = formData
+				return &utils.HTTPResponse{StatusCode: 201, Body: []byte("{}")}, nil
+			},
+		}
+		config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "sf_prod"}}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+		err := service.CreateSalesforceAccount(context.Background(), &utils.SalesforceAccount{
+			Name:         "sf_sandbox",
+			Endpoint:     "test.salesforce.com",
+			APIVersion:   "64.0",
+			AuthType:     "oauth_client_credentials",
+			ClientID:     "sandbox-client",
+			ClientSecret: "sandbox-secret",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "sf_sandbox", capturedForm["name"])
+		assert.Equal(t, "test.salesforce.com", capturedForm["endpoint"])
+		assert.Equal(t, "sandbox-client", capturedForm["client_id_oauth_credentials"])
+	})
+
+	t.Run("Error_NilAccount", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		require.Error(t, service.CreateSalesforceAccount(context.Background(), nil))
+		require.Error(t, service.UpdateSalesforceAccount(context.Background(), nil))
+	})
+}
+
+func TestSplunkService_CreateSalesforceAccount_RejectsUnknownEnums(t *testing.T) {
+	tests := []struct {
+		name       string
+		apiVersion string
+		authType   string
+		errText    string
+	}{
+		{name: "Error_UnknownAPIVersion", apiVersion: "v58.0", authType: "oauth_client_credentials", errText: `invalid sfdc_api_version "v58.0"`},
+		{name: "Error_UnknownAuthType", apiVersion: "64.0", authType: "oauth2", errText: `invalid auth_type "oauth2"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			account := &utils.SalesforceAccount{
+				Name:       "test_account",
+				Endpoint:   "login.salesforce.com",
+				APIVersion: tt.apiVersion,
+				AuthType:   tt.authType,
+			}
+			mockClient := &mocks.MockHTTPClient{}
+			service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+			err := service.CreateSalesforceAccount(context.Background(), account)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errText)
+			assert.Equal(t, 0, mockClient.PostFormCalls, "invalid values must not reach Splunk")
+		})
+	}
+}
+
 func TestSplunkService_CreateDataInput(t *testing.T) {
 	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}, Splunk: utils.SplunkConfig{DefaultIndex: "main"}}
 	input := &utils.DataInput{Name: "Account_Input", Object: "Account"}
@@ -365,6 +455,54 @@ func TestSplunkService_CreateDataInput(t *testing.T) {
 	}
 }
 
+func TestSplunkService_CreateDataInput_Account(t *testing.T) {
+	tests := []struct {
+		name          string
+		inputAccount  string
+		expectAccount string
+	}{
+		{name: "Success_UsesInputAccount", inputAccount: "sf_sandbox", expectAccount: "sf_sandbox"},
+		{name: "Success_FallsBackToDefaultAccount", inputAccount: "", expectAccount: "test_account"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedForm map[string]string
+			mockClient := &mocks.MockHTTPClient{
+				PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+					capturedForm = formData
+					return &utils.HTTPResponse{StatusCode: 201, Body: []byte("{}")}, nil
+				},
+			}
+			config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+			service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+			input := &utils.DataInput{Name: "Account_Input", Account: tt.inputAccount, Object: "Account"}
+			require.NoError(t, service.CreateDataInput(context.Background(), input))
+			assert.Equal(t, tt.expectAccount, capturedForm["account"])
+		})
+	}
+}
+
+func TestSplunkService_CreateDataInput_Disabled(t *testing.T) {
+	var paths []string
+	mockClient := &mocks.MockHTTPClient{
+		PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+			paths = append(paths, path)
+			if len(paths) == 2 {
+				assert.Equal(t, "True", formData["disabled"])
+			}
+			return &utils.HTTPResponse{StatusCode: 201, Body: []byte("{}")}, nil
+		},
+	}
+	service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+	disabled := true
+	require.NoError(t, service.CreateDataInput(context.Background(), &utils.DataInput{Name: "Account_Input", Object: "Account", Disabled: &disabled}))
+	require.Len(t, paths, 2)
+	assert.True(t, strings.HasSuffix(paths[1], "/Account_Input"), paths[1])
+}
+
 func TestSplunkService_ListDataInputs(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -420,6 +558,106 @@ func TestSplunkService_ListDataInputs(t *testing.T) {
 	}
 }
 
+func TestSplunkService_ListDataInputDetails(t *testing.T) {
+	t.Run("Success_ParsesEntries", func(t *testing.T) {
+		response := map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{
+					"name": "sf_accounts",
+					"content": map[string]interface{}{
+						"account":       "prod",
+						"object":        "Account",
+						"object_fields": "Id,Name",
+						"interval":      "300",
+						"delay":         60,
+						"index":         "salesforce",
+						"disabled":      "0",
+					},
+				},
+				map[string]interface{}{
+					"name":    "sf_leads",
+					"content": map[string]interface{}{"object": "Lead", "disabled": true},
+				},
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createSuccessMock(t, 200, response))
+
+		inputs, err := service.ListDataInputDetails(context.Background())
+		require.NoError(t, err)
+		require.Len(t, inputs, 2)
+		assert.Equal(t, "prod", inputs[0].Account)
+		assert.Equal(t, 300, inputs[0].Interval)
+		assert.Equal(t, 60, inputs[0].Delay)
+		assert.Equal(t, "Lead", inputs[1].Object)
+		assert.True(t, inputs[1].Disabled)
+	})
+
+	t.Run("Error_InvalidContent", func(t *testing.T) {
+		response := map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{"name": "sf_accounts", "content": map[string]interface{}{"interval": "often"}},
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createSuccessMock(t, 200, response))
+
+		_, err := service.ListDataInputDetails(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "interval")
+	})
+
+	t.Run("Error_Forbidden", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(403, "forbidden"))
+		_, err := service.ListDataInputDetails(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+	})
+}
+
+func TestSplunkService_ListSalesforceAccounts(t *testing.T) {
+	t.Run("Success_ParsesEntries", func(t *testing.T) {
+		response := map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{
+					"name": "sf_prod",
+					"content": map[string]interface{}{
+						"endpoint":                        "login.salesforce.com",
+						"sfdc_api_version":                "64.0",
+						"auth_type":                       "oauth_client_credentials",
+						"client_id_oauth_credentials":     "client-id",
+						"client_secret_oauth_credentials": "********",
+					},
+				},
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createSuccessMock(t, 200, response))
+
+		accounts, err := service.ListSalesforceAccounts(context.Background())
+		require.NoError(t, err)
+		require.Len(t, accounts, 1)
+		assert.Equal(t, &models.SalesforceAccountSettings{
+			Name:       "sf_prod",
+			Endpoint:   "login.salesforce.com",
+			APIVersion: "64.0",
+			AuthType:   "oauth_client_credentials",
+			ClientID:   "client-id",
+		}, accounts[0])
+	})
+
+	t.Run("Error_Forbidden", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(403, "forbidden"))
+		_, err := service.ListSalesforceAccounts(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+	})
+
+	t.Run("Error_NetworkError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createNetworkErrorMock())
+		_, err := service.ListSalesforceAccounts(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "network error")
+	})
+}
+
 func TestSplunkService_CheckSalesforceAddon(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -507,6 +745,13 @@ func TestSplunkService_CheckResponseMessages(t *testing.T) {
 			},
 			expectErr: false,
 		},
+		{
+			name: "Error_WithErrorMessage",
+			mockFn: func() *mocks.MockHTTPClient {
+				return createSuccessMock(t, 200, map[string]interface{}{"messages": []interface{}{map[string]interface{}{"type": "ERROR", "text": "Invalid frozenTimePeriodInSecs"}}})
+			},
+			expectErr: true,
+		},
 		{
 			name:      "Success_InvalidJSONButSuccessStatus",
 			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(200, "invalid json") },
@@ -533,6 +778,18 @@ func TestSplunkService_CheckResponseMessages(t *testing.T) {
 	}
 }
 
+func TestSplunkService_CheckResponseMessages_TypedError(t *testing.T) {
+	mockClient := createSuccessMock(t, 200, map[string]interface{}{"messages": []interface{}{map[string]interface{}{"type": "ERROR", "text": "Name is already in use"}}})
+	service, _ := services.NewSplunkServiceWithClient(&utils.Config{Splunk: utils.SplunkConfig{IndexName: "test_index"}}, mockClient)
+
+	// An update is not a create, so the caller reports the duplicate
+	err := service.UpdateIndex(context.Background(), "test_index")
+	require.Error(t, err)
+	assert.ErrorIs(t, err, utils.ErrAlreadyExists)
+	assert.Equal(t, 200, utils.HTTPStatusCode(err))
+	assert.Contains(t, err.Error(), "Name is already in use")
+}
+
 func TestSplunkService_CheckIndexExists(t *testing.T) {
 	tests := []struct {
 		name        string
@@ -577,10 +834,18 @@ func TestSplunkService_CheckIndexExists(t *testing.T) {
 			expectExist: true,
 		},
 		{
-			name:        "Success_UnexpectedStatusCode_ReturnsFalse",
-			indexName:   "test_index",
-			mockFn:      func() *mocks.MockHTTPClient { return createErrorMock(500, "Internal Server Error") },
-			expectExist: false,
+			name:      "Error_ServerError",
+			indexName: "test_index",
+			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(500, "Internal Server Error") },
+			expectErr: true,
+			errText:   "status 500",
+		},
+		{
+			name:      "Error_Forbidden",
+			indexName: "test_index",
+			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(403, "Forbidden") },
+			expectErr: true,
+			errText:   "status 403",
 		},
 	}
 
@@ -694,9 +959,16 @@ func TestSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 			expectExist: true,
 		},
 		{
-			name:        "Success_500_WithoutNotFoundMessage_ReturnsFalse",
-			mockFn:      func() *mocks.MockHTTPClient { return createErrorMock(500, "Internal Server Error") },
-			expectExist: false,
+			name:      "Error_500_WithoutNotFoundMessage",
+			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(500, "Internal Server Error") },
+			expectErr: true,
+			errText:   "status 500",
+		},
+		{
+			name:      "Error_Forbidden",
+			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(403, "Forbidden") },
+			expectErr: true,
+			errText:   "status 403",
 		},
 		{
 			name:        "Success_500_WithNotFoundMessage_ReturnsFalse",
@@ -708,7 +980,7 @@ func TestSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service, _ := services.NewSplunkServiceWithClient(config, tt.mockFn())
-			exists, err := service.CheckSalesforceAccountExists(context.Background())
+			exists, err := service.CheckSalesforceAccountExists(context.Background(), "test_account")
 			if tt.expectErr {
 				require.Error(t, err)
 				assert.False(t, exists)
@@ -723,8 +995,59 @@ func TestSplunkService_CheckSalesforceAccountExists(t *testing.T) {
 	}
 }
 
+func TestSplunkService_GetSalesforceAccount(t *testing.T) {
+	t.Run("Success_ParsesEntry", func(t *testing.T) {
+		response := map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{
+					"name": "sf_prod",
+					"content": map[string]interface{}{
+						"endpoint":                        "login.salesforce.com",
+						"sfdc_api_version":                "64.0",
+						"auth_type":                       "oauth_client_credentials",
+						"client_id_oauth_credentials":     "client-id",
+						"client_secret_oauth_credentials": "********",
+					},
+				},
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createSuccessMock(t, 200, response))
+
+		account, err := service.GetSalesforceAccount(context.Background(), "sf_prod")
+		require.NoError(t, err)
+		assert.Equal(t, &models.SalesforceAccountSettings{
+			Name:       "sf_prod",
+			Endpoint:   "login.salesforce.com",
+			APIVersion: "64.0",
+			AuthType:   "oauth_client_credentials",
+			ClientID:   "client-id",
+		}, account)
+	})
+
+	t.Run("Success_NotFound", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(404, "Not Found"))
+		account, err := service.GetSalesforceAccount(context.Background(), "sf_prod")
+		require.NoError(t, err)
+		assert.Nil(t, account)
+	})
+
+	t.Run("Error_EmptyName", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		_, err := service.GetSalesforceAccount(context.Background(), "")
+		require.Error(t, err)
+	})
+
+	t.Run("Error_Forbidden", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(403, "forbidden"))
+		_, err := service.GetSalesforceAccount(context.Background(), "sf_prod")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get Salesforce account")
+	})
+}
+
 func TestSplunkService_UpdateSalesforceAccount(t *testing.T) {
-	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account", Endpoint: "https://login.salesforce.com", ClientID: "client_id", ClientSecret: "client_secret"}}
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+	account := &utils.SalesforceAccount{Name: "test_account", Endpoint: "https://login.salesforce.com", ClientID: "client_id", ClientSecret: "client_secret"}
 	tests := []struct {
 		name      string
 		mockFn    func() *mocks.MockHTTPClient
@@ -758,7 +1081,7 @@ func TestSplunkService_UpdateSalesforceAccount(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			service, _ := services.NewSplunkServiceWithClient(config, tt.mockFn())
-			err := service.UpdateSalesforceAccount(context.Background())
+			err := service.UpdateSalesforceAccount(context.Background(), account)
 			if tt.expectErr {
 				require.Error(t, err)
 				if tt.errText != "" {
@@ -804,9 +1127,16 @@ func TestSplunkService_CheckDataInputExists(t *testing.T) {
 			expectExist: true,
 		},
 		{
-			name:        "Success_500_WithoutNotFoundMessage_ReturnsFalse",
-			mockFn:      func() *mocks.MockHTTPClient { return createErrorMock(500, "Internal Server Error") },
-			expectExist: false,
+			name:      "Error_500_WithoutNotFoundMessage",
+			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(500, "Internal Server Error") },
+			expectErr: true,
+			errText:   "status 500",
+		},
+		{
+			name:      "Error_Forbidden",
+			mockFn:    func() *mocks.MockHTTPClient { return createErrorMock(403, "Forbidden") },
+			expectErr: true,
+			errText:   "status 403",
 		},
 		{
 			name:        "Success_500_WithNotFoundMessage_ReturnsFalse",
@@ -895,6 +1225,39 @@ func TestSplunkService_UpdateDataInput(t *testing.T) {
 	}
 }
 
+func TestSplunkService_UpdateDataInput_Disabled(t *testing.T) {
+	enabled, disabled := false, true
+	tests := []struct {
+		name         string
+		disabled     *bool
+		expectSent   bool
+		expectFormat string
+	}{
+		{name: "Success_NotSentWhenUnset"},
+		{name: "Success_Enables", disabled: &enabled, expectSent: true, expectFormat: "False"},
+		{name: "Success_Disables", disabled: &disabled, expectSent: true, expectFormat: "True"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var capturedForm map[string]string
+			mockClient := &mocks.MockHTTPClient{
+				PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+					capturedForm = formData
+					return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+				},
+			}
+			service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+			input := &utils.DataInput{Name: "Account_Input", Object: "Account", Disabled: tt.disabled}
+			require.NoError(t, service.UpdateDataInput(context.Background(), input))
+			value, sent := capturedForm["disabled"]
+			assert.Equal(t, tt.expectSent, sent)
+			assert.Equal(t, tt.expectFormat, value)
+		})
+	}
+}
+
 func TestSplunkService_GetAuthToken(t *testing.T) {
 	t.Run("Success_ReturnsToken", func(t *testing.T) {
 		mockClient := createAuthMock()
@@ -911,3 +1274,686 @@ func TestSplunkService_GetAuthToken(t *testing.T) {
 		assert.Empty(t, token)
 	})
 }
+
+func TestSplunkService_AuthHeaders(t *testing.T) {
+	config := &utils.Config{Splunk: utils.SplunkConfig{Username: "admin", Password: "password"}}
+
+	t.Run("Success_BasicAuthBeforeAuthenticate", func(t *testing.T) {
+		var captured map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			GetFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				captured = headers
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+		_, err := service.CheckIndexExists(context.Background(), "test_index")
+		require.NoError(t, err)
+		assert.Equal(t, "Basic YWRtaW46cGFzc3dvcmQ=", captured["Authorization"])
+		assert.Equal(t, 0, mockClient.PostFormWithBasicAuthCalls)
+	})
+
+	t.Run("Success_BearerAfterAuthenticate", func(t *testing.T) {
+		var captured map[string]string
+		mockClient := createAuthMock()
+		mockClient.GetFunc = func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+			captured = headers
+			return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+		}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+		require.NoError(t, service.Authenticate(context.Background()))
+
+		_, err := service.CheckIndexExists(context.Background(), "test_index")
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer "+service.GetAuthToken(), captured["Authorization"])
+	})
+}
+
+func TestSplunkService_GetDataInput(t *testing.T) {
+	t.Run("Success_ParsesEntry", func(t *testing.T) {
+		response := map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{
+					"name": "sf_accounts",
+					"content": map[string]interface{}{
+						"account":       "prod",
+						"object":        "Account",
+						"object_fields": "Id,Name",
+						"interval":      "300",
+						"delay":         "60",
+						"index":         "salesforce",
+						"disabled":      false,
+					},
+				},
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createSuccessMock(t, 200, response))
+
+		input, err := service.GetDataInput(context.Background(), "sf_accounts")
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Equal(t, "prod", input.Account)
+		assert.Equal(t, 300, input.Interval)
+		assert.False(t, input.Disabled)
+	})
+
+	t.Run("Success_NotFound", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(404, "Not Found"))
+		input, err := service.GetDataInput(context.Background(), "missing")
+		require.NoError(t, err)
+		assert.Nil(t, input)
+	})
+
+	t.Run("Success_NotFoundAs500", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(500, "Could not find object id=missing"))
+		input, err := service.GetDataInput(context.Background(), "missing")
+		require.NoError(t, err)
+		assert.Nil(t, input)
+	})
+
+	t.Run("Error_EmptyName", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		_, err := service.GetDataInput(context.Background(), "")
+		require.Error(t, err)
+	})
+
+	t.Run("Error_Forbidden", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(403, "forbidden"))
+		_, err := service.GetDataInput(context.Background(), "sf_accounts")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+	})
+
+	t.Run("Error_NetworkError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createNetworkErrorMock())
+		_, err := service.GetDataInput(context.Background(), "sf_accounts")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "network error")
+	})
+}
+
+func TestSplunkService_GetEventLogInput(t *testing.T) {
+	t.Run("Success_ParsesEntry", func(t *testing.T) {
+		response := map[string]interface{}{
+			"entry": []interface{}{
+				map[string]interface{}{
+					"name": "sf_event_logs",
+					"content": map[string]interface{}{
+						"account":             "prod",
+						"monitoring_interval": "Daily",
+						"interval":            "3600",
+						"index":               "salesforce",
+					},
+				},
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createSuccessMock(t, 200, response))
+
+		input, err := service.GetEventLogInput(context.Background(), "sf_event_logs")
+		require.NoError(t, err)
+		require.NotNil(t, input)
+		assert.Equal(t, "Daily", input.MonitoringInterval)
+		assert.Equal(t, 3600, input.Interval)
+	})
+
+	t.Run("Success_NotFound", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(404, "Not Found"))
+		input, err := service.GetEventLogInput(context.Background(), "missing")
+		require.NoError(t, err)
+		assert.Nil(t, input)
+	})
+
+	t.Run("Error_EmptyName", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		_, err := service.GetEventLogInput(context.Background(), "")
+		require.Error(t, err)
+	})
+
+	t.Run("Error_Forbidden", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(403, "forbidden"))
+		_, err := service.GetEventLogInput(context.Background(), "sf_event_logs")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to get event log input")
+	})
+}
+
+func TestSplunkService_DisableDataInput(t *testing.T) {
+	t.Run("Success_PostsDisabledFlag", func(t *testing.T) {
+		var capturedPath string
+		var capturedForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedPath = path
+				capturedForm = formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		require.NoError(t, service.DisableDataInput(context.Background(), "sf_old"))
+		assert.Contains(t, capturedPath, "Splunk_TA_salesforce_sfdc_object/sf_old")
+		assert.Equal(t, "True", capturedForm["disabled"])
+	})
+
+	t.Run("Error_EmptyName", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		require.Error(t, service.DisableDataInput(context.Background(), ""))
+	})
+
+	t.Run("Error_StatusCode", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, createErrorMock(400, "bad request"))
+		err := service.DisableDataInput(context.Background(), "sf_old")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+	})
+}
+
+func TestSplunkService_DeleteDataInput(t *testing.T) {
+	t.Run("Success_SendsDelete", func(t *testing.T) {
+		var capturedPath string
+		mockClient := &mocks.MockHTTPClient{
+			DeleteFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedPath = path
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		require.NoError(t, service.DeleteDataInput(context.Background(), "sf_old"))
+		assert.Equal(t, 1, mockClient.DeleteCalls)
+		assert.Contains(t, capturedPath, "Splunk_TA_salesforce_sfdc_object/sf_old")
+	})
+
+	t.Run("Error_EmptyName", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		require.Error(t, service.DeleteDataInput(context.Background(), ""))
+	})
+
+	t.Run("Error_NetworkError", func(t *testing.T) {
+		mockClient := &mocks.MockHTTPClient{
+			DeleteFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				return nil, fmt.Errorf("network error")
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+		err := service.DeleteDataInput(context.Background(), "sf_old")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "network error")
+	})
+}
+
+func TestSplunkService_CreateEventLogInput(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}, Splunk: utils.SplunkConfig{DefaultIndex: "main"}}
+
+	t.Run("Success_SendsEventLogFields", func(t *testing.T) {
+		var gotPath string
+		var gotForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				gotPath, gotForm = path, formData
+				return &utils.HTTPResponse{StatusCode: 201, Body: []byte(`{"entry":[]}`)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+		input := &utils.EventLogInput{Name: "EventLog_Hourly", MonitoringInterval: "Hourly", StartDate: "2024-01-01T00:00:00.000Z", Interval: 3600}
+		require.NoError(t, service.CreateEventLogInput(context.Background(), input))
+
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_event_log", gotPath)
+		assert.Equal(t, map[string]string{
+			"name":                "EventLog_Hourly",
+			"account":             "test_account",
+			"monitoring_interval": "Hourly",
+			"start_date":          "2024-01-01T00:00:00.000Z",
+			"interval":            "3600",
+			"index":               "main",
+			"output_mode":         "json",
+		}, gotForm)
+	})
+
+	t.Run("Error_NilInput", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, &mocks.MockHTTPClient{})
+		err := service.CreateEventLogInput(context.Background(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "event log input cannot be nil")
+	})
+
+	t.Run("Error_HTTPError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(400, "Bad Request"))
+		err := service.CreateEventLogInput(context.Background(), &utils.EventLogInput{Name: "EventLog_Daily"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 400")
+	})
+
+	t.Run("Error_NetworkError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createNetworkErrorMock())
+		err := service.CreateEventLogInput(context.Background(), &utils.EventLogInput{Name: "EventLog_Daily"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "network error")
+	})
+}
+
+func TestSplunkService_CheckEventLogInputExists(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+	tests := []struct {
+		name        string
+		mockFn      func() *mocks.MockHTTPClient
+		expectExist bool
+		expectErr   bool
+	}{
+		{
+			name: "Success_Exists",
+			mockFn: func() *mocks.MockHTTPClient {
+				return createSuccessMock(t, 200, map[string]interface{}{"entry": []interface{}{map[string]interface{}{"name": "EventLog_Daily"}}})
+			},
+			expectExist: true,
+		},
+		{
+			name:   "Success_NotExists",
+			mockFn: func() *mocks.MockHTTPClient { return createErrorMock(404, "Not Found") },
+		},
+		{
+			name:   "Success_500_WithNotFoundMessage_ReturnsFalse",
+			mockFn: func() *mocks.MockHTTPClient { return createErrorMock(500, "Could not find object id=EventLog_Daily") },
+		},
+		{
+			name:      "Error_NetworkError",
+			mockFn:    createNetworkErrorMock,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, _ := services.NewSplunkServiceWithClient(config, tt.mockFn())
+			exists, err := service.CheckEventLogInputExists(context.Background(), "EventLog_Daily")
+			if tt.expectErr {
+				require.Error(t, err)
+				assert.False(t, exists)
+			} else {
+				require.NoError(t, err)
+				assert.Equal(t, tt.expectExist, exists)
+			}
+		})
+	}
+}
+
+func TestSplunkService_ExistenceChecksClassifyErrors(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+	checks := map[string]func(services.SplunkServiceInterface) (bool, error){
+		"index": func(s services.SplunkServiceInterface) (bool, error) {
+			return s.CheckIndexExists(context.Background(), "test_index")
+		},
+		"account": func(s services.SplunkServiceInterface) (bool, error) {
+			return s.CheckSalesforceAccountExists(context.Background(), "test_account")
+		},
+		"data_input": func(s services.SplunkServiceInterface) (bool, error) {
+			return s.CheckDataInputExists(context.Background(), "Account_Input")
+		},
+		"event_log_input": func(s services.SplunkServiceInterface) (bool, error) {
+			return s.CheckEventLogInputExists(context.Background(), "EventLog_Daily")
+		},
+	}
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		kind       error
+	}{
+		{name: "Unauthorized", statusCode: 401, body: `{"messages":[{"type":"WARN","text":"call not properly authenticated"}]}`, kind: utils.ErrUnauthorized},
+		{name: "Forbidden", statusCode: 403, body: `{"messages":[{"type":"ERROR","text":"You do not have permission"}]}`, kind: utils.ErrForbidden},
+		{name: "ServerError", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"Unexpected error"}]}`, kind: utils.ErrServer},
+		{name: "EmbeddedForbidden", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"REST Error [403]: Forbidden"}]}`, kind: utils.ErrForbidden},
+	}
+
+	for _, tt := range tests {
+		for check, fn := range checks {
+			t.Run(tt.name+"_"+check, func(t *testing.T) {
+				service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(tt.statusCode, tt.body))
+				exists, err := fn(service)
+				require.Error(t, err)
+				assert.False(t, exists)
+				assert.ErrorIs(t, err, tt.kind)
+				assert.Equal(t, tt.statusCode, utils.HTTPStatusCode(err))
+			})
+		}
+	}
+}
+
+func TestSplunkService_UpdateEventLogInput(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}, Splunk: utils.SplunkConfig{DefaultIndex: "main"}}
+
+	t.Run("Success_PostsToInputEndpoint", func(t *testing.T) {
+		var gotPath string
+		var gotForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				gotPath, gotForm = path, formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte(`{"entry":[]}`)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+		input := &utils.EventLogInput{Name: "EventLog_Daily", MonitoringInterval: "Daily", Interval: 3600, Index: "sfdc_events"}
+		require.NoError(t, service.UpdateEventLogInput(context.Background(), input))
+
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_event_log/EventLog_Daily", gotPath)
+		assert.NotContains(t, gotForm, "name")
+		assert.Equal(t, "sfdc_events", gotForm["index"])
+		assert.Equal(t, "Daily", gotForm["monitoring_interval"])
+	})
+
+	t.Run("Error_NilInput", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, &mocks.MockHTTPClient{})
+		err := service.UpdateEventLogInput(context.Background(), nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "event log input cannot be nil")
+	})
+
+	t.Run("Error_InternalServerError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(500, "Internal Server Error"))
+		err := service.UpdateEventLogInput(context.Background(), &utils.EventLogInput{Name: "EventLog_Daily"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update event log input")
+	})
+}
+
+func TestSplunkService_ListEventLogInputs(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+
+	t.Run("Success_ReturnsNames", func(t *testing.T) {
+		response := map[string]interface{}{"entry": []interface{}{
+			map[string]interface{}{"name": "EventLog_Daily"},
+			map[string]interface{}{"name": "EventLog_Hourly"},
+		}}
+		service, _ := services.NewSplunkServiceWithClient(config, createSuccessMock(t, 200, response))
+		names, err := service.ListEventLogInputs(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, []string{"EventLog_Daily", "EventLog_Hourly"}, names)
+	})
+
+	t.Run("Error_HTTPError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(403, "Forbidden"))
+		_, err := service.ListEventLogInputs(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to list event log inputs")
+	})
+
+	t.Run("Error_NetworkError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createNetworkErrorMock())
+		_, err := service.ListEventLogInputs(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestSplunkService_GetProxySettings(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+
+	t.Run("Success_ParsesSettings", func(t *testing.T) {
+		var gotPath string
+		mockClient := &mocks.MockHTTPClient{
+			GetFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				gotPath = path
+				body := `{"entry":[{"name":"proxy","content":{"proxy_enabled":"1","proxy_type":"http","proxy_url":"proxy.corp.local","proxy_port":"3128","proxy_password":"********"}}]}`
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte(body)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+		settings, err := service.GetProxySettings(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_settings/proxy?output_mode=json", gotPath)
+		assert.Equal(t, &models.ProxySettings{Enabled: true, Type: "http", URL: "proxy.corp.local", Port: 3128}, settings)
+	})
+
+	t.Run("Error_EmptyResponse", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createSuccessMock(t, 200, map[string]interface{}{"entry": []interface{}{}}))
+		_, err := service.GetProxySettings(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "proxy settings not found")
+	})
+
+	t.Run("Error_HTTPError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(403, "Forbidden"))
+		_, err := service.GetProxySettings(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 403")
+	})
+}
+
+func TestSplunkService_UpdateProxySettings(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+
+	capture := func(gotPath *string, gotForm *map[string]string) *mocks.MockHTTPClient {
+		return &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				*gotPath, *gotForm = path, formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte(`{"entry":[]}`)}, nil
+			},
+		}
+	}
+
+	t.Run("Success_SendsPassword", func(t *testing.T) {
+		var gotPath string
+		var gotForm map[string]string
+		service, _ := services.NewSplunkServiceWithClient(config, capture(&gotPath, &gotForm))
+
+		proxy := &utils.SalesforceProxyConfig{Enabled: true, Type: "socks5", URL: "proxy.corp.local", Port: 1080, Username: "svc", Password: "p@ss", RDNS: true}
+		require.NoError(t, service.UpdateProxySettings(context.Background(), proxy))
+
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_settings/proxy", gotPath)
+		assert.Equal(t, map[string]string{
+			"proxy_enabled":  "1",
+			"proxy_type":     "socks5",
+			"proxy_url":      "proxy.corp.local",
+			"proxy_port":     "1080",
+			"proxy_username": "svc",
+			"proxy_password": "p@ss",
+			"proxy_rdns":     "1",
+			"output_mode":    "json",
+		}, gotForm)
+	})
+
+	t.Run("Success_OmitsEmptyPassword", func(t *testing.T) {
+		var gotPath string
+		var gotForm map[string]string
+		service, _ := services.NewSplunkServiceWithClient(config, capture(&gotPath, &gotForm))
+
+		require.NoError(t, service.UpdateProxySettings(context.Background(), &utils.SalesforceProxyConfig{Enabled: true, URL: "proxy.corp.local", Port: 3128}))
+		assert.NotContains(t, gotForm, "proxy_password")
+		assert.Equal(t, "0", gotForm["proxy_rdns"])
+	})
+
+	t.Run("Error_NilSettings", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, &mocks.MockHTTPClient{})
+		err := service.UpdateProxySettings(context.Background(), nil)
+		require.Error(t, err)
+	})
+
+	t.Run("Error_HTTPError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createErrorMock(400, "Bad Request"))
+		err := service.UpdateProxySettings(context.Background(), &utils.SalesforceProxyConfig{Enabled: true, URL: "proxy.corp.local", Port: 3128, Password: "p@ss"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to update proxy settings")
+		assert.NotContains(t, err.Error(), "p@ss")
+	})
+}
+
+func TestSplunkService_AddonLogLevel(t *testing.T) {
+	config := &utils.Config{Salesforce: utils.SalesforceConfig{AccountName: "test_account"}}
+
+	t.Run("Success_GetLogLevel", func(t *testing.T) {
+		response := map[string]interface{}{"entry": []interface{}{
+			map[string]interface{}{"name": "logging", "content": map[string]interface{}{"loglevel": "INFO"}},
+		}}
+		service, _ := services.NewSplunkServiceWithClient(config, createSuccessMock(t, 200, response))
+		level, err := service.GetAddonLogLevel(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, "INFO", level)
+	})
+
+	t.Run("Success_UpdateLogLevelUppercases", func(t *testing.T) {
+		var gotPath string
+		var gotForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				gotPath, gotForm = path, formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte(`{"entry":[]}`)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(config, mockClient)
+
+		require.NoError(t, service.UpdateAddonLogLevel(context.Background(), "debug"))
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_settings/logging", gotPath)
+		assert.Equal(t, "DEBUG", gotForm["loglevel"])
+	})
+
+	t.Run("Error_EmptyLogLevel", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, &mocks.MockHTTPClient{})
+		require.Error(t, service.UpdateAddonLogLevel(context.Background(), ""))
+	})
+
+	t.Run("Error_NetworkError", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(config, createNetworkErrorMock())
+		_, err := service.GetAddonLogLevel(context.Background())
+		require.Error(t, err)
+	})
+}
+
+func TestSplunkService_ListDashboards(t *testing.T) {
+	t.Run("Success_LeavesOutViewsOfOtherApps", func(t *testing.T) {
+		var capturedPath string
+		mockClient := &mocks.MockHTTPClient{
+			GetFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedPath = path
+				body := `{"entry": [
+					{"name": "home", "acl": {"app": "search", "owner": "nobody", "sharing": "app", "perms": {"read": ["*"], "write": ["admin"]}}, "content": {"eai:data": "<dashboard/>"}},
+					{"name": "launcher", "acl": {"app": "launcher", "owner": "nobody", "sharing": "global"}, "content": {"eai:data": "<view/>"}}
+				]}`
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte(body)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		dashboards, err := service.ListDashboards(context.Background(), "search")
+		require.NoError(t, err)
+		assert.Equal(t, []*models.Dashboard{{Name: "home", App: "search", Owner: "nobody", Sharing: "app", Read: []string{"*"}, Write: []string{"admin"}, Data: "<dashboard/>"}}, dashboards)
+		assert.Contains(t, capturedPath, "/servicesNS/-/search/data/ui/views")
+		assert.Contains(t, capturedPath, "count=0")
+	})
+
+	t.Run("Error_EmptyApp", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		_, err := service.ListDashboards(context.Background(), "")
+		require.Error(t, err)
+	})
+}
+
+func TestSplunkService_DashboardChanges(t *testing.T) {
+	t.Run("Success_UsesOwnerContext", func(t *testing.T) {
+		var paths []string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				paths = append(paths, path)
+				return &utils.HTTPResponse{StatusCode: 201, Body: []byte("{}")}, nil
+			},
+			DeleteFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				paths = append(paths, path)
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		require.NoError(t, service.CreateDashboard(context.Background(), &models.Dashboard{Name: "home", App: "search", Data: "<dashboard/>"}))
+		require.NoError(t, service.UpdateDashboard(context.Background(), &models.Dashboard{Name: "home", App: "search", Owner: "jdoe", Data: "<dashboard/>"}))
+		require.NoError(t, service.DeleteDashboard(context.Background(), &models.Dashboard{Name: "home", App: "search"}))
+
+		require.Len(t, paths, 3)
+		assert.Equal(t, "/servicesNS/nobody/search/data/ui/views", paths[0])
+		assert.Equal(t, "/servicesNS/jdoe/search/data/ui/views/home", paths[1])
+		assert.Contains(t, paths[2], "/servicesNS/nobody/search/data/ui/views/home")
+	})
+
+	t.Run("Error_ConflictIsClassified", func(t *testing.T) {
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				return &utils.HTTPResponse{StatusCode: 409, Body: []byte(`{"messages":[{"type":"ERROR","text":"An object with name=home already exists"}]}`)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		err := service.CreateDashboard(context.Background(), &models.Dashboard{Name: "home", App: "search", Data: "<dashboard/>"})
+		assert.ErrorIs(t, err, utils.ErrAlreadyExists)
+	})
+
+	t.Run("Error_MissingName", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		require.Error(t, service.CreateDashboard(context.Background(), &models.Dashboard{App: "search"}))
+		require.Error(t, service.UpdateDashboard(context.Background(), nil))
+		require.Error(t, service.DeleteDashboard(context.Background(), &models.Dashboard{Name: "home"}))
+	})
+}
+
+func TestSplunkService_SetDashboardACL(t *testing.T) {
+	t.Run("Success_PostsToACLEndpointOfCurrentOwner", func(t *testing.T) {
+		var capturedPath string
+		var capturedForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedPath, capturedForm = path, formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		dashboard := &models.Dashboard{Name: "sales overview", App: "sales", Owner: "jdoe"}
+		acl := utils.DashboardACL{Owner: "nobody", Sharing: utils.SharingApp, Read: []string{"sales_user", "power"}, Write: []string{"admin"}}
+		require.NoError(t, service.SetDashboardACL(context.Background(), dashboard, acl))
+		assert.Equal(t, "/servicesNS/jdoe/sales/data/ui/views/sales%20overview/acl", capturedPath)
+		assert.Equal(t, map[string]string{
+			"owner":       "nobody",
+			"sharing":     "app",
+			"perms.read":  "sales_user,power",
+			"perms.write": "admin",
+			"output_mode": "json",
+		}, capturedForm)
+	})
+
+	t.Run("Success_LeavesUnsetRolesAlone", func(t *testing.T) {
+		var capturedForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedForm = formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		dashboard := &models.Dashboard{Name: "home", App: "search"}
+		require.NoError(t, service.SetDashboardACL(context.Background(), dashboard, utils.DashboardACL{Owner: "nobody", Sharing: utils.SharingGlobal}))
+		assert.NotContains(t, capturedForm, "perms.read")
+		assert.NotContains(t, capturedForm, "perms.write")
+	})
+
+	t.Run("Error_MissingOwnerOrSharing", func(t *testing.T) {
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, &mocks.MockHTTPClient{})
+		dashboard := &models.Dashboard{Name: "home", App: "search"}
+		require.Error(t, service.SetDashboardACL(context.Background(), dashboard, utils.DashboardACL{Owner: "nobody"}))
+		require.Error(t, service.SetDashboardACL(context.Background(), &models.Dashboard{Name: "home"}, utils.DashboardACL{Owner: "nobody", Sharing: utils.SharingApp}))
+	})
+
+	t.Run("Error_RejectedACLIsClassified", func(t *testing.T) {
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				return &utils.HTTPResponse{StatusCode: 404, Body: []byte(`{"messages":[{"type":"ERROR","text":"Could not find object id=home"}]}`)}, nil
+			},
+		}
+		service, _ := services.NewSplunkServiceWithClient(&utils.Config{}, mockClient)
+
+		err := service.SetDashboardACL(context.Background(), &models.Dashboard{Name: "home", App: "search"}, utils.DashboardACL{Owner: "nobody", Sharing: utils.SharingApp})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, utils.ErrNotFound)
+		assert.Contains(t, err.Error(), "failed to set dashboard permissions")
+	})
+}
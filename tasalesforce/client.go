@@ -0,0 +1,30 @@
+// Package tasalesforce is a typed REST client for the Splunk Add-on for Salesforce
+// (Splunk_TA_salesforce). Schemas, enums and endpoints are generated from openapi.json;
+// run `go generate ./tasalesforce` after updating the spec.
+package tasalesforce
+
+import (
+	"context"
+
+	"salesforce-splunk-migration/utils"
+)
+
+//go:generate go run ./internal/gen -spec ../openapi.json -out openapi_gen.go
+
+// Client calls the Splunk_TA_salesforce REST endpoints through an HTTP client
+type Client struct {
+	http    utils.HTTPClientInterface
+	headers func(ctx context.Context) map[string]string
+}
+
+// NewClient creates a client; headers is called with the request's context for every
+// request so that refreshed auth tokens are picked up
+func NewClient(httpClient utils.HTTPClientInterface, headers func(ctx context.Context) map[string]string) *Client {
+	if headers == nil {
+		headers = func(ctx context.Context) map[string]string { return nil }
+	}
+	return &Client{
+		http:    httpClient,
+		headers: headers,
+	}
+}
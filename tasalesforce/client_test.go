@@ -0,0 +1,167 @@
+package tasalesforce_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/mocks"
+	"salesforce-splunk-migration/tasalesforce"
+	"salesforce-splunk-migration/utils"
+)
+
+func authHeaders(ctx context.Context) map[string]string {
+	return map[string]string{"Authorization": "Bearer test-token"}
+}
+
+func TestClient_CreateAccount(t *testing.T) {
+	t.Run("Success_EncodesForm", func(t *testing.T) {
+		var capturedPath string
+		var capturedForm, capturedHeaders map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedPath = path
+				capturedForm = formData
+				capturedHeaders = headers
+				return &utils.HTTPResponse{StatusCode: 201, Body: []byte("{}")}, nil
+			},
+		}
+		client := tasalesforce.NewClient(mockClient, authHeaders)
+
+		resp, err := client.CreateAccount(context.Background(), tasalesforce.Account{
+			Name:                         "sf_account",
+			Endpoint:                     "login.salesforce.com",
+			SFDCAPIVersion:               tasalesforce.SFDCAPIVersion64_0,
+			AuthType:                     tasalesforce.AuthTypeOAuthClientCredentials,
+			ClientIDOAuthCredentials:     "client-id",
+			ClientSecretOAuthCredentials: "client-secret",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 201, resp.StatusCode)
+
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_account", capturedPath)
+		assert.Equal(t, map[string]string{
+			"name":                            "sf_account",
+			"endpoint":                        "login.salesforce.com",
+			"sfdc_api_version":                "64.0",
+			"auth_type":                       "oauth_client_credentials",
+			"client_id_oauth_credentials":     "client-id",
+			"client_secret_oauth_credentials": "client-secret",
+			"output_mode":                     "json",
+		}, capturedForm)
+		assert.Equal(t, "Bearer test-token", capturedHeaders["Authorization"])
+	})
+
+	t.Run("Error_InvalidEnumNotSent", func(t *testing.T) {
+		mockClient := &mocks.MockHTTPClient{}
+		client := tasalesforce.NewClient(mockClient, nil)
+
+		_, err := client.CreateAccount(context.Background(), tasalesforce.Account{
+			Name:           "sf_account",
+			SFDCAPIVersion: "v58.0",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid sfdc_api_version "v58.0"`)
+
+		_, err = client.CreateAccount(context.Background(), tasalesforce.Account{
+			Name:     "sf_account",
+			AuthType: "oauth2",
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid auth_type "oauth2"`)
+		assert.Equal(t, 0, mockClient.PostFormCalls)
+	})
+}
+
+func TestClient_ItemEndpoints(t *testing.T) {
+	t.Run("Success_EscapesNameAndRequestsJSON", func(t *testing.T) {
+		var getPath, deletePath string
+		mockClient := &mocks.MockHTTPClient{
+			GetFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				getPath = path
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+			DeleteFunc: func(ctx context.Context, path string, headers map[string]string) (*utils.HTTPResponse, error) {
+				deletePath = path
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		client := tasalesforce.NewClient(mockClient, nil)
+
+		_, err := client.GetSFDCObject(context.Background(), "sf accounts/1")
+		require.NoError(t, err)
+		_, err = client.DeleteSFDCEventLog(context.Background(), "eventlog_daily")
+		require.NoError(t, err)
+
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_object/sf%20accounts%2F1?output_mode=json", getPath)
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_sfdc_event_log/eventlog_daily?output_mode=json", deletePath)
+	})
+
+	t.Run("Error_EmptyName", func(t *testing.T) {
+		mockClient := &mocks.MockHTTPClient{}
+		client := tasalesforce.NewClient(mockClient, nil)
+
+		_, err := client.UpdateSFDCObject(context.Background(), "", tasalesforce.SFDCObjectWithoutName{})
+		require.Error(t, err)
+		assert.Equal(t, 0, mockClient.PostFormCalls)
+	})
+
+	t.Run("Error_InvalidDisabledValue", func(t *testing.T) {
+		mockClient := &mocks.MockHTTPClient{}
+		client := tasalesforce.NewClient(mockClient, nil)
+
+		_, err := client.UpdateSFDCObject(context.Background(), "sf_accounts", tasalesforce.SFDCObjectWithoutName{Disabled: "1"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid disabled")
+	})
+}
+
+func TestClient_Settings(t *testing.T) {
+	t.Run("Success_UpdateLoggingSettings", func(t *testing.T) {
+		var capturedPath string
+		var capturedForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedPath = path
+				capturedForm = formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		client := tasalesforce.NewClient(mockClient, nil)
+
+		_, err := client.UpdateLoggingSettings(context.Background(), tasalesforce.Logging{LogLevel: tasalesforce.LogLevelDebug})
+		require.NoError(t, err)
+		assert.Equal(t, "/servicesNS/-/Splunk_TA_salesforce/Splunk_TA_salesforce_settings/logging", capturedPath)
+		assert.Equal(t, "DEBUG", capturedForm["loglevel"])
+	})
+
+	t.Run("Success_ProxyOmitsEmptyPassword", func(t *testing.T) {
+		var capturedForm map[string]string
+		mockClient := &mocks.MockHTTPClient{
+			PostFormFunc: func(ctx context.Context, path string, formData map[string]string, headers map[string]string) (*utils.HTTPResponse, error) {
+				capturedForm = formData
+				return &utils.HTTPResponse{StatusCode: 200, Body: []byte("{}")}, nil
+			},
+		}
+		client := tasalesforce.NewClient(mockClient, nil)
+
+		_, err := client.UpdateProxySettings(context.Background(), tasalesforce.Proxy{
+			ProxyEnabled: "1",
+			ProxyType:    tasalesforce.ProxyTypeHTTP,
+			ProxyURL:     "proxy.example.com",
+			ProxyPort:    "8080",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "http", capturedForm["proxy_type"])
+		assert.NotContains(t, capturedForm, "proxy_password")
+	})
+
+	t.Run("Error_InvalidLogLevel", func(t *testing.T) {
+		client := tasalesforce.NewClient(&mocks.MockHTTPClient{}, nil)
+		_, err := client.UpdateLoggingSettings(context.Background(), tasalesforce.Logging{LogLevel: "VERBOSE"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid loglevel")
+	})
+}
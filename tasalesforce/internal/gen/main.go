@@ -0,0 +1,391 @@
+// Command gen generates the typed Splunk_TA_salesforce REST client from openapi.json.
+//
+// Usage (from the tasalesforce directory):
+//
+//	go run ./internal/gen -spec ../openapi.json -out openapi_gen.go
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"log"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+)
+
+// spec is the subset of an OpenAPI 3 document used by the generator
+type spec struct {
+	Servers []struct {
+		URL string `json:"url"`
+	} `json:"servers"`
+	Components struct {
+		Schemas map[string]schema `json:"schemas"`
+	} `json:"components"`
+	Paths map[string]map[string]json.RawMessage `json:"paths"`
+}
+
+type schema struct {
+	Type       string              `json:"type"`
+	Properties map[string]property `json:"properties"`
+}
+
+type property struct {
+	Type   string   `json:"type"`
+	Format string   `json:"format"`
+	Enum   []string `json:"enum"`
+}
+
+type operation struct {
+	Description string `json:"description"`
+	RequestBody *struct {
+		Content map[string]struct {
+			Schema struct {
+				Ref string `json:"$ref"`
+			} `json:"schema"`
+		} `json:"content"`
+	} `json:"requestBody"`
+}
+
+// enumType is a named string type generated for an enum property
+type enumType struct {
+	Name     string
+	Property string
+	Values   []string
+}
+
+// endpoint is a generated client method
+type endpoint struct {
+	Method      string
+	HTTPMethod  string
+	Path        string
+	Description string
+	ItemPath    bool
+	Body        string
+}
+
+// initialisms keeps generated identifiers in Go style
+var initialisms = map[string]string{
+	"api":      "API",
+	"id":       "ID",
+	"url":      "URL",
+	"sfdc":     "SFDC",
+	"rdns":     "RDNS",
+	"http":     "HTTP",
+	"socks5":   "SOCKS5",
+	"oauth":    "OAuth",
+	"loglevel": "LogLevel",
+}
+
+func main() {
+	specPath := flag.String("spec", "../openapi.json", "path to the OpenAPI document")
+	outPath := flag.String("out", "openapi_gen.go", "output Go file")
+	pkg := flag.String("package", "tasalesforce", "Go package name")
+	flag.Parse()
+
+	raw, err := os.ReadFile(*specPath)
+	if err != nil {
+		log.Fatalf("failed to read spec: %v", err)
+	}
+
+	src, err := Generate(raw, *pkg)
+	if err != nil {
+		log.Fatalf("failed to generate client: %v", err)
+	}
+
+	if err := os.WriteFile(*outPath, src, 0o644); err != nil {
+		log.Fatalf("failed to write %s: %v", *outPath, err)
+	}
+}
+
+// Generate renders the typed client for an OpenAPI document
+func Generate(raw []byte, pkg string) ([]byte, error) {
+	var doc spec
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	basePath, err := serverBasePath(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	enums, err := collectEnums(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints, err := collectEndpoints(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "// Code generated by tasalesforce/internal/gen from openapi.json. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&b, "package %s\n\n", pkg)
+	b.WriteString("import (\n\t\"context\"\n\t\"fmt\"\n\t\"net/url\"\n\n\t\"salesforce-splunk-migration/utils\"\n)\n\n")
+
+	fmt.Fprintf(&b, "// BasePath is the add-on REST namespace all endpoints are relative to\n")
+	fmt.Fprintf(&b, "const BasePath = %q\n\n", basePath)
+
+	for _, enum := range enums {
+		writeEnum(&b, enum)
+	}
+
+	for _, name := range sortedKeys(doc.Components.Schemas) {
+		writeSchema(&b, name, doc.Components.Schemas[name])
+	}
+
+	for _, ep := range endpoints {
+		writeEndpoint(&b, ep)
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return src, nil
+}
+
+// serverBasePath returns the path component of the first server URL
+func serverBasePath(doc spec) (string, error) {
+	if len(doc.Servers) == 0 {
+		return "", fmt.Errorf("spec has no servers")
+	}
+	serverURL := strings.NewReplacer("{domain}", "localhost", "{port}", "8089").Replace(doc.Servers[0].URL)
+	parsed, err := url.Parse(serverURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid server url %q: %w", doc.Servers[0].URL, err)
+	}
+	return parsed.Path, nil
+}
+
+// collectEnums builds one named type per enum property, shared across schemas
+func collectEnums(doc spec) ([]enumType, error) {
+	byProperty := make(map[string]enumType)
+	for _, schemaName := range sortedKeys(doc.Components.Schemas) {
+		for propName, prop := range doc.Components.Schemas[schemaName].Properties {
+			if len(prop.Enum) == 0 {
+				continue
+			}
+			enum := enumType{Name: goName(propName), Property: propName, Values: prop.Enum}
+			if existing, ok := byProperty[propName]; ok {
+				if strings.Join(existing.Values, ",") != strings.Join(enum.Values, ",") {
+					return nil, fmt.Errorf("property %s has conflicting enums in schema %s", propName, schemaName)
+				}
+				continue
+			}
+			byProperty[propName] = enum
+		}
+	}
+
+	enums := make([]enumType, 0, len(byProperty))
+	for _, name := range sortedKeys(byProperty) {
+		enums = append(enums, byProperty[name])
+	}
+	return enums, nil
+}
+
+// collectEndpoints maps every path and method to a client method
+func collectEndpoints(doc spec) ([]endpoint, error) {
+	var endpoints []endpoint
+	for _, path := range sortedKeys(doc.Paths) {
+		itemPath := strings.HasSuffix(path, "/{name}")
+		collection := strings.TrimSuffix(path, "/{name}")
+		_, hasItems := doc.Paths[collection+"/{name}"]
+
+		resource := collection[strings.LastIndex(collection, "/")+1:]
+		resource = goName(strings.TrimPrefix(resource, "Splunk_TA_salesforce_"))
+
+		for _, httpMethod := range sortedKeys(doc.Paths[path]) {
+			if httpMethod == "parameters" {
+				continue
+			}
+
+			var op operation
+			if err := json.Unmarshal(doc.Paths[path][httpMethod], &op); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", httpMethod, path, err)
+			}
+
+			ep := endpoint{
+				HTTPMethod:  strings.ToUpper(httpMethod),
+				Path:        strings.TrimSuffix(path, "/{name}"),
+				Description: op.Description,
+				ItemPath:    itemPath,
+			}
+			if op.RequestBody != nil {
+				for _, content := range op.RequestBody.Content {
+					ep.Body = goName(strings.TrimPrefix(content.Schema.Ref, "#/components/schemas/"))
+				}
+			}
+
+			switch {
+			case itemPath && httpMethod == "get":
+				ep.Method = "Get" + resource
+			case itemPath && httpMethod == "post":
+				ep.Method = "Update" + resource
+			case itemPath && httpMethod == "delete":
+				ep.Method = "Delete" + resource
+			case hasItems && httpMethod == "get":
+				ep.Method = "List" + resource + "s"
+			case hasItems && httpMethod == "post":
+				ep.Method = "Create" + resource
+			case httpMethod == "get":
+				ep.Method = "Get" + resource + "Settings"
+			case httpMethod == "post":
+				ep.Method = "Update" + resource + "Settings"
+			default:
+				return nil, fmt.Errorf("unsupported operation %s %s", httpMethod, path)
+			}
+
+			if ep.HTTPMethod == "POST" && ep.Body == "" {
+				return nil, fmt.Errorf("operation %s %s has no form request body", httpMethod, path)
+			}
+			endpoints = append(endpoints, ep)
+		}
+	}
+	return endpoints, nil
+}
+
+func writeEnum(b *bytes.Buffer, enum enumType) {
+	fmt.Fprintf(b, "// %s enumerates the values accepted for %s\n", enum.Name, enum.Property)
+	fmt.Fprintf(b, "type %s string\n\n", enum.Name)
+
+	b.WriteString("const (\n")
+	for _, value := range enum.Values {
+		fmt.Fprintf(b, "\t%s %s = %q\n", enumConstName(enum.Name, value), enum.Name, value)
+	}
+	b.WriteString(")\n\n")
+
+	fmt.Fprintf(b, "// Valid reports whether v is one of the documented %s values\n", enum.Property)
+	fmt.Fprintf(b, "func (v %s) Valid() bool {\n\tswitch v {\n\tcase ", enum.Name)
+	for i, value := range enum.Values {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(enumConstName(enum.Name, value))
+	}
+	b.WriteString(":\n\t\treturn true\n\t}\n\treturn false\n}\n\n")
+}
+
+func writeSchema(b *bytes.Buffer, name string, s schema) {
+	typeName := goName(name)
+	props := sortedKeys(s.Properties)
+
+	fmt.Fprintf(b, "// %s is the %s form schema\n", typeName, name)
+	fmt.Fprintf(b, "type %s struct {\n", typeName)
+	for _, propName := range props {
+		prop := s.Properties[propName]
+		comment := ""
+		if prop.Format == "password" {
+			comment = " // sensitive"
+		}
+		fmt.Fprintf(b, "\t%s %s `json:\"%s,omitempty\"`%s\n", goName(propName), fieldType(propName, prop), propName, comment)
+	}
+	b.WriteString("}\n\n")
+
+	fmt.Fprintf(b, "// Validate checks enum fields against the documented values\n")
+	fmt.Fprintf(b, "func (s %s) Validate() error {\n", typeName)
+	for _, propName := range props {
+		if len(s.Properties[propName].Enum) == 0 {
+			continue
+		}
+		field := goName(propName)
+		fmt.Fprintf(b, "\tif s.%s != \"\" && !s.%s.Valid() {\n", field, field)
+		fmt.Fprintf(b, "\t\treturn fmt.Errorf(\"invalid %s %%q\", s.%s)\n\t}\n", propName, field)
+	}
+	b.WriteString("\treturn nil\n}\n\n")
+
+	fmt.Fprintf(b, "// Form validates s and encodes its non-empty fields as form data\n")
+	fmt.Fprintf(b, "func (s %s) Form() (map[string]string, error) {\n", typeName)
+	b.WriteString("\tif err := s.Validate(); err != nil {\n\t\treturn nil, err\n\t}\n\n")
+	b.WriteString("\tform := map[string]string{\"output_mode\": \"json\"}\n")
+	for _, propName := range props {
+		field := goName(propName)
+		value := "s." + field
+		if len(s.Properties[propName].Enum) > 0 {
+			value = "string(s." + field + ")"
+		}
+		fmt.Fprintf(b, "\tif s.%s != \"\" {\n\t\tform[%q] = %s\n\t}\n", field, propName, value)
+	}
+	b.WriteString("\treturn form, nil\n}\n\n")
+}
+
+func writeEndpoint(b *bytes.Buffer, ep endpoint) {
+	params := "ctx context.Context"
+	path := fmt.Sprintf("%q", ep.Path)
+	if ep.ItemPath {
+		params += ", name string"
+		path = fmt.Sprintf("%q + \"/\" + url.PathEscape(name)", ep.Path)
+	}
+	if ep.Body != "" {
+		params += ", body " + ep.Body
+	}
+
+	docPath := ep.Path
+	if ep.ItemPath {
+		docPath += "/{name}"
+	}
+	fmt.Fprintf(b, "// %s calls %s %s (%s)\n", ep.Method, ep.HTTPMethod, docPath, ep.Description)
+	fmt.Fprintf(b, "func (c *Client) %s(%s) (*utils.HTTPResponse, error) {\n", ep.Method, params)
+	if ep.ItemPath {
+		b.WriteString("\tif name == \"\" {\n\t\treturn nil, fmt.Errorf(\"name cannot be empty\")\n\t}\n")
+	}
+	fmt.Fprintf(b, "\tpath := BasePath + %s\n", path)
+
+	switch ep.HTTPMethod {
+	case "GET":
+		b.WriteString("\treturn c.http.Get(ctx, path+\"?output_mode=json\", c.headers(ctx))\n")
+	case "DELETE":
+		b.WriteString("\treturn c.http.Delete(ctx, path+\"?output_mode=json\", c.headers(ctx))\n")
+	case "POST":
+		b.WriteString("\tform, err := body.Form()\n\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		b.WriteString("\treturn c.http.PostForm(ctx, path, form, c.headers(ctx))\n")
+	}
+	b.WriteString("}\n\n")
+}
+
+// fieldType returns the Go type used for a schema property
+func fieldType(name string, prop property) string {
+	if len(prop.Enum) > 0 {
+		return goName(name)
+	}
+	return "string"
+}
+
+// enumConstName builds a constant name such as SFDCAPIVersion64_0 or AuthTypeOAuth
+func enumConstName(typeName, value string) string {
+	if strings.ContainsAny(value, "0123456789") && !strings.ContainsAny(value, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ") {
+		return typeName + strings.ReplaceAll(value, ".", "_")
+	}
+	return typeName + goName(strings.ToLower(value))
+}
+
+// goName converts snake_case identifiers to exported Go names
+func goName(s string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(s, "_") {
+		if part == "" {
+			continue
+		}
+		if initialism, ok := initialisms[part]; ok {
+			b.WriteString(initialism)
+			continue
+		}
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestGenerate_UpToDate fails when openapi.json changed without re-running go generate
+func TestGenerate_UpToDate(t *testing.T) {
+	raw, err := os.ReadFile("../../../openapi.json")
+	require.NoError(t, err)
+
+	want, err := Generate(raw, "tasalesforce")
+	require.NoError(t, err)
+
+	got, err := os.ReadFile("../../openapi_gen.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(want), string(got), "tasalesforce/openapi_gen.go is stale; run go generate ./tasalesforce")
+}
+
+func TestGenerate(t *testing.T) {
+	t.Run("Success_EnumsAndEndpoints", func(t *testing.T) {
+		spec := `{
+			"servers": [{"url": "https://{domain}:{port}/servicesNS/-/Splunk_TA_demo"}],
+			"paths": {
+				"/Splunk_TA_salesforce_widget": {
+					"get": {"description": "List widgets"},
+					"post": {"description": "Create widget", "requestBody": {"content": {"application/x-www-form-urlencoded": {"schema": {"$ref": "#/components/schemas/widget"}}}}}
+				},
+				"/Splunk_TA_salesforce_widget/{name}": {
+					"delete": {"description": "Delete widget"}
+				},
+				"/Splunk_TA_salesforce_settings/theme": {
+					"get": {"description": "Get theme"}
+				}
+			},
+			"components": {"schemas": {"widget": {"type": "object", "properties": {
+				"name": {"type": "string"},
+				"color": {"type": "string", "enum": ["red", "green"]}
+			}}}}
+		}`
+
+		src, err := Generate([]byte(spec), "demo")
+		require.NoError(t, err)
+
+		out := string(src)
+		assert.Contains(t, out, `const BasePath = "/servicesNS/-/Splunk_TA_demo"`)
+		assert.Contains(t, out, `ColorRed   Color = "red"`)
+		assert.Contains(t, out, "func (c *Client) ListWidgets(ctx context.Context)")
+		assert.Contains(t, out, "func (c *Client) CreateWidget(ctx context.Context, body Widget)")
+		assert.Contains(t, out, "func (c *Client) DeleteWidget(ctx context.Context, name string)")
+		assert.Contains(t, out, "func (c *Client) GetThemeSettings(ctx context.Context)")
+	})
+
+	t.Run("Error_ConflictingEnums", func(t *testing.T) {
+		spec := `{
+			"servers": [{"url": "https://localhost/servicesNS/-/Splunk_TA_demo"}],
+			"paths": {},
+			"components": {"schemas": {
+				"a": {"type": "object", "properties": {"color": {"type": "string", "enum": ["red"]}}},
+				"b": {"type": "object", "properties": {"color": {"type": "string", "enum": ["blue"]}}}
+			}}
+		}`
+
+		_, err := Generate([]byte(spec), "demo")
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "color"))
+	})
+}
@@ -0,0 +1,931 @@
+// Code generated by tasalesforce/internal/gen from openapi.json. DO NOT EDIT.
+
+package tasalesforce
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// BasePath is the add-on REST namespace all endpoints are relative to
+const BasePath = "/servicesNS/-/Splunk_TA_salesforce"
+
+// AuthType enumerates the values accepted for auth_type
+type AuthType string
+
+const (
+	AuthTypeBasic                  AuthType = "basic"
+	AuthTypeOAuth                  AuthType = "oauth"
+	AuthTypeOAuthClientCredentials AuthType = "oauth_client_credentials"
+)
+
+// Valid reports whether v is one of the documented auth_type values
+func (v AuthType) Valid() bool {
+	switch v {
+	case AuthTypeBasic, AuthTypeOAuth, AuthTypeOAuthClientCredentials:
+		return true
+	}
+	return false
+}
+
+// Disabled enumerates the values accepted for disabled
+type Disabled string
+
+const (
+	DisabledFalse Disabled = "False"
+	DisabledTrue  Disabled = "True"
+)
+
+// Valid reports whether v is one of the documented disabled values
+func (v Disabled) Valid() bool {
+	switch v {
+	case DisabledFalse, DisabledTrue:
+		return true
+	}
+	return false
+}
+
+// LogLevel enumerates the values accepted for loglevel
+type LogLevel string
+
+const (
+	LogLevelDebug    LogLevel = "DEBUG"
+	LogLevelInfo     LogLevel = "INFO"
+	LogLevelWarning  LogLevel = "WARNING"
+	LogLevelError    LogLevel = "ERROR"
+	LogLevelCritical LogLevel = "CRITICAL"
+)
+
+// Valid reports whether v is one of the documented loglevel values
+func (v LogLevel) Valid() bool {
+	switch v {
+	case LogLevelDebug, LogLevelInfo, LogLevelWarning, LogLevelError, LogLevelCritical:
+		return true
+	}
+	return false
+}
+
+// MonitoringInterval enumerates the values accepted for monitoring_interval
+type MonitoringInterval string
+
+const (
+	MonitoringIntervalDaily  MonitoringInterval = "Daily"
+	MonitoringIntervalHourly MonitoringInterval = "Hourly"
+)
+
+// Valid reports whether v is one of the documented monitoring_interval values
+func (v MonitoringInterval) Valid() bool {
+	switch v {
+	case MonitoringIntervalDaily, MonitoringIntervalHourly:
+		return true
+	}
+	return false
+}
+
+// ProxyType enumerates the values accepted for proxy_type
+type ProxyType string
+
+const (
+	ProxyTypeHTTP   ProxyType = "http"
+	ProxyTypeSOCKS5 ProxyType = "socks5"
+)
+
+// Valid reports whether v is one of the documented proxy_type values
+func (v ProxyType) Valid() bool {
+	switch v {
+	case ProxyTypeHTTP, ProxyTypeSOCKS5:
+		return true
+	}
+	return false
+}
+
+// SFDCAPIVersion enumerates the values accepted for sfdc_api_version
+type SFDCAPIVersion string
+
+const (
+	SFDCAPIVersion64_0 SFDCAPIVersion = "64.0"
+	SFDCAPIVersion63_0 SFDCAPIVersion = "63.0"
+	SFDCAPIVersion62_0 SFDCAPIVersion = "62.0"
+	SFDCAPIVersion61_0 SFDCAPIVersion = "61.0"
+	SFDCAPIVersion60_0 SFDCAPIVersion = "60.0"
+	SFDCAPIVersion59_0 SFDCAPIVersion = "59.0"
+	SFDCAPIVersion58_0 SFDCAPIVersion = "58.0"
+	SFDCAPIVersion57_0 SFDCAPIVersion = "57.0"
+	SFDCAPIVersion56_0 SFDCAPIVersion = "56.0"
+	SFDCAPIVersion55_0 SFDCAPIVersion = "55.0"
+	SFDCAPIVersion54_0 SFDCAPIVersion = "54.0"
+	SFDCAPIVersion53_0 SFDCAPIVersion = "53.0"
+	SFDCAPIVersion52_0 SFDCAPIVersion = "52.0"
+	SFDCAPIVersion51_0 SFDCAPIVersion = "51.0"
+	SFDCAPIVersion50_0 SFDCAPIVersion = "50.0"
+	SFDCAPIVersion49_0 SFDCAPIVersion = "49.0"
+	SFDCAPIVersion48_0 SFDCAPIVersion = "48.0"
+	SFDCAPIVersion47_0 SFDCAPIVersion = "47.0"
+	SFDCAPIVersion46_0 SFDCAPIVersion = "46.0"
+	SFDCAPIVersion45_0 SFDCAPIVersion = "45.0"
+	SFDCAPIVersion44_0 SFDCAPIVersion = "44.0"
+	SFDCAPIVersion43_0 SFDCAPIVersion = "43.0"
+	SFDCAPIVersion42_0 SFDCAPIVersion = "42.0"
+)
+
+// Valid reports whether v is one of the documented sfdc_api_version values
+func (v SFDCAPIVersion) Valid() bool {
+	switch v {
+	case SFDCAPIVersion64_0, SFDCAPIVersion63_0, SFDCAPIVersion62_0, SFDCAPIVersion61_0, SFDCAPIVersion60_0, SFDCAPIVersion59_0, SFDCAPIVersion58_0, SFDCAPIVersion57_0, SFDCAPIVersion56_0, SFDCAPIVersion55_0, SFDCAPIVersion54_0, SFDCAPIVersion53_0, SFDCAPIVersion52_0, SFDCAPIVersion51_0, SFDCAPIVersion50_0, SFDCAPIVersion49_0, SFDCAPIVersion48_0, SFDCAPIVersion47_0, SFDCAPIVersion46_0, SFDCAPIVersion45_0, SFDCAPIVersion44_0, SFDCAPIVersion43_0, SFDCAPIVersion42_0:
+		return true
+	}
+	return false
+}
+
+// Account is the account form schema
+type Account struct {
+	AuthType                     AuthType       `json:"auth_type,omitempty"`
+	ClientID                     string         `json:"client_id,omitempty"`
+	ClientIDOAuthCredentials     string         `json:"client_id_oauth_credentials,omitempty"`
+	ClientSecret                 string         `json:"client_secret,omitempty"`                   // sensitive
+	ClientSecretOAuthCredentials string         `json:"client_secret_oauth_credentials,omitempty"` // sensitive
+	Endpoint                     string         `json:"endpoint,omitempty"`
+	Name                         string         `json:"name,omitempty"`
+	Password                     string         `json:"password,omitempty"` // sensitive
+	RedirectURL                  string         `json:"redirect_url,omitempty"`
+	SFDCAPIVersion               SFDCAPIVersion `json:"sfdc_api_version,omitempty"`
+	Token                        string         `json:"token,omitempty"` // sensitive
+	Username                     string         `json:"username,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s Account) Validate() error {
+	if s.AuthType != "" && !s.AuthType.Valid() {
+		return fmt.Errorf("invalid auth_type %q", s.AuthType)
+	}
+	if s.SFDCAPIVersion != "" && !s.SFDCAPIVersion.Valid() {
+		return fmt.Errorf("invalid sfdc_api_version %q", s.SFDCAPIVersion)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s Account) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.AuthType != "" {
+		form["auth_type"] = string(s.AuthType)
+	}
+	if s.ClientID != "" {
+		form["client_id"] = s.ClientID
+	}
+	if s.ClientIDOAuthCredentials != "" {
+		form["client_id_oauth_credentials"] = s.ClientIDOAuthCredentials
+	}
+	if s.ClientSecret != "" {
+		form["client_secret"] = s.ClientSecret
+	}
+	if s.ClientSecretOAuthCredentials != "" {
+		form["client_secret_oauth_credentials"] = s.ClientSecretOAuthCredentials
+	}
+	if s.Endpoint != "" {
+		form["endpoint"] = s.Endpoint
+	}
+	if s.Name != "" {
+		form["name"] = s.Name
+	}
+	if s.Password != "" {
+		form["password"] = s.Password
+	}
+	if s.RedirectURL != "" {
+		form["redirect_url"] = s.RedirectURL
+	}
+	if s.SFDCAPIVersion != "" {
+		form["sfdc_api_version"] = string(s.SFDCAPIVersion)
+	}
+	if s.Token != "" {
+		form["token"] = s.Token
+	}
+	if s.Username != "" {
+		form["username"] = s.Username
+	}
+	return form, nil
+}
+
+// AccountWithoutName is the account_without_name form schema
+type AccountWithoutName struct {
+	AuthType                     AuthType       `json:"auth_type,omitempty"`
+	ClientID                     string         `json:"client_id,omitempty"`
+	ClientIDOAuthCredentials     string         `json:"client_id_oauth_credentials,omitempty"`
+	ClientSecret                 string         `json:"client_secret,omitempty"`                   // sensitive
+	ClientSecretOAuthCredentials string         `json:"client_secret_oauth_credentials,omitempty"` // sensitive
+	Endpoint                     string         `json:"endpoint,omitempty"`
+	Password                     string         `json:"password,omitempty"` // sensitive
+	RedirectURL                  string         `json:"redirect_url,omitempty"`
+	SFDCAPIVersion               SFDCAPIVersion `json:"sfdc_api_version,omitempty"`
+	Token                        string         `json:"token,omitempty"` // sensitive
+	Username                     string         `json:"username,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s AccountWithoutName) Validate() error {
+	if s.AuthType != "" && !s.AuthType.Valid() {
+		return fmt.Errorf("invalid auth_type %q", s.AuthType)
+	}
+	if s.SFDCAPIVersion != "" && !s.SFDCAPIVersion.Valid() {
+		return fmt.Errorf("invalid sfdc_api_version %q", s.SFDCAPIVersion)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s AccountWithoutName) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.AuthType != "" {
+		form["auth_type"] = string(s.AuthType)
+	}
+	if s.ClientID != "" {
+		form["client_id"] = s.ClientID
+	}
+	if s.ClientIDOAuthCredentials != "" {
+		form["client_id_oauth_credentials"] = s.ClientIDOAuthCredentials
+	}
+	if s.ClientSecret != "" {
+		form["client_secret"] = s.ClientSecret
+	}
+	if s.ClientSecretOAuthCredentials != "" {
+		form["client_secret_oauth_credentials"] = s.ClientSecretOAuthCredentials
+	}
+	if s.Endpoint != "" {
+		form["endpoint"] = s.Endpoint
+	}
+	if s.Password != "" {
+		form["password"] = s.Password
+	}
+	if s.RedirectURL != "" {
+		form["redirect_url"] = s.RedirectURL
+	}
+	if s.SFDCAPIVersion != "" {
+		form["sfdc_api_version"] = string(s.SFDCAPIVersion)
+	}
+	if s.Token != "" {
+		form["token"] = s.Token
+	}
+	if s.Username != "" {
+		form["username"] = s.Username
+	}
+	return form, nil
+}
+
+// Logging is the logging form schema
+type Logging struct {
+	LogLevel LogLevel `json:"loglevel,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s Logging) Validate() error {
+	if s.LogLevel != "" && !s.LogLevel.Valid() {
+		return fmt.Errorf("invalid loglevel %q", s.LogLevel)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s Logging) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.LogLevel != "" {
+		form["loglevel"] = string(s.LogLevel)
+	}
+	return form, nil
+}
+
+// LoggingWithoutName is the logging_without_name form schema
+type LoggingWithoutName struct {
+	LogLevel LogLevel `json:"loglevel,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s LoggingWithoutName) Validate() error {
+	if s.LogLevel != "" && !s.LogLevel.Valid() {
+		return fmt.Errorf("invalid loglevel %q", s.LogLevel)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s LoggingWithoutName) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.LogLevel != "" {
+		form["loglevel"] = string(s.LogLevel)
+	}
+	return form, nil
+}
+
+// Proxy is the proxy form schema
+type Proxy struct {
+	ProxyEnabled  string    `json:"proxy_enabled,omitempty"`
+	ProxyPassword string    `json:"proxy_password,omitempty"` // sensitive
+	ProxyPort     string    `json:"proxy_port,omitempty"`
+	ProxyRDNS     string    `json:"proxy_rdns,omitempty"`
+	ProxyType     ProxyType `json:"proxy_type,omitempty"`
+	ProxyURL      string    `json:"proxy_url,omitempty"`
+	ProxyUsername string    `json:"proxy_username,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s Proxy) Validate() error {
+	if s.ProxyType != "" && !s.ProxyType.Valid() {
+		return fmt.Errorf("invalid proxy_type %q", s.ProxyType)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s Proxy) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.ProxyEnabled != "" {
+		form["proxy_enabled"] = s.ProxyEnabled
+	}
+	if s.ProxyPassword != "" {
+		form["proxy_password"] = s.ProxyPassword
+	}
+	if s.ProxyPort != "" {
+		form["proxy_port"] = s.ProxyPort
+	}
+	if s.ProxyRDNS != "" {
+		form["proxy_rdns"] = s.ProxyRDNS
+	}
+	if s.ProxyType != "" {
+		form["proxy_type"] = string(s.ProxyType)
+	}
+	if s.ProxyURL != "" {
+		form["proxy_url"] = s.ProxyURL
+	}
+	if s.ProxyUsername != "" {
+		form["proxy_username"] = s.ProxyUsername
+	}
+	return form, nil
+}
+
+// ProxyWithoutName is the proxy_without_name form schema
+type ProxyWithoutName struct {
+	ProxyEnabled  string    `json:"proxy_enabled,omitempty"`
+	ProxyPassword string    `json:"proxy_password,omitempty"` // sensitive
+	ProxyPort     string    `json:"proxy_port,omitempty"`
+	ProxyRDNS     string    `json:"proxy_rdns,omitempty"`
+	ProxyType     ProxyType `json:"proxy_type,omitempty"`
+	ProxyURL      string    `json:"proxy_url,omitempty"`
+	ProxyUsername string    `json:"proxy_username,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s ProxyWithoutName) Validate() error {
+	if s.ProxyType != "" && !s.ProxyType.Valid() {
+		return fmt.Errorf("invalid proxy_type %q", s.ProxyType)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s ProxyWithoutName) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.ProxyEnabled != "" {
+		form["proxy_enabled"] = s.ProxyEnabled
+	}
+	if s.ProxyPassword != "" {
+		form["proxy_password"] = s.ProxyPassword
+	}
+	if s.ProxyPort != "" {
+		form["proxy_port"] = s.ProxyPort
+	}
+	if s.ProxyRDNS != "" {
+		form["proxy_rdns"] = s.ProxyRDNS
+	}
+	if s.ProxyType != "" {
+		form["proxy_type"] = string(s.ProxyType)
+	}
+	if s.ProxyURL != "" {
+		form["proxy_url"] = s.ProxyURL
+	}
+	if s.ProxyUsername != "" {
+		form["proxy_username"] = s.ProxyUsername
+	}
+	return form, nil
+}
+
+// SFDCEventLog is the sfdc_event_log form schema
+type SFDCEventLog struct {
+	Account            string             `json:"account,omitempty"`
+	Disabled           Disabled           `json:"disabled,omitempty"`
+	Index              string             `json:"index,omitempty"`
+	Interval           string             `json:"interval,omitempty"`
+	MonitoringInterval MonitoringInterval `json:"monitoring_interval,omitempty"`
+	Name               string             `json:"name,omitempty"`
+	StartDate          string             `json:"start_date,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s SFDCEventLog) Validate() error {
+	if s.Disabled != "" && !s.Disabled.Valid() {
+		return fmt.Errorf("invalid disabled %q", s.Disabled)
+	}
+	if s.MonitoringInterval != "" && !s.MonitoringInterval.Valid() {
+		return fmt.Errorf("invalid monitoring_interval %q", s.MonitoringInterval)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s SFDCEventLog) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.Account != "" {
+		form["account"] = s.Account
+	}
+	if s.Disabled != "" {
+		form["disabled"] = string(s.Disabled)
+	}
+	if s.Index != "" {
+		form["index"] = s.Index
+	}
+	if s.Interval != "" {
+		form["interval"] = s.Interval
+	}
+	if s.MonitoringInterval != "" {
+		form["monitoring_interval"] = string(s.MonitoringInterval)
+	}
+	if s.Name != "" {
+		form["name"] = s.Name
+	}
+	if s.StartDate != "" {
+		form["start_date"] = s.StartDate
+	}
+	return form, nil
+}
+
+// SFDCEventLogWithoutDisabled is the sfdc_event_log_without_disabled form schema
+type SFDCEventLogWithoutDisabled struct {
+	Account            string             `json:"account,omitempty"`
+	Index              string             `json:"index,omitempty"`
+	Interval           string             `json:"interval,omitempty"`
+	MonitoringInterval MonitoringInterval `json:"monitoring_interval,omitempty"`
+	Name               string             `json:"name,omitempty"`
+	StartDate          string             `json:"start_date,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s SFDCEventLogWithoutDisabled) Validate() error {
+	if s.MonitoringInterval != "" && !s.MonitoringInterval.Valid() {
+		return fmt.Errorf("invalid monitoring_interval %q", s.MonitoringInterval)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s SFDCEventLogWithoutDisabled) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.Account != "" {
+		form["account"] = s.Account
+	}
+	if s.Index != "" {
+		form["index"] = s.Index
+	}
+	if s.Interval != "" {
+		form["interval"] = s.Interval
+	}
+	if s.MonitoringInterval != "" {
+		form["monitoring_interval"] = string(s.MonitoringInterval)
+	}
+	if s.Name != "" {
+		form["name"] = s.Name
+	}
+	if s.StartDate != "" {
+		form["start_date"] = s.StartDate
+	}
+	return form, nil
+}
+
+// SFDCEventLogWithoutName is the sfdc_event_log_without_name form schema
+type SFDCEventLogWithoutName struct {
+	Account            string             `json:"account,omitempty"`
+	Disabled           Disabled           `json:"disabled,omitempty"`
+	Index              string             `json:"index,omitempty"`
+	Interval           string             `json:"interval,omitempty"`
+	MonitoringInterval MonitoringInterval `json:"monitoring_interval,omitempty"`
+	StartDate          string             `json:"start_date,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s SFDCEventLogWithoutName) Validate() error {
+	if s.Disabled != "" && !s.Disabled.Valid() {
+		return fmt.Errorf("invalid disabled %q", s.Disabled)
+	}
+	if s.MonitoringInterval != "" && !s.MonitoringInterval.Valid() {
+		return fmt.Errorf("invalid monitoring_interval %q", s.MonitoringInterval)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s SFDCEventLogWithoutName) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.Account != "" {
+		form["account"] = s.Account
+	}
+	if s.Disabled != "" {
+		form["disabled"] = string(s.Disabled)
+	}
+	if s.Index != "" {
+		form["index"] = s.Index
+	}
+	if s.Interval != "" {
+		form["interval"] = s.Interval
+	}
+	if s.MonitoringInterval != "" {
+		form["monitoring_interval"] = string(s.MonitoringInterval)
+	}
+	if s.StartDate != "" {
+		form["start_date"] = s.StartDate
+	}
+	return form, nil
+}
+
+// SFDCObject is the sfdc_object form schema
+type SFDCObject struct {
+	Account      string   `json:"account,omitempty"`
+	Delay        string   `json:"delay,omitempty"`
+	Disabled     Disabled `json:"disabled,omitempty"`
+	Index        string   `json:"index,omitempty"`
+	Interval     string   `json:"interval,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	Object       string   `json:"object,omitempty"`
+	ObjectFields string   `json:"object_fields,omitempty"`
+	OrderBy      string   `json:"order_by,omitempty"`
+	StartDate    string   `json:"start_date,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s SFDCObject) Validate() error {
+	if s.Disabled != "" && !s.Disabled.Valid() {
+		return fmt.Errorf("invalid disabled %q", s.Disabled)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s SFDCObject) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.Account != "" {
+		form["account"] = s.Account
+	}
+	if s.Delay != "" {
+		form["delay"] = s.Delay
+	}
+	if s.Disabled != "" {
+		form["disabled"] = string(s.Disabled)
+	}
+	if s.Index != "" {
+		form["index"] = s.Index
+	}
+	if s.Interval != "" {
+		form["interval"] = s.Interval
+	}
+	if s.Name != "" {
+		form["name"] = s.Name
+	}
+	if s.Object != "" {
+		form["object"] = s.Object
+	}
+	if s.ObjectFields != "" {
+		form["object_fields"] = s.ObjectFields
+	}
+	if s.OrderBy != "" {
+		form["order_by"] = s.OrderBy
+	}
+	if s.StartDate != "" {
+		form["start_date"] = s.StartDate
+	}
+	return form, nil
+}
+
+// SFDCObjectWithoutDisabled is the sfdc_object_without_disabled form schema
+type SFDCObjectWithoutDisabled struct {
+	Account      string `json:"account,omitempty"`
+	Delay        string `json:"delay,omitempty"`
+	Index        string `json:"index,omitempty"`
+	Interval     string `json:"interval,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Object       string `json:"object,omitempty"`
+	ObjectFields string `json:"object_fields,omitempty"`
+	OrderBy      string `json:"order_by,omitempty"`
+	StartDate    string `json:"start_date,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s SFDCObjectWithoutDisabled) Validate() error {
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s SFDCObjectWithoutDisabled) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.Account != "" {
+		form["account"] = s.Account
+	}
+	if s.Delay != "" {
+		form["delay"] = s.Delay
+	}
+	if s.Index != "" {
+		form["index"] = s.Index
+	}
+	if s.Interval != "" {
+		form["interval"] = s.Interval
+	}
+	if s.Name != "" {
+		form["name"] = s.Name
+	}
+	if s.Object != "" {
+		form["object"] = s.Object
+	}
+	if s.ObjectFields != "" {
+		form["object_fields"] = s.ObjectFields
+	}
+	if s.OrderBy != "" {
+		form["order_by"] = s.OrderBy
+	}
+	if s.StartDate != "" {
+		form["start_date"] = s.StartDate
+	}
+	return form, nil
+}
+
+// SFDCObjectWithoutName is the sfdc_object_without_name form schema
+type SFDCObjectWithoutName struct {
+	Account      string   `json:"account,omitempty"`
+	Delay        string   `json:"delay,omitempty"`
+	Disabled     Disabled `json:"disabled,omitempty"`
+	Index        string   `json:"index,omitempty"`
+	Interval     string   `json:"interval,omitempty"`
+	Object       string   `json:"object,omitempty"`
+	ObjectFields string   `json:"object_fields,omitempty"`
+	OrderBy      string   `json:"order_by,omitempty"`
+	StartDate    string   `json:"start_date,omitempty"`
+}
+
+// Validate checks enum fields against the documented values
+func (s SFDCObjectWithoutName) Validate() error {
+	if s.Disabled != "" && !s.Disabled.Valid() {
+		return fmt.Errorf("invalid disabled %q", s.Disabled)
+	}
+	return nil
+}
+
+// Form validates s and encodes its non-empty fields as form data
+func (s SFDCObjectWithoutName) Form() (map[string]string, error) {
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	form := map[string]string{"output_mode": "json"}
+	if s.Account != "" {
+		form["account"] = s.Account
+	}
+	if s.Delay != "" {
+		form["delay"] = s.Delay
+	}
+	if s.Disabled != "" {
+		form["disabled"] = string(s.Disabled)
+	}
+	if s.Index != "" {
+		form["index"] = s.Index
+	}
+	if s.Interval != "" {
+		form["interval"] = s.Interval
+	}
+	if s.Object != "" {
+		form["object"] = s.Object
+	}
+	if s.ObjectFields != "" {
+		form["object_fields"] = s.ObjectFields
+	}
+	if s.OrderBy != "" {
+		form["order_by"] = s.OrderBy
+	}
+	if s.StartDate != "" {
+		form["start_date"] = s.StartDate
+	}
+	return form, nil
+}
+
+// ListAccounts calls GET /Splunk_TA_salesforce_account (Get list of items for account)
+func (c *Client) ListAccounts(ctx context.Context) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_account"
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// CreateAccount calls POST /Splunk_TA_salesforce_account (Create item in account)
+func (c *Client) CreateAccount(ctx context.Context, body Account) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_account"
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// DeleteAccount calls DELETE /Splunk_TA_salesforce_account/{name} (Delete account item)
+func (c *Client) DeleteAccount(ctx context.Context, name string) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_account" + "/" + url.PathEscape(name)
+	return c.http.Delete(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// GetAccount calls GET /Splunk_TA_salesforce_account/{name} (Get account item details)
+func (c *Client) GetAccount(ctx context.Context, name string) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_account" + "/" + url.PathEscape(name)
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// UpdateAccount calls POST /Splunk_TA_salesforce_account/{name} (Update account item)
+func (c *Client) UpdateAccount(ctx context.Context, name string, body AccountWithoutName) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_account" + "/" + url.PathEscape(name)
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// GetLoggingSettings calls GET /Splunk_TA_salesforce_settings/logging (Get list of items for logging)
+func (c *Client) GetLoggingSettings(ctx context.Context) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_settings/logging"
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// UpdateLoggingSettings calls POST /Splunk_TA_salesforce_settings/logging (Create item in logging)
+func (c *Client) UpdateLoggingSettings(ctx context.Context, body Logging) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_settings/logging"
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// GetProxySettings calls GET /Splunk_TA_salesforce_settings/proxy (Get list of items for proxy)
+func (c *Client) GetProxySettings(ctx context.Context) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_settings/proxy"
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// UpdateProxySettings calls POST /Splunk_TA_salesforce_settings/proxy (Create item in proxy)
+func (c *Client) UpdateProxySettings(ctx context.Context, body Proxy) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_settings/proxy"
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// ListSFDCEventLogs calls GET /Splunk_TA_salesforce_sfdc_event_log (Get list of items for sfdc_event_log)
+func (c *Client) ListSFDCEventLogs(ctx context.Context) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_event_log"
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// CreateSFDCEventLog calls POST /Splunk_TA_salesforce_sfdc_event_log (Create item in sfdc_event_log)
+func (c *Client) CreateSFDCEventLog(ctx context.Context, body SFDCEventLogWithoutDisabled) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_event_log"
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// DeleteSFDCEventLog calls DELETE /Splunk_TA_salesforce_sfdc_event_log/{name} (Delete sfdc_event_log item)
+func (c *Client) DeleteSFDCEventLog(ctx context.Context, name string) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_event_log" + "/" + url.PathEscape(name)
+	return c.http.Delete(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// GetSFDCEventLog calls GET /Splunk_TA_salesforce_sfdc_event_log/{name} (Get sfdc_event_log item details)
+func (c *Client) GetSFDCEventLog(ctx context.Context, name string) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_event_log" + "/" + url.PathEscape(name)
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// UpdateSFDCEventLog calls POST /Splunk_TA_salesforce_sfdc_event_log/{name} (Update sfdc_event_log item)
+func (c *Client) UpdateSFDCEventLog(ctx context.Context, name string, body SFDCEventLogWithoutName) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_event_log" + "/" + url.PathEscape(name)
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// ListSFDCObjects calls GET /Splunk_TA_salesforce_sfdc_object (Get list of items for sfdc_object)
+func (c *Client) ListSFDCObjects(ctx context.Context) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_object"
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// CreateSFDCObject calls POST /Splunk_TA_salesforce_sfdc_object (Create item in sfdc_object)
+func (c *Client) CreateSFDCObject(ctx context.Context, body SFDCObjectWithoutDisabled) (*utils.HTTPResponse, error) {
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_object"
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
+
+// DeleteSFDCObject calls DELETE /Splunk_TA_salesforce_sfdc_object/{name} (Delete sfdc_object item)
+func (c *Client) DeleteSFDCObject(ctx context.Context, name string) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_object" + "/" + url.PathEscape(name)
+	return c.http.Delete(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// GetSFDCObject calls GET /Splunk_TA_salesforce_sfdc_object/{name} (Get sfdc_object item details)
+func (c *Client) GetSFDCObject(ctx context.Context, name string) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_object" + "/" + url.PathEscape(name)
+	return c.http.Get(ctx, path+"?output_mode=json", c.headers(ctx))
+}
+
+// UpdateSFDCObject calls POST /Splunk_TA_salesforce_sfdc_object/{name} (Update sfdc_object item)
+func (c *Client) UpdateSFDCObject(ctx context.Context, name string, body SFDCObjectWithoutName) (*utils.HTTPResponse, error) {
+	if name == "" {
+		return nil, fmt.Errorf("name cannot be empty")
+	}
+	path := BasePath + "/Splunk_TA_salesforce_sfdc_object" + "/" + url.PathEscape(name)
+	form, err := body.Form()
+	if err != nil {
+		return nil, err
+	}
+	return c.http.PostForm(ctx, path, form, c.headers(ctx))
+}
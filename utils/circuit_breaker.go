@@ -0,0 +1,128 @@
+package utils
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// ErrCircuitOpen is returned, wrapped, for requests refused while a circuit breaker is open
+var ErrCircuitOpen = errors.New("circuit breaker open")
+
+// circuitState is the state of a circuit breaker
+type circuitState int
+
+const (
+	circuitClosed circuitState = iota
+	circuitOpen
+	circuitHalfOpen
+)
+
+// CircuitBreaker stops requests to a host that keeps answering 503 Service Unavailable.
+// After threshold consecutive 503 responses the circuit opens and requests fail at once.
+// When the cooldown has passed, a single probe request is let through: a response other
+// than 503 closes the circuit, a 503 or a failed request opens it again.
+type CircuitBreaker struct {
+	mu        sync.Mutex
+	threshold int
+	cooldown  time.Duration
+	state     circuitState
+	failures  int
+	openedAt  time.Time
+	probing   bool
+	opened    int
+	now       func() time.Time
+}
+
+// NewCircuitBreaker creates a circuit breaker. A threshold of zero or less disables it.
+func NewCircuitBreaker(threshold int, cooldown time.Duration) *CircuitBreaker {
+	return &CircuitBreaker{threshold: threshold, cooldown: cooldown, now: time.Now}
+}
+
+// Allow reports whether a request may be sent. It returns ErrCircuitOpen while the
+// circuit is open, or while the probe request of a half-open circuit is in flight.
+func (b *CircuitBreaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case circuitOpen:
+		if b.now().Sub(b.openedAt) < b.cooldown {
+			return ErrCircuitOpen
+		}
+		b.state = circuitHalfOpen
+		b.probing = true
+		return nil
+	case circuitHalfOpen:
+		if b.probing {
+			return ErrCircuitOpen
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+// Record records the outcome of a request allowed by Allow. statusCode is zero when no
+// response was received. It reports whether this outcome opened the circuit.
+func (b *CircuitBreaker) Record(statusCode int) bool {
+	if b.threshold <= 0 {
+		return false
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.probing = false
+		if statusCode == 0 || statusCode == http.StatusServiceUnavailable {
+			b.openLocked()
+			return true
+		}
+	}
+
+	switch statusCode {
+	case 0:
+		// A failed connection says nothing about the 503 streak
+		return false
+	case http.StatusServiceUnavailable:
+		b.failures++
+		if b.state == circuitClosed && b.failures >= b.threshold {
+			b.openLocked()
+			return true
+		}
+		return false
+	default:
+		b.state = circuitClosed
+		b.failures = 0
+		return false
+	}
+}
+
+// Release returns a request allowed by Allow that was never sent, for example because
+// its context was cancelled while waiting for the rate limiter. The probe of a
+// half-open circuit is freed without counting as a failure.
+func (b *CircuitBreaker) Release() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == circuitHalfOpen {
+		b.probing = false
+	}
+}
+
+// Opened returns how many times the circuit has opened
+func (b *CircuitBreaker) Opened() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.opened
+}
+
+// openLocked opens the circuit; b.mu must be held
+func (b *CircuitBreaker) openLocked() {
+	b.state = circuitOpen
+	b.openedAt = b.now()
+	b.opened++
+}
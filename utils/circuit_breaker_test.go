@@ -0,0 +1,78 @@
+package utils_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/utils"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	t.Run("Success_OpensAfterConsecutive503", func(t *testing.T) {
+		breaker := utils.NewCircuitBreaker(3, time.Hour)
+
+		assert.False(t, breaker.Record(http.StatusServiceUnavailable))
+		assert.False(t, breaker.Record(http.StatusServiceUnavailable))
+		assert.False(t, breaker.Record(0), "failed connection counted")
+		require.NoError(t, breaker.Allow())
+		assert.True(t, breaker.Record(http.StatusServiceUnavailable))
+
+		assert.ErrorIs(t, breaker.Allow(), utils.ErrCircuitOpen)
+		assert.Equal(t, 1, breaker.Opened())
+	})
+
+	t.Run("Success_OtherResponsesResetStreak", func(t *testing.T) {
+		breaker := utils.NewCircuitBreaker(2, time.Hour)
+
+		breaker.Record(http.StatusServiceUnavailable)
+		breaker.Record(http.StatusInternalServerError)
+		assert.False(t, breaker.Record(http.StatusServiceUnavailable))
+		assert.NoError(t, breaker.Allow())
+	})
+
+	t.Run("Success_HalfOpenProbe", func(t *testing.T) {
+		breaker := utils.NewCircuitBreaker(1, 20*time.Millisecond)
+		require.True(t, breaker.Record(http.StatusServiceUnavailable))
+		time.Sleep(25 * time.Millisecond)
+
+		require.NoError(t, breaker.Allow(), "probe refused after cooldown")
+		assert.ErrorIs(t, breaker.Allow(), utils.ErrCircuitOpen, "second request let through while probing")
+
+		// A failed probe opens the circuit again
+		assert.True(t, breaker.Record(http.StatusServiceUnavailable))
+		assert.ErrorIs(t, breaker.Allow(), utils.ErrCircuitOpen)
+		time.Sleep(25 * time.Millisecond)
+
+		// A successful probe closes it
+		require.NoError(t, breaker.Allow())
+		assert.False(t, breaker.Record(http.StatusOK))
+		assert.NoError(t, breaker.Allow())
+		assert.NoError(t, breaker.Allow())
+		assert.Equal(t, 2, breaker.Opened())
+	})
+
+	t.Run("Success_ReleasedProbe", func(t *testing.T) {
+		breaker := utils.NewCircuitBreaker(1, 20*time.Millisecond)
+		require.True(t, breaker.Record(http.StatusServiceUnavailable))
+		time.Sleep(25 * time.Millisecond)
+
+		// A probe that was never sent frees the slot without opening the circuit again
+		require.NoError(t, breaker.Allow())
+		breaker.Release()
+		require.NoError(t, breaker.Allow(), "probe slot not released")
+		assert.False(t, breaker.Record(http.StatusOK))
+		assert.Equal(t, 1, breaker.Opened())
+	})
+
+	t.Run("Success_Disabled", func(t *testing.T) {
+		breaker := utils.NewCircuitBreaker(0, time.Hour)
+		for i := 0; i < 10; i++ {
+			assert.False(t, breaker.Record(http.StatusServiceUnavailable))
+		}
+		assert.NoError(t, breaker.Allow())
+	})
+}
@@ -2,8 +2,10 @@
 package utils
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"reflect"
 	"strconv"
@@ -27,6 +29,9 @@ type SplunkConfig struct {
 	Password           string `env:"SPLUNK_PASSWORD"`
 	TokenName          string `env:"SPLUNK_TOKEN_NAME"`     // Token name for /authorization/tokens endpoint
 	TokenAudience      string `env:"SPLUNK_TOKEN_AUDIENCE"` // Token audience (e.g., "Automation")
+	AuthMethod         string `env:"SPLUNK_AUTH_METHOD"`    // "token", "session" or "static"; see EffectiveAuthMethod
+	Token              string `env:"SPLUNK_TOKEN"`          // Externally supplied token for the static method
+	TokenLifetime      int    `env:"SPLUNK_TOKEN_LIFETIME"` // Lifetime in seconds of the tokens the tool creates
 	SkipSSLVerify      bool   `env:"SPLUNK_SKIP_SSL_VERIFY"`
 	DefaultIndex       string `env:"SPLUNK_DEFAULT_INDEX"`
 	IndexName          string `env:"SPLUNK_INDEX_NAME"`
@@ -34,6 +39,21 @@ type SplunkConfig struct {
 	RequestTimeout     int    `env:"SPLUNK_REQUEST_TIMEOUT"`
 	MaxRetries         int    `env:"SPLUNK_MAX_RETRIES"`
 	RetryDelay         int    `env:"SPLUNK_RETRY_DELAY"`
+
+	// Client-side throttling of management API requests, per Splunk host
+	RateLimit               float64 `env:"SPLUNK_RATE_LIMIT"`                // Requests per second; zero disables the limit
+	RateLimitBurst          int     `env:"SPLUNK_RATE_LIMIT_BURST"`          // Requests sent at once before RateLimit applies
+	MaxConcurrentRequests   int     `env:"SPLUNK_MAX_CONCURRENT_REQUESTS"`   // Requests in flight; zero disables the bound
+	CircuitBreakerThreshold int     `env:"SPLUNK_CIRCUIT_BREAKER_THRESHOLD"` // Consecutive 503 responses that stop requests; negative disables
+	CircuitBreakerCooldown  int     `env:"SPLUNK_CIRCUIT_BREAKER_COOLDOWN"`  // Seconds before a request is tried again
+
+	// Index provisioning
+	Platform            string `env:"SPLUNK_PLATFORM"`              // "enterprise" or "cloud"; see EffectivePlatform
+	Stack               string `env:"SPLUNK_STACK"`                 // Splunk Cloud stack name used with ACS; see EffectiveStack
+	ACSURL              string `env:"SPLUNK_ACS_URL"`               // Admin Config Service endpoint of Splunk Cloud
+	IndexApp            string `env:"SPLUNK_INDEX_APP"`             // App context of indexes created on Splunk Enterprise
+	IndexDatatype       string `env:"SPLUNK_INDEX_DATATYPE"`        // "event" or "metric"
+	IndexSearchableDays int    `env:"SPLUNK_INDEX_SEARCHABLE_DAYS"` // Retention of created indexes; zero keeps the Splunk default
 }
 
 // SalesforceConfig holds Salesforce-specific configuration
@@ -43,19 +63,207 @@ type SalesforceConfig struct {
 	AuthType     string `env:"SALESFORCE_AUTH_TYPE"`
 	ClientID     string `env:"SALESFORCE_CLIENT_ID"`
 	ClientSecret string `env:"SALESFORCE_CLIENT_SECRET"`
-	AccountName  string `env:"SALESFORCE_ACCOUNT_NAME"`
+	AccountName  string `env:"SALESFORCE_ACCOUNT_NAME"` // Default account for inputs that do not name one
+
+	// Splunk_TA_salesforce add-on settings
+	Proxy         SalesforceProxyConfig `env:"SALESFORCE_PROXY"`
+	AddonLogLevel string                `env:"SALESFORCE_ADDON_LOGLEVEL"` // DEBUG, INFO, WARNING, ERROR or CRITICAL; empty leaves the add-on setting untouched
+}
+
+// SalesforceAccount represents one Salesforce org connected through a Splunk_TA_salesforce account
+type SalesforceAccount struct {
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoint"`
+	APIVersion   string `json:"api_version"`
+	AuthType     string `json:"auth_type"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+// SalesforceProxyConfig holds the add-on proxy settings used to reach Salesforce
+type SalesforceProxyConfig struct {
+	Enabled  bool   `env:"SALESFORCE_PROXY_ENABLED"`
+	Type     string `env:"SALESFORCE_PROXY_TYPE"` // "http" or "socks5"
+	URL      string `env:"SALESFORCE_PROXY_URL"`  // Proxy host
+	Port     int    `env:"SALESFORCE_PROXY_PORT"`
+	Username string `env:"SALESFORCE_PROXY_USERNAME"`
+	Password string `env:"SALESFORCE_PROXY_PASSWORD"`
+	RDNS     bool   `env:"SALESFORCE_PROXY_RDNS"` // Resolve DNS through the proxy
+}
+
+// IsConfigured reports whether any proxy settings were provided.
+// When false the add-on proxy settings are left untouched.
+func (p SalesforceProxyConfig) IsConfigured() bool {
+	return p.Enabled || p.URL != ""
+}
+
+// Splunk authentication methods
+const (
+	// SplunkAuthToken creates a token through /services/authorization/tokens and revokes it when done
+	SplunkAuthToken = "token"
+	// SplunkAuthSession logs in through /services/auth/login and uses the session key
+	SplunkAuthSession = "session"
+	// SplunkAuthStatic uses SPLUNK_TOKEN as is
+	SplunkAuthStatic = "static"
+)
+
+// EffectiveAuthMethod returns the configured authentication method. Without
+// SPLUNK_AUTH_METHOD, a configured SPLUNK_TOKEN selects the static method and the
+// token method is used otherwise.
+func (s SplunkConfig) EffectiveAuthMethod() string {
+	if s.AuthMethod != "" {
+		return strings.ToLower(s.AuthMethod)
+	}
+	if s.Token != "" {
+		return SplunkAuthStatic
+	}
+	return SplunkAuthToken
+}
+
+// Splunk platforms, which select how indexes are provisioned
+const (
+	// SplunkPlatformEnterprise creates indexes through /servicesNS/nobody/{app}/data/indexes
+	SplunkPlatformEnterprise = "enterprise"
+	// SplunkPlatformCloud creates indexes through the Admin Config Service (ACS)
+	SplunkPlatformCloud = "cloud"
+)
+
+// Index provisioning defaults
+const (
+	DefaultACSURL   = "https://admin.splunk.com"
+	DefaultIndexApp = "search"
+)
+
+// Index datatypes
+const (
+	IndexDatatypeEvent  = "event"
+	IndexDatatypeMetric = "metric"
+)
+
+// splunkCloudDomain is the domain of Splunk Cloud Platform stacks
+const splunkCloudDomain = ".splunkcloud.com"
+
+// EffectivePlatform returns the configured platform. Without SPLUNK_PLATFORM, a
+// SPLUNK_URL on splunkcloud.com selects Splunk Cloud and Splunk Enterprise is used
+// otherwise.
+func (s SplunkConfig) EffectivePlatform() string {
+	if s.Platform != "" {
+		return strings.ToLower(s.Platform)
+	}
+	if strings.HasSuffix(urlHost(s.URL), splunkCloudDomain) {
+		return SplunkPlatformCloud
+	}
+	return SplunkPlatformEnterprise
+}
+
+// EffectiveStack returns the Splunk Cloud stack: SPLUNK_STACK, or the first label of
+// a splunkcloud.com SPLUNK_URL (acme for https://acme.splunkcloud.com:8089). It is
+// empty when neither names a stack.
+func (s SplunkConfig) EffectiveStack() string {
+	if s.Stack != "" {
+		return s.Stack
+	}
+	host := urlHost(s.URL)
+	if !strings.HasSuffix(host, splunkCloudDomain) {
+		return ""
+	}
+	stack, _, _ := strings.Cut(host, ".")
+	return stack
 }
 
+// urlHost returns the lowercase host name of rawURL, or "" when it cannot be parsed
+func urlHost(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(parsed.Hostname())
+}
+
+// Proxy types supported by Splunk_TA_salesforce
+const (
+	ProxyTypeHTTP   = "http"
+	ProxyTypeSOCKS5 = "socks5"
+)
+
+// AddonLogLevels lists the log levels accepted by Splunk_TA_salesforce
+var AddonLogLevels = []string{"DEBUG", "INFO", "WARNING", "ERROR", "CRITICAL"}
+
 // MigrationConfig holds migration-specific settings
 type MigrationConfig struct {
-	DashboardDirectory string `env:"MIGRATION_DASHBOARD_DIRECTORY"`
-	ConcurrentRequests int    `env:"MIGRATION_CONCURRENT_REQUESTS"`
-	LogLevel           string `env:"MIGRATION_LOG_LEVEL"`
+	DashboardDirectory   string `env:"MIGRATION_DASHBOARD_DIRECTORY"`
+	DashboardApp         string `env:"MIGRATION_DASHBOARD_APP"`          // App the dashboards are deployed to
+	DashboardOwner       string `env:"MIGRATION_DASHBOARD_OWNER"`        // Owner of created dashboards; nobody shares them in the app
+	DashboardSharing     string `env:"MIGRATION_DASHBOARD_SHARING"`      // user, app or global; empty keeps the sharing dashboards are created with
+	DashboardReadRoles   string `env:"MIGRATION_DASHBOARD_READ_ROLES"`   // Comma-separated roles that may view the dashboards
+	DashboardWriteRoles  string `env:"MIGRATION_DASHBOARD_WRITE_ROLES"`  // Comma-separated roles that may edit the dashboards
+	DashboardPrune       bool   `env:"MIGRATION_DASHBOARD_PRUNE"`        // Delete dashboards whose file was removed from the directory
+	DashboardPrunePrefix string `env:"MIGRATION_DASHBOARD_PRUNE_PREFIX"` // Only dashboards whose name starts with this prefix may be deleted
+	ConcurrentRequests   int    `env:"MIGRATION_CONCURRENT_REQUESTS"`
+	LogLevel             string `env:"MIGRATION_LOG_LEVEL"`
+	PruneMode            string `env:"MIGRATION_PRUNE_MODE"`           // "disable" or "delete" unmanaged inputs; empty turns pruning off
+	PrunePrefix          string `env:"MIGRATION_PRUNE_PREFIX"`         // Only inputs whose name starts with this prefix may be pruned
+	PruneConfirm         bool   `env:"MIGRATION_PRUNE_CONFIRM"`        // Apply prune actions instead of only reporting them
+	MaxParallelTargets   int    `env:"MIGRATION_MAX_PARALLEL_TARGETS"` // Splunk targets migrated at the same time
+	StateFile            string `env:"MIGRATION_STATE_FILE"`           // SQLite database holding run checkpoints for --resume
+	ReportFile           string `env:"MIGRATION_REPORT_FILE"`          // JSON run report written at the end of apply
+	JUnitFile            string `env:"MIGRATION_JUNIT_FILE"`           // Optional JUnit XML run report for CI
+}
+
+// Target is one Splunk instance (e.g. dev, UAT, prod) the migration is applied to.
+// Empty fields inherit the top-level SPLUNK_* values.
+type Target struct {
+	Name          string `json:"name"`
+	URL           string `json:"splunk_url"`
+	Username      string `json:"username"`
+	Password      string `json:"password"`
+	Token         string `json:"token"`
+	TokenName     string `json:"token_name"`
+	TokenAudience string `json:"token_audience"`
+	SkipSSLVerify *bool  `json:"skip_ssl_verify"`
+	IndexName     string `json:"index_name"`
+	DefaultIndex  string `json:"default_index"`
+}
+
+// Defaults of the dashboard deployment settings
+const (
+	DefaultDashboardApp   = "search"
+	DefaultDashboardOwner = "nobody"
+)
+
+// Sharing levels of a dashboard
+const (
+	SharingUser   = "user"
+	SharingApp    = "app"
+	SharingGlobal = "global"
+)
+
+// DashboardACL is the app a dashboard is deployed to, its owner and who may view and
+// edit it. Empty role lists leave the roles of the dashboard as they are.
+type DashboardACL struct {
+	App     string
+	Owner   string
+	Sharing string   // user, app or global; empty keeps the sharing the dashboard is created with
+	Read    []string // Roles that may view the dashboard
+	Write   []string // Roles that may edit the dashboard
 }
 
+// HasPermissions reports whether the ACL sets sharing or roles, which are applied
+// through the acl endpoint of the dashboard once it exists
+func (a DashboardACL) HasPermissions() bool {
+	return a.Sharing != "" || len(a.Read) > 0 || len(a.Write) > 0
+}
+
+// Prune modes for unmanaged data inputs
+const (
+	PruneModeDisable = "disable"
+	PruneModeDelete  = "delete"
+)
+
 // DataInput represents a Salesforce data input configuration
 type DataInput struct {
 	Name         string `json:"name"`
+	Account      string `json:"account"` // Salesforce account the input reads from; defaults to SALESFORCE_ACCOUNT_NAME
 	Object       string `json:"object"`
 	ObjectFields string `json:"object_fields"`
 	OrderBy      string `json:"order_by"`
@@ -63,8 +271,33 @@ type DataInput struct {
 	Interval     int    `json:"interval"`
 	Delay        int    `json:"delay"`
 	Index        string `json:"index"`
+	Disabled     *bool  `json:"disabled,omitempty"` // Nil leaves the live value alone, so operators can disable inputs
+}
+
+// EventLogInput represents a Salesforce Event Log File (sfdc_event_log) input configuration
+type EventLogInput struct {
+	Name               string `json:"name"`
+	Account            string `json:"account"` // Salesforce account the input reads from; defaults to SALESFORCE_ACCOUNT_NAME
+	MonitoringInterval string `json:"monitoring_interval"`
+	StartDate          string `json:"start_date"`
+	Interval           int    `json:"interval"`
+	Index              string `json:"index"`
+}
+
+// IndexSpec describes an index the migration provisions
+type IndexSpec struct {
+	Name           string
+	Datatype       string // "event" or "metric"
+	SearchableDays int    // Zero keeps the platform default
+	MaxDataSizeMB  int    // Zero keeps the platform default
 }
 
+// Event Log File monitoring intervals supported by Splunk_TA_salesforce
+const (
+	MonitoringIntervalDaily  = "Daily"
+	MonitoringIntervalHourly = "Hourly"
+)
+
 // Loader handles environment and file-based configuration loading
 type Loader struct {
 	values map[string]string
@@ -106,14 +339,20 @@ func (l *Loader) Load(structPtr interface{}) error {
 	return nil
 }
 
-// LoadConfig loads configuration using the loader pattern
+// LoadConfig loads configuration using the loader pattern. Secret references are
+// resolved through DefaultSecretResolver.
 func LoadConfig(filePath string) (*Config, error) {
+	return LoadConfigWithSecrets(filePath, DefaultSecretResolver())
+}
+
+// LoadConfigWithSecrets loads configuration, resolving secret references through resolver
+func LoadConfigWithSecrets(filePath string, resolver *SecretResolver) (*Config, error) {
 	if filePath == "" {
 		filePath = "credentials.json"
 	}
 
 	// Create loader
-	loader, err := CreateLoader(filePath)
+	loader, err := CreateLoaderWithSecrets(filePath, resolver)
 	if err != nil {
 		return nil, err
 	}
@@ -142,37 +381,99 @@ func LoadConfig(filePath string) (*Config, error) {
 	if config.Splunk.RetryDelay == 0 {
 		config.Splunk.RetryDelay = 5
 	}
+	if config.Splunk.TokenLifetime == 0 {
+		config.Splunk.TokenLifetime = 3600
+	}
+	if config.Splunk.CircuitBreakerThreshold == 0 {
+		config.Splunk.CircuitBreakerThreshold = 5
+	}
+	if config.Splunk.CircuitBreakerCooldown == 0 {
+		config.Splunk.CircuitBreakerCooldown = 30
+	}
+	if config.Splunk.ACSURL == "" {
+		config.Splunk.ACSURL = DefaultACSURL
+	}
+	if config.Splunk.IndexApp == "" {
+		config.Splunk.IndexApp = DefaultIndexApp
+	}
+	config.Splunk.IndexDatatype = strings.ToLower(config.Splunk.IndexDatatype)
+	if config.Splunk.IndexDatatype == "" {
+		config.Splunk.IndexDatatype = IndexDatatypeEvent
+	}
 	if config.Salesforce.APIVersion == "" {
 		config.Salesforce.APIVersion = "64.0"
 	}
 	if config.Salesforce.AuthType == "" {
 		config.Salesforce.AuthType = "oauth_client_credentials"
 	}
+	if config.Salesforce.Proxy.IsConfigured() && config.Salesforce.Proxy.Type == "" {
+		config.Salesforce.Proxy.Type = ProxyTypeHTTP
+	}
+	config.Salesforce.AddonLogLevel = strings.ToUpper(config.Salesforce.AddonLogLevel)
 	if config.Migration.DashboardDirectory == "" {
 		config.Migration.DashboardDirectory = "resources/dashboards"
 	}
+	if config.Migration.DashboardApp == "" {
+		config.Migration.DashboardApp = DefaultDashboardApp
+	}
+	if config.Migration.DashboardOwner == "" {
+		config.Migration.DashboardOwner = DefaultDashboardOwner
+	}
 	if config.Migration.ConcurrentRequests == 0 {
 		config.Migration.ConcurrentRequests = 3
 	}
 	if config.Migration.LogLevel == "" {
 		config.Migration.LogLevel = "info"
 	}
+	if config.Migration.MaxParallelTargets == 0 {
+		config.Migration.MaxParallelTargets = 2
+	}
+	if config.Migration.StateFile == "" {
+		config.Migration.StateFile = "migration-state.db"
+	}
+	if config.Migration.ReportFile == "" {
+		config.Migration.ReportFile = "migration-report.json"
+	}
 
 	// Load extensions (DATA_INPUTS, etc.)
-	if err := LoadExtensions(filePath, config); err != nil {
+	if err := loadExtensions(filePath, config, resolver); err != nil {
 		return nil, err
 	}
 
+	config.registerSecrets()
 	return config, nil
 }
 
+// registerSecrets registers every credential of the configuration with the redactor,
+// so that it never appears in logs, errors or reports
+func (c *Config) registerSecrets() {
+	RegisterSecret(c.Splunk.Password, c.Splunk.Token, c.Salesforce.ClientSecret, c.Salesforce.Proxy.Password)
+	if accounts, err := c.GetSalesforceAccounts(); err == nil {
+		for _, account := range accounts {
+			RegisterSecret(account.ClientSecret)
+		}
+	}
+	if targets, err := c.GetTargets(); err == nil {
+		for _, target := range targets {
+			RegisterSecret(target.Password, target.Token)
+		}
+	}
+}
+
 // CreateLoader creates a new loader from file and environment
 func CreateLoader(credentialsPath string) (*Loader, error) {
+	return CreateLoaderWithSecrets(credentialsPath, DefaultSecretResolver())
+}
+
+// CreateLoaderWithSecrets creates a loader from file and environment. The file may be
+// encrypted (see EncryptConfig), and vault:, file: and env: references in SPLUNK_*,
+// SALESFORCE_* and MIGRATION_* values are resolved through resolver.
+func CreateLoaderWithSecrets(credentialsPath string, resolver *SecretResolver) (*Loader, error) {
 	values := make(map[string]string)
 
 	// Load from file
 	if credentialsPath != "" {
-		content, err := os.ReadFile(credentialsPath)
+		content, err := readConfigFile(credentialsPath)
 		if err != nil {
 			return nil, fmt.Errorf("failed to read credentials file: %w", err)
 		}
@@ -206,12 +507,22 @@ func CreateLoader(credentialsPath string) (*Loader, error) {
 		}
 	}
 
+	if err := resolver.resolveValues(context.Background(), values); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
 	return &Loader{values: values}, nil
 }
 
 // LoadExtensions loads dynamic configuration like DATA_INPUTS into Extensions map
 func LoadExtensions(filePath string, config *Config) error {
-	data, err := os.ReadFile(filePath)
+	return loadExtensions(filePath, config, DefaultSecretResolver())
+}
+
+// loadExtensions loads the extensions, resolving secret references in their string values
+// (for example SALESFORCE_ACCOUNTS client secrets and TARGETS passwords)
+func loadExtensions(filePath string, config *Config, resolver *SecretResolver) error {
+	data, err := readConfigFile(filePath)
 	if err != nil {
 		return fmt.Errorf("failed to read config file for extensions: %w", err)
 	}
@@ -221,11 +532,28 @@ func LoadExtensions(filePath string, config *Config) error {
 		return fmt.Errorf("failed to parse config file for extensions: %w", err)
 	}
 
+	for key, value := range rawConfig {
+		if _, isString := value.(string); isString && IsStructuredField(key) {
+			// Already resolved by the loader
+			continue
+		}
+		resolved, err := resolver.resolveTree(context.Background(), "$."+key, value)
+		if err != nil {
+			return fmt.Errorf("failed to resolve secrets: %w", err)
+		}
+		rawConfig[key] = resolved
+	}
+
 	// Load DATA_INPUTS array
 	if dataInputsRaw, ok := rawConfig["DATA_INPUTS"]; ok {
 		config.Extensions["DATA_INPUTS"] = dataInputsRaw
 	}
 
+	// Load SALESFORCE_ACCOUNTS array (the SALESFORCE_ prefix is otherwise reserved for structured fields)
+	if accountsRaw, ok := rawConfig["SALESFORCE_ACCOUNTS"]; ok {
+		config.Extensions["SALESFORCE_ACCOUNTS"] = accountsRaw
+	}
+
 	// Load any other dynamic extensions as needed
 	for key, value := range rawConfig {
 		// Skip known structured fields
@@ -345,6 +673,7 @@ func (c *Config) GetDataInputs() ([]DataInput, error) {
 			if inputMap, ok := inputRaw.(map[string]interface{}); ok {
 				input := DataInput{
 					Name:         getStringFromMap(inputMap, "name", ""),
+					Account:      getStringFromMap(inputMap, "account", c.DefaultAccountName()),
 					Object:       getStringFromMap(inputMap, "object", ""),
 					ObjectFields: getStringFromMap(inputMap, "object_fields", ""),
 					OrderBy:      getStringFromMap(inputMap, "order_by", "LastModifiedDate"),
@@ -352,6 +681,7 @@ func (c *Config) GetDataInputs() ([]DataInput, error) {
 					Interval:     getIntFromMap(inputMap, "interval", 300),
 					Delay:        getIntFromMap(inputMap, "delay", 60),
 					Index:        getStringFromMap(inputMap, "index", ""),
+					Disabled:     getOptionalBoolFromMap(inputMap, "disabled"),
 				}
 
 				// Use default index if not specified
@@ -373,77 +703,412 @@ func (c *Config) GetDataInputs() ([]DataInput, error) {
 	return inputs, nil
 }
 
-// Helper functions for map extraction
-func getStringFromMap(m map[string]interface{}, key, defaultValue string) string {
-	if val, ok := m[key]; ok {
-		if strVal, ok := val.(string); ok {
-			return strVal
+// GetEventLogInputs retrieves and parses the optional EVENT_LOG_INPUTS from extensions
+func (c *Config) GetEventLogInputs() ([]EventLogInput, error) {
+	eventLogInputsRaw, exists := c.Extensions["EVENT_LOG_INPUTS"]
+	if !exists {
+		return nil, nil
+	}
+
+	eventLogInputsArray, ok := eventLogInputsRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("EVENT_LOG_INPUTS must be an array")
+	}
+
+	var inputs []EventLogInput
+	for i, inputRaw := range eventLogInputsArray {
+		inputMap, ok := inputRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("event log input [%d] is not a valid object", i)
+		}
+
+		input := EventLogInput{
+			Name:               getStringFromMap(inputMap, "name", ""),
+			Account:            getStringFromMap(inputMap, "account", c.DefaultAccountName()),
+			MonitoringInterval: getStringFromMap(inputMap, "monitoring_interval", MonitoringIntervalDaily),
+			StartDate:          getStringFromMap(inputMap, "start_date", "2024-01-01T00:00:00.000Z"),
+			Interval:           getIntFromMap(inputMap, "interval", 3600),
+			Index:              getStringFromMap(inputMap, "index", ""),
+		}
+
+		// Use default index if not specified
+		if input.Index == "" {
+			input.Index = c.Splunk.DefaultIndex
+		}
+
+		if input.Name == "" {
+			return nil, fmt.Errorf("event log input [%d] missing required field (name)", i)
 		}
+
+		inputs = append(inputs, input)
 	}
-	return defaultValue
+
+	return inputs, nil
 }
 
-func getIntFromMap(m map[string]interface{}, key string, defaultValue int) int {
-	if val, ok := m[key]; ok {
-		switch v := val.(type) {
-		case float64:
-			return int(v)
-		case int:
-			return v
+// GetIndexes returns the indexes the migration writes to: SPLUNK_INDEX_NAME followed
+// by the index of every data input and event log input, without duplicates
+func (c *Config) GetIndexes() ([]IndexSpec, error) {
+	names := []string{c.Splunk.IndexName}
+
+	if _, exists := c.Extensions["DATA_INPUTS"]; exists {
+		inputs, err := c.GetDataInputs()
+		if err != nil {
+			return nil, err
+		}
+		for _, input := range inputs {
+			names = append(names, input.Index)
+		}
+	}
+
+	eventLogInputs, err := c.GetEventLogInputs()
+	if err != nil {
+		return nil, err
+	}
+	for _, input := range eventLogInputs {
+		names = append(names, input.Index)
+	}
+
+	var specs []IndexSpec
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		specs = append(specs, c.IndexSpec(name))
+	}
+	return specs, nil
+}
+
+// GetDashboardVars returns the optional DASHBOARD_VARS object: user-defined values
+// available to dashboard templates. Numbers and booleans are returned as text.
+func (c *Config) GetDashboardVars() (map[string]string, error) {
+	varsRaw, exists := c.Extensions["DASHBOARD_VARS"]
+	if !exists {
+		return map[string]string{}, nil
+	}
+
+	varsMap, ok := varsRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("DASHBOARD_VARS must be an object")
+	}
+
+	vars := make(map[string]string, len(varsMap))
+	for name, value := range varsMap {
+		switch v := value.(type) {
 		case string:
-			if intVal, err := strconv.Atoi(v); err == nil {
-				return intVal
+			vars[name] = v
+		case float64:
+			vars[name] = strconv.FormatFloat(v, 'f', -1, 64)
+		case bool:
+			vars[name] = strconv.FormatBool(v)
+		default:
+			return nil, fmt.Errorf("dashboard variable %s must be a string, number or boolean", name)
+		}
+	}
+	return vars, nil
+}
+
+// DefaultDashboardACL returns the ACL of the dashboards that have no DASHBOARD_ACL
+// entry, from the MIGRATION_DASHBOARD_* settings
+func (c *Config) DefaultDashboardACL() DashboardACL {
+	acl := DashboardACL{
+		App:     c.Migration.DashboardApp,
+		Owner:   c.Migration.DashboardOwner,
+		Sharing: c.Migration.DashboardSharing,
+		Read:    splitRoles(c.Migration.DashboardReadRoles),
+		Write:   splitRoles(c.Migration.DashboardWriteRoles),
+	}
+	if acl.App == "" {
+		acl.App = DefaultDashboardApp
+	}
+	if acl.Owner == "" {
+		acl.Owner = DefaultDashboardOwner
+	}
+	return acl.withDefaultSharing()
+}
+
+// GetDashboardACLs returns the ACL of every dashboard named in the optional DASHBOARD_ACL
+// object. Settings an entry leaves out are taken from DefaultDashboardACL.
+func (c *Config) GetDashboardACLs() (map[string]DashboardACL, error) {
+	aclsRaw, exists := c.Extensions["DASHBOARD_ACL"]
+	if !exists {
+		return map[string]DashboardACL{}, nil
+	}
+
+	aclsMap, ok := aclsRaw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("DASHBOARD_ACL must be an object")
+	}
+
+	defaults := c.DefaultDashboardACL()
+	acls := make(map[string]DashboardACL, len(aclsMap))
+	var err error
+	for name, entryRaw := range aclsMap {
+		entry, ok := entryRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("dashboard ACL %s must be an object", name)
+		}
+
+		acl := DashboardACL{
+			App:     getStringFromMap(entry, "app", defaults.App),
+			Owner:   getStringFromMap(entry, "owner", defaults.Owner),
+			Sharing: getStringFromMap(entry, "sharing", defaults.Sharing),
+			Read:    defaults.Read,
+			Write:   defaults.Write,
+		}
+		if _, exists := entry["read"]; exists {
+			if acl.Read, err = roleList(entry["read"]); err != nil {
+				return nil, fmt.Errorf("dashboard ACL %s: read %w", name, err)
 			}
 		}
+		if _, exists := entry["write"]; exists {
+			if acl.Write, err = roleList(entry["write"]); err != nil {
+				return nil, fmt.Errorf("dashboard ACL %s: write %w", name, err)
+			}
+		}
+		acls[name] = acl.withDefaultSharing()
 	}
-	return defaultValue
+	return acls, nil
 }
 
-// Validate checks if all required configuration values are present
-func (c *Config) Validate() error {
-	// Validate Splunk configuration
-	if c.Splunk.URL == "" {
-		return fmt.Errorf("SPLUNK_URL is required")
+// withDefaultSharing shares a dashboard whose roles are set in its app, since Splunk
+// only applies roles to shared objects
+func (a DashboardACL) withDefaultSharing() DashboardACL {
+	if a.Sharing == "" && (len(a.Read) > 0 || len(a.Write) > 0) {
+		a.Sharing = SharingApp
 	}
-	if c.Splunk.Username == "" || c.Splunk.Password == "" {
-		return fmt.Errorf("splunk authentication required: provide SPLUNK_USERNAME and SPLUNK_PASSWORD")
+	return a
+}
+
+// splitRoles splits a comma-separated role list, dropping empty names
+func splitRoles(value string) []string {
+	var roles []string
+	for _, role := range strings.Split(value, ",") {
+		if role = strings.TrimSpace(role); role != "" {
+			roles = append(roles, role)
+		}
 	}
+	return roles
+}
 
-	// Validate Salesforce configuration
-	if c.Salesforce.Endpoint == "" {
-		return fmt.Errorf("SALESFORCE_ENDPOINT is required")
+// roleList reads a JSON array of role names
+func roleList(value interface{}) ([]string, error) {
+	array, ok := value.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("must be an array of role names")
 	}
-	if c.Salesforce.ClientID == "" {
-		return fmt.Errorf("SALESFORCE_CLIENT_ID is required")
+	roles := make([]string, 0, len(array))
+	for _, item := range array {
+		role, ok := item.(string)
+		if !ok || strings.TrimSpace(role) == "" {
+			return nil, fmt.Errorf("must be an array of role names")
+		}
+		roles = append(roles, strings.TrimSpace(role))
 	}
-	if c.Salesforce.ClientSecret == "" {
-		return fmt.Errorf("SALESFORCE_CLIENT_SECRET is required")
+	return roles, nil
+}
+
+// IndexSpec returns the settings of a provisioned index from the SPLUNK_INDEX_* values
+func (c *Config) IndexSpec(name string) IndexSpec {
+	datatype := strings.ToLower(c.Splunk.IndexDatatype)
+	if datatype == "" {
+		datatype = IndexDatatypeEvent
 	}
-	if c.Salesforce.AccountName == "" {
-		return fmt.Errorf("SALESFORCE_ACCOUNT_NAME is required")
+	return IndexSpec{
+		Name:           name,
+		Datatype:       datatype,
+		SearchableDays: c.Splunk.IndexSearchableDays,
+		MaxDataSizeMB:  c.Splunk.MaxTotalDataSizeMB,
 	}
+}
 
-	// Validate Data Inputs
-	dataInputs, err := c.GetDataInputs()
-	if err != nil {
-		return fmt.Errorf("failed to load data inputs: %w", err)
+// GetSalesforceAccounts returns the Salesforce accounts to provision.
+// SALESFORCE_ACCOUNTS lists several named accounts; without it the single account
+// described by the SALESFORCE_* fields is used.
+func (c *Config) GetSalesforceAccounts() ([]SalesforceAccount, error) {
+	accountsRaw, exists := c.Extensions["SALESFORCE_ACCOUNTS"]
+	if !exists {
+		if c.Salesforce.AccountName == "" {
+			return nil, nil
+		}
+		return []SalesforceAccount{{
+			Name:         c.Salesforce.AccountName,
+			Endpoint:     c.Salesforce.Endpoint,
+			APIVersion:   c.Salesforce.APIVersion,
+			AuthType:     c.Salesforce.AuthType,
+			ClientID:     c.Salesforce.ClientID,
+			ClientSecret: c.Salesforce.ClientSecret,
+		}}, nil
 	}
 
-	if len(dataInputs) == 0 {
-		return fmt.Errorf("at least one data input configuration is required")
+	accountsArray, ok := accountsRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("SALESFORCE_ACCOUNTS must be an array")
 	}
 
-	for i, input := range dataInputs {
-		if input.Name == "" {
-			return fmt.Errorf("data input [%d] name is required", i)
+	var accounts []SalesforceAccount
+	for i, accountRaw := range accountsArray {
+		accountMap, ok := accountRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("salesforce account [%d] is not a valid object", i)
 		}
-		if input.Object == "" {
-			return fmt.Errorf("data input [%d] object is required", i)
+
+		// API version and auth type fall back to the top-level SALESFORCE_* values
+		account := SalesforceAccount{
+			Name:         getStringFromMap(accountMap, "name", ""),
+			Endpoint:     getStringFromMap(accountMap, "endpoint", ""),
+			APIVersion:   getStringFromMap(accountMap, "api_version", c.Salesforce.APIVersion),
+			AuthType:     getStringFromMap(accountMap, "auth_type", c.Salesforce.AuthType),
+			ClientID:     getStringFromMap(accountMap, "client_id", ""),
+			ClientSecret: getStringFromMap(accountMap, "client_secret", ""),
 		}
-		if input.ObjectFields == "" {
-			return fmt.Errorf("data input [%d] object_fields is required", i)
+
+		if account.Name == "" {
+			return nil, fmt.Errorf("salesforce account [%d] missing required field (name)", i)
 		}
+
+		accounts = append(accounts, account)
 	}
 
+	return accounts, nil
+}
+
+// DefaultAccountName returns the account used by inputs that do not name one:
+// SALESFORCE_ACCOUNT_NAME, or the first entry of SALESFORCE_ACCOUNTS when it is unset
+func (c *Config) DefaultAccountName() string {
+	if c.Salesforce.AccountName != "" {
+		return c.Salesforce.AccountName
+	}
+	accounts, err := c.GetSalesforceAccounts()
+	if err != nil || len(accounts) == 0 {
+		return ""
+	}
+	return accounts[0].Name
+}
+
+// GetTargets returns the Splunk targets to migrate. Without a TARGETS section the
+// top-level SPLUNK_* settings form a single target named "default".
+func (c *Config) GetTargets() ([]Target, error) {
+	targetsRaw, exists := c.Extensions["TARGETS"]
+	if !exists {
+		return []Target{{Name: "default", URL: c.Splunk.URL}}, nil
+	}
+
+	targetsArray, ok := targetsRaw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("TARGETS must be an array")
+	}
+
+	var targets []Target
+	for i, targetRaw := range targetsArray {
+		targetMap, ok := targetRaw.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("target [%d] is not a valid object", i)
+		}
+
+		target := Target{
+			Name:          getStringFromMap(targetMap, "name", ""),
+			URL:           getStringFromMap(targetMap, "splunk_url", ""),
+			Username:      getStringFromMap(targetMap, "username", ""),
+			Password:      getStringFromMap(targetMap, "password", ""),
+			Token:         getStringFromMap(targetMap, "token", ""),
+			TokenName:     getStringFromMap(targetMap, "token_name", ""),
+			TokenAudience: getStringFromMap(targetMap, "token_audience", ""),
+			IndexName:     getStringFromMap(targetMap, "index_name", ""),
+			DefaultIndex:  getStringFromMap(targetMap, "default_index", ""),
+		}
+		if skip, ok := targetMap["skip_ssl_verify"].(bool); ok {
+			target.SkipSSLVerify = &skip
+		}
+
+		if target.Name == "" {
+			return nil, fmt.Errorf("target [%d] missing required field (name)", i)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// ForTarget returns a copy of the configuration with the target's Splunk overrides applied.
+// Extensions are shared with c and must not be modified through the copy.
+func (c *Config) ForTarget(target Target) *Config {
+	targetConfig := *c
+	splunk := &targetConfig.Splunk
+
+	if target.URL != "" {
+		splunk.URL = target.URL
+	}
+	if target.Username != "" {
+		splunk.Username = target.Username
+	}
+	if target.Password != "" {
+		splunk.Password = target.Password
+	}
+	if target.Token != "" {
+		splunk.Token = target.Token
+	}
+	if target.TokenName != "" {
+		splunk.TokenName = target.TokenName
+	}
+	if target.TokenAudience != "" {
+		splunk.TokenAudience = target.TokenAudience
+	}
+	if target.SkipSSLVerify != nil {
+		splunk.SkipSSLVerify = *target.SkipSSLVerify
+	}
+	if target.DefaultIndex != "" {
+		splunk.DefaultIndex = target.DefaultIndex
+		// The index to verify follows the default index unless the target names one
+		if target.IndexName == "" {
+			splunk.IndexName = target.DefaultIndex
+		}
+	}
+	if target.IndexName != "" {
+		splunk.IndexName = target.IndexName
+	}
+
+	return &targetConfig
+}
+
+// Helper functions for map extraction
+func getStringFromMap(m map[string]interface{}, key, defaultValue string) string {
+	if val, ok := m[key]; ok {
+		if strVal, ok := val.(string); ok {
+			return strVal
+		}
+	}
+	return defaultValue
+}
+
+// getOptionalBoolFromMap returns nil when key is absent or not a boolean
+func getOptionalBoolFromMap(m map[string]interface{}, key string) *bool {
+	switch v := m[key].(type) {
+	case bool:
+		return &v
+	case string:
+		if boolVal, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+			return &boolVal
+		}
+	}
 	return nil
 }
+
+func getIntFromMap(m map[string]interface{}, key string, defaultValue int) int {
+	if val, ok := m[key]; ok {
+		switch v := val.(type) {
+		case float64:
+			return int(v)
+		case int:
+			return v
+		case string:
+			if intVal, err := strconv.Atoi(v); err == nil {
+				return intVal
+			}
+		}
+	}
+	return defaultValue
+}
@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// encryptedConfigHeader starts every configuration file written by EncryptConfig
+const encryptedConfigHeader = "salesforce-splunk-migration:aes-256-gcm:v1\n"
+
+// ConfigKeySize is the length of the AES-256 key protecting encrypted configuration files
+const ConfigKeySize = 32
+
+// IsEncryptedConfig reports whether data is an encrypted configuration file
+func IsEncryptedConfig(data []byte) bool {
+	return bytes.HasPrefix(data, []byte(encryptedConfigHeader))
+}
+
+// EncryptConfig encrypts a configuration file with AES-256-GCM. The result is a text
+// header followed by the base64-encoded nonce and ciphertext.
+func EncryptConfig(plaintext, key []byte) ([]byte, error) {
+	gcm, err := newConfigCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := gcm.Seal(nonce, nonce, plaintext, []byte(encryptedConfigHeader))
+
+	encoded := base64.StdEncoding.EncodeToString(sealed)
+	return []byte(encryptedConfigHeader + encoded + "\n"), nil
+}
+
+// DecryptConfig decrypts a configuration file written by EncryptConfig
+func DecryptConfig(data, key []byte) ([]byte, error) {
+	if !IsEncryptedConfig(data) {
+		return nil, fmt.Errorf("not an encrypted configuration file")
+	}
+	gcm, err := newConfigCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sealed, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data[len(encryptedConfigHeader):])))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode encrypted configuration: %w", err)
+	}
+	if len(sealed) < gcm.NonceSize() {
+		return nil, fmt.Errorf("encrypted configuration is truncated")
+	}
+
+	nonce, ciphertext := sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():]
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, []byte(encryptedConfigHeader))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt configuration (wrong key or corrupted file)")
+	}
+	return plaintext, nil
+}
+
+// ConfigKeyFromEnv reads the base64-encoded configuration key from CONFIG_ENCRYPTION_KEY,
+// or from the file named by CONFIG_ENCRYPTION_KEY_FILE
+func ConfigKeyFromEnv() ([]byte, error) {
+	encoded := os.Getenv("CONFIG_ENCRYPTION_KEY")
+	if encoded == "" {
+		path := os.Getenv("CONFIG_ENCRYPTION_KEY_FILE")
+		if path == "" {
+			return nil, fmt.Errorf("CONFIG_ENCRYPTION_KEY or CONFIG_ENCRYPTION_KEY_FILE must be set (generate a key with: openssl rand -base64 32)")
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read configuration key: %w", err)
+		}
+		encoded = string(data)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("configuration key is not valid base64: %w", err)
+	}
+	if len(key) != ConfigKeySize {
+		return nil, fmt.Errorf("configuration key must be %d bytes, got %d", ConfigKeySize, len(key))
+	}
+	return key, nil
+}
+
+// readConfigFile reads a configuration file, decrypting it when it is encrypted
+func readConfigFile(path string) ([]byte, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if !IsEncryptedConfig(content) {
+		return content, nil
+	}
+
+	key, err := ConfigKeyFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	return DecryptConfig(content, key)
+}
+
+// newConfigCipher creates the AES-GCM cipher for a configuration key
+func newConfigCipher(key []byte) (cipher.AEAD, error) {
+	if len(key) != ConfigKeySize {
+		return nil, fmt.Errorf("configuration key must be %d bytes, got %d", ConfigKeySize, len(key))
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	return cipher.NewGCM(block)
+}
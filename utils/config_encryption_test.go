@@ -0,0 +1,138 @@
+package utils_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"salesforce-splunk-migration/utils"
+)
+
+func newConfigKey(t *testing.T) []byte {
+	t.Helper()
+	return bytes.Repeat([]byte{0x42}, utils.ConfigKeySize)
+}
+
+func TestEncryptConfig_RoundTrip(t *testing.T) {
+	key := newConfigKey(t)
+	plaintext := []byte(`{"SPLUNK_PASSWORD": "changeme"}`)
+
+	encrypted, err := utils.EncryptConfig(plaintext, key)
+	if err != nil {
+		t.Fatalf("EncryptConfig() unexpected error: %v", err)
+	}
+	if !utils.IsEncryptedConfig(encrypted) {
+		t.Error("IsEncryptedConfig() = false for encrypted data")
+	}
+	if bytes.Contains(encrypted, []byte("changeme")) {
+		t.Error("EncryptConfig() output contains the plaintext secret")
+	}
+
+	decrypted, err := utils.DecryptConfig(encrypted, key)
+	if err != nil {
+		t.Fatalf("DecryptConfig() unexpected error: %v", err)
+	}
+	if !bytes.Equal(decrypted, plaintext) {
+		t.Errorf("DecryptConfig() = %s, want %s", decrypted, plaintext)
+	}
+
+	t.Run("Error_WrongKey", func(t *testing.T) {
+		wrongKey := bytes.Repeat([]byte{0x24}, utils.ConfigKeySize)
+		if _, err := utils.DecryptConfig(encrypted, wrongKey); err == nil {
+			t.Error("DecryptConfig() with wrong key expected error, got nil")
+		}
+	})
+
+	t.Run("Error_ShortKey", func(t *testing.T) {
+		if _, err := utils.EncryptConfig(plaintext, key[:16]); err == nil {
+			t.Error("EncryptConfig() with 16-byte key expected error, got nil")
+		}
+	})
+
+	t.Run("Error_NotEncrypted", func(t *testing.T) {
+		if _, err := utils.DecryptConfig(plaintext, key); err == nil {
+			t.Error("DecryptConfig() of plain file expected error, got nil")
+		}
+	})
+}
+
+func TestConfigKeyFromEnv(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString(newConfigKey(t))
+
+	t.Run("Success_FromVariable", func(t *testing.T) {
+		t.Setenv("CONFIG_ENCRYPTION_KEY", encoded)
+		key, err := utils.ConfigKeyFromEnv()
+		if err != nil || len(key) != utils.ConfigKeySize {
+			t.Errorf("ConfigKeyFromEnv() = %d bytes, %v; want %d bytes", len(key), err, utils.ConfigKeySize)
+		}
+	})
+
+	t.Run("Success_FromFile", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.key")
+		if err := os.WriteFile(path, []byte(encoded+"\n"), 0o600); err != nil {
+			t.Fatalf("Failed to write key file: %v", err)
+		}
+		t.Setenv("CONFIG_ENCRYPTION_KEY", "")
+		t.Setenv("CONFIG_ENCRYPTION_KEY_FILE", path)
+		if _, err := utils.ConfigKeyFromEnv(); err != nil {
+			t.Errorf("ConfigKeyFromEnv() unexpected error: %v", err)
+		}
+	})
+
+	t.Run("Error_NotSet", func(t *testing.T) {
+		t.Setenv("CONFIG_ENCRYPTION_KEY", "")
+		t.Setenv("CONFIG_ENCRYPTION_KEY_FILE", "")
+		if _, err := utils.ConfigKeyFromEnv(); err == nil {
+			t.Error("ConfigKeyFromEnv() expected error, got nil")
+		}
+	})
+
+	t.Run("Error_WrongLength", func(t *testing.T) {
+		t.Setenv("CONFIG_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString([]byte("short")))
+		if _, err := utils.ConfigKeyFromEnv(); err == nil || !strings.Contains(err.Error(), "32 bytes") {
+			t.Errorf("ConfigKeyFromEnv() error = %v, want key length error", err)
+		}
+	})
+}
+
+func TestLoadConfig_EncryptedFile(t *testing.T) {
+	key := newConfigKey(t)
+	encrypted, err := utils.EncryptConfig([]byte(`{
+		"SPLUNK_URL": "https://splunk.example.com:8089",
+		"SPLUNK_PASSWORD": "changeme",
+		"DATA_INPUTS": [{"name": "sf_accounts", "object": "Account"}]
+	}`), key)
+	if err != nil {
+		t.Fatalf("EncryptConfig() unexpected error: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "credentials.json.enc")
+	if err := os.WriteFile(path, encrypted, 0o600); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+
+	t.Run("Success_WithKey", func(t *testing.T) {
+		t.Setenv("CONFIG_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(key))
+		config, err := utils.LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if config.Splunk.Password != "changeme" {
+			t.Errorf("Splunk.Password = %q, want changeme", config.Splunk.Password)
+		}
+		inputs, err := config.GetDataInputs()
+		if err != nil || len(inputs) != 1 {
+			t.Errorf("GetDataInputs() = %+v, %v; want 1 input", inputs, err)
+		}
+	})
+
+	t.Run("Error_WithoutKey", func(t *testing.T) {
+		t.Setenv("CONFIG_ENCRYPTION_KEY", "")
+		t.Setenv("CONFIG_ENCRYPTION_KEY_FILE", "")
+		if _, err := utils.LoadConfig(path); err == nil || !strings.Contains(err.Error(), "CONFIG_ENCRYPTION_KEY") {
+			t.Errorf("LoadConfig() error = %v, want missing key error", err)
+		}
+	})
+}
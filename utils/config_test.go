@@ -4,6 +4,7 @@ package utils_test
 import (
 	"os"
 	"reflect"
+	"strings"
 	"testing"
 
 	"salesforce-splunk-migration/utils"
@@ -23,8 +24,8 @@ func TestConfig_Validate(t *testing.T) {
 			},
 			Salesforce: utils.SalesforceConfig{
 				Endpoint:     "https://login.salesforce.com",
-				APIVersion:   "v58.0",
-				AuthType:     "oauth2",
+				APIVersion:   "58.0",
+				AuthType:     "oauth_client_credentials",
 				ClientID:     "client123",
 				ClientSecret: "secret456",
 				AccountName:  "test_account",
@@ -40,7 +41,7 @@ func TestConfig_Validate(t *testing.T) {
 						"object":        "Account",
 						"object_fields": "Id,Name,CreatedDate",
 						"order_by":      "CreatedDate",
-						"start_date":    "2024-01-01",
+						"start_date":    "2024-01-01T00:00:00.000Z",
 						"interval":      300,
 						"delay":         60,
 						"index":         "main",
@@ -106,6 +107,127 @@ func TestConfig_Validate(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:    "Success_PruneModeWithPrefix",
+			config:  validConfig(),
+			wantErr: false,
+			setupFunc: func(c *utils.Config) {
+				c.Migration.PruneMode = utils.PruneModeDelete
+				c.Migration.PrunePrefix = "sf_"
+			},
+		},
+		{
+			name:    "Error_InvalidPruneMode",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Migration.PruneMode = "purge"
+				c.Migration.PrunePrefix = "sf_"
+			},
+		},
+		{
+			name:    "Error_PruneModeWithoutPrefix",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Migration.PruneMode = utils.PruneModeDisable
+			},
+		},
+		{
+			name:    "Error_DashboardPruneWithoutPrefix",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Migration.DashboardPrune = true
+			},
+		},
+		{
+			name:    "Success_WithEventLogInputs",
+			config:  validConfig(),
+			wantErr: false,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["EVENT_LOG_INPUTS"] = []interface{}{
+					map[string]interface{}{"name": "EventLog_Hourly", "monitoring_interval": "Hourly"},
+				}
+			},
+		},
+		{
+			name:    "Error_InvalidEventLogMonitoringInterval",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["EVENT_LOG_INPUTS"] = []interface{}{
+					map[string]interface{}{"name": "EventLog_Weekly", "monitoring_interval": "Weekly"},
+				}
+			},
+		},
+		{
+			name:    "Error_EventLogInputMissingName",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["EVENT_LOG_INPUTS"] = []interface{}{
+					map[string]interface{}{"monitoring_interval": "Daily"},
+				}
+			},
+		},
+		{
+			name:    "Success_WithProxyAndAddonLogLevel",
+			config:  validConfig(),
+			wantErr: false,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, Type: "socks5", URL: "proxy.corp.local", Port: 1080, Username: "svc", Password: "p@ss"}
+				c.Salesforce.AddonLogLevel = "warning"
+			},
+		},
+		{
+			name:    "Error_ProxyEnabledWithoutURL",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, Port: 8080}
+			},
+		},
+		{
+			name:    "Error_ProxyURLWithScheme",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, URL: "http://proxy.corp.local", Port: 8080}
+			},
+		},
+		{
+			name:    "Error_InvalidProxyType",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, Type: "https", URL: "proxy.corp.local", Port: 8080}
+			},
+		},
+		{
+			name:    "Error_InvalidProxyPort",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, URL: "proxy.corp.local", Port: 70000}
+			},
+		},
+		{
+			name:    "Error_ProxyPasswordWithoutUsername",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{Enabled: true, URL: "proxy.corp.local", Port: 8080, Password: "p@ss"}
+			},
+		},
+		{
+			name:    "Error_InvalidAddonLogLevel",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.AddonLogLevel = "VERBOSE"
+			},
+		},
 		{
 			name:    "Error_NoDataInputs",
 			config:  validConfig(),
@@ -114,6 +236,114 @@ func TestConfig_Validate(t *testing.T) {
 				c.Extensions["DATA_INPUTS"] = []interface{}{}
 			},
 		},
+		{
+			name:    "Success_MultipleAccounts",
+			config:  validConfig(),
+			wantErr: false,
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce = utils.SalesforceConfig{AccountName: "sf_prod"}
+				c.Extensions["SALESFORCE_ACCOUNTS"] = []interface{}{
+					map[string]interface{}{"name": "sf_prod", "endpoint": "login.salesforce.com", "client_id": "id1", "client_secret": "secret1"},
+					map[string]interface{}{"name": "sf_sandbox", "endpoint": "test.salesforce.com", "client_id": "id2", "client_secret": "secret2"},
+				}
+				c.Extensions["DATA_INPUTS"] = []interface{}{
+					map[string]interface{}{"name": "prod_accounts", "object": "Account", "object_fields": "Id"},
+					map[string]interface{}{"name": "sandbox_accounts", "account": "sf_sandbox", "object": "Account", "object_fields": "Id"},
+				}
+			},
+		},
+		{
+			name:    "Error_DataInputUnknownAccount",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["DATA_INPUTS"] = []interface{}{
+					map[string]interface{}{"name": "orphan", "account": "sf_acquired", "object": "Account", "object_fields": "Id"},
+				}
+			},
+		},
+		{
+			name:    "Error_EventLogInputUnknownAccount",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["EVENT_LOG_INPUTS"] = []interface{}{
+					map[string]interface{}{"name": "eventlog", "account": "sf_acquired"},
+				}
+			},
+		},
+		{
+			name:    "Error_DuplicateAccountNames",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["SALESFORCE_ACCOUNTS"] = []interface{}{
+					map[string]interface{}{"name": "test_account", "endpoint": "login.salesforce.com", "client_id": "id1", "client_secret": "secret1"},
+					map[string]interface{}{"name": "test_account", "endpoint": "test.salesforce.com", "client_id": "id2", "client_secret": "secret2"},
+				}
+			},
+		},
+		{
+			name:    "Error_AccountMissingCredentials",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["SALESFORCE_ACCOUNTS"] = []interface{}{
+					map[string]interface{}{"name": "test_account", "endpoint": "login.salesforce.com", "client_id": "id1"},
+				}
+			},
+		},
+		{
+			name:    "Success_TargetsInheritCredentials",
+			config:  validConfig(),
+			wantErr: false,
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.URL = ""
+				c.Extensions["TARGETS"] = []interface{}{
+					map[string]interface{}{"name": "dev", "splunk_url": "https://splunk-dev:8089"},
+					map[string]interface{}{"name": "prod", "splunk_url": "https://splunk-prod:8089", "username": "svc", "password": "prod-secret"},
+				}
+			},
+		},
+		{
+			name:    "Error_TargetMissingURL",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.URL = ""
+				c.Extensions["TARGETS"] = []interface{}{map[string]interface{}{"name": "dev"}}
+			},
+		},
+		{
+			name:    "Error_TargetMissingCredentials",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.Password = ""
+				c.Extensions["TARGETS"] = []interface{}{map[string]interface{}{"name": "dev", "splunk_url": "https://splunk-dev:8089"}}
+			},
+		},
+		{
+			name:    "Error_DuplicateTargetNames",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["TARGETS"] = []interface{}{
+					map[string]interface{}{"name": "dev"},
+					map[string]interface{}{"name": "dev"},
+				}
+			},
+		},
+		{
+			name:    "Error_DefaultAccountNotListed",
+			config:  validConfig(),
+			wantErr: true,
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["SALESFORCE_ACCOUNTS"] = []interface{}{
+					map[string]interface{}{"name": "sf_prod", "endpoint": "login.salesforce.com", "client_id": "id1", "client_secret": "secret1"},
+				}
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -310,6 +540,7 @@ func TestConfig_GetDataInputs_MissingFieldsAndDefaults(t *testing.T) {
 		config      *utils.Config
 		wantErr     bool
 		checkDefaults bool
+		wantDisabled  bool
 	}{
 		{
 			name: "Error_MissingName",
@@ -329,6 +560,16 @@ func TestConfig_GetDataInputs_MissingFieldsAndDefaults(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "Success_Disabled",
+			config: &utils.Config{
+				Extensions: map[string]interface{}{
+					"DATA_INPUTS": []interface{}{map[string]interface{}{"name": "Test_Input", "object": "Account", "disabled": true}},
+				},
+			},
+			wantErr:      false,
+			wantDisabled: true,
+		},
 		{
 			name: "Success_AppliesDefaults",
 			config: &utils.Config{
@@ -349,6 +590,9 @@ func TestConfig_GetDataInputs_MissingFieldsAndDefaults(t *testing.T) {
 				t.Errorf("GetDataInputs() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.wantDisabled && (len(inputs) != 1 || inputs[0].Disabled == nil || !*inputs[0].Disabled) {
+				t.Errorf("Expected Disabled=true, got %+v", inputs)
+			}
 			if tt.checkDefaults && len(inputs) > 0 {
 				input := inputs[0]
 				if input.Index != "default_index" {
@@ -363,11 +607,452 @@ func TestConfig_GetDataInputs_MissingFieldsAndDefaults(t *testing.T) {
 				if input.Delay != 60 {
 					t.Errorf("Expected default Delay=60, got %d", input.Delay)
 				}
+				if input.Disabled != nil {
+					t.Errorf("Expected Disabled to be unset, got %t", *input.Disabled)
+				}
+			}
+		})
+	}
+}
+
+func TestConfig_GetEventLogInputs(t *testing.T) {
+	t.Run("Success_NotConfigured", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{}}
+		inputs, err := config.GetEventLogInputs()
+		if err != nil {
+			t.Errorf("GetEventLogInputs() unexpected error = %v", err)
+		}
+		if len(inputs) != 0 {
+			t.Errorf("Expected no event log inputs, got %d", len(inputs))
+		}
+	})
+
+	t.Run("Success_ParsesAndAppliesDefaults", func(t *testing.T) {
+		config := &utils.Config{
+			Splunk: utils.SplunkConfig{DefaultIndex: "default_index"},
+			Extensions: map[string]interface{}{
+				"EVENT_LOG_INPUTS": []interface{}{
+					map[string]interface{}{"name": "EventLog_Daily"},
+					map[string]interface{}{
+						"name":                "EventLog_Hourly",
+						"monitoring_interval": "Hourly",
+						"start_date":          "2024-06-01T00:00:00.000Z",
+						"interval":            float64(600),
+						"index":               "sfdc_events",
+					},
+				},
+			},
+		}
+
+		inputs, err := config.GetEventLogInputs()
+		if err != nil {
+			t.Fatalf("GetEventLogInputs() unexpected error = %v", err)
+		}
+		if len(inputs) != 2 {
+			t.Fatalf("Expected 2 event log inputs, got %d", len(inputs))
+		}
+
+		expected := []utils.EventLogInput{
+			{Name: "EventLog_Daily", MonitoringInterval: "Daily", StartDate: "2024-01-01T00:00:00.000Z", Interval: 3600, Index: "default_index"},
+			{Name: "EventLog_Hourly", MonitoringInterval: "Hourly", StartDate: "2024-06-01T00:00:00.000Z", Interval: 600, Index: "sfdc_events"},
+		}
+		if !reflect.DeepEqual(inputs, expected) {
+			t.Errorf("GetEventLogInputs() = %+v, want %+v", inputs, expected)
+		}
+	})
+
+	t.Run("Error_MissingName", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"EVENT_LOG_INPUTS": []interface{}{map[string]interface{}{"monitoring_interval": "Daily"}},
+		}}
+		if _, err := config.GetEventLogInputs(); err == nil {
+			t.Error("GetEventLogInputs() expected error for missing name")
+		}
+	})
+
+	t.Run("Error_InvalidType", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{"EVENT_LOG_INPUTS": "invalid_type"}}
+		if _, err := config.GetEventLogInputs(); err == nil {
+			t.Error("GetEventLogInputs() expected error for non-array value")
+		}
+	})
+}
+
+func TestConfig_GetIndexes(t *testing.T) {
+	t.Run("Success_CollectsInputIndexes", func(t *testing.T) {
+		config := &utils.Config{
+			Splunk: utils.SplunkConfig{IndexName: "salesforce", DefaultIndex: "salesforce", IndexSearchableDays: 90, MaxTotalDataSizeMB: 2048},
+			Extensions: map[string]interface{}{
+				"DATA_INPUTS": []interface{}{
+					map[string]interface{}{"name": "Account_Input", "object": "Account"},
+					map[string]interface{}{"name": "Opportunity_Input", "object": "Opportunity", "index": "sfdc_sales"},
+					map[string]interface{}{"name": "Lead_Input", "object": "Lead", "index": "sfdc_sales"},
+				},
+				"EVENT_LOG_INPUTS": []interface{}{map[string]interface{}{"name": "EventLog_Daily", "index": "sfdc_events"}},
+			},
+		}
+
+		indexes, err := config.GetIndexes()
+		if err != nil {
+			t.Fatalf("GetIndexes() unexpected error = %v", err)
+		}
+
+		expected := []utils.IndexSpec{
+			{Name: "salesforce", Datatype: "event", SearchableDays: 90, MaxDataSizeMB: 2048},
+			{Name: "sfdc_sales", Datatype: "event", SearchableDays: 90, MaxDataSizeMB: 2048},
+			{Name: "sfdc_events", Datatype: "event", SearchableDays: 90, MaxDataSizeMB: 2048},
+		}
+		if !reflect.DeepEqual(indexes, expected) {
+			t.Errorf("GetIndexes() = %+v, want %+v", indexes, expected)
+		}
+	})
+
+	t.Run("Success_WithoutDataInputs", func(t *testing.T) {
+		config := &utils.Config{Splunk: utils.SplunkConfig{IndexName: "salesforce", IndexDatatype: "Metric"}}
+
+		indexes, err := config.GetIndexes()
+		if err != nil {
+			t.Fatalf("GetIndexes() unexpected error = %v", err)
+		}
+		if !reflect.DeepEqual(indexes, []utils.IndexSpec{{Name: "salesforce", Datatype: "metric"}}) {
+			t.Errorf("GetIndexes() = %+v", indexes)
+		}
+	})
+
+	t.Run("Error_InvalidDataInputs", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"DATA_INPUTS": []interface{}{map[string]interface{}{"name": "Account_Input"}},
+		}}
+		if _, err := config.GetIndexes(); err == nil {
+			t.Error("GetIndexes() expected error for an input without object")
+		}
+	})
+}
+
+func TestConfig_GetDashboardVars(t *testing.T) {
+	t.Run("Success_ConvertsScalars", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"DASHBOARD_VARS": map[string]interface{}{"env": "prod", "retention": float64(30), "debug": false},
+		}}
+
+		vars, err := config.GetDashboardVars()
+		if err != nil {
+			t.Fatalf("GetDashboardVars() unexpected error = %v", err)
+		}
+		expected := map[string]string{"env": "prod", "retention": "30", "debug": "false"}
+		if !reflect.DeepEqual(vars, expected) {
+			t.Errorf("GetDashboardVars() = %v, want %v", vars, expected)
+		}
+	})
+
+	t.Run("Success_NotConfigured", func(t *testing.T) {
+		vars, err := (&utils.Config{}).GetDashboardVars()
+		if err != nil || len(vars) != 0 {
+			t.Errorf("GetDashboardVars() = %v, %v; want empty map", vars, err)
+		}
+	})
+
+	t.Run("Error_InvalidValue", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"DASHBOARD_VARS": map[string]interface{}{"indexes": []interface{}{"a", "b"}},
+		}}
+		if _, err := config.GetDashboardVars(); err == nil {
+			t.Error("GetDashboardVars() expected error for an array value")
+		}
+	})
+}
+
+func TestConfig_GetDashboardACLs(t *testing.T) {
+	newConfig := func() *utils.Config {
+		return &utils.Config{
+			Migration: utils.MigrationConfig{
+				DashboardApp:        "salesforce_app",
+				DashboardReadRoles:  "user, power,",
+				DashboardWriteRoles: "admin",
+			},
+			Extensions: map[string]interface{}{
+				"DASHBOARD_ACL": map[string]interface{}{
+					"sales_overview": map[string]interface{}{"app": "sales", "sharing": "global", "read": []interface{}{"sales_user"}},
+					"private_notes":  map[string]interface{}{"owner": "jdoe", "sharing": "user", "read": []interface{}{}, "write": []interface{}{}},
+				},
+			},
+		}
+	}
+
+	t.Run("Success_DefaultsFromMigrationSettings", func(t *testing.T) {
+		acl := newConfig().DefaultDashboardACL()
+		expected := utils.DashboardACL{App: "salesforce_app", Owner: "nobody", Sharing: "app", Read: []string{"user", "power"}, Write: []string{"admin"}}
+		if !reflect.DeepEqual(acl, expected) {
+			t.Errorf("DefaultDashboardACL() = %+v, want %+v", acl, expected)
+		}
+		if !acl.HasPermissions() {
+			t.Error("HasPermissions() = false, want true when roles are set")
+		}
+	})
+
+	t.Run("Success_NoPermissionsByDefault", func(t *testing.T) {
+		acl := (&utils.Config{}).DefaultDashboardACL()
+		expected := utils.DashboardACL{App: utils.DefaultDashboardApp, Owner: utils.DefaultDashboardOwner}
+		if !reflect.DeepEqual(acl, expected) || acl.HasPermissions() {
+			t.Errorf("DefaultDashboardACL() = %+v, want %+v without permissions", acl, expected)
+		}
+	})
+
+	t.Run("Success_EntriesOverrideDefaults", func(t *testing.T) {
+		acls, err := newConfig().GetDashboardACLs()
+		if err != nil {
+			t.Fatalf("GetDashboardACLs() unexpected error = %v", err)
+		}
+		expected := map[string]utils.DashboardACL{
+			"sales_overview": {App: "sales", Owner: "nobody", Sharing: "global", Read: []string{"sales_user"}, Write: []string{"admin"}},
+			"private_notes":  {App: "salesforce_app", Owner: "jdoe", Sharing: "user", Read: []string{}, Write: []string{}},
+		}
+		if !reflect.DeepEqual(acls, expected) {
+			t.Errorf("GetDashboardACLs() = %+v, want %+v", acls, expected)
+		}
+	})
+
+	t.Run("Error_InvalidRoles", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"DASHBOARD_ACL": map[string]interface{}{"home": map[string]interface{}{"read": "power"}},
+		}}
+		if _, err := config.GetDashboardACLs(); err == nil {
+			t.Error("GetDashboardACLs() expected error for a role string instead of an array")
+		}
+	})
+}
+
+func TestSplunkConfig_EffectivePlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   utils.SplunkConfig
+		platform string
+		stack    string
+	}{
+		{name: "Enterprise", config: utils.SplunkConfig{URL: "https://splunk.example.com:8089"}, platform: "enterprise"},
+		{name: "CloudFromURL", config: utils.SplunkConfig{URL: "https://Acme.splunkcloud.com:8089"}, platform: "cloud", stack: "acme"},
+		{name: "ConfiguredPlatform", config: utils.SplunkConfig{URL: "https://splunk.acme.internal:8089", Platform: "Cloud", Stack: "acme"}, platform: "cloud", stack: "acme"},
+		{name: "ConfiguredEnterpriseOnCloudHost", config: utils.SplunkConfig{URL: "https://acme.splunkcloud.com:8089", Platform: "enterprise"}, platform: "enterprise", stack: "acme"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.EffectivePlatform(); got != tt.platform {
+				t.Errorf("EffectivePlatform() = %q, want %q", got, tt.platform)
+			}
+			if got := tt.config.EffectiveStack(); got != tt.stack {
+				t.Errorf("EffectiveStack() = %q, want %q", got, tt.stack)
 			}
 		})
 	}
 }
 
+func TestConfig_GetSalesforceAccounts(t *testing.T) {
+	t.Run("Success_SingleAccountFromSalesforceFields", func(t *testing.T) {
+		config := &utils.Config{
+			Salesforce: utils.SalesforceConfig{
+				Endpoint:     "login.salesforce.com",
+				APIVersion:   "64.0",
+				AuthType:     "oauth_client_credentials",
+				ClientID:     "client123",
+				ClientSecret: "secret456",
+				AccountName:  "sf_prod",
+			},
+			Extensions: map[string]interface{}{},
+		}
+
+		accounts, err := config.GetSalesforceAccounts()
+		if err != nil {
+			t.Fatalf("GetSalesforceAccounts() unexpected error = %v", err)
+		}
+
+		expected := []utils.SalesforceAccount{
+			{Name: "sf_prod", Endpoint: "login.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "client123", ClientSecret: "secret456"},
+		}
+		if !reflect.DeepEqual(accounts, expected) {
+			t.Errorf("GetSalesforceAccounts() = %+v, want %+v", accounts, expected)
+		}
+	})
+
+	t.Run("Success_ListAppliesDefaults", func(t *testing.T) {
+		config := &utils.Config{
+			Salesforce: utils.SalesforceConfig{APIVersion: "64.0", AuthType: "oauth_client_credentials"},
+			Extensions: map[string]interface{}{
+				"SALESFORCE_ACCOUNTS": []interface{}{
+					map[string]interface{}{"name": "sf_prod", "endpoint": "login.salesforce.com", "client_id": "id1", "client_secret": "secret1"},
+					map[string]interface{}{"name": "sf_sandbox", "endpoint": "test.salesforce.com", "api_version": "62.0", "client_id": "id2", "client_secret": "secret2"},
+				},
+			},
+		}
+
+		accounts, err := config.GetSalesforceAccounts()
+		if err != nil {
+			t.Fatalf("GetSalesforceAccounts() unexpected error = %v", err)
+		}
+
+		expected := []utils.SalesforceAccount{
+			{Name: "sf_prod", Endpoint: "login.salesforce.com", APIVersion: "64.0", AuthType: "oauth_client_credentials", ClientID: "id1", ClientSecret: "secret1"},
+			{Name: "sf_sandbox", Endpoint: "test.salesforce.com", APIVersion: "62.0", AuthType: "oauth_client_credentials", ClientID: "id2", ClientSecret: "secret2"},
+		}
+		if !reflect.DeepEqual(accounts, expected) {
+			t.Errorf("GetSalesforceAccounts() = %+v, want %+v", accounts, expected)
+		}
+		if got := config.DefaultAccountName(); got != "sf_prod" {
+			t.Errorf("DefaultAccountName() = %q, want %q", got, "sf_prod")
+		}
+	})
+
+	t.Run("Success_InputsDefaultToDefaultAccount", func(t *testing.T) {
+		config := &utils.Config{
+			Salesforce: utils.SalesforceConfig{AccountName: "sf_prod"},
+			Extensions: map[string]interface{}{
+				"DATA_INPUTS": []interface{}{
+					map[string]interface{}{"name": "prod_accounts", "object": "Account"},
+					map[string]interface{}{"name": "sandbox_accounts", "account": "sf_sandbox", "object": "Account"},
+				},
+			},
+		}
+
+		inputs, err := config.GetDataInputs()
+		if err != nil {
+			t.Fatalf("GetDataInputs() unexpected error = %v", err)
+		}
+		if inputs[0].Account != "sf_prod" || inputs[1].Account != "sf_sandbox" {
+			t.Errorf("GetDataInputs() accounts = %q, %q; want sf_prod, sf_sandbox", inputs[0].Account, inputs[1].Account)
+		}
+	})
+
+	t.Run("Error_MissingName", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"SALESFORCE_ACCOUNTS": []interface{}{map[string]interface{}{"endpoint": "login.salesforce.com"}},
+		}}
+		if _, err := config.GetSalesforceAccounts(); err == nil {
+			t.Error("GetSalesforceAccounts() expected error for missing name")
+		}
+	})
+
+	t.Run("Error_InvalidType", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{"SALESFORCE_ACCOUNTS": "sf_prod"}}
+		if _, err := config.GetSalesforceAccounts(); err == nil {
+			t.Error("GetSalesforceAccounts() expected error for non-array value")
+		}
+	})
+}
+
+func TestConfig_GetTargets(t *testing.T) {
+	t.Run("Success_DefaultTargetWithoutSection", func(t *testing.T) {
+		config := &utils.Config{Splunk: utils.SplunkConfig{URL: "https://splunk:8089"}, Extensions: map[string]interface{}{}}
+		targets, err := config.GetTargets()
+		if err != nil {
+			t.Fatalf("GetTargets() unexpected error = %v", err)
+		}
+		expected := []utils.Target{{Name: "default", URL: "https://splunk:8089"}}
+		if !reflect.DeepEqual(targets, expected) {
+			t.Errorf("GetTargets() = %+v, want %+v", targets, expected)
+		}
+	})
+
+	t.Run("Success_ParsesTargets", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"TARGETS": []interface{}{
+				map[string]interface{}{"name": "dev", "splunk_url": "https://splunk-dev:8089", "skip_ssl_verify": true},
+				map[string]interface{}{"name": "prod", "splunk_url": "https://splunk-prod:8089", "username": "svc", "password": "secret", "index_name": "sfdc"},
+			},
+		}}
+		targets, err := config.GetTargets()
+		if err != nil {
+			t.Fatalf("GetTargets() unexpected error = %v", err)
+		}
+		if len(targets) != 2 {
+			t.Fatalf("Expected 2 targets, got %d", len(targets))
+		}
+		if targets[0].SkipSSLVerify == nil || !*targets[0].SkipSSLVerify {
+			t.Errorf("Expected dev target to skip SSL verification")
+		}
+		if targets[1].SkipSSLVerify != nil {
+			t.Errorf("Expected prod target to inherit SSL verification")
+		}
+		if targets[1].Username != "svc" || targets[1].IndexName != "sfdc" {
+			t.Errorf("Unexpected prod target %+v", targets[1])
+		}
+	})
+
+	t.Run("Error_MissingName", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{
+			"TARGETS": []interface{}{map[string]interface{}{"splunk_url": "https://splunk:8089"}},
+		}}
+		if _, err := config.GetTargets(); err == nil {
+			t.Error("GetTargets() expected error for missing name")
+		}
+	})
+
+	t.Run("Error_InvalidType", func(t *testing.T) {
+		config := &utils.Config{Extensions: map[string]interface{}{"TARGETS": "dev"}}
+		if _, err := config.GetTargets(); err == nil {
+			t.Error("GetTargets() expected error for non-array value")
+		}
+	})
+}
+
+func TestConfig_ForTarget(t *testing.T) {
+	skip := true
+	base := &utils.Config{
+		Splunk: utils.SplunkConfig{
+			URL:          "https://splunk-dev:8089",
+			Username:     "admin",
+			Password:     "changeme",
+			IndexName:    "salesforce",
+			DefaultIndex: "salesforce",
+		},
+		Extensions: map[string]interface{}{},
+	}
+
+	targetConfig := base.ForTarget(utils.Target{
+		Name:          "prod",
+		URL:           "https://splunk-prod:8089",
+		Password:      "prod-secret",
+		SkipSSLVerify: &skip,
+		DefaultIndex:  "sfdc_prod",
+	})
+
+	expected := utils.SplunkConfig{
+		URL:           "https://splunk-prod:8089",
+		Username:      "admin",
+		Password:      "prod-secret",
+		SkipSSLVerify: true,
+		IndexName:     "sfdc_prod",
+		DefaultIndex:  "sfdc_prod",
+	}
+	if !reflect.DeepEqual(targetConfig.Splunk, expected) {
+		t.Errorf("ForTarget() Splunk = %+v, want %+v", targetConfig.Splunk, expected)
+	}
+	if base.Splunk.URL != "https://splunk-dev:8089" || base.Splunk.Password != "changeme" {
+		t.Errorf("ForTarget() must not modify the base configuration, got %+v", base.Splunk)
+	}
+}
+
+func TestConfig_ValidateNeverLeaksProxyPassword(t *testing.T) {
+	config := &utils.Config{
+		Splunk:     utils.SplunkConfig{URL: "https://splunk.example.com:8089", Username: "admin", Password: "password"},
+		Salesforce: utils.SalesforceConfig{Endpoint: "https://login.salesforce.com", ClientID: "id", ClientSecret: "secret", AccountName: "acct"},
+	}
+
+	for _, proxy := range []utils.SalesforceProxyConfig{
+		{Enabled: true, Port: 8080, Username: "svc", Password: "s3cr3t-proxy-pass"},
+		{Enabled: true, URL: "proxy.corp.local", Port: 0, Username: "svc", Password: "s3cr3t-proxy-pass"},
+		{Enabled: true, URL: "proxy.corp.local", Port: 8080, Password: "s3cr3t-proxy-pass"},
+	} {
+		config.Salesforce.Proxy = proxy
+		err := config.Validate()
+		if err == nil {
+			t.Fatalf("Validate() expected error for proxy %+v", proxy.URL)
+		}
+		if strings.Contains(err.Error(), "s3cr3t-proxy-pass") {
+			t.Errorf("Validate() error leaks proxy password: %v", err)
+		}
+	}
+}
+
 func TestLoadConfig(t *testing.T) {
 	createTestFile := func(content string) string {
 		tmpFile, err := os.CreateTemp("", "test-config-*.json")
@@ -423,6 +1108,41 @@ func TestLoadConfig(t *testing.T) {
 				if config.Migration.ConcurrentRequests != assertDefaults["ConcurrentRequests"] {
 					t.Errorf("Expected ConcurrentRequests=%v, got %d", assertDefaults["ConcurrentRequests"], config.Migration.ConcurrentRequests)
 				}
+				if config.Splunk.IndexApp != "search" || config.Splunk.IndexDatatype != "event" || config.Splunk.ACSURL != "https://admin.splunk.com" {
+					t.Errorf("Unexpected index provisioning defaults: app=%q datatype=%q acs=%q", config.Splunk.IndexApp, config.Splunk.IndexDatatype, config.Splunk.ACSURL)
+				}
+				if config.Migration.DashboardApp != "search" || config.Migration.DashboardOwner != "nobody" || config.Migration.DashboardPrune {
+					t.Errorf("Unexpected dashboard defaults: app=%q owner=%q prune=%v", config.Migration.DashboardApp, config.Migration.DashboardOwner, config.Migration.DashboardPrune)
+				}
+			},
+		},
+		{
+			name: "Success_ProxyAndAddonLogLevel",
+			content: `{
+				"SPLUNK_URL": "https://test.splunk.com:8089",
+				"SPLUNK_USERNAME": "admin",
+				"SPLUNK_PASSWORD": "password",
+				"SALESFORCE_ENDPOINT": "https://login.salesforce.com",
+				"SALESFORCE_CLIENT_ID": "test-client",
+				"SALESFORCE_CLIENT_SECRET": "test-secret",
+				"SALESFORCE_ACCOUNT_NAME": "test-account",
+				"SALESFORCE_PROXY_ENABLED": true,
+				"SALESFORCE_PROXY_URL": "proxy.corp.local",
+				"SALESFORCE_PROXY_PORT": 3128,
+				"SALESFORCE_PROXY_USERNAME": "svc",
+				"SALESFORCE_PROXY_PASSWORD": "p@ss",
+				"SALESFORCE_ADDON_LOGLEVEL": "debug",
+				"DATA_INPUTS": [{"name": "Account_Test", "object": "Account", "object_fields": "Id,Name"}]
+			}`,
+			wantErr: false,
+			checkFunc: func(t *testing.T, config *utils.Config) {
+				expected := utils.SalesforceProxyConfig{Enabled: true, Type: "http", URL: "proxy.corp.local", Port: 3128, Username: "svc", Password: "p@ss"}
+				if config.Salesforce.Proxy != expected {
+					t.Errorf("Expected proxy %+v, got %+v", expected, config.Salesforce.Proxy)
+				}
+				if config.Salesforce.AddonLogLevel != "DEBUG" {
+					t.Errorf("Expected AddonLogLevel='DEBUG', got '%s'", config.Salesforce.AddonLogLevel)
+				}
 			},
 		},
 		{
@@ -818,6 +1538,13 @@ func TestLoadExtensions(t *testing.T) {
 			checkKeys: []string{"CUSTOM_FIELD", "ANOTHER_EXTENSION"},
 			skipKeys:  []string{"SPLUNK_URL", "APP_NAME"},
 		},
+		{
+			name:      "Success_LoadSalesforceAccounts",
+			content:   `{"SALESFORCE_ACCOUNTS": [{"name": "sf_prod"}], "SALESFORCE_ENDPOINT": "login.salesforce.com"}`,
+			wantErr:   false,
+			checkKeys: []string{"SALESFORCE_ACCOUNTS"},
+			skipKeys:  []string{"SALESFORCE_ENDPOINT"},
+		},
 		{name: "Error_InvalidJSON", content: `{invalid}`, wantErr: true},
 		{name: "Error_FileNotFound", skipFile: true, wantErr: true},
 	}
@@ -0,0 +1,761 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SalesforceAuthTypes lists the account auth types accepted by Splunk_TA_salesforce
+var SalesforceAuthTypes = []string{"basic", "oauth", "oauth_client_credentials"}
+
+// Ranges accepted for input polling settings, in seconds
+const (
+	MinInputInterval = 60
+	MaxInputInterval = 86400
+	MaxInputDelay    = 86400
+)
+
+// RedactedSecret replaces secrets in exported configurations. Validate rejects it so
+// that the placeholder is never sent to Splunk.
+const RedactedSecret = "<redacted>"
+
+// MaxIndexNameLength is the longest index name Splunk accepts
+const MaxIndexNameLength = 255
+
+var (
+	// soqlIdentifier matches SOQL object and field API names, including custom (__c) and namespaced names
+	soqlIdentifier = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*$`)
+	// soqlFieldPath matches a field or a relationship path such as Owner.Name
+	soqlFieldPath = regexp.MustCompile(`^[A-Za-z][A-Za-z0-9_]*(\.[A-Za-z][A-Za-z0-9_]*)*$`)
+	// splunkIndexName matches the characters Splunk allows in index names
+	splunkIndexName = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]*$`)
+	// salesforceAPIVersion matches versions such as 64.0
+	salesforceAPIVersion = regexp.MustCompile(`^[0-9]+\.0$`)
+	// templateIdentifier matches names that dashboard templates can reference as .Vars.<name>
+	templateIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+)
+
+// ValidationError is one configuration problem, located by the JSON path of the offending value
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+// Error implements the error interface
+func (e ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Message)
+}
+
+// ValidationErrors lists every problem found by Config.Validate
+type ValidationErrors []ValidationError
+
+// Error implements the error interface
+func (e ValidationErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return fmt.Sprintf("%d configuration problems: %s", len(e), strings.Join(messages, "; "))
+}
+
+// validator collects validation problems instead of stopping at the first one
+type validator struct {
+	errs ValidationErrors
+}
+
+// addf records a problem at the given JSON path
+func (v *validator) addf(path, format string, args ...interface{}) {
+	v.errs = append(v.errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// err returns the collected problems, or nil when there are none
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+	return v.errs
+}
+
+// Validate checks the configuration offline against the rules Splunk and Salesforce
+// enforce. All problems are reported at once as ValidationErrors.
+func (c *Config) Validate() error {
+	v := &validator{}
+
+	// Validate Splunk configuration
+	c.validateTargets(v)
+
+	// Validate Salesforce configuration
+	accountNames := c.validateSalesforceAccounts(v)
+
+	// Validate add-on proxy and logging settings
+	c.Salesforce.Proxy.validate(v)
+	if c.Salesforce.AddonLogLevel != "" && !slices.Contains(AddonLogLevels, strings.ToUpper(c.Salesforce.AddonLogLevel)) {
+		v.addf("$.SALESFORCE_ADDON_LOGLEVEL", "must be one of %s", strings.Join(AddonLogLevels, ", "))
+	}
+
+	// Validate migration settings
+	c.Migration.validate(v)
+
+	// Validate inputs
+	c.validateDataInputs(v, accountNames)
+	c.validateEventLogInputs(v, accountNames)
+
+	// Validate dashboard template variables and permissions
+	c.validateDashboardVars(v)
+	c.validateDashboardACLs(v)
+
+	return v.err()
+}
+
+// validateTargets checks that every Splunk target has a URL, credentials and valid index names
+func (c *Config) validateTargets(v *validator) {
+	validateIndexName(v, "$.SPLUNK_INDEX_NAME", c.Splunk.IndexName)
+	validateIndexName(v, "$.SPLUNK_DEFAULT_INDEX", c.Splunk.DefaultIndex)
+	switch c.Splunk.EffectiveAuthMethod() {
+	case SplunkAuthToken, SplunkAuthSession, SplunkAuthStatic:
+	default:
+		v.addf("$.SPLUNK_AUTH_METHOD", "must be %s, %s or %s", SplunkAuthToken, SplunkAuthSession, SplunkAuthStatic)
+	}
+	if c.Splunk.TokenLifetime < 0 {
+		v.addf("$.SPLUNK_TOKEN_LIFETIME", "must be positive, got %d", c.Splunk.TokenLifetime)
+	} else if c.Splunk.TokenLifetime > 0 && c.Splunk.TokenLifetime < 300 {
+		v.addf("$.SPLUNK_TOKEN_LIFETIME", "must be at least 300 seconds, got %d", c.Splunk.TokenLifetime)
+	}
+
+	if c.Splunk.RateLimit < 0 {
+		v.addf("$.SPLUNK_RATE_LIMIT", "must not be negative, got %g", c.Splunk.RateLimit)
+	}
+	if c.Splunk.RateLimitBurst < 0 {
+		v.addf("$.SPLUNK_RATE_LIMIT_BURST", "must not be negative, got %d", c.Splunk.RateLimitBurst)
+	}
+	if c.Splunk.MaxConcurrentRequests < 0 {
+		v.addf("$.SPLUNK_MAX_CONCURRENT_REQUESTS", "must not be negative, got %d", c.Splunk.MaxConcurrentRequests)
+	}
+	if c.Splunk.CircuitBreakerCooldown < 0 {
+		v.addf("$.SPLUNK_CIRCUIT_BREAKER_COOLDOWN", "must not be negative, got %d", c.Splunk.CircuitBreakerCooldown)
+	}
+	c.Splunk.validateIndexProvisioning(v)
+
+	if _, exists := c.Extensions["TARGETS"]; !exists {
+		validateSplunkURL(v, "$.SPLUNK_URL", c.Splunk.URL)
+		validateCloudTarget(v, "$.SPLUNK_URL", c.Splunk)
+		if c.Splunk.EffectiveAuthMethod() == SplunkAuthStatic {
+			validateSecret(v, "$.SPLUNK_TOKEN", c.Splunk.Token, "is required with the static authentication method")
+			return
+		}
+		if c.Splunk.Username == "" {
+			v.addf("$.SPLUNK_USERNAME", "is required")
+		}
+		validateSecret(v, "$.SPLUNK_PASSWORD", c.Splunk.Password, "is required")
+		return
+	}
+
+	targets, err := c.GetTargets()
+	if err != nil {
+		v.addf("$.TARGETS", "%v", err)
+		return
+	}
+	if len(targets) == 0 {
+		v.addf("$.TARGETS", "must contain at least one target")
+		return
+	}
+
+	names := make(map[string]int, len(targets))
+	for i, target := range targets {
+		path := fmt.Sprintf("$.TARGETS[%d]", i)
+		if first, seen := names[target.Name]; seen {
+			v.addf(path+".name", "duplicate target name %q (also at $.TARGETS[%d])", target.Name, first)
+		} else {
+			names[target.Name] = i
+		}
+
+		splunk := c.ForTarget(target).Splunk
+		validateSplunkURL(v, path+".splunk_url", splunk.URL)
+		validateCloudTarget(v, path+".splunk_url", splunk)
+		if splunk.EffectiveAuthMethod() == SplunkAuthStatic {
+			validateSecret(v, path+".token", splunk.Token, "is required with the static authentication method when SPLUNK_TOKEN is not set")
+		} else {
+			if splunk.Username == "" {
+				v.addf(path+".username", "is required when SPLUNK_USERNAME is not set")
+			}
+			validateSecret(v, path+".password", splunk.Password, "is required when SPLUNK_PASSWORD is not set")
+		}
+		if target.IndexName != "" {
+			validateIndexName(v, path+".index_name", target.IndexName)
+		}
+		if target.DefaultIndex != "" {
+			validateIndexName(v, path+".default_index", target.DefaultIndex)
+		}
+	}
+}
+
+// validateIndexProvisioning checks the platform and the settings of created indexes
+func (s SplunkConfig) validateIndexProvisioning(v *validator) {
+	switch s.EffectivePlatform() {
+	case SplunkPlatformEnterprise, SplunkPlatformCloud:
+	default:
+		v.addf("$.SPLUNK_PLATFORM", "must be %s or %s", SplunkPlatformEnterprise, SplunkPlatformCloud)
+	}
+	switch strings.ToLower(s.IndexDatatype) {
+	case "", IndexDatatypeEvent, IndexDatatypeMetric:
+	default:
+		v.addf("$.SPLUNK_INDEX_DATATYPE", "must be %s or %s", IndexDatatypeEvent, IndexDatatypeMetric)
+	}
+	if s.IndexSearchableDays < 0 {
+		v.addf("$.SPLUNK_INDEX_SEARCHABLE_DAYS", "must not be negative, got %d", s.IndexSearchableDays)
+	}
+	if s.MaxTotalDataSizeMB < 0 {
+		v.addf("$.SPLUNK_MAX_TOTAL_DATA_SIZE_MB", "must not be negative, got %d", s.MaxTotalDataSizeMB)
+	}
+	if s.ACSURL != "" {
+		validateSplunkURL(v, "$.SPLUNK_ACS_URL", s.ACSURL)
+	}
+}
+
+// validateCloudTarget checks that a Splunk Cloud target can reach ACS: the stack must
+// be known and ACS only accepts authentication tokens, not session keys
+func validateCloudTarget(v *validator, path string, splunk SplunkConfig) {
+	if splunk.EffectivePlatform() != SplunkPlatformCloud {
+		return
+	}
+	if splunk.EffectiveStack() == "" {
+		v.addf(path, "does not name a Splunk Cloud stack; set SPLUNK_STACK")
+	}
+	if splunk.EffectiveAuthMethod() == SplunkAuthSession {
+		v.addf("$.SPLUNK_AUTH_METHOD", "must be %s or %s on Splunk Cloud, where indexes are created through ACS", SplunkAuthToken, SplunkAuthStatic)
+	}
+}
+
+// validateSalesforceAccounts checks the configured accounts and returns their names.
+// It returns nil when the account list itself could not be read.
+func (c *Config) validateSalesforceAccounts(v *validator) map[string]bool {
+	if _, exists := c.Extensions["SALESFORCE_ACCOUNTS"]; !exists {
+		// Single account configured through the SALESFORCE_* fields
+		if c.Salesforce.Endpoint == "" {
+			v.addf("$.SALESFORCE_ENDPOINT", "is required")
+		}
+		if c.Salesforce.ClientID == "" {
+			v.addf("$.SALESFORCE_CLIENT_ID", "is required")
+		}
+		validateSecret(v, "$.SALESFORCE_CLIENT_SECRET", c.Salesforce.ClientSecret, "is required")
+		validateAPIVersion(v, "$.SALESFORCE_API_VERSION", c.Salesforce.APIVersion)
+		validateAuthType(v, "$.SALESFORCE_AUTH_TYPE", c.Salesforce.AuthType)
+		if c.Salesforce.AccountName == "" {
+			v.addf("$.SALESFORCE_ACCOUNT_NAME", "is required")
+			return nil
+		}
+		return map[string]bool{c.Salesforce.AccountName: true}
+	}
+
+	accounts, err := c.GetSalesforceAccounts()
+	if err != nil {
+		v.addf("$.SALESFORCE_ACCOUNTS", "%v", err)
+		return nil
+	}
+	if len(accounts) == 0 {
+		v.addf("$.SALESFORCE_ACCOUNTS", "must contain at least one account")
+		return nil
+	}
+
+	names := make(map[string]bool, len(accounts))
+	for i, account := range accounts {
+		path := fmt.Sprintf("$.SALESFORCE_ACCOUNTS[%d]", i)
+		if names[account.Name] {
+			v.addf(path+".name", "duplicate account name %q", account.Name)
+		}
+		names[account.Name] = true
+
+		if account.Endpoint == "" {
+			v.addf(path+".endpoint", "is required")
+		}
+		if account.ClientID == "" {
+			v.addf(path+".client_id", "is required")
+		}
+		validateSecret(v, path+".client_secret", account.ClientSecret, "is required")
+		validateAPIVersion(v, path+".api_version", account.APIVersion)
+		validateAuthType(v, path+".auth_type", account.AuthType)
+	}
+
+	if c.Salesforce.AccountName != "" && !names[c.Salesforce.AccountName] {
+		v.addf("$.SALESFORCE_ACCOUNT_NAME", "%q is not listed in SALESFORCE_ACCOUNTS", c.Salesforce.AccountName)
+	}
+
+	return names
+}
+
+// validate checks the proxy settings; it never includes the proxy password in errors
+func (p SalesforceProxyConfig) validate(v *validator) {
+	if !p.IsConfigured() {
+		return
+	}
+	if p.URL == "" {
+		v.addf("$.SALESFORCE_PROXY_URL", "is required when SALESFORCE_PROXY_ENABLED is true")
+	} else if strings.Contains(p.URL, "://") {
+		v.addf("$.SALESFORCE_PROXY_URL", "must be a host name without a scheme")
+	}
+	if p.Type != "" && p.Type != ProxyTypeHTTP && p.Type != ProxyTypeSOCKS5 {
+		v.addf("$.SALESFORCE_PROXY_TYPE", "must be '%s' or '%s'", ProxyTypeHTTP, ProxyTypeSOCKS5)
+	}
+	if p.Port < 1 || p.Port > 65535 {
+		v.addf("$.SALESFORCE_PROXY_PORT", "must be between 1 and 65535")
+	}
+	if p.Password != "" && p.Username == "" {
+		v.addf("$.SALESFORCE_PROXY_USERNAME", "is required when SALESFORCE_PROXY_PASSWORD is set")
+	}
+	if p.Password == RedactedSecret {
+		v.addf("$.SALESFORCE_PROXY_PASSWORD", "is redacted; set the real value in the file or through the environment")
+	}
+}
+
+// validateSecret reports a missing secret, or one still holding the RedactedSecret placeholder
+func validateSecret(v *validator, path, value, missing string) {
+	switch value {
+	case "":
+		v.addf(path, "%s", missing)
+	case RedactedSecret:
+		v.addf(path, "is redacted; set the real value in the file or through the environment")
+	}
+}
+
+// validate checks the migration settings
+func (m MigrationConfig) validate(v *validator) {
+	switch m.PruneMode {
+	case "", PruneModeDisable, PruneModeDelete:
+	default:
+		v.addf("$.MIGRATION_PRUNE_MODE", "must be '%s' or '%s'", PruneModeDisable, PruneModeDelete)
+	}
+	if m.PruneMode != "" && strings.TrimSpace(m.PrunePrefix) == "" {
+		v.addf("$.MIGRATION_PRUNE_PREFIX", "is required when MIGRATION_PRUNE_MODE is set")
+	}
+	if m.DashboardPrune && strings.TrimSpace(m.DashboardPrunePrefix) == "" {
+		v.addf("$.MIGRATION_DASHBOARD_PRUNE_PREFIX", "is required when MIGRATION_DASHBOARD_PRUNE is set")
+	}
+	if m.ConcurrentRequests < 0 {
+		v.addf("$.MIGRATION_CONCURRENT_REQUESTS", "must not be negative")
+	}
+	if m.MaxParallelTargets < 0 {
+		v.addf("$.MIGRATION_MAX_PARALLEL_TARGETS", "must not be negative")
+	}
+}
+
+// validateDataInputs checks every DATA_INPUTS entry. The raw entries are inspected so that
+// values of the wrong type are reported instead of silently replaced by defaults.
+func (c *Config) validateDataInputs(v *validator, accountNames map[string]bool) {
+	entries, ok := inputEntries(v, c.Extensions, "DATA_INPUTS", true)
+	if !ok {
+		return
+	}
+	if len(entries) == 0 {
+		v.addf("$.DATA_INPUTS", "must contain at least one data input")
+		return
+	}
+
+	names := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		path := fmt.Sprintf("$.DATA_INPUTS[%d]", i)
+		if entry == nil {
+			v.addf(path, "must be an object")
+			continue
+		}
+
+		name := stringField(v, entry, path, "name")
+		if name == "" {
+			v.addf(path+".name", "is required")
+		} else if first, seen := names[name]; seen {
+			v.addf(path+".name", "duplicate data input name %q (also at $.DATA_INPUTS[%d])", name, first)
+		} else {
+			names[name] = i
+		}
+
+		object := stringField(v, entry, path, "object")
+		if object == "" {
+			v.addf(path+".object", "is required")
+		} else if !soqlIdentifier.MatchString(object) {
+			v.addf(path+".object", "%q is not a valid SOQL object name", object)
+		}
+
+		fields := validateObjectFields(v, path+".object_fields", stringField(v, entry, path, "object_fields"))
+
+		if orderBy := stringField(v, entry, path, "order_by"); orderBy != "" {
+			if !soqlFieldPath.MatchString(orderBy) {
+				v.addf(path+".order_by", "%q is not a valid SOQL field name", orderBy)
+			} else if fields != nil && !containsFold(fields, orderBy) {
+				v.addf(path+".order_by", "%q must be one of the object_fields", orderBy)
+			}
+		}
+
+		validateStartDate(v, path+".start_date", stringField(v, entry, path, "start_date"))
+		validateInterval(v, path, entry)
+		if delay, set := intField(v, entry, path, "delay"); set && (delay < 0 || delay > MaxInputDelay) {
+			v.addf(path+".delay", "must be between 0 and %d seconds, got %d", MaxInputDelay, delay)
+		}
+		if index := stringField(v, entry, path, "index"); index != "" {
+			validateIndexName(v, path+".index", index)
+		}
+		validateBoolField(v, entry, path, "disabled")
+
+		validateAccountReference(v, path, stringField(v, entry, path, "account"), c.DefaultAccountName(), accountNames)
+	}
+}
+
+// validateEventLogInputs checks every EVENT_LOG_INPUTS entry
+func (c *Config) validateEventLogInputs(v *validator, accountNames map[string]bool) {
+	entries, ok := inputEntries(v, c.Extensions, "EVENT_LOG_INPUTS", false)
+	if !ok {
+		return
+	}
+
+	names := make(map[string]int, len(entries))
+	for i, entry := range entries {
+		path := fmt.Sprintf("$.EVENT_LOG_INPUTS[%d]", i)
+		if entry == nil {
+			v.addf(path, "must be an object")
+			continue
+		}
+
+		name := stringField(v, entry, path, "name")
+		if name == "" {
+			v.addf(path+".name", "is required")
+		} else if first, seen := names[name]; seen {
+			v.addf(path+".name", "duplicate event log input name %q (also at $.EVENT_LOG_INPUTS[%d])", name, first)
+		} else {
+			names[name] = i
+		}
+
+		if interval := stringField(v, entry, path, "monitoring_interval"); interval != "" &&
+			interval != MonitoringIntervalDaily && interval != MonitoringIntervalHourly {
+			v.addf(path+".monitoring_interval", "must be '%s' or '%s'", MonitoringIntervalDaily, MonitoringIntervalHourly)
+		}
+
+		validateStartDate(v, path+".start_date", stringField(v, entry, path, "start_date"))
+		validateInterval(v, path, entry)
+		if index := stringField(v, entry, path, "index"); index != "" {
+			validateIndexName(v, path+".index", index)
+		}
+
+		validateAccountReference(v, path, stringField(v, entry, path, "account"), c.DefaultAccountName(), accountNames)
+	}
+}
+
+// validateDashboardVars checks that DASHBOARD_VARS maps template identifiers to scalar values
+func (c *Config) validateDashboardVars(v *validator) {
+	raw, exists := c.Extensions["DASHBOARD_VARS"]
+	if !exists {
+		return
+	}
+	vars, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addf("$.DASHBOARD_VARS", "must be an object")
+		return
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	for _, name := range names {
+		path := "$.DASHBOARD_VARS." + name
+		if !templateIdentifier.MatchString(name) {
+			v.addf(path, "%q is not a valid variable name; use letters, digits and underscores", name)
+		}
+		switch vars[name].(type) {
+		case string, float64, bool:
+		default:
+			v.addf(path, "must be a string, number or boolean")
+		}
+	}
+}
+
+// validateDashboardACLs checks the MIGRATION_DASHBOARD_* defaults and every DASHBOARD_ACL
+// entry, including the combinations Splunk rejects once the settings are merged
+func (c *Config) validateDashboardACLs(v *validator) {
+	validateSharing(v, "$.MIGRATION_DASHBOARD_SHARING", c.Migration.DashboardSharing)
+	validateDashboardACL(v, "$.MIGRATION_DASHBOARD_SHARING", "$.MIGRATION_DASHBOARD_OWNER", c.DefaultDashboardACL())
+
+	raw, exists := c.Extensions["DASHBOARD_ACL"]
+	if !exists {
+		return
+	}
+	entries, ok := raw.(map[string]interface{})
+	if !ok {
+		v.addf("$.DASHBOARD_ACL", "must be an object")
+		return
+	}
+
+	names := make([]string, 0, len(entries))
+	for name := range entries {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	valid := true
+	for _, name := range names {
+		path := "$.DASHBOARD_ACL." + name
+		entry, ok := entries[name].(map[string]interface{})
+		if !ok {
+			v.addf(path, "must be an object")
+			valid = false
+			continue
+		}
+
+		keys := make([]string, 0, len(entry))
+		for key := range entry {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			switch key {
+			case "app", "owner", "sharing":
+				value, ok := entry[key].(string)
+				if !ok || strings.TrimSpace(value) == "" {
+					v.addf(path+"."+key, "must be a non-empty string")
+					valid = false
+				} else if key == "sharing" {
+					validateSharing(v, path+".sharing", value)
+				}
+			case "read", "write":
+				if _, err := roleList(entry[key]); err != nil {
+					v.addf(path+"."+key, "%s", err)
+					valid = false
+				}
+			default:
+				v.addf(path+"."+key, "unknown setting; use app, owner, sharing, read or write")
+			}
+		}
+	}
+	if !valid {
+		return
+	}
+
+	acls, err := c.GetDashboardACLs()
+	if err != nil {
+		v.addf("$.DASHBOARD_ACL", "%s", err)
+		return
+	}
+	for _, name := range names {
+		path := "$.DASHBOARD_ACL." + name
+		validateDashboardACL(v, path+".sharing", path+".owner", acls[name])
+	}
+}
+
+// validateSharing checks a dashboard sharing level
+func validateSharing(v *validator, path, sharing string) {
+	switch sharing {
+	case "", SharingUser, SharingApp, SharingGlobal:
+	default:
+		v.addf(path, "must be '%s', '%s' or '%s'", SharingUser, SharingApp, SharingGlobal)
+	}
+}
+
+// validateDashboardACL checks the settings of a dashboard ACL that only conflict once
+// defaults and overrides are merged
+func validateDashboardACL(v *validator, sharingPath, ownerPath string, acl DashboardACL) {
+	if acl.Sharing != SharingUser {
+		return
+	}
+	if len(acl.Read) > 0 || len(acl.Write) > 0 {
+		v.addf(sharingPath, "must be '%s' or '%s' when read or write roles are set", SharingApp, SharingGlobal)
+	}
+	if acl.Owner == DefaultDashboardOwner {
+		v.addf(ownerPath, "must name a user when sharing is '%s'", SharingUser)
+	}
+}
+
+// inputEntries returns the objects of an input array extension; non-object entries are
+// returned as nil. ok is false when the key is missing or not an array.
+func inputEntries(v *validator, extensions map[string]interface{}, key string, required bool) ([]map[string]interface{}, bool) {
+	raw, exists := extensions[key]
+	if !exists {
+		if required {
+			v.addf("$."+key, "is required")
+		}
+		return nil, false
+	}
+
+	array, ok := raw.([]interface{})
+	if !ok {
+		v.addf("$."+key, "must be an array")
+		return nil, false
+	}
+
+	entries := make([]map[string]interface{}, len(array))
+	for i, item := range array {
+		entries[i], _ = item.(map[string]interface{})
+	}
+	return entries, true
+}
+
+// stringField returns a string value from an input entry, reporting values of another type
+func stringField(v *validator, entry map[string]interface{}, path, key string) string {
+	raw, exists := entry[key]
+	if !exists || raw == nil {
+		return ""
+	}
+	value, ok := raw.(string)
+	if !ok {
+		v.addf(path+"."+key, "must be a string")
+		return ""
+	}
+	return strings.TrimSpace(value)
+}
+
+// intField returns an integer value from an input entry; set is false when the key is
+// absent or not an integer (the latter is reported)
+func intField(v *validator, entry map[string]interface{}, path, key string) (value int, set bool) {
+	raw, exists := entry[key]
+	if !exists {
+		return 0, false
+	}
+
+	switch n := raw.(type) {
+	case int:
+		return n, true
+	case float64:
+		if n == math.Trunc(n) {
+			return int(n), true
+		}
+	case string:
+		if parsed, err := strconv.Atoi(strings.TrimSpace(n)); err == nil {
+			return parsed, true
+		}
+	}
+	v.addf(path+"."+key, "must be an integer number of seconds")
+	return 0, false
+}
+
+// validateBoolField reports an input entry value that is neither a boolean nor a
+// string parsed as one
+func validateBoolField(v *validator, entry map[string]interface{}, path, key string) {
+	switch value := entry[key].(type) {
+	case nil, bool:
+		return
+	case string:
+		if _, err := strconv.ParseBool(strings.TrimSpace(value)); err == nil {
+			return
+		}
+	}
+	v.addf(path+"."+key, "must be true or false")
+}
+
+// validateInterval checks the polling interval of an input entry
+func validateInterval(v *validator, path string, entry map[string]interface{}) {
+	interval, set := intField(v, entry, path, "interval")
+	if set && (interval < MinInputInterval || interval > MaxInputInterval) {
+		v.addf(path+".interval", "must be between %d and %d seconds, got %d", MinInputInterval, MaxInputInterval, interval)
+	}
+}
+
+// validateObjectFields checks a comma-separated SOQL field list and returns the fields,
+// or nil when the list is "*" (all fields) or invalid
+func validateObjectFields(v *validator, path, objectFields string) []string {
+	if objectFields == "" {
+		v.addf(path, "is required")
+		return nil
+	}
+	if objectFields == "*" {
+		return nil
+	}
+
+	var fields []string
+	valid := true
+	for _, field := range strings.Split(objectFields, ",") {
+		field = strings.TrimSpace(field)
+		switch {
+		case field == "":
+			v.addf(path, "contains an empty field name")
+			valid = false
+		case !soqlFieldPath.MatchString(field):
+			v.addf(path, "%q is not a valid SOQL field name", field)
+			valid = false
+		default:
+			fields = append(fields, field)
+		}
+	}
+	if !valid {
+		return nil
+	}
+	return fields
+}
+
+// validateStartDate checks that a start date is an ISO-8601 UTC timestamp as the add-on expects
+func validateStartDate(v *validator, path, startDate string) {
+	if startDate == "" {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, startDate); err != nil || !strings.HasSuffix(startDate, "Z") {
+		v.addf(path, "%q must be an ISO-8601 UTC timestamp such as 2024-01-01T00:00:00.000Z", startDate)
+	}
+}
+
+// validateAccountReference checks that an input reads from a configured account
+func validateAccountReference(v *validator, path, account, defaultAccount string, accountNames map[string]bool) {
+	if accountNames == nil {
+		return
+	}
+	if account == "" {
+		account = defaultAccount
+	}
+	if !accountNames[account] {
+		v.addf(path+".account", "references unknown account %q", account)
+	}
+}
+
+// validateIndexName checks the Splunk index naming rules
+func validateIndexName(v *validator, path, name string) {
+	switch {
+	case name == "":
+		return
+	case len(name) > MaxIndexNameLength:
+		v.addf(path, "must be %d characters or less", MaxIndexNameLength)
+	case !splunkIndexName.MatchString(name):
+		v.addf(path, "%q may only contain lowercase letters, digits, underscores and hyphens, and must not start with an underscore or hyphen", name)
+	case strings.Contains(name, "kvstore"):
+		v.addf(path, "%q must not contain the word kvstore", name)
+	}
+}
+
+// validateSplunkURL checks that a Splunk management URL is present and absolute
+func validateSplunkURL(v *validator, path, rawURL string) {
+	if rawURL == "" {
+		v.addf(path, "is required")
+		return
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		v.addf(path, "%q must be an absolute http(s) URL such as https://splunk:8089", rawURL)
+	}
+}
+
+// validateAPIVersion checks the Salesforce API version format
+func validateAPIVersion(v *validator, path, version string) {
+	if version != "" && !salesforceAPIVersion.MatchString(version) {
+		v.addf(path, "%q must be a Salesforce API version such as 64.0", version)
+	}
+}
+
+// validateAuthType checks the account auth type against the add-on's options
+func validateAuthType(v *validator, path, authType string) {
+	if authType != "" && !slices.Contains(SalesforceAuthTypes, authType) {
+		v.addf(path, "must be one of %s", strings.Join(SalesforceAuthTypes, ", "))
+	}
+}
+
+// containsFold reports whether values contains target, ignoring case as SOQL does
+func containsFold(values []string, target string) bool {
+	for _, value := range values {
+		if strings.EqualFold(value, target) {
+			return true
+		}
+	}
+	return false
+}
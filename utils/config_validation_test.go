@@ -0,0 +1,463 @@
+package utils_test
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"salesforce-splunk-migration/utils"
+)
+
+func newValidationTestConfig(dataInputs ...map[string]interface{}) *utils.Config {
+	inputs := make([]interface{}, len(dataInputs))
+	for i, input := range dataInputs {
+		inputs[i] = input
+	}
+
+	return &utils.Config{
+		Splunk: utils.SplunkConfig{
+			URL:          "https://splunk.example.com:8089",
+			Username:     "admin",
+			Password:     "password",
+			IndexName:    "salesforce",
+			DefaultIndex: "salesforce",
+		},
+		Salesforce: utils.SalesforceConfig{
+			Endpoint:     "login.salesforce.com",
+			APIVersion:   "64.0",
+			AuthType:     "oauth_client_credentials",
+			ClientID:     "client123",
+			ClientSecret: "secret456",
+			AccountName:  "sf_prod",
+		},
+		Extensions: map[string]interface{}{"DATA_INPUTS": inputs},
+	}
+}
+
+func validInput(name string) map[string]interface{} {
+	return map[string]interface{}{
+		"name":          name,
+		"object":        "Account",
+		"object_fields": "Id,Name,LastModifiedDate",
+		"order_by":      "LastModifiedDate",
+		"start_date":    "2024-01-01T00:00:00.000Z",
+		"interval":      float64(300),
+		"delay":         float64(60),
+		"index":         "salesforce",
+	}
+}
+
+// validationPaths returns the JSON paths reported by Validate
+func validationPaths(t *testing.T, err error) []string {
+	t.Helper()
+	if err == nil {
+		return nil
+	}
+
+	var problems utils.ValidationErrors
+	if !errors.As(err, &problems) {
+		t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+	}
+
+	paths := make([]string, len(problems))
+	for i, problem := range problems {
+		paths[i] = problem.Path
+	}
+	return paths
+}
+
+func TestConfig_ValidateRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupFunc func(input map[string]interface{})
+		wantPaths []string
+	}{
+		{
+			name:      "Success_ValidInput",
+			setupFunc: func(input map[string]interface{}) {},
+		},
+		{
+			name: "Success_AllFieldsWildcard",
+			setupFunc: func(input map[string]interface{}) {
+				input["object_fields"] = "*"
+			},
+		},
+		{
+			name: "Success_CustomAndRelationshipFields",
+			setupFunc: func(input map[string]interface{}) {
+				input["object"] = "ns__Invoice__c"
+				input["object_fields"] = "Id, Owner.Name, Amount__c, lastmodifieddate"
+			},
+		},
+		{
+			name: "Success_NumericStrings",
+			setupFunc: func(input map[string]interface{}) {
+				input["interval"] = "600"
+				input["delay"] = "0"
+			},
+		},
+		{
+			name: "Error_BareStartDate",
+			setupFunc: func(input map[string]interface{}) {
+				input["start_date"] = "2024-01-01"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].start_date"},
+		},
+		{
+			name: "Error_StartDateWithOffset",
+			setupFunc: func(input map[string]interface{}) {
+				input["start_date"] = "2024-01-01T00:00:00+02:00"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].start_date"},
+		},
+		{
+			name: "Error_IntervalZero",
+			setupFunc: func(input map[string]interface{}) {
+				input["interval"] = float64(0)
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].interval"},
+		},
+		{
+			name: "Error_IntervalTooLarge",
+			setupFunc: func(input map[string]interface{}) {
+				input["interval"] = float64(utils.MaxInputInterval + 1)
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].interval"},
+		},
+		{
+			name: "Error_IntervalNotANumber",
+			setupFunc: func(input map[string]interface{}) {
+				input["interval"] = "often"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].interval"},
+		},
+		{
+			name: "Error_NegativeDelay",
+			setupFunc: func(input map[string]interface{}) {
+				input["delay"] = float64(-1)
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].delay"},
+		},
+		{
+			name: "Error_FractionalDelay",
+			setupFunc: func(input map[string]interface{}) {
+				input["delay"] = 1.5
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].delay"},
+		},
+		{
+			name: "Error_DisabledNotBoolean",
+			setupFunc: func(input map[string]interface{}) {
+				input["disabled"] = "sometimes"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].disabled"},
+		},
+		{
+			name: "Error_InvalidObjectName",
+			setupFunc: func(input map[string]interface{}) {
+				input["object"] = "Account; DELETE"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].object"},
+		},
+		{
+			name: "Error_InvalidFieldNames",
+			setupFunc: func(input map[string]interface{}) {
+				input["object_fields"] = "Id,,Bad-Field,LastModifiedDate"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].object_fields", "$.DATA_INPUTS[0].object_fields"},
+		},
+		{
+			name: "Error_OrderByNotInFields",
+			setupFunc: func(input map[string]interface{}) {
+				input["object_fields"] = "Id,Name"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].order_by"},
+		},
+		{
+			name: "Error_UppercaseIndex",
+			setupFunc: func(input map[string]interface{}) {
+				input["index"] = "Salesforce"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].index"},
+		},
+		{
+			name: "Error_IndexStartsWithUnderscore",
+			setupFunc: func(input map[string]interface{}) {
+				input["index"] = "_internal_copy"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].index"},
+		},
+		{
+			name: "Error_IndexContainsKVStore",
+			setupFunc: func(input map[string]interface{}) {
+				input["index"] = "my_kvstore"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].index"},
+		},
+		{
+			name: "Error_WrongType",
+			setupFunc: func(input map[string]interface{}) {
+				input["name"] = float64(42)
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].name", "$.DATA_INPUTS[0].name"},
+		},
+		{
+			name: "Error_UnknownAccount",
+			setupFunc: func(input map[string]interface{}) {
+				input["account"] = "sf_missing"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].account"},
+		},
+		{
+			name: "Error_ReportsAllProblems",
+			setupFunc: func(input map[string]interface{}) {
+				input["start_date"] = "2024-01-01"
+				input["interval"] = float64(0)
+				input["index"] = "SFDC"
+			},
+			wantPaths: []string{"$.DATA_INPUTS[0].start_date", "$.DATA_INPUTS[0].interval", "$.DATA_INPUTS[0].index"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := validInput("sf_accounts")
+			tt.setupFunc(input)
+
+			paths := validationPaths(t, newValidationTestConfig(input).Validate())
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("Validate() paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestConfig_ValidateTopLevelRules(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupFunc func(c *utils.Config)
+		wantPaths []string
+	}{
+		{
+			name: "Error_DuplicateInputNames",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["DATA_INPUTS"] = []interface{}{validInput("sf_accounts"), validInput("sf_contacts"), validInput("sf_accounts")}
+			},
+			wantPaths: []string{"$.DATA_INPUTS[2].name"},
+		},
+		{
+			name: "Error_DuplicateEventLogInputNames",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["EVENT_LOG_INPUTS"] = []interface{}{
+					map[string]interface{}{"name": "event_logs"},
+					map[string]interface{}{"name": "event_logs", "monitoring_interval": "Weekly"},
+				}
+			},
+			wantPaths: []string{"$.EVENT_LOG_INPUTS[1].name", "$.EVENT_LOG_INPUTS[1].monitoring_interval"},
+		},
+		{
+			name: "Error_NonObjectInput",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["DATA_INPUTS"] = []interface{}{validInput("sf_accounts"), "sf_contacts"}
+			},
+			wantPaths: []string{"$.DATA_INPUTS[1]"},
+		},
+		{
+			name: "Error_MissingDataInputs",
+			setupFunc: func(c *utils.Config) {
+				delete(c.Extensions, "DATA_INPUTS")
+			},
+			wantPaths: []string{"$.DATA_INPUTS"},
+		},
+		{
+			name: "Error_InvalidSplunkSettings",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.URL = "splunk.example.com:8089"
+				c.Splunk.Password = ""
+				c.Splunk.IndexName = "Salesforce Data"
+			},
+			wantPaths: []string{"$.SPLUNK_INDEX_NAME", "$.SPLUNK_URL", "$.SPLUNK_PASSWORD"},
+		},
+		{
+			name: "Error_RedactedSecrets",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.Password = utils.RedactedSecret
+				c.Salesforce.ClientSecret = utils.RedactedSecret
+				c.Salesforce.Proxy = utils.SalesforceProxyConfig{URL: "proxy.corp.local", Port: 3128, Username: "svc", Password: utils.RedactedSecret}
+			},
+			wantPaths: []string{"$.SPLUNK_PASSWORD", "$.SALESFORCE_CLIENT_SECRET", "$.SALESFORCE_PROXY_PASSWORD"},
+		},
+		{
+			name: "Success_StaticTokenWithoutPassword",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.AuthMethod = utils.SplunkAuthStatic
+				c.Splunk.Token = "eyJhbGciOiJIUzUxMiJ9.e30.c2ln"
+				c.Splunk.Username = ""
+				c.Splunk.Password = ""
+			},
+		},
+		{
+			name: "Error_InvalidDashboardVars",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["DASHBOARD_VARS"] = map[string]interface{}{
+					"env":        "prod",
+					"retention":  float64(30),
+					"app-name":   "sfdc",
+					"thresholds": []interface{}{1, 2},
+				}
+			},
+			wantPaths: []string{"$.DASHBOARD_VARS.app-name", "$.DASHBOARD_VARS.thresholds"},
+		},
+		{
+			name: "Error_DashboardVarsNotObject",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["DASHBOARD_VARS"] = []interface{}{"env"}
+			},
+			wantPaths: []string{"$.DASHBOARD_VARS"},
+		},
+		{
+			name: "Error_InvalidDashboardACL",
+			setupFunc: func(c *utils.Config) {
+				c.Migration.DashboardSharing = "private"
+				c.Extensions["DASHBOARD_ACL"] = map[string]interface{}{
+					"sales_overview": map[string]interface{}{"app": "sales", "sharing": "everyone", "read": "sales_user"},
+					"home":           map[string]interface{}{"owner": "", "roles": []interface{}{"admin"}},
+					"broken":         "app",
+				}
+			},
+			wantPaths: []string{
+				"$.MIGRATION_DASHBOARD_SHARING",
+				"$.DASHBOARD_ACL.broken",
+				"$.DASHBOARD_ACL.home.owner",
+				"$.DASHBOARD_ACL.home.roles",
+				"$.DASHBOARD_ACL.sales_overview.read",
+				"$.DASHBOARD_ACL.sales_overview.sharing",
+			},
+		},
+		{
+			name: "Error_PrivateDashboardACL",
+			setupFunc: func(c *utils.Config) {
+				c.Migration.DashboardSharing = utils.SharingUser
+				c.Migration.DashboardReadRoles = "power"
+				c.Extensions["DASHBOARD_ACL"] = map[string]interface{}{
+					"mine": map[string]interface{}{"owner": "jdoe", "read": []interface{}{}},
+				}
+			},
+			wantPaths: []string{"$.MIGRATION_DASHBOARD_SHARING", "$.MIGRATION_DASHBOARD_OWNER"},
+		},
+		{
+			name: "Error_StaticTokenMissing",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.AuthMethod = utils.SplunkAuthStatic
+			},
+			wantPaths: []string{"$.SPLUNK_TOKEN"},
+		},
+		{
+			name: "Error_InvalidTokenSettings",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.AuthMethod = "oauth"
+				c.Splunk.TokenLifetime = 60
+			},
+			wantPaths: []string{"$.SPLUNK_AUTH_METHOD", "$.SPLUNK_TOKEN_LIFETIME"},
+		},
+		{
+			name: "Error_InvalidIndexProvisioning",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.Platform = "onprem"
+				c.Splunk.IndexDatatype = "logs"
+				c.Splunk.IndexSearchableDays = -1
+			},
+			wantPaths: []string{"$.SPLUNK_PLATFORM", "$.SPLUNK_INDEX_DATATYPE", "$.SPLUNK_INDEX_SEARCHABLE_DAYS"},
+		},
+		{
+			name: "Error_CloudWithoutStackOrToken",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.Platform = utils.SplunkPlatformCloud
+				c.Splunk.AuthMethod = utils.SplunkAuthSession
+			},
+			wantPaths: []string{"$.SPLUNK_URL", "$.SPLUNK_AUTH_METHOD"},
+		},
+		{
+			name: "Error_CloudWithSessionAuth",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.URL = "https://acme.splunkcloud.com:8089"
+				c.Splunk.AuthMethod = utils.SplunkAuthSession
+			},
+			wantPaths: []string{"$.SPLUNK_AUTH_METHOD"},
+		},
+		{
+			name: "Error_CloudTargetWithSessionAuth",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.AuthMethod = utils.SplunkAuthSession
+				c.Extensions["TARGETS"] = []interface{}{
+					map[string]interface{}{"name": "dev", "splunk_url": "https://splunk-dev:8089"},
+					map[string]interface{}{"name": "prod", "splunk_url": "https://acme.splunkcloud.com:8089"},
+				}
+			},
+			wantPaths: []string{"$.SPLUNK_AUTH_METHOD"},
+		},
+		{
+			name: "Success_CloudStackFromURL",
+			setupFunc: func(c *utils.Config) {
+				c.Splunk.URL = "https://acme.splunkcloud.com:8089"
+			},
+		},
+		{
+			name: "Error_InvalidSalesforceSettings",
+			setupFunc: func(c *utils.Config) {
+				c.Salesforce.APIVersion = "v58.0"
+				c.Salesforce.AuthType = "oauth2"
+			},
+			wantPaths: []string{"$.SALESFORCE_API_VERSION", "$.SALESFORCE_AUTH_TYPE"},
+		},
+		{
+			name: "Error_TargetProblems",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["TARGETS"] = []interface{}{
+					map[string]interface{}{"name": "dev", "splunk_url": "https://splunk-dev:8089", "index_name": "SFDC"},
+					map[string]interface{}{"name": "dev", "splunk_url": "ftp://splunk-prod"},
+				}
+			},
+			wantPaths: []string{"$.TARGETS[0].index_name", "$.TARGETS[1].name", "$.TARGETS[1].splunk_url"},
+		},
+		{
+			name: "Error_AccountListProblems",
+			setupFunc: func(c *utils.Config) {
+				c.Extensions["SALESFORCE_ACCOUNTS"] = []interface{}{
+					map[string]interface{}{"name": "sf_prod", "endpoint": "login.salesforce.com", "client_id": "id", "client_secret": "secret", "api_version": "latest"},
+					map[string]interface{}{"name": "sf_sandbox", "endpoint": "test.salesforce.com"},
+				}
+			},
+			wantPaths: []string{"$.SALESFORCE_ACCOUNTS[0].api_version", "$.SALESFORCE_ACCOUNTS[1].client_id", "$.SALESFORCE_ACCOUNTS[1].client_secret"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := newValidationTestConfig(validInput("sf_accounts"))
+			tt.setupFunc(config)
+
+			paths := validationPaths(t, config.Validate())
+			if !reflect.DeepEqual(paths, tt.wantPaths) {
+				t.Errorf("Validate() paths = %v, want %v", paths, tt.wantPaths)
+			}
+		})
+	}
+}
+
+func TestValidationErrors_Error(t *testing.T) {
+	single := utils.ValidationErrors{{Path: "$.SPLUNK_URL", Message: "is required"}}
+	if got := single.Error(); got != "$.SPLUNK_URL: is required" {
+		t.Errorf("Error() = %q", got)
+	}
+
+	multiple := utils.ValidationErrors{
+		{Path: "$.SPLUNK_URL", Message: "is required"},
+		{Path: "$.DATA_INPUTS[0].interval", Message: "must be between 60 and 86400 seconds, got 0"},
+	}
+	got := multiple.Error()
+	if !strings.HasPrefix(got, "2 configuration problems: ") || !strings.Contains(got, "$.DATA_INPUTS[0].interval") {
+		t.Errorf("Error() = %q", got)
+	}
+}
@@ -9,8 +9,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math/rand/v2"
 	"net"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -24,6 +29,11 @@ type HTTPClientInterface interface {
 	Delete(ctx context.Context, path string, headers map[string]string) (*HTTPResponse, error)
 }
 
+// HTTPStatsReporter is implemented by HTTP clients that count their requests
+type HTTPStatsReporter interface {
+	Stats() HTTPStats
+}
+
 // HTTPClient provides a wrapper around http.Client with additional utilities
 type HTTPClient struct {
 	client      *http.Client
@@ -32,6 +42,12 @@ type HTTPClient struct {
 	timeout     time.Duration
 	retryConfig RetryConfig
 	logger      Logger
+
+	// Every request goes to the host of baseURL, so these limits apply per host
+	limiter *RateLimiter
+	breaker *CircuitBreaker
+	slots   chan struct{}
+	stats   httpCounters
 }
 
 // HTTPClientConfig holds configuration for HTTP client
@@ -43,6 +59,17 @@ type HTTPClientConfig struct {
 	SkipSSLVerify   bool
 	MaxIdleConns    int
 	MaxConnsPerHost int
+
+	// RateLimit is the number of requests per second sent to the host, with bursts of up
+	// to RateBurst requests. Zero disables the limit.
+	RateLimit float64
+	RateBurst int
+	// MaxConcurrentRequests bounds the requests in flight to the host. Zero disables the bound.
+	MaxConcurrentRequests int
+	// CircuitBreakerThreshold is the number of consecutive 503 responses that stops
+	// requests to the host for CircuitBreakerCooldown. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+	CircuitBreakerCooldown  time.Duration
 }
 
 // RetryConfig holds retry configuration
@@ -50,6 +77,43 @@ type RetryConfig struct {
 	MaxRetries int
 	RetryDelay time.Duration
 	BackoffExp float64
+	// MaxDelay caps the backoff and the Retry-After wait between two attempts
+	MaxDelay time.Duration
+}
+
+// HTTPStats counts the requests of an HTTPClient
+type HTTPStats struct {
+	Requests      int64 `json:"requests"`       // Attempts sent, retries included
+	Retries       int64 `json:"retries"`        // Attempts after the first of a request
+	RateLimited   int64 `json:"rate_limited"`   // 429 Too Many Requests responses
+	Unavailable   int64 `json:"unavailable"`    // 503 Service Unavailable responses
+	CircuitOpened int64 `json:"circuit_opened"` // Times the circuit breaker opened
+	Rejected      int64 `json:"rejected"`       // Requests refused while the circuit was open
+	ThrottledMS   int64 `json:"throttled_ms"`   // Time spent waiting for the rate limiter
+}
+
+// Add returns the sum of s and other, for example to report the requests of several
+// clients as one
+func (s HTTPStats) Add(other HTTPStats) HTTPStats {
+	return HTTPStats{
+		Requests:      s.Requests + other.Requests,
+		Retries:       s.Retries + other.Retries,
+		RateLimited:   s.RateLimited + other.RateLimited,
+		Unavailable:   s.Unavailable + other.Unavailable,
+		CircuitOpened: s.CircuitOpened + other.CircuitOpened,
+		Rejected:      s.Rejected + other.Rejected,
+		ThrottledMS:   s.ThrottledMS + other.ThrottledMS,
+	}
+}
+
+// httpCounters holds the counters behind HTTPStats
+type httpCounters struct {
+	requests    atomic.Int64
+	retries     atomic.Int64
+	rateLimited atomic.Int64
+	unavailable atomic.Int64
+	rejected    atomic.Int64
+	throttled   atomic.Int64
 }
 
 // HTTPResponse represents a standardized HTTP response
@@ -82,6 +146,14 @@ func NewHTTPClient(config HTTPClientConfig) *HTTPClient {
 		config.RetryConfig.BackoffExp = 2.0
 	}
 
+	if config.RetryConfig.MaxDelay == 0 {
+		config.RetryConfig.MaxDelay = 5 * time.Minute
+	}
+
+	if config.CircuitBreakerCooldown == 0 {
+		config.CircuitBreakerCooldown = 30 * time.Second
+	}
+
 	if config.MaxIdleConns == 0 {
 		config.MaxIdleConns = 100
 	}
@@ -107,7 +179,7 @@ func NewHTTPClient(config HTTPClientConfig) *HTTPClient {
 		},
 	}
 
-	return &HTTPClient{
+	hc := &HTTPClient{
 		client: &http.Client{
 			Timeout:   config.Timeout,
 			Transport: transport,
@@ -117,6 +189,25 @@ func NewHTTPClient(config HTTPClientConfig) *HTTPClient {
 		timeout:     config.Timeout,
 		retryConfig: config.RetryConfig,
 		logger:      GetLogger(),
+		limiter:     NewRateLimiter(config.RateLimit, config.RateBurst),
+		breaker:     NewCircuitBreaker(config.CircuitBreakerThreshold, config.CircuitBreakerCooldown),
+	}
+	if config.MaxConcurrentRequests > 0 {
+		hc.slots = make(chan struct{}, config.MaxConcurrentRequests)
+	}
+	return hc
+}
+
+// Stats returns the request counters of the client
+func (hc *HTTPClient) Stats() HTTPStats {
+	return HTTPStats{
+		Requests:      hc.stats.requests.Load(),
+		Retries:       hc.stats.retries.Load(),
+		RateLimited:   hc.stats.rateLimited.Load(),
+		Unavailable:   hc.stats.unavailable.Load(),
+		CircuitOpened: int64(hc.breaker.Opened()),
+		Rejected:      hc.stats.rejected.Load(),
+		ThrottledMS:   time.Duration(hc.stats.throttled.Load()).Milliseconds(),
 	}
 }
 
@@ -173,26 +264,20 @@ func (hc *HTTPClient) makeRequest(ctx context.Context, method, path string, body
 
 // makeFormRequest performs a form-encoded HTTP request with retry logic
 func (hc *HTTPClient) makeFormRequest(ctx context.Context, method, path string, formData map[string]string, headers map[string]string) (*HTTPResponse, error) {
-	url := hc.baseURL + path
-
-	// Encode form data
-	formValues := ""
-	first := true
+	// Encode form data; values such as "+3600s" or secrets containing "&" must be escaped
+	formValues := make(url.Values, len(formData))
 	for key, value := range formData {
-		if !first {
-			formValues += "&"
-		}
-		formValues += fmt.Sprintf("%s=%s", key, value)
-		first = false
+		formValues.Set(key, value)
 	}
 
-	bodyReader := bytes.NewReader([]byte(formValues))
-	return hc.executeWithRetry(ctx, method, url, bodyReader, headers, "application/x-www-form-urlencoded")
+	bodyReader := strings.NewReader(formValues.Encode())
+	return hc.executeWithRetry(ctx, method, hc.baseURL+path, bodyReader, headers, "application/x-www-form-urlencoded")
 }
 
 // executeWithRetry handles the retry logic for HTTP requests
 func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string, bodyReader io.Reader, headers map[string]string, contentType string) (*HTTPResponse, error) {
 	var lastErr error
+	var retryAfter time.Duration
 	start := time.Now()
 
 	// Store original body for retries
@@ -207,8 +292,8 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 
 	for attempt := 0; attempt <= hc.retryConfig.MaxRetries; attempt++ {
 		if attempt > 0 {
-			delay := time.Duration(float64(hc.retryConfig.RetryDelay) *
-				pow(hc.retryConfig.BackoffExp, float64(attempt-1)))
+			delay := hc.retryDelay(attempt, retryAfter)
+			hc.stats.retries.Add(1)
 
 			hc.logger.Info("Retrying HTTP request",
 				Int("attempt", attempt+1),
@@ -220,12 +305,20 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 				return nil, ctx.Err()
 			case <-time.After(delay):
 			}
+		}
+		retryAfter = 0
 
-			// Reset body reader for retry
-			if originalBody != nil {
-				bodyReader = bytes.NewReader(originalBody)
-			}
+		if err := hc.breaker.Allow(); err != nil {
+			hc.stats.rejected.Add(1)
+			return nil, fmt.Errorf("request to %s not sent: %w", hc.baseURL, err)
+		}
+
+		waited, err := hc.limiter.Wait(ctx)
+		if err != nil {
+			hc.breaker.Release()
+			return nil, err
 		}
+		hc.stats.throttled.Add(int64(waited))
 
 		// Create new body reader for each attempt
 		var currentBodyReader io.Reader
@@ -235,6 +328,7 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 
 		req, err := http.NewRequestWithContext(ctx, method, url, currentBodyReader)
 		if err != nil {
+			hc.breaker.Release()
 			return nil, fmt.Errorf("failed to create request: %w", err)
 		}
 
@@ -254,8 +348,29 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 			req.ContentLength = int64(len(originalBody))
 		}
 
-		resp, err := hc.client.Do(req)
+		resp, responseBody, err := hc.send(ctx, req)
+		statusCode := 0
+		if resp != nil {
+			statusCode = resp.StatusCode
+		}
+		switch statusCode {
+		case http.StatusTooManyRequests:
+			hc.stats.rateLimited.Add(1)
+		case http.StatusServiceUnavailable:
+			hc.stats.unavailable.Add(1)
+		}
+		if hc.breaker.Record(statusCode) {
+			hc.logger.Warn("Circuit breaker opened after consecutive 503 responses",
+				String("host", hc.baseURL))
+			if err == nil {
+				err = NewHTTPError(statusCode, responseBody)
+			}
+			return nil, fmt.Errorf("%w for %s, last error: %w", ErrCircuitOpen, hc.baseURL, err)
+		}
 		if err != nil {
+			if resp != nil {
+				return nil, err
+			}
 			lastErr = err
 			if attempt < hc.retryConfig.MaxRetries && isRetryableError(err) {
 				continue
@@ -263,13 +378,6 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 			return nil, fmt.Errorf("HTTP request failed after %d attempts: %w", attempt+1, err)
 		}
 
-		defer resp.Body.Close()
-
-		responseBody, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to read response body: %w", err)
-		}
-
 		response := &HTTPResponse{
 			StatusCode: resp.StatusCode,
 			Headers:    resp.Header,
@@ -282,41 +390,23 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 			return response, nil
 		}
 
-		// Handle specific error codes
-		if resp.StatusCode == 409 {
-			// Conflict - resource already exists
-			return response, nil
-		}
-
-		// Check if it's a 500 error with "already in use" message
-		if resp.StatusCode == 500 {
-			if bytes.Contains(responseBody, []byte("already in use")) || bytes.Contains(responseBody, []byte("already exists")) {
-				return response, nil
-			}
-			// Check if it's a 500 error with "Not Found" or "404" - treat as resource doesn't exist
-			if bytes.Contains(responseBody, []byte("Not Found")) || bytes.Contains(responseBody, []byte("[404]")) || bytes.Contains(responseBody, []byte("Could not find object")) {
-				return response, nil
+		// Honor Retry-After on throttling: hold back every request to the host, not only this one
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			if retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); retryAfter > 0 {
+				hc.limiter.Pause(min(retryAfter, hc.retryConfig.MaxDelay))
 			}
 		}
 
-		// Retry on server errors (5xx) and rate limiting (429)
-		if (resp.StatusCode >= 500 || resp.StatusCode == 429) && attempt < hc.retryConfig.MaxRetries {
-			lastErr = fmt.Errorf("server error: %d - %s", resp.StatusCode, string(responseBody))
+		// Retry on server errors (5xx) and rate limiting (429). A 500 reporting a
+		// missing or duplicate object is final.
+		httpErr := NewHTTPError(resp.StatusCode, responseBody)
+		if httpErr.retryable() && attempt < hc.retryConfig.MaxRetries {
+			lastErr = httpErr
 			continue
 		}
 
-		// 404 Not Found or 403 Forbidden - return response without error (resource doesn't exist or no access)
-		if resp.StatusCode == 404 || resp.StatusCode == 403 {
-			return response, nil
-		}
-
-		// Client error (4xx except 404, 403) - don't retry, return error
-		if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != 429 {
-			return response, fmt.Errorf("client error: %d - %s", resp.StatusCode, string(responseBody))
-		}
-
-		// For other errors, return the response
-		return response, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(responseBody))
+		// The response is returned with the error so that callers can inspect the body
+		return response, httpErr
 	}
 
 	if lastErr != nil {
@@ -326,6 +416,69 @@ func (hc *HTTPClient) executeWithRetry(ctx context.Context, method, url string,
 	return nil, fmt.Errorf("max retries (%d) exceeded", hc.retryConfig.MaxRetries)
 }
 
+// send performs one attempt, holding a concurrency slot until the response body is read.
+// The response is nil when no response was received.
+func (hc *HTTPClient) send(ctx context.Context, req *http.Request) (*http.Response, []byte, error) {
+	if hc.slots != nil {
+		select {
+		case hc.slots <- struct{}{}:
+			defer func() { <-hc.slots }()
+		case <-ctx.Done():
+			return nil, nil, ctx.Err()
+		}
+	}
+
+	hc.stats.requests.Add(1)
+	resp, err := hc.client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return resp, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	return resp, responseBody, nil
+}
+
+// retryDelay returns the wait before the given retry: the Retry-After of the previous
+// response when it had one, otherwise exponential backoff with jitter. Both are capped
+// at MaxDelay.
+func (hc *HTTPClient) retryDelay(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, hc.retryConfig.MaxDelay)
+	}
+
+	backoff := time.Duration(float64(hc.retryConfig.RetryDelay) *
+		pow(hc.retryConfig.BackoffExp, float64(attempt-1)))
+	if backoff <= 0 || backoff > hc.retryConfig.MaxDelay {
+		backoff = hc.retryConfig.MaxDelay
+	}
+
+	// Half fixed, half random, so that parallel requests do not retry in lockstep
+	half := backoff / 2
+	return half + rand.N(half+1)
+}
+
+// parseRetryAfter parses a Retry-After header, given either in seconds or as an HTTP
+// date. It returns zero when the header is missing or invalid.
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0
+		}
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil && date.After(now) {
+		return date.Sub(now)
+	}
+	return 0
+}
+
 // JSON unmarshals the response body as JSON
 func (hr *HTTPResponse) JSON(v interface{}) error {
 	return json.Unmarshal(hr.Body, v)
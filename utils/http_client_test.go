@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -392,6 +393,23 @@ func TestHTTPClient_PostForm(t *testing.T) {
 		require.NoError(t, err)
 		assert.True(t, resp.IsSuccess())
 	})
+
+	t.Run("Success_EscapesReservedCharacters", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			assert.Equal(t, "+3600s", r.PostForm.Get("expires_on"))
+			assert.Equal(t, "a&b=c %d", r.PostForm.Get("client_secret"))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := utils.NewHTTPClient(utils.HTTPClientConfig{BaseURL: server.URL})
+		formData := map[string]string{"expires_on": "+3600s", "client_secret": "a&b=c %d"}
+
+		resp, err := client.PostForm(context.Background(), "/test", formData, nil)
+		require.NoError(t, err)
+		assert.True(t, resp.IsSuccess())
+	})
 }
 
 func TestHTTPClient_Put(t *testing.T) {
@@ -670,7 +688,7 @@ func TestHTTPClient_RetryLogic(t *testing.T) {
 }
 
 func TestHTTPClient_SpecialStatusCodes(t *testing.T) {
-	t.Run("Success_Handle409Conflict", func(t *testing.T) {
+	t.Run("Error_Handle409Conflict", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusConflict)
 			w.Write([]byte(`{"error": "resource already exists"}`))
@@ -681,13 +699,13 @@ func TestHTTPClient_SpecialStatusCodes(t *testing.T) {
 		client := utils.NewHTTPClient(config)
 		ctx := context.Background()
 
-		// 409 should be treated as success (resource already exists)
+		// 409 is returned with the response as an "already exists" error
 		resp, err := client.Get(ctx, "/test", nil)
-		require.NoError(t, err)
+		require.ErrorIs(t, err, utils.ErrAlreadyExists)
 		assert.Equal(t, http.StatusConflict, resp.StatusCode)
 	})
 
-	t.Run("Success_Handle404NotFound", func(t *testing.T) {
+	t.Run("Error_Handle404NotFound", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusNotFound)
 			w.Write([]byte(`{"error": "not found"}`))
@@ -698,13 +716,13 @@ func TestHTTPClient_SpecialStatusCodes(t *testing.T) {
 		client := utils.NewHTTPClient(config)
 		ctx := context.Background()
 
-		// 404 should return response without error
+		// 404 is returned with the response as a "not found" error
 		resp, err := client.Get(ctx, "/test", nil)
-		require.NoError(t, err)
+		require.ErrorIs(t, err, utils.ErrNotFound)
 		assert.Equal(t, http.StatusNotFound, resp.StatusCode)
 	})
 
-	t.Run("Success_Handle500WithAlreadyExists", func(t *testing.T) {
+	t.Run("Error_Handle500WithAlreadyExists", func(t *testing.T) {
 		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			w.WriteHeader(http.StatusInternalServerError)
 			w.Write([]byte(`{"error": "resource already exists"}`))
@@ -715,11 +733,29 @@ func TestHTTPClient_SpecialStatusCodes(t *testing.T) {
 		client := utils.NewHTTPClient(config)
 		ctx := context.Background()
 
-		// 500 with "already exists" message should return without error
+		// 500 with "already exists" message is classified by its message and not retried
 		resp, err := client.Get(ctx, "/test", nil)
-		require.NoError(t, err)
+		require.ErrorIs(t, err, utils.ErrAlreadyExists)
 		assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
 	})
+
+	t.Run("Error_Handle403Forbidden", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"messages":[{"type":"ERROR","text":"You (user=migration) do not have permission to perform this operation (requires capability: edit_indexes)."}]}`))
+		}))
+		defer server.Close()
+
+		client := utils.NewHTTPClient(utils.HTTPClientConfig{BaseURL: server.URL, RetryConfig: utils.RetryConfig{MaxRetries: 2, RetryDelay: time.Millisecond}})
+
+		// 403 is never retried and never hidden
+		_, err := client.Get(context.Background(), "/test", nil)
+		require.ErrorIs(t, err, utils.ErrForbidden)
+		assert.Contains(t, err.Error(), "requires capability: edit_indexes")
+		assert.Equal(t, 1, attempts)
+	})
 }
 
 func TestHTTPClient_BackoffRetry(t *testing.T) {
@@ -840,3 +876,208 @@ func TestHTTPClient_MaxRetriesExceeded(t *testing.T) {
 		assert.Equal(t, 3, attempts) // Initial + 2 retries
 	})
 }
+
+func TestHTTPClient_ErrorsRedactSecrets(t *testing.T) {
+	utils.RegisterSecret("registered-Secret-value")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"messages":[{"type":"ERROR","text":"invalid registered-Secret-value"}],"client_secret_oauth_credentials":"leaked-from-body"}`))
+	}))
+	defer server.Close()
+
+	client := utils.NewHTTPClient(utils.HTTPClientConfig{BaseURL: server.URL})
+	_, err := client.PostForm(context.Background(), "/test", map[string]string{"client_secret": "form-secret"}, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "status 400")
+	assert.NotContains(t, err.Error(), "registered-Secret-value")
+	assert.NotContains(t, err.Error(), "leaked-from-body")
+}
+
+func TestHTTPClient_RetryAfter(t *testing.T) {
+	t.Run("Success_WaitsForRetryAfter", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := utils.NewHTTPClient(utils.HTTPClientConfig{
+			BaseURL:     server.URL,
+			RetryConfig: utils.RetryConfig{MaxRetries: 1, RetryDelay: time.Millisecond},
+		})
+
+		start := time.Now()
+		resp, err := client.Get(context.Background(), "/test", nil)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.GreaterOrEqual(t, time.Since(start), time.Second, "Retry-After ignored")
+
+		stats := client.Stats()
+		assert.Equal(t, int64(2), stats.Requests)
+		assert.Equal(t, int64(1), stats.Retries)
+		assert.Equal(t, int64(1), stats.RateLimited)
+	})
+
+	t.Run("Success_RetryAfterCappedByMaxDelay", func(t *testing.T) {
+		attempts := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			attempts++
+			if attempts == 1 {
+				w.Header().Set("Retry-After", time.Now().Add(time.Hour).UTC().Format(http.TimeFormat))
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := utils.NewHTTPClient(utils.HTTPClientConfig{
+			BaseURL:     server.URL,
+			RetryConfig: utils.RetryConfig{MaxRetries: 1, RetryDelay: time.Millisecond, MaxDelay: 20 * time.Millisecond},
+		})
+
+		start := time.Now()
+		_, err := client.Get(context.Background(), "/test", nil)
+		require.NoError(t, err)
+		assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond)
+		assert.Less(t, time.Since(start), 5*time.Second)
+		assert.Equal(t, int64(1), client.Stats().Unavailable)
+	})
+}
+
+func TestHTTPClient_CircuitBreaker(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+		w.Write([]byte(`{"messages":[{"type":"ERROR","text":"Service Unavailable"}]}`))
+	}))
+	defer server.Close()
+
+	client := utils.NewHTTPClient(utils.HTTPClientConfig{
+		BaseURL:                 server.URL,
+		RetryConfig:             utils.RetryConfig{MaxRetries: 5, RetryDelay: time.Millisecond},
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  time.Hour,
+	})
+
+	_, err := client.Get(context.Background(), "/test", nil)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, utils.ErrCircuitOpen)
+	assert.Contains(t, err.Error(), "Service Unavailable")
+	assert.Equal(t, 2, attempts, "requests sent after the circuit opened")
+
+	_, err = client.Get(context.Background(), "/other", nil)
+	assert.ErrorIs(t, err, utils.ErrCircuitOpen)
+	assert.Equal(t, 2, attempts)
+
+	stats := client.Stats()
+	assert.Equal(t, int64(1), stats.CircuitOpened)
+	assert.Equal(t, int64(1), stats.Rejected)
+	assert.Equal(t, int64(2), stats.Unavailable)
+}
+
+func TestHTTPStats_Add(t *testing.T) {
+	management := utils.HTTPStats{Requests: 10, Retries: 2, Unavailable: 1, ThrottledMS: 40}
+	acs := utils.HTTPStats{Requests: 3, RateLimited: 1, CircuitOpened: 1, Rejected: 2, ThrottledMS: 5}
+
+	assert.Equal(t, utils.HTTPStats{Requests: 13, Retries: 2, RateLimited: 1, Unavailable: 1, CircuitOpened: 1, Rejected: 2, ThrottledMS: 45}, management.Add(acs))
+}
+
+func TestHTTPClient_CircuitBreakerCancelledProbe(t *testing.T) {
+	var mu sync.Mutex
+	status := http.StatusServiceUnavailable
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		w.WriteHeader(status)
+	}))
+	defer server.Close()
+
+	client := utils.NewHTTPClient(utils.HTTPClientConfig{
+		BaseURL:                 server.URL,
+		RateLimit:               10,
+		RateBurst:               1,
+		CircuitBreakerThreshold: 1,
+		CircuitBreakerCooldown:  20 * time.Millisecond,
+	})
+
+	_, err := client.Get(context.Background(), "/test", nil)
+	require.ErrorIs(t, err, utils.ErrCircuitOpen)
+	time.Sleep(25 * time.Millisecond)
+
+	mu.Lock()
+	status = http.StatusOK
+	mu.Unlock()
+
+	// The probe is cancelled while waiting for the rate limiter, before it is sent
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Millisecond)
+	defer cancel()
+	_, err = client.Get(ctx, "/test", nil)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	_, err = client.Get(context.Background(), "/test", nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), client.Stats().CircuitOpened)
+}
+
+func TestHTTPClient_Throttling(t *testing.T) {
+	t.Run("Success_RateLimit", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := utils.NewHTTPClient(utils.HTTPClientConfig{BaseURL: server.URL, RateLimit: 20, RateBurst: 1})
+
+		start := time.Now()
+		for i := 0; i < 5; i++ {
+			_, err := client.Get(context.Background(), "/test", nil)
+			require.NoError(t, err)
+		}
+		// Four requests beyond the burst at 20 per second take about 200ms
+		assert.GreaterOrEqual(t, time.Since(start), 150*time.Millisecond)
+		assert.Greater(t, client.Stats().ThrottledMS, int64(0))
+	})
+
+	t.Run("Success_MaxConcurrentRequests", func(t *testing.T) {
+		var mu sync.Mutex
+		inFlight, maxInFlight := 0, 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+
+			time.Sleep(10 * time.Millisecond)
+
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		client := utils.NewHTTPClient(utils.HTTPClientConfig{BaseURL: server.URL, MaxConcurrentRequests: 2})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := client.Get(context.Background(), "/test", nil)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.LessOrEqual(t, maxInFlight, 2)
+		assert.Equal(t, int64(8), client.Stats().Requests)
+	})
+}
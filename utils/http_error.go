@@ -0,0 +1,174 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Kinds of failed Splunk requests. An *HTTPError unwraps to one of them, so callers
+// test the kind with errors.Is and read the status with errors.As.
+var (
+	ErrNotFound      = errors.New("not found")
+	ErrAlreadyExists = errors.New("already exists")
+	ErrUnauthorized  = errors.New("unauthorized")
+	ErrForbidden     = errors.New("forbidden")
+	ErrRateLimited   = errors.New("rate limited")
+	ErrServer        = errors.New("server error")
+)
+
+// embeddedStatusPattern finds the status a REST handler reports inside a 500 message,
+// as in "REST Error [404]: Not Found -- Could not find object"
+var embeddedStatusPattern = regexp.MustCompile(`\[(\d{3})\]`)
+
+// HTTPError is a non-2xx response from Splunk
+type HTTPError struct {
+	// StatusCode is the HTTP status of the response
+	StatusCode int
+	// Messages are the texts of the response's "messages" array, the message of an
+	// Admin Config Service error, or the body otherwise. They are redacted.
+	Messages []string
+	// Kind is ErrNotFound, ErrAlreadyExists, ErrUnauthorized, ErrForbidden,
+	// ErrRateLimited or ErrServer, or nil for other client errors
+	Kind error
+}
+
+// NewHTTPError classifies a failed response from its status and Splunk messages.
+// Splunk's add-on handlers answer 500 for missing or duplicate objects and give the
+// real status in the message, which takes precedence.
+func NewHTTPError(statusCode int, body []byte) *HTTPError {
+	messages := splunkMessages(body)
+	return &HTTPError{
+		StatusCode: statusCode,
+		Messages:   messages,
+		Kind:       classifyHTTPError(statusCode, messages),
+	}
+}
+
+// NewMessageError returns the error for an ERROR message in a Splunk response whose
+// status did not report the failure. A duplicate object is classified as
+// ErrAlreadyExists; other messages have no kind.
+func NewMessageError(statusCode int, message string) *HTTPError {
+	var kind error
+	if alreadyExistsMessage(message) {
+		kind = ErrAlreadyExists
+	}
+	return &HTTPError{
+		StatusCode: statusCode,
+		Messages:   []string{Redact(message)},
+		Kind:       kind,
+	}
+}
+
+// Error returns the status and the Splunk messages
+func (e *HTTPError) Error() string {
+	if len(e.Messages) == 0 {
+		return fmt.Sprintf("status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("status %d - %s", e.StatusCode, strings.Join(e.Messages, "; "))
+}
+
+// Unwrap returns the kind of the error
+func (e *HTTPError) Unwrap() error {
+	return e.Kind
+}
+
+// HTTPStatusCode returns the status of the *HTTPError in err's chain, or 0 when there is none
+func HTTPStatusCode(err error) int {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode
+	}
+	return 0
+}
+
+// retryable reports whether the request may succeed when sent again
+func (e *HTTPError) retryable() bool {
+	return e.Kind == ErrServer || e.Kind == ErrRateLimited
+}
+
+// splunkMessages returns the redacted texts of a Splunk "messages" array, the message
+// of an ACS error such as {"code":"404-index-not-found","message":"..."}, or the
+// trimmed body when it is neither
+func splunkMessages(body []byte) []string {
+	var response struct {
+		Messages []struct {
+			Type string `json:"type"`
+			Text string `json:"text"`
+		} `json:"messages"`
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &response); err == nil && len(response.Messages) > 0 {
+		messages := make([]string, 0, len(response.Messages))
+		for _, msg := range response.Messages {
+			if msg.Text != "" {
+				messages = append(messages, Redact(msg.Text))
+			}
+		}
+		if len(messages) > 0 {
+			return messages
+		}
+	}
+	if response.Message != "" {
+		return []string{Redact(response.Message)}
+	}
+
+	if text := strings.TrimSpace(string(body)); text != "" {
+		return []string{Redact(text)}
+	}
+	return nil
+}
+
+// classifyHTTPError maps a status and its messages to an error kind. A 500 counts as
+// not found only when the add-on says "Could not find object" or embeds a [404]; any
+// other "not found" text may come from a failure inside the handler.
+func classifyHTTPError(statusCode int, messages []string) error {
+	if statusCode == http.StatusInternalServerError {
+		for _, msg := range messages {
+			if alreadyExistsMessage(msg) {
+				return ErrAlreadyExists
+			}
+			if strings.Contains(strings.ToLower(msg), "could not find object") {
+				return ErrNotFound
+			}
+			if match := embeddedStatusPattern.FindStringSubmatch(msg); match != nil {
+				if embedded, _ := strconv.Atoi(match[1]); embedded != statusCode {
+					if kind := statusKind(embedded); kind != ErrServer {
+						return kind
+					}
+				}
+			}
+		}
+	}
+	return statusKind(statusCode)
+}
+
+// alreadyExistsMessage reports whether a Splunk message rejects a duplicate object
+func alreadyExistsMessage(msg string) bool {
+	lower := strings.ToLower(msg)
+	return strings.Contains(lower, "already exists") || strings.Contains(lower, "already in use")
+}
+
+// statusKind maps an HTTP status to an error kind
+func statusKind(statusCode int) error {
+	switch {
+	case statusCode == http.StatusNotFound:
+		return ErrNotFound
+	case statusCode == http.StatusConflict:
+		return ErrAlreadyExists
+	case statusCode == http.StatusUnauthorized:
+		return ErrUnauthorized
+	case statusCode == http.StatusForbidden:
+		return ErrForbidden
+	case statusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case statusCode >= 500:
+		return ErrServer
+	default:
+		return nil
+	}
+}
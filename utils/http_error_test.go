@@ -0,0 +1,86 @@
+package utils_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/utils"
+)
+
+func TestNewHTTPError(t *testing.T) {
+	tests := []struct {
+		name       string
+		statusCode int
+		body       string
+		kind       error
+		message    string
+	}{
+		{name: "NotFound", statusCode: 404, body: `{"messages":[{"type":"ERROR","text":"Could not find object id=Account"}]}`, kind: utils.ErrNotFound, message: "status 404 - Could not find object id=Account"},
+		{name: "Conflict", statusCode: 409, body: `{"messages":[{"type":"ERROR","text":"Object id=main exists."}]}`, kind: utils.ErrAlreadyExists},
+		{name: "Unauthorized", statusCode: 401, body: `{"messages":[{"type":"WARN","text":"call not properly authenticated"}]}`, kind: utils.ErrUnauthorized},
+		{name: "Forbidden", statusCode: 403, body: `{"messages":[{"type":"ERROR","text":"You do not have permission"}]}`, kind: utils.ErrForbidden},
+		{name: "RateLimited", statusCode: 429, body: ``, kind: utils.ErrRateLimited, message: "status 429"},
+		{name: "ServerError", statusCode: 503, body: `Service Unavailable`, kind: utils.ErrServer, message: "status 503 - Service Unavailable"},
+		{name: "BadRequest", statusCode: 400, body: `{"messages":[{"type":"ERROR","text":"Argument \"x\" is not supported"}]}`, kind: nil},
+		{name: "500_AlreadyExists", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"Name is already in use"}]}`, kind: utils.ErrAlreadyExists},
+		{name: "500_NotFound", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"REST Error [404]: Not Found -- Could not find object"}]}`, kind: utils.ErrNotFound},
+		{name: "500_CouldNotFindObject", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"Could not find object id=sf_accounts"}]}`, kind: utils.ErrNotFound},
+		{name: "500_EmbeddedNotFound", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"REST Error [404]: Not Found"}]}`, kind: utils.ErrNotFound},
+		{name: "500_OtherNotFoundText", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"Unexpected error: module not found"}]}`, kind: utils.ErrServer},
+		{name: "500_EmbeddedForbidden", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"REST Error [403]: Forbidden"}]}`, kind: utils.ErrForbidden},
+		{name: "500_Plain", statusCode: 500, body: `{"messages":[{"type":"ERROR","text":"Unexpected error \"KeyError\""}]}`, kind: utils.ErrServer},
+		{name: "ACS_NotFound", statusCode: 404, body: `{"code":"404-index-not-found","message":"index salesforce not found"}`, kind: utils.ErrNotFound, message: "status 404 - index salesforce not found"},
+		{name: "404_MessageDoesNotOverrideStatus", statusCode: 404, body: `{"messages":[{"type":"ERROR","text":"already exists"}]}`, kind: utils.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpErr := utils.NewHTTPError(tt.statusCode, []byte(tt.body))
+
+			assert.Equal(t, tt.statusCode, httpErr.StatusCode)
+			assert.Equal(t, tt.kind, httpErr.Kind)
+			if tt.kind != nil {
+				assert.ErrorIs(t, httpErr, tt.kind)
+			}
+			if tt.message != "" {
+				assert.Equal(t, tt.message, httpErr.Error())
+			}
+		})
+	}
+}
+
+func TestNewMessageError(t *testing.T) {
+	duplicate := utils.NewMessageError(200, "An object with name=sf_accounts already exists")
+	assert.ErrorIs(t, duplicate, utils.ErrAlreadyExists)
+	assert.Equal(t, "status 200 - An object with name=sf_accounts already exists", duplicate.Error())
+
+	other := utils.NewMessageError(200, "Invalid value for interval")
+	assert.Nil(t, other.Kind)
+	assert.Equal(t, 200, utils.HTTPStatusCode(other))
+}
+
+func TestHTTPError_Wrapped(t *testing.T) {
+	err := fmt.Errorf("failed to create index: %w", utils.NewHTTPError(403, []byte(`{"messages":[{"type":"ERROR","text":"denied"}]}`)))
+
+	assert.ErrorIs(t, err, utils.ErrForbidden)
+	assert.NotErrorIs(t, err, utils.ErrUnauthorized)
+	assert.Equal(t, 403, utils.HTTPStatusCode(err))
+	assert.Equal(t, "failed to create index: status 403 - denied", err.Error())
+
+	var httpErr *utils.HTTPError
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, []string{"denied"}, httpErr.Messages)
+
+	assert.Equal(t, 0, utils.HTTPStatusCode(errors.New("connection refused")))
+}
+
+func TestHTTPError_RedactsMessages(t *testing.T) {
+	utils.RegisterSecret("http-error-Secret-value")
+	httpErr := utils.NewHTTPError(400, []byte(`{"messages":[{"type":"ERROR","text":"bad password http-error-Secret-value"}]}`))
+
+	assert.NotContains(t, httpErr.Error(), "http-error-Secret-value")
+}
@@ -2,6 +2,9 @@
 package utils
 
 import (
+	"fmt"
+	"io"
+	"strings"
 	"time"
 
 	"go.uber.org/zap"
@@ -61,6 +64,8 @@ type LoggerConfig struct {
 	ServiceName string
 	InstanceID  string
 	Development bool
+	// Output receives the log entries instead of standard error when set
+	Output io.Writer
 }
 
 // field implements the Field interface using Zap fields internally
@@ -132,23 +137,23 @@ type zapLogger struct {
 }
 
 func (l *zapLogger) Info(msg string, fields ...Field) {
-	l.zap.Info(msg, l.convertFields(fields)...)
+	l.zap.Info(Redact(msg), l.convertFields(fields)...)
 }
 
 func (l *zapLogger) Warn(msg string, fields ...Field) {
-	l.zap.Warn(msg, l.convertFields(fields)...)
+	l.zap.Warn(Redact(msg), l.convertFields(fields)...)
 }
 
 func (l *zapLogger) Error(msg string, fields ...Field) {
-	l.zap.Error(msg, l.convertFields(fields)...)
+	l.zap.Error(Redact(msg), l.convertFields(fields)...)
 }
 
 func (l *zapLogger) Debug(msg string, fields ...Field) {
-	l.zap.Debug(msg, l.convertFields(fields)...)
+	l.zap.Debug(Redact(msg), l.convertFields(fields)...)
 }
 
 func (l *zapLogger) Fatal(msg string, fields ...Field) {
-	l.zap.Fatal(msg, l.convertFields(fields)...)
+	l.zap.Fatal(Redact(msg), l.convertFields(fields)...)
 }
 
 func (l *zapLogger) With(fields ...Field) Logger {
@@ -157,10 +162,21 @@ func (l *zapLogger) With(fields ...Field) Logger {
 	}
 }
 
+// convertFields converts the fields to Zap fields, redacting secrets in string and
+// error values
 func (l *zapLogger) convertFields(fields []Field) []zap.Field {
 	zapFields := make([]zap.Field, len(fields))
 	for i, f := range fields {
-		zapFields[i] = f.(field).zapField
+		zapField := f.(field).zapField
+		switch zapField.Type {
+		case zapcore.StringType:
+			zapField.String = Redact(zapField.String)
+		case zapcore.ErrorType:
+			if err, ok := zapField.Interface.(error); ok {
+				zapField.Interface = RedactError(err)
+			}
+		}
+		zapFields[i] = zapField
 	}
 	return zapFields
 }
@@ -201,6 +217,18 @@ func NewLogger(config LoggerConfig) (Logger, error) {
 		"instance_id": config.InstanceID,
 	}
 
+	if config.Output != nil {
+		var encoder zapcore.Encoder
+		if config.Development {
+			encoder = zapcore.NewConsoleEncoder(zapConfig.EncoderConfig)
+		} else {
+			encoder = zapcore.NewJSONEncoder(zapConfig.EncoderConfig)
+		}
+		core := zapcore.NewCore(encoder, zapcore.AddSync(config.Output), zapConfig.Level)
+		fields := []zap.Field{zap.String("service", config.ServiceName), zap.String("instance_id", config.InstanceID)}
+		return &zapLogger{zap: zap.New(core).With(fields...)}, nil
+	}
+
 	zapLog, err := zapConfig.Build()
 	if err != nil {
 		return nil, err
@@ -236,15 +264,17 @@ var globalLogger Logger
 
 // InitializeGlobalLogger initializes the global logger
 func InitializeGlobalLogger(serviceName, instanceID string, development bool) error {
-	var logger Logger
-	var err error
-
-	if development {
-		logger, err = NewDevelopmentLogger(serviceName, instanceID)
-	} else {
-		logger, err = NewProductionLogger(serviceName, instanceID)
-	}
+	return InitializeGlobalLoggerWithLevel(serviceName, instanceID, development, InfoLevel)
+}
 
+// InitializeGlobalLoggerWithLevel initializes the global logger at the given level
+func InitializeGlobalLoggerWithLevel(serviceName, instanceID string, development bool, level LogLevel) error {
+	logger, err := NewLogger(LoggerConfig{
+		Level:       level,
+		ServiceName: serviceName,
+		InstanceID:  instanceID,
+		Development: development,
+	})
 	if err != nil {
 		return err
 	}
@@ -253,6 +283,22 @@ func InitializeGlobalLogger(serviceName, instanceID string, development bool) er
 	return nil
 }
 
+// ParseLogLevel converts a level name (debug, info, warn, error) into a LogLevel
+func ParseLogLevel(level string) (LogLevel, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return DebugLevel, nil
+	case "", "info":
+		return InfoLevel, nil
+	case "warn", "warning":
+		return WarnLevel, nil
+	case "error":
+		return ErrorLevel, nil
+	default:
+		return InfoLevel, fmt.Errorf("invalid log level %q (must be debug, info, warn or error)", level)
+	}
+}
+
 // GetLogger returns the global logger instance
 func GetLogger() Logger {
 	if globalLogger == nil {
@@ -297,6 +297,29 @@ func TestInitializeGlobalLogger(t *testing.T) {
 	})
 }
 
+func TestParseLogLevel(t *testing.T) {
+	t.Run("Success_KnownLevels", func(t *testing.T) {
+		cases := map[string]utils.LogLevel{
+			"debug": utils.DebugLevel,
+			"INFO":  utils.InfoLevel,
+			"":      utils.InfoLevel,
+			"warn":  utils.WarnLevel,
+			"error": utils.ErrorLevel,
+		}
+		for input, expected := range cases {
+			level, err := utils.ParseLogLevel(input)
+			require.NoError(t, err, input)
+			assert.Equal(t, expected, level, input)
+		}
+	})
+
+	t.Run("Error_UnknownLevel", func(t *testing.T) {
+		_, err := utils.ParseLogLevel("verbose")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "verbose")
+	})
+}
+
 func TestLoggerWith(t *testing.T) {
 	t.Run("Success_AddsField", func(t *testing.T) {
 		logger := utils.GetLogger()
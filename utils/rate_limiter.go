@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter is a token bucket limiting the requests sent to one host. Besides the
+// steady rate it can be paused, so that a Retry-After answer holds back every request
+// to the host and not only the one that was throttled.
+type RateLimiter struct {
+	mu          sync.Mutex
+	rate        float64 // tokens per second; zero means unlimited
+	burst       float64
+	tokens      float64
+	last        time.Time
+	pausedUntil time.Time
+	now         func() time.Time
+}
+
+// NewRateLimiter creates a limiter allowing rate requests per second with bursts of up
+// to burst requests. A rate of zero or less disables the limit; the limiter then only
+// honors pauses. A burst below one is raised to one.
+func NewRateLimiter(rate float64, burst int) *RateLimiter {
+	if rate < 0 {
+		rate = 0
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &RateLimiter{
+		rate:   rate,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		now:    time.Now,
+	}
+}
+
+// Wait blocks until a request may be sent and returns how long it waited. The token is
+// taken even when ctx is cancelled during the wait.
+func (l *RateLimiter) Wait(ctx context.Context) (time.Duration, error) {
+	delay := l.reserve()
+	if delay <= 0 {
+		return 0, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return 0, ctx.Err()
+	case <-timer.C:
+		return delay, nil
+	}
+}
+
+// Pause holds back every request for d, unless a longer pause is already in effect
+func (l *RateLimiter) Pause(d time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until := l.now().Add(d); until.After(l.pausedUntil) {
+		l.pausedUntil = until
+	}
+}
+
+// reserve takes a token and returns how long the caller must wait before using it
+func (l *RateLimiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	var delay time.Duration
+	if l.rate > 0 {
+		if !l.last.IsZero() {
+			l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		}
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+	if pause := l.pausedUntil.Sub(now); pause > delay {
+		delay = pause
+	}
+	return delay
+}
@@ -0,0 +1,63 @@
+package utils_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"salesforce-splunk-migration/utils"
+)
+
+func TestRateLimiter_Wait(t *testing.T) {
+	t.Run("Success_BurstThenRate", func(t *testing.T) {
+		limiter := utils.NewRateLimiter(50, 2)
+		ctx := context.Background()
+
+		start := time.Now()
+		for i := 0; i < 2; i++ {
+			waited, err := limiter.Wait(ctx)
+			require.NoError(t, err)
+			assert.Zero(t, waited, "burst request %d waited", i)
+		}
+		for i := 0; i < 2; i++ {
+			waited, err := limiter.Wait(ctx)
+			require.NoError(t, err)
+			assert.Greater(t, waited, time.Duration(0))
+		}
+		// Two requests beyond the burst at 50 per second take about 40ms
+		assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	})
+
+	t.Run("Success_Unlimited", func(t *testing.T) {
+		limiter := utils.NewRateLimiter(0, 0)
+		for i := 0; i < 100; i++ {
+			waited, err := limiter.Wait(context.Background())
+			require.NoError(t, err)
+			assert.Zero(t, waited)
+		}
+	})
+
+	t.Run("Success_PauseHoldsBackUnlimited", func(t *testing.T) {
+		limiter := utils.NewRateLimiter(0, 0)
+		limiter.Pause(30 * time.Millisecond)
+		limiter.Pause(time.Millisecond) // A shorter pause does not cut the current one
+
+		waited, err := limiter.Wait(context.Background())
+		require.NoError(t, err)
+		assert.Greater(t, waited, 20*time.Millisecond)
+	})
+
+	t.Run("Error_ContextCancelled", func(t *testing.T) {
+		limiter := utils.NewRateLimiter(1, 1)
+		_, err := limiter.Wait(context.Background())
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = limiter.Wait(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -0,0 +1,167 @@
+package utils
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// minRedactedSecretLength is the shortest value RegisterSecret masks. Masking shorter
+// values would garble unrelated text.
+const minRedactedSecretLength = 4
+
+// secretFormKeys are request and response keys whose values are always masked
+var secretFormKeys = []string{
+	"password",
+	"passwd",
+	"proxy_password",
+	"client_secret",
+	"client_secret_oauth_credentials",
+	"token",
+	"access_token",
+	"refresh_token",
+	"id_token",
+	"sessionKey",
+	"session_key",
+	"api_key",
+}
+
+var (
+	secretKeyAlternation = strings.Join(secretFormKeys, "|")
+
+	// "key": "value" in JSON bodies
+	jsonSecretPattern = regexp.MustCompile(`(?i)("(?:` + secretKeyAlternation + `)"\s*:\s*")(?:[^"\\]|\\.)*(")`)
+	// key=value in form-encoded bodies and query strings
+	formSecretPattern = regexp.MustCompile(`(?i)((?:^|[?&\s])(?:` + secretKeyAlternation + `)=)[^&\s]*`)
+	// <s:key name="key">value</s:key> in Splunk Atom responses
+	atomSecretPattern = regexp.MustCompile(`(?i)(<s:key name="(?:` + secretKeyAlternation + `)">)[^<]*`)
+	// Authorization header values and bearer tokens
+	authHeaderPattern = regexp.MustCompile(`(?i)(Authorization"?\s*[:=]\s*"?(?:Basic|Bearer|Splunk)\s+)[^\s",]+`)
+	bearerPattern     = regexp.MustCompile(`(?i)\b(Bearer\s+)[A-Za-z0-9._~+/=-]+`)
+	// JSON Web Tokens, such as Splunk authentication tokens
+	jwtPattern = regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`)
+)
+
+// Redactor masks secrets in text. It masks every registered secret value as well as
+// bearer tokens, JWTs and the values of known secret keys.
+type Redactor struct {
+	mu       sync.RWMutex
+	secrets  map[string]bool
+	replacer *strings.Replacer
+}
+
+// NewRedactor creates a redactor without registered secrets
+func NewRedactor() *Redactor {
+	return &Redactor{secrets: make(map[string]bool)}
+}
+
+// Register adds secret values to mask. Empty values, values shorter than four
+// characters and the RedactedSecret placeholder are ignored.
+func (r *Redactor) Register(values ...string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	changed := false
+	for _, value := range values {
+		if len(value) < minRedactedSecretLength || value == RedactedSecret {
+			continue
+		}
+		// Form bodies and URLs carry the escaped value
+		for _, variant := range []string{value, url.QueryEscape(value)} {
+			if !r.secrets[variant] {
+				r.secrets[variant] = true
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return
+	}
+
+	// Longer secrets first, so a secret containing another is masked whole
+	secrets := make([]string, 0, len(r.secrets))
+	for secret := range r.secrets {
+		secrets = append(secrets, secret)
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		if len(secrets[i]) != len(secrets[j]) {
+			return len(secrets[i]) > len(secrets[j])
+		}
+		return secrets[i] < secrets[j]
+	})
+	pairs := make([]string, 0, 2*len(secrets))
+	for _, secret := range secrets {
+		pairs = append(pairs, secret, RedactedSecret)
+	}
+	r.replacer = strings.NewReplacer(pairs...)
+}
+
+// Redact returns s with every secret masked
+func (r *Redactor) Redact(s string) string {
+	if s == "" {
+		return s
+	}
+
+	r.mu.RLock()
+	replacer := r.replacer
+	r.mu.RUnlock()
+	if replacer != nil {
+		s = replacer.Replace(s)
+	}
+
+	s = jsonSecretPattern.ReplaceAllString(s, "${1}"+RedactedSecret+"${2}")
+	s = formSecretPattern.ReplaceAllString(s, "${1}"+RedactedSecret)
+	s = atomSecretPattern.ReplaceAllString(s, "${1}"+RedactedSecret)
+	s = authHeaderPattern.ReplaceAllString(s, "${1}"+RedactedSecret)
+	s = bearerPattern.ReplaceAllString(s, "${1}"+RedactedSecret)
+	s = jwtPattern.ReplaceAllString(s, RedactedSecret)
+	return s
+}
+
+// RedactError wraps err so that its message is redacted. errors.Is and errors.As
+// still see the original error.
+func (r *Redactor) RedactError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := err.(*redactedError); ok {
+		return err
+	}
+	return &redactedError{err: err, redactor: r}
+}
+
+// redactedError is an error whose message is redacted
+type redactedError struct {
+	err      error
+	redactor *Redactor
+}
+
+func (e *redactedError) Error() string {
+	return e.redactor.Redact(e.err.Error())
+}
+
+func (e *redactedError) Unwrap() error {
+	return e.err
+}
+
+// defaultRedactor is used by the logger and by the package-level functions
+var defaultRedactor = NewRedactor()
+
+// RegisterSecret adds secret values that the logger, HTTP client errors and run
+// reports must never show. LoadConfig registers every secret of the configuration.
+func RegisterSecret(values ...string) {
+	defaultRedactor.Register(values...)
+}
+
+// Redact masks registered secrets, bearer tokens, JWTs and the values of known secret
+// keys in s
+func Redact(s string) string {
+	return defaultRedactor.Redact(s)
+}
+
+// RedactError wraps err so that its message is redacted
+func RedactError(err error) error {
+	return defaultRedactor.RedactError(err)
+}
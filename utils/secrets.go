@@ -0,0 +1,268 @@
+package utils
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Secret reference schemes accepted in configuration values, e.g.
+// "SPLUNK_PASSWORD": "vault:secret/data/splunk#password"
+const (
+	SecretSchemeVault = "vault"
+	SecretSchemeFile  = "file"
+	SecretSchemeEnv   = "env"
+)
+
+// SecretProvider resolves the reference part of a "scheme:reference" configuration value
+type SecretProvider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretResolver resolves configuration values through the provider registered for
+// their scheme. Values without a registered scheme are returned unchanged.
+type SecretResolver struct {
+	providers map[string]SecretProvider
+}
+
+// NewSecretResolver creates a resolver without any providers
+func NewSecretResolver() *SecretResolver {
+	return &SecretResolver{providers: make(map[string]SecretProvider)}
+}
+
+// DefaultSecretResolver returns a resolver for env:, file: and vault: references.
+// Vault is configured from VAULT_ADDR, VAULT_TOKEN and VAULT_NAMESPACE when a vault:
+// reference is first resolved.
+func DefaultSecretResolver() *SecretResolver {
+	r := NewSecretResolver()
+	r.Register(SecretSchemeEnv, EnvSecretProvider{})
+	r.Register(SecretSchemeFile, FileSecretProvider{})
+	r.Register(SecretSchemeVault, &lazyVaultProvider{})
+	return r
+}
+
+// Register sets the provider for a scheme, replacing any previous one
+func (r *SecretResolver) Register(scheme string, provider SecretProvider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve returns the secret a value refers to. The second result reports whether
+// the value was a reference.
+func (r *SecretResolver) Resolve(ctx context.Context, value string) (string, bool, error) {
+	scheme, ref, found := strings.Cut(value, ":")
+	if !found {
+		return value, false, nil
+	}
+	provider, ok := r.providers[scheme]
+	if !ok {
+		return value, false, nil
+	}
+
+	secret, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", true, fmt.Errorf("failed to resolve %s: %w", value, err)
+	}
+	return secret, true, nil
+}
+
+// resolveValues resolves the references in the values of structured fields. Other
+// environment variables are left alone.
+func (r *SecretResolver) resolveValues(ctx context.Context, values map[string]string) error {
+	for key, value := range values {
+		if !IsStructuredField(key) {
+			continue
+		}
+		secret, isRef, err := r.Resolve(ctx, value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		if isRef {
+			values[key] = secret
+		}
+	}
+	return nil
+}
+
+// resolveTree resolves the references in the string values of a decoded JSON value,
+// such as the entries of SALESFORCE_ACCOUNTS or TARGETS
+func (r *SecretResolver) resolveTree(ctx context.Context, path string, value interface{}) (interface{}, error) {
+	switch v := value.(type) {
+	case string:
+		secret, _, err := r.Resolve(ctx, v)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		return secret, nil
+	case []interface{}:
+		for i, item := range v {
+			resolved, err := r.resolveTree(ctx, fmt.Sprintf("%s[%d]", path, i), item)
+			if err != nil {
+				return nil, err
+			}
+			v[i] = resolved
+		}
+		return v, nil
+	case map[string]interface{}:
+		for key, item := range v {
+			resolved, err := r.resolveTree(ctx, path+"."+key, item)
+			if err != nil {
+				return nil, err
+			}
+			v[key] = resolved
+		}
+		return v, nil
+	default:
+		return value, nil
+	}
+}
+
+// EnvSecretProvider resolves env:NAME to the value of an environment variable
+type EnvSecretProvider struct{}
+
+// Resolve implements SecretProvider
+func (EnvSecretProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	value, ok := os.LookupEnv(ref)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", ref)
+	}
+	return value, nil
+}
+
+// FileSecretProvider resolves file:/path to the contents of a file, such as a Docker
+// or Kubernetes secret mount. A trailing newline is removed.
+type FileSecretProvider struct{}
+
+// Resolve implements SecretProvider
+func (FileSecretProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	data, err := os.ReadFile(ref)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// VaultSecretProvider resolves vault:path#field references against the HashiCorp Vault
+// HTTP API. Both KV version 2 (secret/data/...) and version 1 mounts are supported.
+// Each path is read once.
+type VaultSecretProvider struct {
+	address    string
+	token      string
+	namespace  string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string]map[string]interface{}
+}
+
+// NewVaultSecretProvider creates a provider for the Vault server at address
+func NewVaultSecretProvider(address, token, namespace string) *VaultSecretProvider {
+	return &VaultSecretProvider{
+		address:    strings.TrimRight(address, "/"),
+		token:      token,
+		namespace:  namespace,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		cache:      make(map[string]map[string]interface{}),
+	}
+}
+
+// Resolve implements SecretProvider
+func (p *VaultSecretProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, found := strings.Cut(ref, "#")
+	path = strings.Trim(path, "/")
+	if !found || path == "" || field == "" {
+		return "", fmt.Errorf("vault reference must have the form vault:<path>#<field>")
+	}
+
+	data, err := p.read(ctx, path)
+	if err != nil {
+		return "", err
+	}
+
+	value, ok := data[field]
+	if !ok {
+		return "", fmt.Errorf("field %s not found in vault secret %s", field, path)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprintf("%v", value), nil
+}
+
+// read returns the key/value data of a secret
+func (p *VaultSecretProvider) read(ctx context.Context, path string) (map[string]interface{}, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if data, ok := p.cache[path]; ok {
+		return data, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.address+"/v1/"+(&url.URL{Path: path}).EscapedPath(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create vault request: %w", err)
+	}
+	req.Header.Set("X-Vault-Token", p.token)
+	if p.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read vault secret %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to read vault secret %s: status %d", path, resp.StatusCode)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("failed to parse vault secret %s: %w", path, err)
+	}
+
+	data := body.Data
+	// KV version 2 nests the secret under data.data next to data.metadata
+	if nested, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMetadata := data["metadata"]; hasMetadata {
+			data = nested
+		}
+	}
+
+	p.cache[path] = data
+	return data, nil
+}
+
+// lazyVaultProvider creates the Vault provider from the environment on first use, so
+// that configurations without vault: references do not need VAULT_ADDR
+type lazyVaultProvider struct {
+	once     sync.Once
+	provider *VaultSecretProvider
+	err      error
+}
+
+// Resolve implements SecretProvider
+func (l *lazyVaultProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	l.once.Do(func() {
+		address := os.Getenv("VAULT_ADDR")
+		token := os.Getenv("VAULT_TOKEN")
+		if address == "" || token == "" {
+			l.err = fmt.Errorf("VAULT_ADDR and VAULT_TOKEN must be set to resolve vault references")
+			return
+		}
+		RegisterSecret(token)
+		l.provider = NewVaultSecretProvider(address, token, os.Getenv("VAULT_NAMESPACE"))
+	})
+	if l.err != nil {
+		return "", l.err
+	}
+	return l.provider.Resolve(ctx, ref)
+}
@@ -0,0 +1,275 @@
+package utils_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"salesforce-splunk-migration/utils"
+)
+
+// newVaultServer starts a stand-in Vault server holding a KV v2 secret at
+// secret/data/splunk and a KV v1 secret at kv/salesforce
+func newVaultServer(t *testing.T, requests *int32) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		if r.Header.Get("X-Vault-Token") != "s.test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"errors":["permission denied"]}`))
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/secret/data/splunk":
+			w.Write([]byte(`{"data":{"data":{"password":"vault-splunk-pass","port":8089},"metadata":{"version":3}}}`))
+		case "/v1/kv/salesforce":
+			w.Write([]byte(`{"data":{"client_secret":"vault-sf-secret"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"errors":[]}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultSecretProvider_Resolve(t *testing.T) {
+	var requests int32
+	server := newVaultServer(t, &requests)
+	provider := utils.NewVaultSecretProvider(server.URL, "s.test-token", "")
+
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{name: "Success_KVv2", ref: "secret/data/splunk#password", want: "vault-splunk-pass"},
+		{name: "Success_KVv2NonStringField", ref: "secret/data/splunk#port", want: "8089"},
+		{name: "Success_KVv1", ref: "kv/salesforce#client_secret", want: "vault-sf-secret"},
+		{name: "Error_MissingField", ref: "secret/data/splunk#username", wantErr: "field username not found"},
+		{name: "Error_MissingSecret", ref: "secret/data/unknown#password", wantErr: "status 404"},
+		{name: "Error_NoField", ref: "secret/data/splunk", wantErr: "vault:<path>#<field>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.Resolve(context.Background(), tt.ref)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Resolve() error = %v, want error containing %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Success_SecretReadOnce", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		provider := utils.NewVaultSecretProvider(server.URL, "s.test-token", "")
+		for i := 0; i < 3; i++ {
+			if _, err := provider.Resolve(context.Background(), "secret/data/splunk#password"); err != nil {
+				t.Fatalf("Resolve() unexpected error: %v", err)
+			}
+		}
+		if got := atomic.LoadInt32(&requests); got != 1 {
+			t.Errorf("Vault requests = %d, want 1", got)
+		}
+	})
+
+	t.Run("Error_PermissionDenied", func(t *testing.T) {
+		provider := utils.NewVaultSecretProvider(server.URL, "s.wrong-token", "")
+		_, err := provider.Resolve(context.Background(), "secret/data/splunk#password")
+		if err == nil || !strings.Contains(err.Error(), "status 403") {
+			t.Fatalf("Resolve() error = %v, want status 403", err)
+		}
+		if strings.Contains(err.Error(), "s.wrong-token") {
+			t.Errorf("Resolve() error leaks the Vault token: %v", err)
+		}
+	})
+}
+
+func TestSecretResolver_Resolve(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "splunk_password")
+	if err := os.WriteFile(secretFile, []byte("file-pass\n"), 0o600); err != nil {
+		t.Fatalf("Failed to write secret file: %v", err)
+	}
+	t.Setenv("TEST_SECRET_VALUE", "env-pass")
+
+	resolver := utils.DefaultSecretResolver()
+
+	tests := []struct {
+		name      string
+		value     string
+		want      string
+		wantIsRef bool
+		wantErr   bool
+	}{
+		{name: "Success_PlainValue", value: "changeme", want: "changeme"},
+		{name: "Success_URLIsNotReference", value: "https://splunk.example.com:8089", want: "https://splunk.example.com:8089"},
+		{name: "Success_DateIsNotReference", value: "2024-01-01T00:00:00.000Z", want: "2024-01-01T00:00:00.000Z"},
+		{name: "Success_Env", value: "env:TEST_SECRET_VALUE", want: "env-pass", wantIsRef: true},
+		{name: "Success_File", value: "file:" + secretFile, want: "file-pass", wantIsRef: true},
+		{name: "Error_EnvNotSet", value: "env:TEST_SECRET_UNSET", wantIsRef: true, wantErr: true},
+		{name: "Error_FileMissing", value: "file:/nonexistent/secret", wantIsRef: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, isRef, err := resolver.Resolve(context.Background(), tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Resolve() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if isRef != tt.wantIsRef {
+				t.Errorf("Resolve() isRef = %v, want %v", isRef, tt.wantIsRef)
+			}
+			if !tt.wantErr && got != tt.want {
+				t.Errorf("Resolve() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("Success_CustomProvider", func(t *testing.T) {
+		resolver := utils.NewSecretResolver()
+		resolver.Register("static", staticSecretProvider{"static-secret"})
+		got, isRef, err := resolver.Resolve(context.Background(), "static:anything")
+		if err != nil || !isRef || got != "static-secret" {
+			t.Errorf("Resolve() = %q, %v, %v; want static-secret, true, nil", got, isRef, err)
+		}
+		if got, isRef, _ := resolver.Resolve(context.Background(), "env:HOME"); isRef || got != "env:HOME" {
+			t.Errorf("Resolve() of unregistered scheme = %q, %v; want value unchanged", got, isRef)
+		}
+	})
+}
+
+// staticSecretProvider resolves every reference to the same value
+type staticSecretProvider struct {
+	value string
+}
+
+func (p staticSecretProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	if ref == "" {
+		return "", errors.New("empty reference")
+	}
+	return p.value, nil
+}
+
+func TestLoadConfig_SecretReferences(t *testing.T) {
+	var requests int32
+	server := newVaultServer(t, &requests)
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "s.test-token")
+	t.Setenv("TEST_SF_CLIENT_ID", "env-client-id")
+
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "credentials.json")
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatalf("Failed to write config: %v", err)
+		}
+		return path
+	}
+
+	t.Run("Success_ResolvesStructuredAndExtensionValues", func(t *testing.T) {
+		path := writeConfig(t, `{
+			"SPLUNK_URL": "https://splunk.example.com:8089",
+			"SPLUNK_USERNAME": "admin",
+			"SPLUNK_PASSWORD": "vault:secret/data/splunk#password",
+			"SALESFORCE_CLIENT_ID": "env:TEST_SF_CLIENT_ID",
+			"SALESFORCE_ACCOUNTS": [
+				{"name": "sf_prod", "client_id": "prod", "client_secret": "vault:kv/salesforce#client_secret"}
+			],
+			"DATA_INPUTS": [{"name": "sf_accounts", "object": "Account", "start_date": "2024-01-01T00:00:00.000Z"}]
+		}`)
+
+		config, err := utils.LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if config.Splunk.Password != "vault-splunk-pass" {
+			t.Errorf("Splunk.Password = %q, want vault-splunk-pass", config.Splunk.Password)
+		}
+		if config.Salesforce.ClientID != "env-client-id" {
+			t.Errorf("Salesforce.ClientID = %q, want env-client-id", config.Salesforce.ClientID)
+		}
+
+		accounts, err := config.GetSalesforceAccounts()
+		if err != nil {
+			t.Fatalf("GetSalesforceAccounts() unexpected error: %v", err)
+		}
+		if len(accounts) != 1 || accounts[0].ClientSecret != "vault-sf-secret" {
+			t.Errorf("GetSalesforceAccounts() = %+v, want client_secret vault-sf-secret", accounts)
+		}
+
+		inputs, err := config.GetDataInputs()
+		if err != nil {
+			t.Fatalf("GetDataInputs() unexpected error: %v", err)
+		}
+		if len(inputs) != 1 || inputs[0].StartDate != "2024-01-01T00:00:00.000Z" {
+			t.Errorf("GetDataInputs() = %+v, want start_date unchanged", inputs)
+		}
+	})
+
+	t.Run("Success_EnvironmentReferenceOverridesFile", func(t *testing.T) {
+		t.Setenv("SPLUNK_PASSWORD", "vault:secret/data/splunk#password")
+		path := writeConfig(t, `{"SPLUNK_URL": "https://splunk.example.com:8089", "SPLUNK_PASSWORD": "from-file"}`)
+
+		config, err := utils.LoadConfig(path)
+		if err != nil {
+			t.Fatalf("LoadConfig() unexpected error: %v", err)
+		}
+		if config.Splunk.Password != "vault-splunk-pass" {
+			t.Errorf("Splunk.Password = %q, want vault-splunk-pass", config.Splunk.Password)
+		}
+	})
+
+	t.Run("Error_UnresolvableReference", func(t *testing.T) {
+		path := writeConfig(t, `{"SPLUNK_URL": "https://splunk.example.com:8089", "SPLUNK_PASSWORD": "vault:secret/data/missing#password"}`)
+
+		_, err := utils.LoadConfig(path)
+		if err == nil {
+			t.Fatal("LoadConfig() expected error, got nil")
+		}
+		if !strings.Contains(err.Error(), "SPLUNK_PASSWORD") || !strings.Contains(err.Error(), "status 404") {
+			t.Errorf("LoadConfig() error = %v, want SPLUNK_PASSWORD and status 404", err)
+		}
+	})
+
+	t.Run("Error_UnresolvableExtensionReference", func(t *testing.T) {
+		path := writeConfig(t, `{
+			"SPLUNK_URL": "https://splunk.example.com:8089",
+			"TARGETS": [{"name": "dev", "url": "https://dev:8089", "password": "env:TEST_TARGET_PASSWORD_UNSET"}]
+		}`)
+
+		_, err := utils.LoadConfig(path)
+		if err == nil || !strings.Contains(err.Error(), "$.TARGETS[0].password") {
+			t.Errorf("LoadConfig() error = %v, want $.TARGETS[0].password", err)
+		}
+	})
+
+	t.Run("Success_CustomResolver", func(t *testing.T) {
+		resolver := utils.NewSecretResolver()
+		resolver.Register("static", staticSecretProvider{"static-secret"})
+		path := writeConfig(t, `{"SPLUNK_URL": "https://splunk.example.com:8089", "SPLUNK_PASSWORD": "static:splunk"}`)
+
+		config, err := utils.LoadConfigWithSecrets(path, resolver)
+		if err != nil {
+			t.Fatalf("LoadConfigWithSecrets() unexpected error: %v", err)
+		}
+		if config.Splunk.Password != "static-secret" {
+			t.Errorf("Splunk.Password = %q, want static-secret", config.Splunk.Password)
+		}
+	})
+}